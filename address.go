@@ -0,0 +1,91 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrInvalidAddress is returned when an address parameter fails validation before signing
+var ErrInvalidAddress = errors.New("invalid address")
+
+// ValidateAddress checks that address is "0x" followed by 40 hex characters and, when it
+// mixes upper and lower case, that the case matches the EIP-55 checksum. All-lowercase and
+// all-uppercase addresses carry no checksum and are accepted.
+func ValidateAddress(address string) error {
+	return validateAddress(address, true)
+}
+
+// ChecksumAddress returns the EIP-55 checksummed form of a valid address
+func ChecksumAddress(address string) (string, error) {
+	if err := ValidateAddress(address); err != nil {
+		return "", err
+	}
+	return common.HexToAddress(address).Hex(), nil
+}
+
+// ConfigLenientAddresses disables EIP-55 checksum verification of mixed-case address
+// parameters on the default client, for legacy inputs whose case isn't a checksum. The
+// 0x + 40 hex format is still enforced.
+func ConfigLenientAddresses(enabled bool) {
+	defaultClient.lenientAddresses = enabled
+}
+
+// WithLenientAddresses disables EIP-55 checksum verification (see ConfigLenientAddresses)
+func WithLenientAddresses() Option {
+	return func(o *clientOptions) { o.lenientAddresses = true }
+}
+
+// Internal method: format and optional checksum check
+func validateAddress(address string, checksum bool) error {
+	if len(address) != 42 || (address[:2] != "0x" && address[:2] != "0X") || !common.IsHexAddress(address) {
+		return fmt.Errorf("%w: %q is not a 0x-prefixed 20-byte hex address", ErrInvalidAddress, address)
+	}
+
+	digits := address[2:]
+	mixedCase := strings.ToLower(digits) != digits && strings.ToUpper(digits) != digits
+	if checksum && mixedCase && common.HexToAddress(address).Hex()[2:] != digits {
+		return fmt.Errorf("%w: %q has an invalid EIP-55 checksum", ErrInvalidAddress, address)
+	}
+	return nil
+}
+
+// Internal method: validate an address parameter before signing
+func (c *Client) checkAddress(name, address string) error {
+	if err := validateAddress(address, !c.lenientAddresses); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// AllowZeroAddress lets a write name the zero address where it is normally refused as a
+// mistake: as masterAuthority of CreateToken, as recipient of Mint or Seize, as account of
+// GrantAuthority or as new master authority. Tokens sent there are gone for good, and a zero
+// master authority leaves the token without an administrator.
+func AllowZeroAddress() CallOption {
+	return func(c *callConfig) {
+		c.allowZeroAddress = true
+	}
+}
+
+// Internal method: require a valid address that is not the zero address, unless
+// AllowZeroAddress is passed
+func (c *Client) checkTarget(name, address string, opts []CallOption) error {
+	if err := c.checkAddress(name, address); err != nil {
+		return err
+	}
+	if common.HexToAddress(address) == (common.Address{}) && !c.newCallConfig(opts).allowZeroAddress {
+		return fmt.Errorf("%w: %s is the zero address (pass AllowZeroAddress if that is intended)", ErrInvalidAddress, name)
+	}
+	return nil
+}
+
+// Internal method: EIP-55 form of a server-returned address, unchanged if not an address
+func checksummed(address string) string {
+	if !common.IsHexAddress(address) || !strings.HasPrefix(strings.ToLower(address), "0x") {
+		return address
+	}
+	return common.HexToAddress(address).Hex()
+}
@@ -0,0 +1,112 @@
+package alchemy_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		address string
+		valid   bool
+	}{
+		{"checksummed", testRecipient, true},
+		{"lowercase", strings.ToLower(testRecipient), true},
+		{"uppercase digits", "0x" + strings.ToUpper(testRecipient[2:]), true},
+		{"upper prefix", "0X" + testRecipient[2:], true},
+		{"zero address", "0x0000000000000000000000000000000000000000", true},
+		{"checksum mismatch", "0x70997970c51812dc3A010C7d01b50e0d17dc79C8", false},
+		{"single flipped case", "0x70997970C51812dc3A010C7d01b50e0d17dc79c8", false},
+		{"truncated", testRecipient[:41], false},
+		{"too long", testRecipient + "0", false},
+		{"no prefix", testRecipient[2:] + "00", false},
+		{"bare hex", testRecipient[2:], false},
+		{"non-hex", "0x70997970C51812dc3A010C7d01b50e0d17dc79CG", false},
+		{"empty", "", false},
+		{"prefix only", "0x", false},
+		{"whitespace", " " + testRecipient[:41], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := alchemy.ValidateAddress(tt.address)
+			if tt.valid && err != nil {
+				t.Fatalf("ValidateAddress(%q) = %v", tt.address, err)
+			}
+			if !tt.valid && !errors.Is(err, alchemy.ErrInvalidAddress) {
+				t.Fatalf("ValidateAddress(%q) = %v, want ErrInvalidAddress", tt.address, err)
+			}
+		})
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	for _, in := range []string{testRecipient, strings.ToLower(testRecipient), "0x" + strings.ToUpper(testRecipient[2:])} {
+		if got, err := alchemy.ChecksumAddress(in); err != nil || got != testRecipient {
+			t.Errorf("ChecksumAddress(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := alchemy.ChecksumAddress("0x1234"); !errors.Is(err, alchemy.ErrInvalidAddress) {
+		t.Errorf("err = %v, want ErrInvalidAddress", err)
+	}
+}
+
+func TestAddressParametersCheckedBeforeSigning(t *testing.T) {
+	badChecksum := "0x70997970c51812dc3A010C7d01b50e0d17dc79C8"
+
+	srv, client, _ := newTestServer(t)
+	calls := map[string]func(string) error{
+		"Mint":           func(a string) error { return client.Mint(testToken, a, "1", 0).Err() },
+		"AdminBurn":      func(a string) error { return client.AdminBurn(testToken, a, "1", 0).Err() },
+		"AddToBlacklist": func(a string) error { return client.AddToBlacklist(testToken, a, 0).Err() },
+		"GrantAuthority": func(a string) error { return client.GrantAuthority(testToken, alchemy.RoleMint, a, 0).Err() },
+		"token":          func(a string) error { return client.Mint(a, testRecipient, "1", 0).Err() },
+	}
+	for name, call := range calls {
+		for _, address := range []string{testRecipient[:41], badChecksum} {
+			if err := call(address); !errors.Is(err, alchemy.ErrInvalidAddress) {
+				t.Errorf("%s(%q) err = %v, want ErrInvalidAddress", name, address, err)
+			}
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent, want none", n)
+	}
+
+	// Lenient clients accept the bad checksum but still enforce the format
+	srv, lenient, _ := newTestServer(t, alchemy.WithLenientAddresses())
+	if err := lenient.Mint(testToken, badChecksum, "1", 0).Err(); err != nil {
+		t.Fatalf("lenient Mint: %v", err)
+	}
+	if err := lenient.Mint(testToken, testRecipient[:41], "1", 0).Err(); !errors.Is(err, alchemy.ErrInvalidAddress) {
+		t.Fatalf("lenient Mint of truncated address err = %v", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d mints sent, want 1", n)
+	}
+}
+
+func TestReturnedAddressesChecksummed(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("create_token", map[string]string{"hash": "0xabc", "token": strings.ToLower(testToken), "masterAuthority": strings.ToLower(testRecipient)})
+	srv.SetResult("getAuthorities", []string{strings.ToLower(testRecipient), "not-an-address"})
+
+	token, err := client.CreateToken("Test", "TST", 6, testRecipient).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Token != testToken || token.MasterAuthority != testRecipient {
+		t.Fatalf("token %s, master authority %s", token.Token, token.MasterAuthority)
+	}
+
+	holders, err := client.GetAuthorities(testToken, alchemy.RoleMint).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holders) != 2 || holders[0] != testRecipient || holders[1] != "not-an-address" {
+		t.Fatalf("holders = %v", holders)
+	}
+}
@@ -2,47 +2,118 @@ package alchemy
 
 import (
 	"bytes"
+	"context"
+	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"math/big"
 	"net/http"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// Global configuration
+// secp256k1 curve order and its half, used for low-S normalization
 var (
-	baseURL    = "http://localhost:8545"
-	privateKey = ""
-	client     = &http.Client{Timeout: 30 * time.Second}
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
 )
 
-// Config configures the API endpoint and private key
+// VFormat selects how the recovery id is encoded in Signature.V
+type VFormat int
+
+const (
+	// VFormatEthereum emits V as 27/28 (default)
+	VFormatEthereum VFormat = iota
+	// VFormatRaw emits V as the raw recovery id 0/1
+	VFormatRaw
+)
+
+// Config configures the API endpoint and private key of the default client
 func Config(url, key string) {
-	baseURL = url
-	privateKey = key
+	c := defaultClient
+	c.privateKey = key
+	c.key = nil
+	c.configEndpoint(url)
+}
+
+// ConfigWithKey is Config with an already parsed private key, e.g. one loaded from a
+// vault, so the key never needs to exist as a hex string
+func ConfigWithKey(url string, key *ecdsa.PrivateKey) {
+	c := defaultClient
+	c.privateKey = ""
+	c.key = key
+	c.configEndpoint(url)
+}
+
+// Internal method: point the client at url with the configured key, dropping the signer
+// and everything cached about the previous endpoint
+func (c *Client) configEndpoint(url string) {
+	c.baseURL = url
+	c.signer = nil
+	c.resetChainIDCache()
+	c.resetServerInfoCache()
+	c.decimalsCache.Clear()
+	c.metadataCache.clear()
+}
+
+// ConfigNodeURL sets the Ethereum node URL used for eth_* calls (balances, blocks,
+// recentCheckpoint), for deployments where the node is hosted separately from the token
+// service. Empty falls back to the Config URL.
+func ConfigNodeURL(url string) {
+	defaultClient.nodeURL = url
+	defaultClient.resetChainIDCache()
+}
+
+// ConfigServiceURL sets the full token service URL (e.g. "https://tokens.example.com/rpc")
+// used for token operations. Empty falls back to the Config URL + "/rpc".
+func ConfigServiceURL(url string) {
+	defaultClient.serviceURL = url
+	defaultClient.resetServerInfoCache()
+	defaultClient.decimalsCache.Clear()
+	defaultClient.metadataCache.clear()
+}
+
+// ConfigVFormat selects the V encoding expected by the server
+func ConfigVFormat(format VFormat) {
+	defaultClient.vFormat = format
 }
 
-// ResponseHandler handles responses with success/error callbacks
+// ErrUnsupportedValue is returned when a signed param has no canonical message encoding
+var ErrUnsupportedValue = errors.New("unsupported value in signed params")
+
+// ErrTokenPaused is returned when the server rejects an operation because the token is paused
+var ErrTokenPaused = errors.New("token is paused")
+
+// ResponseHandler handles responses with success/error callbacks. Callbacks run
+// synchronously in the order they are chained, so in r.Success(f).Error(g).Finally(h)
+// f runs first, then g (which also sees a panic recovered from f), then h.
 type ResponseHandler[T any] struct {
 	data T
 	err  error
+	raw  json.RawMessage // copy of the server's JSON-RPC result, see Raw
 }
 
+// Success calls callback with the result if there is no error. A panic in callback is
+// recovered and becomes the handler's error (a *CallbackPanicError).
 func (r *ResponseHandler[T]) Success(callback func(T)) *ResponseHandler[T] {
 	if r.err == nil {
-		callback(r.data)
+		r.run(func() { callback(r.data) })
 	}
 	return r
 }
 
+// Error calls callback with the error if there is one. A panic in callback is recovered
+// and becomes the handler's error (a *CallbackPanicError).
 func (r *ResponseHandler[T]) Error(callback func(error)) *ResponseHandler[T] {
 	if r.err != nil {
-		callback(r.err)
+		err := r.err
+		r.run(func() { callback(err) })
 	}
 	return r
 }
@@ -54,26 +125,113 @@ type TokenMetadata struct {
 	Decimals uint8  `json:"decimals"`
 	Supply   string `json:"supply"`
 	IsPaused bool   `json:"isPaused"`
+	Cap      string `json:"cap,omitempty"` // maximum supply, empty if the server doesn't report it
+
+	// Optional fields, left zero by servers that don't send them
+	MasterAuthority string `json:"masterAuthority,omitempty"`
+	CreatedAtBlock  int64  `json:"createdAtBlock,omitempty"`
+	Creator         string `json:"creator,omitempty"`
+}
+
+// SupplyDecimal returns the supply scaled by decimals (e.g. "1.5"), or "" if supply isn't a valid integer.
+// The scaling is exact string arithmetic, so supplies beyond float64 precision are kept
+// digit for digit; trailing fractional zeros are trimmed.
+func (m *TokenMetadata) SupplyDecimal() string {
+	value, err := formatUnits(m.Supply, m.Decimals)
+	if err != nil {
+		return ""
+	}
+	return value
 }
 
 type TokenIssueResult struct {
-	Hash  string `json:"hash"`
-	Token string `json:"token"`
+	Hash           string `json:"hash"`
+	Token          string `json:"token"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty"` // key the request was sent with, if any
+
+	// Optional fields, left zero by servers that don't send them
+	BlockNumber     int64  `json:"blockNumber,omitempty"` // creation block, e.g. to anchor event scans
+	Status          string `json:"status,omitempty"`
+	MasterAuthority string `json:"masterAuthority,omitempty"` // as echoed back by the server
+
+	Receipt *Receipt `json:"-"` // set when the creation waited for confirmations
+}
+
+// UnmarshalJSON decodes the result object and, from servers that return only the hash,
+// a bare JSON string. Token is then left empty; CreateTokenAndWait fills it from the receipt.
+func (r *TokenIssueResult) UnmarshalJSON(data []byte) error {
+	var hash string
+	if err := json.Unmarshal(data, &hash); err == nil {
+		*r = TokenIssueResult{Hash: hash}
+		return nil
+	}
+	type plain TokenIssueResult // without this method
+	return json.Unmarshal(data, (*plain)(r))
 }
 
 type TransactionResult struct {
-	Hash string `json:"hash"`
+	Hash           string `json:"hash"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty"` // key the request was sent with, if any
+	Nonce          int64  `json:"nonce"`                    // nonce the request was finally sent with
+
+	// Optional fields, left zero by servers that return only the hash
+	BlockNumber int64  `json:"blockNumber,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Timestamp   int64  `json:"timestamp,omitempty"` // unix seconds
+
+	Receipt *Receipt `json:"-"` // set when the write waited for confirmations (WithConfirmations)
+}
+
+// UnmarshalJSON decodes the result object and, from servers that return only the hash,
+// a bare JSON string
+func (r *TransactionResult) UnmarshalJSON(data []byte) error {
+	var hash string
+	if err := json.Unmarshal(data, &hash); err == nil {
+		*r = TransactionResult{Hash: hash}
+		return nil
+	}
+	type plain TransactionResult // without this method
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// Time returns Timestamp as time.Time, the zero time if the server didn't send it
+func (r *TransactionResult) Time() time.Time {
+	if r.Timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(r.Timestamp, 0).UTC()
 }
 
 // Signature represents cryptographic signature
 type Signature struct {
-	R string `json:"r"`
-	S string `json:"s"`
-	V string `json:"v"`
+	R      string `json:"r"`
+	S      string `json:"s"`
+	V      string `json:"v"`
+	Scheme string `json:"scheme,omitempty"` // "" for the hash itself, SignatureSchemePersonal (see PersonalSigner)
+}
+
+// SignedMessage returns the message the SDK signs for params: values sorted by key a-z and
+// joined with commas. The "signature" and "signatures" (see SubmitMultisig) keys, if
+// present, are ignored, so servers can rebuild the message from a received request's params.
+func SignedMessage(params map[string]interface{}) (string, error) {
+	_, single := params["signature"]
+	_, multi := params["signatures"]
+	if single || multi {
+		unsigned := make(map[string]interface{}, len(params))
+		for key, value := range params {
+			if key != "signature" && key != "signatures" {
+				unsigned[key] = value
+			}
+		}
+		params = unsigned
+	}
+	return buildSortedMessage(params)
 }
 
-// buildSortedMessage creates message string sorted by keys a-z (consistent with server side)
-func buildSortedMessage(params map[string]interface{}) string {
+// buildSortedMessage creates message string sorted by keys a-z (consistent with server side).
+// Values are rendered canonically (see formatMessageValue) so that the same logical
+// params always produce the same bytes; unsupported types are rejected with an error.
+func buildSortedMessage(params map[string]interface{}) (string, error) {
 	keys := make([]string, 0, len(params))
 	for key := range params {
 		keys = append(keys, key)
@@ -88,67 +246,213 @@ func buildSortedMessage(params map[string]interface{}) string {
 			continue
 		}
 
+		// []interface{} (methodArgs) is flattened element by element
 		if slice, ok := value.([]interface{}); ok {
-			if len(slice) > 0 {
-				for _, element := range slice {
-					if element != nil {
-						messageParts = append(messageParts, fmt.Sprintf("%v", element))
-					}
+			for i, element := range slice {
+				if element == nil {
+					continue
+				}
+				formatted, err := formatMessageValue(element)
+				if err != nil {
+					return "", fmt.Errorf("param %q[%d]: %w", key, i, err)
 				}
+				messageParts = append(messageParts, formatted)
 			}
-		} else {
-			formattedValue := fmt.Sprintf("%v", value)
-			messageParts = append(messageParts, formattedValue)
+			continue
+		}
+
+		formatted, err := formatMessageValue(value)
+		if err != nil {
+			return "", fmt.Errorf("param %q: %w", key, err)
 		}
+		messageParts = append(messageParts, formatted)
 	}
 
-	return strings.Join(messageParts, ",")
+	return strings.Join(messageParts, ","), nil
+}
+
+// formatMessageValue renders a single value for the signed message:
+//   - strings (and string-based types) as-is
+//   - integers in base-10 without exponent
+//   - json.Number only when it holds an integer, normalized the same way
+//   - *big.Int via String()
+//   - booleans as true/false
+//
+// Floats, maps, slices and any other type are rejected, since their textual
+// form is not stable across Go versions or JSON round trips.
+func formatMessageValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case json.Number:
+		n, ok := new(big.Int).SetString(string(v), 10)
+		if !ok {
+			return "", fmt.Errorf("%w: non-integer json.Number %q", ErrUnsupportedValue, string(v))
+		}
+		return n.String(), nil
+	case *big.Int:
+		if v == nil {
+			return "", fmt.Errorf("%w: nil *big.Int", ErrUnsupportedValue)
+		}
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
+}
+
+// signerAddress returns the checksummed address of the key signing the operation ctx
+// belongs to
+func (c *Client) signerAddress(ctx context.Context) (string, error) {
+	signer, err := c.getSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	return signer.Address(), nil
 }
 
 // generateSignature universal signing method - sorts keys a-z then signs
-func generateSignature(params map[string]interface{}) (*Signature, error) {
-	// Remove 0x prefix if present
-	privateKeyHex := privateKey
-	if len(privateKeyHex) > 2 && privateKeyHex[:2] == "0x" {
-		privateKeyHex = privateKeyHex[2:]
+func (c *Client) generateSignature(ctx context.Context, params map[string]interface{}) (*Signature, error) {
+	signer, err := c.getSigner(ctx)
+	if err != nil {
+		return nil, withSigner(ctx, err)
 	}
 
-	// Parse private key
-	privKey, err := crypto.HexToECDSA(privateKeyHex)
+	payload, err := signParams(signer, params, c.vFormat)
 	if err != nil {
-		return nil, fmt.Errorf("invalid private key: %w", err)
+		return nil, withSigner(ctx, err)
 	}
+	return &payload.Signature, nil
+}
 
+// signParams builds the sorted message for params, hashes it and signs the hash
+func signParams(signer Signer, params map[string]interface{}, format VFormat) (*SignedPayload, error) {
 	// Build message string sorted by keys a-z
-	message := buildSortedMessage(params)
+	message, err := buildSortedMessage(params)
+	if err != nil {
+		return nil, err
+	}
 
 	// Calculate message hash
 	hash := crypto.Keccak256Hash([]byte(message))
 
-	// Sign
-	signature, err := crypto.Sign(hash.Bytes(), privKey)
+	// Sign; personal signers sign the EIP-191 digest of the hash instead
+	signature, err := signer.Sign(hash.Bytes())
 	if err != nil {
 		return nil, fmt.Errorf("sign error: %w", err)
 	}
+	if len(signature) != 65 || signature[64] > 1 {
+		return nil, fmt.Errorf("sign error: signer returned a malformed signature")
+	}
 
 	// Extract r, s, v values
 	r := new(big.Int).SetBytes(signature[:32])
 	s := new(big.Int).SetBytes(signature[32:64])
-	v := new(big.Int).SetBytes([]byte{signature[64] + 27}) // Add 27 is Ethereum convention
+	recID := signature[64]
+
+	// Normalize S to the lower half of the curve order; flipping S flips the recovery id
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(secp256k1N, s)
+		recID ^= 1
+	}
+
+	v := new(big.Int).SetUint64(uint64(recID))
+	if format == VFormatEthereum {
+		v.Add(v, big.NewInt(27)) // Add 27 is Ethereum convention
+	}
 
-	return &Signature{
-		R: r.String(),
-		S: s.String(),
-		V: v.String(),
+	return &SignedPayload{
+		Message: message,
+		Hash:    hash.Hex(),
+		Signature: Signature{
+			R:      r.String(),
+			S:      s.String(),
+			V:      v.String(),
+			Scheme: signatureScheme(signer),
+		},
 	}, nil
 }
 
-// CreateToken creates a new token
-func CreateToken(name, symbol string, decimals int32, masterAuthority string) *ResponseHandler[*TokenIssueResult] {
-	blockNum, err := getBlockNumber()
+// signRequest signs params and returns the request params with the signature attached.
+// When chain ID signing is enabled the chain ID is added to params before signing.
+func (c *Client) signRequest(params map[string]interface{}) (map[string]interface{}, error) {
+	return c.signRequestContext(context.Background(), params)
+}
+
+// Internal method: signRequest as part of the operation ctx belongs to
+func (c *Client) signRequestContext(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := c.addSigningChainID(ctx, params); err != nil {
+		return nil, err
+	}
+
+	signature, err := c.generateSignature(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	reqParams := make(map[string]interface{}, len(params)+1)
+	for key, value := range params {
+		reqParams[key] = value
+	}
+	reqParams["signature"] = signature.encode(c.sigEncoding)
+
+	return reqParams, nil
+}
+
+// Internal method: add the chainId key to params when chain ID signing is enabled
+func (c *Client) addSigningChainID(ctx context.Context, params map[string]interface{}) error {
+	c.chainMu.Lock()
+	signChain := c.chainIDSigning
+	c.chainMu.Unlock()
+
+	if signChain {
+		id, err := c.signingChainID(ctx)
+		if err != nil {
+			return err
+		}
+		params["chainId"] = id
+	}
+	return nil
+}
+
+// CreateToken creates a new token. The name, symbol and decimals are checked against the
+// TokenRules (see WithTokenRules) before any request is made.
+func (c *Client) CreateToken(name, symbol string, decimals int32, masterAuthority string, opts ...CallOption) (r *ResponseHandler[*TokenIssueResult]) {
+	defer withOperation(&r, "CreateToken", "")
+	cfg := c.newCallConfig(opts)
+	ctx := cfg.context()
+
+	if err := c.tokenRules.check(name, symbol, decimals); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{err: err}
+	}
+	if err := c.checkTarget("masterAuthority", masterAuthority, opts); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{err: err}
+	}
+	if err := c.checkChainContext(ctx); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{err: err}
+	}
+	if cfg.uniqueSymbol {
+		if err := c.checkUniqueSymbol(symbol); err != nil {
+			return &ResponseHandler[*TokenIssueResult]{err: err}
+		}
+	}
+
+	blockNum, err := c.getBlockNumberContext(ctx)
 	if err != nil {
 		return &ResponseHandler[*TokenIssueResult]{err: err}
 	}
+	if err := c.checkNodeLag(ctx, blockNum); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{err: err}
+	}
 
 	nonce := int64(0)
 
@@ -161,146 +465,388 @@ func CreateToken(name, symbol string, decimals int32, masterAuthority string) *R
 		"recentCheckpoint": blockNum,
 		"symbol":           symbol,
 	}
+	if cfg.idempotencyKey != "" {
+		params["idempotencyKey"] = cfg.idempotencyKey
+	}
 
-	signature, err := generateSignature(params)
+	reqParams, err := c.signRequestContext(ctx, params)
 	if err != nil {
 		return &ResponseHandler[*TokenIssueResult]{err: err}
 	}
 
-	reqParams := map[string]interface{}{
-		"decimals":         decimals,
-		"masterAuthority":  masterAuthority,
-		"name":             name,
-		"symbol":           symbol,
-		"nonce":            nonce,
-		"recentCheckpoint": blockNum,
-		"signature": map[string]string{
-			"r": signature.R,
-			"s": signature.S,
-			"v": signature.V,
-		},
-	}
-
-	result, err := rpcCall("create_token", reqParams)
+	result, err := c.rpcWriteContext(ctx, "create_token", reqParams, 0)
 	if err != nil {
 		return &ResponseHandler[*TokenIssueResult]{err: err}
 	}
 
+	raw := bytes.Clone(result)
 	var response TokenIssueResult
-	if err := json.Unmarshal(result, &response); err != nil {
-		return &ResponseHandler[*TokenIssueResult]{err: err}
+	if err := c.decodeResult(result, &response); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{err: err, raw: raw}
+	}
+	response.Token = checksummed(response.Token)
+	response.MasterAuthority = checksummed(response.MasterAuthority)
+	setIdempotencyKey(&response, cfg.idempotencyKey)
+	if err := c.awaitConfirmations(&response, cfg); err != nil {
+		return &ResponseHandler[*TokenIssueResult]{data: &response, err: err, raw: raw}
 	}
 
-	return &ResponseHandler[*TokenIssueResult]{data: &response}
+	return &ResponseHandler[*TokenIssueResult]{data: &response, raw: raw}
 }
 
-// GetTokenMetadata gets token metadata
-func GetTokenMetadata(tokenAddress string) *ResponseHandler[*TokenMetadata] {
-	return dynamicCallWithType[*TokenMetadata](tokenAddress, "getTokenMetadata", []interface{}{}, 0)
+// GetTokenMetadata gets token metadata, at a past block with WithBlock. With a metadata
+// cache (WithMetadataCache) fresh entries are served without a request; WithBlock reads
+// bypass the cache.
+func (c *Client) GetTokenMetadata(tokenAddress string, opts ...CallOption) (r *ResponseHandler[*TokenMetadata]) {
+	defer withOperation(&r, "GetTokenMetadata", tokenAddress)
+	cacheable := c.newCallConfig(opts).block == nil
+	cached, generation, ok := c.metadataCache.get(tokenAddress)
+	if ok && cacheable {
+		return &ResponseHandler[*TokenMetadata]{data: cached}
+	}
+
+	result := ClientCallRead[*TokenMetadata](c, tokenAddress, "getTokenMetadata", []interface{}{}, opts...)
+	if result.err == nil && result.data != nil {
+		result.data.MasterAuthority = checksummed(result.data.MasterAuthority)
+		result.data.Creator = checksummed(result.data.Creator)
+		if cacheable {
+			c.metadataCache.put(tokenAddress, result.data, generation)
+		}
+	}
+	return result
 }
 
 // UpdateMetadata updates token metadata
-func UpdateMetadata(tokenAddress, newName, newSymbol string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "updateMetadata", []interface{}{newName, newSymbol}, nonce)
+func (c *Client) UpdateMetadata(tokenAddress, newName, newSymbol string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "UpdateMetadata", tokenAddress)
+	return c.CallWrite(tokenAddress, "updateMetadata", []interface{}{newName, newSymbol}, nonce, opts...)
 }
 
-// Mint mints new tokens
-func Mint(tokenAddress, toAddress, amount string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "mint", []interface{}{toAddress, amount}, nonce)
+// Mint mints new tokens. Minting to the zero address (see AllowZeroAddress) or to the token
+// contract itself is refused before signing, as the tokens would be stuck.
+func (c *Client) Mint(tokenAddress, toAddress, amount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "Mint", tokenAddress)
+	if err := c.checkTarget("toAddress", toAddress, opts); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if strings.EqualFold(toAddress, tokenAddress) {
+		return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("%w: toAddress is the token contract itself", ErrInvalidAddress)}
+	}
+	if err := c.checkWriteAmount("amount", amount); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "mint", []interface{}{toAddress, amount}, nonce, opts...)
 }
 
 // GrantAuthority grants authority to account
-func GrantAuthority(tokenAddress, role, account string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "grantAuthority", []interface{}{role, account}, nonce)
+func (c *Client) GrantAuthority(tokenAddress string, role Role, account string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "GrantAuthority", tokenAddress)
+	if err := c.checkTarget("account", account, opts); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := role.Validate(); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "grantAuthority", []interface{}{role.String(), account}, nonce, opts...)
+}
+
+// GrantCustomAuthority grants a custom (non-predefined) role to account
+func (c *Client) GrantCustomAuthority(tokenAddress, role, account string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "GrantCustomAuthority", tokenAddress)
+	return c.GrantAuthority(tokenAddress, Role(role), account, nonce, opts...)
 }
 
 // RevokeAuthority revokes authority from account
-func RevokeAuthority(tokenAddress, role, account string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "revokeAuthority", []interface{}{role, account}, nonce)
+func (c *Client) RevokeAuthority(tokenAddress string, role Role, account string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "RevokeAuthority", tokenAddress)
+	if err := c.checkAddress("account", account); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := role.Validate(); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "revokeAuthority", []interface{}{role.String(), account}, nonce, opts...)
+}
+
+// RevokeCustomAuthority revokes a custom (non-predefined) role from account
+func (c *Client) RevokeCustomAuthority(tokenAddress, role, account string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "RevokeCustomAuthority", tokenAddress)
+	return c.RevokeAuthority(tokenAddress, Role(role), account, nonce, opts...)
+}
+
+// ErrMasterRenounce is returned by RenounceAuthority for MASTER_ROLE without ForceRenounce
+var ErrMasterRenounce = errors.New("renouncing MASTER_ROLE leaves the token without a master authority (pass ForceRenounce)")
+
+// ForceRenounce lets RenounceAuthority renounce MASTER_ROLE. Without a master authority
+// nobody can grant roles, update metadata or change the supply cap any more, so the token
+// can't be administered again.
+func ForceRenounce() CallOption {
+	return func(c *callConfig) {
+		c.forceRenounce = true
+	}
+}
+
+// RenounceAuthority gives up role for the configured key itself (the chain's
+// renounceAuthority, which needs no master authority). The account is derived from the key,
+// so there's none to mistype. MASTER_ROLE is refused unless ForceRenounce is passed.
+func (c *Client) RenounceAuthority(tokenAddress string, role Role, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "RenounceAuthority", tokenAddress)
+	if err := role.Validate(); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	cfg := c.newCallConfig(opts)
+	if role == RoleMaster && !cfg.forceRenounce {
+		return &ResponseHandler[*TransactionResult]{err: ErrMasterRenounce}
+	}
+	signer, err := c.signerAddress(cfg.context())
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "renounceAuthority", []interface{}{role.String(), signer}, nonce, opts...)
+}
+
+// GetAuthorities gets the accounts currently holding role on the token
+func (c *Client) GetAuthorities(tokenAddress string, role Role, opts ...CallOption) (r *ResponseHandler[[]string]) {
+	defer withOperation(&r, "GetAuthorities", tokenAddress)
+	if err := role.Validate(); err != nil {
+		return &ResponseHandler[[]string]{err: err}
+	}
+
+	result := ClientCallRead[[]string](c, tokenAddress, "getAuthorities", []interface{}{role.String()}, opts...)
+	if result.err == nil && result.data == nil {
+		result.data = []string{}
+	}
+	for i, holder := range result.data {
+		result.data[i] = checksummed(holder)
+	}
+	return result
+}
+
+// HasAuthority checks whether account holds role on the token
+func (c *Client) HasAuthority(tokenAddress string, role Role, account string, opts ...CallOption) (r *ResponseHandler[bool]) {
+	defer withOperation(&r, "HasAuthority", tokenAddress)
+	if err := c.checkAddress("account", account); err != nil {
+		return &ResponseHandler[bool]{err: err}
+	}
+	authorities := c.GetAuthorities(tokenAddress, role, opts...)
+	if authorities.err != nil {
+		return &ResponseHandler[bool]{err: authorities.err}
+	}
+
+	for _, holder := range authorities.data {
+		if strings.EqualFold(holder, account) {
+			return &ResponseHandler[bool]{data: true}
+		}
+	}
+	return &ResponseHandler[bool]{data: false}
+}
+
+// TransferMasterAuthorityIrreversibly transfers the token's master authority to
+// newMasterAuthority. This can't be undone: the configured key loses master authority as
+// soon as the transaction lands, so double-check the new address before calling.
+func (c *Client) TransferMasterAuthorityIrreversibly(tokenAddress, newMasterAuthority string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "TransferMasterAuthorityIrreversibly", tokenAddress)
+	if err := c.checkTarget("newMasterAuthority", newMasterAuthority, opts); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "transferMasterAuthority", []interface{}{newMasterAuthority}, nonce, opts...)
+}
+
+// TransferMasterAuthority transfers the token's master authority to newMasterAuthority.
+//
+// Deprecated: the name hides that the transfer is irreversible; use
+// TransferMasterAuthorityIrreversibly.
+func (c *Client) TransferMasterAuthority(tokenAddress, newMasterAuthority string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "TransferMasterAuthority", tokenAddress)
+	return c.TransferMasterAuthorityIrreversibly(tokenAddress, newMasterAuthority, nonce, opts...)
 }
 
 // AdminBurn burns tokens by admin
-func AdminBurn(tokenAddress, fromAddress, amount string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "adminBurn", []interface{}{fromAddress, amount}, nonce)
+func (c *Client) AdminBurn(tokenAddress, fromAddress, amount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "AdminBurn", tokenAddress)
+	if err := c.checkAddress("fromAddress", fromAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := c.checkWriteAmount("amount", amount); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "adminBurn", []interface{}{fromAddress, amount}, nonce, opts...)
+}
+
+// Burn burns tokens from the configured account's own balance
+func (c *Client) Burn(tokenAddress, amount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "Burn", tokenAddress)
+	if err := c.checkWriteAmount("amount", amount); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "burn", []interface{}{amount}, nonce, opts...)
+}
+
+// Seize moves tokens out of fromAddress (e.g. a blacklisted account) into toAddress
+func (c *Client) Seize(tokenAddress, fromAddress, toAddress, amount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "Seize", tokenAddress)
+	if err := c.checkAddress("fromAddress", fromAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := c.checkTarget("toAddress", toAddress, opts); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := c.checkWriteAmount("amount", amount); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "seize", []interface{}{fromAddress, toAddress, amount}, nonce, opts...)
+}
+
+// SetSupplyCap sets the maximum supply of the token.
+// The server rejects a cap smaller than the current supply.
+func (c *Client) SetSupplyCap(tokenAddress, supplyCap string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "SetSupplyCap", tokenAddress)
+	if err := c.checkWriteAmount("cap", supplyCap); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "setSupplyCap", []interface{}{supplyCap}, nonce, opts...)
+}
+
+// GetSupplyCap gets the maximum supply of the token
+func (c *Client) GetSupplyCap(tokenAddress string, opts ...CallOption) (r *ResponseHandler[string]) {
+	defer withOperation(&r, "GetSupplyCap", tokenAddress)
+	return ClientCallRead[string](c, tokenAddress, "getSupplyCap", []interface{}{}, opts...)
 }
 
 // Pause pauses the contract
-func Pause(tokenAddress string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "pause", []interface{}{}, nonce)
+func (c *Client) Pause(tokenAddress string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "Pause", tokenAddress)
+	return c.CallWrite(tokenAddress, "pause", []interface{}{}, nonce, opts...)
 }
 
 // Unpause unpauses the contract
-func Unpause(tokenAddress string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "unpause", []interface{}{}, nonce)
+func (c *Client) Unpause(tokenAddress string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "Unpause", tokenAddress)
+	return c.CallWrite(tokenAddress, "unpause", []interface{}{}, nonce, opts...)
 }
 
 // AddToBlacklist adds account to blacklist
-func AddToBlacklist(tokenAddress, accountAddress string, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCall(tokenAddress, "addToBlacklist", []interface{}{accountAddress}, nonce)
+func (c *Client) AddToBlacklist(tokenAddress, accountAddress string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "AddToBlacklist", tokenAddress)
+	if err := c.checkAddress("accountAddress", accountAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "addToBlacklist", []interface{}{accountAddress}, nonce, opts...)
 }
 
 // BalanceInfo contains balance information
 type BalanceInfo struct {
-	Wei string `json:"wei"`
-	Eth string `json:"eth"`
+	Wei  string `json:"wei"`  // exact balance, the source of truth
+	Gwei string `json:"gwei"` // Wei scaled by 10^9, exact
+	Eth  string `json:"eth"`  // Wei scaled by 10^18, exact
 }
 
-// GetBalance gets account ETH balance - direct call to Ethereum node
-func GetBalance(address string) *ResponseHandler[*BalanceInfo] {
-	// Direct call to Ethereum node, not our RPC server
-	rpcReq := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  "eth_getBalance",
-		"params":  []interface{}{address, "latest"},
-		"id":      1,
+// GetBalance gets account ETH balance - direct call to Ethereum node. WithBlock reads the
+// balance at that block instead of the latest.
+func (c *Client) GetBalance(address string, opts ...CallOption) (r *ResponseHandler[*BalanceInfo]) {
+	defer withOperation(&r, "GetBalance", "")
+	if err := c.checkChain(); err != nil {
+		return &ResponseHandler[*BalanceInfo]{err: err}
 	}
-
-	reqBody, _ := json.Marshal(rpcReq)
-	resp, err := client.Post(baseURL, "application/json", bytes.NewBuffer(reqBody)) // Note: baseURL not baseURL+"/rpc"
+	cfg := c.newCallConfig(opts)
+	tag, err := blockTag(cfg)
 	if err != nil {
 		return &ResponseHandler[*BalanceInfo]{err: err}
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
 
-	var rpcResp struct {
-		Result string `json:"result"`
-		Error  *struct {
-			Message string `json:"message"`
-		} `json:"error"`
+	// Direct call to Ethereum node, not our RPC server
+	result, err := c.nodeCallContext(cfg.context(), "eth_getBalance", []interface{}{address, tag})
+	if err != nil {
+		return &ResponseHandler[*BalanceInfo]{err: err}
 	}
 
-	json.Unmarshal(respBody, &rpcResp)
+	var balanceHex string
+	if err := c.decodeResult(result, &balanceHex); err != nil {
+		return &ResponseHandler[*BalanceInfo]{err: fmt.Errorf("decode balance: %w", err)}
+	}
 
-	if rpcResp.Error != nil {
-		return &ResponseHandler[*BalanceInfo]{err: fmt.Errorf("RPC error: %s", rpcResp.Error.Message)}
+	// Convert hex string to big.Int
+	balanceWei, err := parseHexBig(balanceHex)
+	if err != nil {
+		return &ResponseHandler[*BalanceInfo]{err: fmt.Errorf("decode balance: %w", err)}
 	}
 
-	balanceHex := rpcResp.Result
+	return &ResponseHandler[*BalanceInfo]{data: newBalanceInfo(balanceWei)}
+}
 
-	// Convert hex string to big.Int
-	balanceWei := new(big.Int)
-	balanceWei.SetString(balanceHex[2:], 16) // Remove "0x" prefix
+// Internal method: generic dynamic call (supports different return types)
+func dynamicCallWithType[T any](c *Client, tokenAddress, methodName string, methodArgs []interface{}, nonce int64, opts ...CallOption) *ResponseHandler[T] {
+	cfg := c.newCallConfig(opts)
+	ctx := cfg.context()
+
+	var result json.RawMessage
+	for attempt := 0; ; attempt++ {
+		reqParams, err := c.buildDynamicRequest(ctx, tokenAddress, methodArgs, nonce, cfg)
+		if err != nil {
+			return &ResponseHandler[T]{err: err}
+		}
+
+		if cfg.write {
+			result, err = c.rpcWriteContext(ctx, methodName, reqParams, cfg.maxResponseSize)
+		} else {
+			result, err = c.rpcCallContext(ctx, methodName, reqParams, cfg.maxResponseSize)
+		}
+		c.invalidateAfterWrite(tokenAddress, methodName)
+		if err == nil {
+			break
+		}
+		if attempt >= cfg.nonceRetries || !isRetryableNonceError(err) {
+			return &ResponseHandler[T]{err: err}
+		}
 
-	// Convert to ETH (1 ETH = 10^18 Wei)
-	ethValue := new(big.Float).SetInt(balanceWei)
-	ethValue.Quo(ethValue, big.NewFloat(1e18))
-	balanceEth := ethValue.String()
+		// Nonce rejected before acceptance: resync and re-sign with the server's nonce
+		fresh, nonceErr := c.getAccountNonce(ctx, tokenAddress)
+		if nonceErr != nil {
+			return &ResponseHandler[T]{err: fmt.Errorf("%w (nonce resync failed: %v)", err, nonceErr)}
+		}
+		nonce = fresh
+	}
 
-	response := &BalanceInfo{
-		Wei: balanceWei.String(),
-		Eth: balanceEth,
+	raw := bytes.Clone(result)
+	var response T
+	if err := c.decodeResult(result, &response); err != nil {
+		return &ResponseHandler[T]{err: err, raw: raw}
+	}
+	setIdempotencyKey(response, cfg.idempotencyKey)
+	setResultNonce(response, nonce)
+	if err := c.awaitConfirmations(response, cfg); err != nil {
+		return &ResponseHandler[T]{data: response, err: err, raw: raw}
 	}
 
-	return &ResponseHandler[*BalanceInfo]{data: response}
+	return &ResponseHandler[T]{data: response, raw: raw}
 }
 
-// Internal method: generic dynamic call (supports different return types)
-func dynamicCallWithType[T any](tokenAddress, methodName string, methodArgs []interface{}, nonce int64) *ResponseHandler[T] {
-	blockNum, err := getBlockNumber()
+// Internal method: build the signed request params of a dynamic call
+func (c *Client) buildDynamicRequest(ctx context.Context, tokenAddress string, methodArgs []interface{}, nonce int64, cfg *callConfig) (map[string]interface{}, error) {
+	params, err := c.buildDynamicParams(ctx, tokenAddress, methodArgs, nonce, cfg)
 	if err != nil {
-		return &ResponseHandler[T]{err: err}
+		return nil, err
+	}
+	return c.signRequestContext(ctx, params)
+}
+
+// Internal method: the params of a dynamic call, before chainId and the signature
+func (c *Client) buildDynamicParams(ctx context.Context, tokenAddress string, methodArgs []interface{}, nonce int64, cfg *callConfig) (map[string]interface{}, error) {
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return nil, err
+	}
+	if err := c.checkChainContext(ctx); err != nil {
+		return nil, err
+	}
+
+	blockNum, err := c.getBlockNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.write {
+		if err := c.checkNodeLag(ctx, blockNum); err != nil {
+			return nil, err
+		}
 	}
 
 	// Build parameter mapping with consistent key names and sorting as server side (methodName doesn't participate in signature)
@@ -310,95 +856,111 @@ func dynamicCallWithType[T any](tokenAddress, methodName string, methodArgs []in
 		"recentCheckpoint": blockNum,
 		"token":            tokenAddress,
 	}
-
-	signature, err := generateSignature(params)
-	if err != nil {
-		return &ResponseHandler[T]{err: err}
+	if cfg.idempotencyKey != "" {
+		params["idempotencyKey"] = cfg.idempotencyKey
 	}
-
-	reqParams := map[string]interface{}{
-		"nonce":            nonce,
-		"token":            tokenAddress,
-		"methodArgs":       methodArgs,
-		"recentCheckpoint": blockNum,
-		"signature": map[string]string{
-			"r": signature.R,
-			"s": signature.S,
-			"v": signature.V,
-		},
+	if cfg.block != nil && !cfg.write { // writes always apply to the latest state
+		if *cfg.block < 0 {
+			return nil, fmt.Errorf("invalid block %d", *cfg.block)
+		}
+		params["block"] = *cfg.block
 	}
-
-	result, err := rpcCall(methodName, reqParams)
-	if err != nil {
-		return &ResponseHandler[T]{err: err}
+	if cfg.dryRun {
+		params["dryRun"] = true
 	}
-
-	var response T
-	if err := json.Unmarshal(result, &response); err != nil {
-		return &ResponseHandler[T]{err: err}
+	if cfg.memo != "" {
+		params["memo"] = cfg.memo
 	}
 
-	return &ResponseHandler[T]{data: response}
+	return params, nil
 }
 
-// Internal method: dynamic call (backward compatible wrapper)
-func dynamicCall(tokenAddress, methodName string, methodArgs []interface{}, nonce int64) *ResponseHandler[*TransactionResult] {
-	return dynamicCallWithType[*TransactionResult](tokenAddress, methodName, methodArgs, nonce)
+// Internal method: RPC call
+func (c *Client) rpcCall(method string, params interface{}) (json.RawMessage, error) {
+	return c.jsonRPCCallLimit(c.serviceEndpoint(), method, params, 0)
 }
 
-// Internal method: RPC call
-func rpcCall(method string, params interface{}) (json.RawMessage, error) {
-	rpcReq := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  method,
-		"params":  params,
-		"id":      1,
-	}
+// Internal method: RPC call with a response size limit (0 means the configured maximum)
+func (c *Client) rpcCallLimit(method string, params interface{}, limit int64) (json.RawMessage, error) {
+	return c.rpcCallContext(context.Background(), method, params, limit)
+}
+
+// Internal method: rpcCallLimit as part of the operation ctx belongs to
+func (c *Client) rpcCallContext(ctx context.Context, method string, params interface{}, limit int64) (json.RawMessage, error) {
+	return c.call(ctx, c.serviceEndpoint(), method, params, limit)
+}
+
+// Internal method: state-changing RPC call, retried only when it can't have been processed
+func (c *Client) rpcWrite(method string, params interface{}, limit int64) (json.RawMessage, error) {
+	return c.rpcWriteContext(context.Background(), method, params, limit)
+}
 
-	reqBody, _ := json.Marshal(rpcReq)
-	resp, err := client.Post(baseURL+"/rpc", "application/json", bytes.NewBuffer(reqBody))
+// Internal method: rpcWrite as part of the operation ctx belongs to
+func (c *Client) rpcWriteContext(ctx context.Context, method string, params interface{}, limit int64) (json.RawMessage, error) {
+	return c.call(withWriteRequest(ctx), c.serviceEndpoint(), method, params, limit)
+}
+
+// Internal method: call an eth_* method directly on the Ethereum node
+func (c *Client) nodeCall(method string, params []interface{}) (json.RawMessage, error) {
+	return c.nodeCallContext(context.Background(), method, params)
+}
+
+// Internal method: nodeCall as part of the operation ctx belongs to
+func (c *Client) nodeCallContext(ctx context.Context, method string, params []interface{}) (json.RawMessage, error) {
+	return c.call(ctx, c.nodeEndpoint(), method, params, 0)
+}
+
+// Internal method: POST a JSON body with the configured headers and credentials
+func (c *Client) postJSON(ctx context.Context, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	respBody, _ := io.ReadAll(resp.Body)
-
-	var rpcResp struct {
-		Result json.RawMessage `json:"result"`
-		Error  *struct {
-			Message string `json:"message"`
-		} `json:"error"`
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	if err := c.applyHeaders(req.Header); err != nil {
+		return nil, err
+	}
+	if err := c.compressRequest(req, body); err != nil {
+		return nil, err
 	}
 
-	json.Unmarshal(respBody, &rpcResp)
-
-	if rpcResp.Error != nil {
-		return nil, fmt.Errorf("RPC error: %s", rpcResp.Error.Message)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, c.redactProxyError(err)
+	}
+	if err := c.decompressResponse(resp); err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
 
-	return rpcResp.Result, nil
+// Internal method: JSON-RPC call against url reading at most limit bytes
+func (c *Client) jsonRPCCallLimit(url, method string, params interface{}, limit int64) (json.RawMessage, error) {
+	return c.call(context.Background(), url, method, params, limit)
 }
 
 // Internal method: get block number
-func getBlockNumber() (int64, error) {
-	resp, err := client.Post(baseURL, "application/json",
-		bytes.NewBufferString(`{"jsonrpc":"2.0","method":"eth_blockNumber","params":[],"id":1}`))
+func (c *Client) getBlockNumber() (int64, error) {
+	return c.getBlockNumberContext(context.Background())
+}
+
+// Internal method: getBlockNumber as part of the operation ctx belongs to
+func (c *Client) getBlockNumberContext(ctx context.Context) (int64, error) {
+	result, err := c.nodeCallContext(ctx, "eth_blockNumber", []interface{}{})
 	if err != nil {
 		return 0, err
 	}
-	defer resp.Body.Close()
 
-	respBody, _ := io.ReadAll(resp.Body)
-
-	var blockResp struct {
-		Result string `json:"result"`
+	var blockHex string
+	if err := json.Unmarshal(result, &blockHex); err != nil {
+		return 0, fmt.Errorf("decode block number: %w", err)
+	}
+	blockNumber, err := parseHexQuantity(blockHex)
+	if err != nil {
+		return 0, fmt.Errorf("decode block number: %w", err)
 	}
 
-	json.Unmarshal(respBody, &blockResp)
-
-	var blockNumber int64
-	fmt.Sscanf(blockResp.Result, "0x%x", &blockNumber)
-
-	return blockNumber, nil
+	c.observeHead(int64(blockNumber))
+	return int64(blockNumber), nil
 }
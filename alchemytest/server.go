@@ -0,0 +1,411 @@
+// Package alchemytest provides an in-process fake of the node and token service endpoints
+// for testing code built on the alchemy SDK without a chain server.
+//
+//	srv := alchemytest.NewServer()
+//	defer srv.Close()
+//	srv.FailNext("mint", http.StatusServiceUnavailable, 1)
+//	client, _ := srv.NewClient(alchemy.WithPrivateKey(key),
+//		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2}))
+//	client.Mint(token, to, "100", 0)
+//	// len(srv.RequestsFor("mint")) == 2
+package alchemytest
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// JSON-RPC error codes returned by the fake
+const (
+	CodeMethodNotFound   = -32601
+	CodeInvalidParams    = -32602
+	CodeInvalidSignature = -32000
+)
+
+// Request is a JSON-RPC request received by the server
+type Request struct {
+	Path     string                 // "/" for node calls, "/rpc" for token service calls
+	Method   string                 // JSON-RPC method
+	Params   json.RawMessage        // raw params as sent
+	ParamMap map[string]interface{} // object params decoded with json.Number, nil for positional params
+	Header   http.Header
+
+	Signature    *alchemy.Signature // nil for unsigned requests
+	Signer       string             // checksummed address recovered from Signature
+	SignatureErr error              // why the signature was rejected, nil when valid
+
+	Signatures []alchemy.Signature // the "signatures" list of multisig requests
+	Signers    []string            // checksummed addresses recovered from Signatures, in order
+}
+
+// RPCError is a JSON-RPC error object
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Response is a programmed reply. A non-zero Status other than 200 sends Body (default the
+// status text) as text/plain, like a gateway would; otherwise Error or Result is sent as a
+// JSON-RPC response.
+type Response struct {
+	Status int
+	Body   string
+	Result interface{}
+	Error  *RPCError
+	Header http.Header // extra response headers, e.g. Retry-After
+}
+
+// Server is a fake node and token service. The node is served at URL and the token service
+// at URL + "/rpc", matching the SDK's default layout. It answers eth_blockNumber,
+// eth_chainId, eth_getBalance, eth_getCode, get_nonce and create_token, and accepts any other signed
+// request as a dynamic contract call returning a transaction hash. Programmed responses
+// take precedence over the defaults.
+type Server struct {
+	URL string
+
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	requests    []Request
+	queued      map[string][]Response
+	responses   map[string]Response
+	signers     map[common.Address]bool
+	blockNumber int64
+	chainID     uint64
+	balances    map[common.Address]*big.Int
+	codes       map[common.Address][]byte
+	txCount     int
+}
+
+// NewServer starts a server; call Close when done
+func NewServer() *Server {
+	s := &Server{
+		queued:      map[string][]Response{},
+		responses:   map[string]Response{},
+		signers:     map[common.Address]bool{},
+		blockNumber: 1,
+		chainID:     1,
+		balances:    map[common.Address]*big.Int{},
+		codes:       map[common.Address][]byte{},
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// NewClient creates a client pointed at the server
+func (s *Server) NewClient(opts ...alchemy.Option) (*alchemy.Client, error) {
+	return alchemy.NewClient(s.URL, opts...)
+}
+
+// RegisterKey makes the server reject signed requests not signed by pub (or another
+// registered key). Without registered keys signatures are recovered but not enforced.
+func (s *Server) RegisterKey(pub *ecdsa.PublicKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signers[crypto.PubkeyToAddress(*pub)] = true
+}
+
+// RegisterAddress is RegisterKey for a signer address
+func (s *Server) RegisterAddress(address string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signers[common.HexToAddress(address)] = true
+}
+
+// SetBlockNumber sets the eth_blockNumber result (default 1)
+func (s *Server) SetBlockNumber(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blockNumber = n
+}
+
+// SetChainID sets the eth_chainId result (default 1)
+func (s *Server) SetChainID(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chainID = id
+}
+
+// SetBalance sets the eth_getBalance result for address (default 0)
+func (s *Server) SetBalance(address string, wei *big.Int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balances[common.HexToAddress(address)] = new(big.Int).Set(wei)
+}
+
+// SetCode sets the eth_getCode result for address (default empty, i.e. not a contract)
+func (s *Server) SetCode(address string, code []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.codes[common.HexToAddress(address)] = append([]byte(nil), code...)
+}
+
+// SetResponse answers every call of method with resp until changed
+func (s *Server) SetResponse(method string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = resp
+}
+
+// SetResult answers every call of method with result
+func (s *Server) SetResult(method string, result interface{}) {
+	s.SetResponse(method, Response{Result: result})
+}
+
+// SetError answers every call of method with a JSON-RPC error
+func (s *Server) SetError(method string, code int, message string) {
+	s.SetResponse(method, Response{Error: &RPCError{Code: code, Message: message}})
+}
+
+// QueueResponse answers the next calls of method with resps, one each, before falling
+// back to SetResponse or the default behavior
+func (s *Server) QueueResponse(method string, resps ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued[method] = append(s.queued[method], resps...)
+}
+
+// FailNext answers the next n calls of method with HTTP status
+func (s *Server) FailNext(method string, status, n int) {
+	for i := 0; i < n; i++ {
+		s.QueueResponse(method, Response{Status: status})
+	}
+}
+
+// Requests returns every request received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsFor returns the requests received for method, in order
+func (s *Server) RequestsFor(method string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []Request
+	for _, req := range s.requests {
+		if req.Method == method {
+			matched = append(matched, req)
+		}
+	}
+	return matched
+}
+
+// Reset forgets recorded requests and programmed responses; registered keys, block
+// number, chain ID, balances and code are kept
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.queued = map[string][]Response{}
+	s.responses = map[string]Response{}
+}
+
+// Internal method: serve one JSON-RPC request
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var envelope struct {
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+		ID     json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid JSON-RPC request", http.StatusBadRequest)
+		return
+	}
+
+	req := Request{
+		Path:   r.URL.Path,
+		Method: envelope.Method,
+		Params: envelope.Params,
+		Header: r.Header.Clone(),
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(envelope.Params), []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(envelope.Params))
+		decoder.UseNumber()
+		if err := decoder.Decode(&req.ParamMap); err != nil {
+			http.Error(w, "invalid params", http.StatusBadRequest)
+			return
+		}
+	}
+	if raw, ok := req.ParamMap["signature"]; ok {
+		req.Signature, req.Signer, req.SignatureErr = recoverSigner(req.ParamMap, raw)
+	}
+	if raw, ok := req.ParamMap["signatures"]; ok {
+		req.Signatures, req.Signers, req.SignatureErr = recoverSigners(req.ParamMap, raw)
+	}
+
+	s.mu.Lock()
+	if req.SignatureErr == nil && req.Signature != nil && len(s.signers) > 0 &&
+		!s.signers[common.HexToAddress(req.Signer)] {
+		req.SignatureErr = fmt.Errorf("signer %s is not registered", req.Signer)
+	}
+	for _, signer := range req.Signers {
+		if req.SignatureErr == nil && len(s.signers) > 0 && !s.signers[common.HexToAddress(signer)] {
+			req.SignatureErr = fmt.Errorf("signer %s is not registered", signer)
+		}
+	}
+	s.requests = append(s.requests, req)
+	resp, programmed := s.nextResponse(req.Method)
+	if !programmed {
+		resp = s.defaultResponse(req)
+	}
+	s.mu.Unlock()
+
+	for name, values := range resp.Header {
+		w.Header()[name] = values
+	}
+	if resp.Status != 0 && resp.Status != http.StatusOK {
+		text := resp.Body
+		if text == "" {
+			text = http.StatusText(resp.Status)
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(resp.Status)
+		io.WriteString(w, text)
+		return
+	}
+
+	reply := map[string]interface{}{"jsonrpc": "2.0", "id": envelope.ID}
+	if resp.Error != nil {
+		reply["error"] = resp.Error
+	} else {
+		reply["result"] = resp.Result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(reply)
+}
+
+// Internal method: pop a queued response or return the persistent one, s.mu held
+func (s *Server) nextResponse(method string) (Response, bool) {
+	if queue := s.queued[method]; len(queue) > 0 {
+		s.queued[method] = queue[1:]
+		return queue[0], true
+	}
+	resp, ok := s.responses[method]
+	return resp, ok
+}
+
+// Internal method: built-in behavior, s.mu held
+func (s *Server) defaultResponse(req Request) Response {
+	if req.SignatureErr != nil {
+		message := req.SignatureErr.Error()
+		if !errors.Is(req.SignatureErr, alchemy.ErrInvalidSignature) {
+			message = "invalid signature: " + message
+		}
+		return Response{Error: &RPCError{Code: CodeInvalidSignature, Message: message}}
+	}
+
+	switch req.Method {
+	case "eth_blockNumber":
+		return Response{Result: "0x" + strconv.FormatInt(s.blockNumber, 16)}
+	case "eth_chainId":
+		return Response{Result: "0x" + strconv.FormatUint(s.chainID, 16)}
+	case "eth_getBalance":
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+			return Response{Error: &RPCError{Code: CodeInvalidParams, Message: "invalid params"}}
+		}
+		balance := s.balances[common.HexToAddress(params[0])]
+		if balance == nil {
+			balance = new(big.Int)
+		}
+		return Response{Result: "0x" + balance.Text(16)}
+	case "eth_getCode":
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) == 0 {
+			return Response{Error: &RPCError{Code: CodeInvalidParams, Message: "invalid params"}}
+		}
+		return Response{Result: hexutil.Encode(s.codes[common.HexToAddress(params[0])])}
+	case "get_nonce":
+		return Response{Result: 0}
+	case "create_token":
+		if req.Signature == nil {
+			return Response{Error: &RPCError{Code: CodeInvalidSignature, Message: "missing signature"}}
+		}
+		hash := s.nextHash()
+		return Response{Result: map[string]string{
+			"hash":  hash.Hex(),
+			"token": common.BytesToAddress(hash[12:]).Hex(),
+		}}
+	}
+
+	if req.Signature != nil || len(req.Signatures) > 0 {
+		return Response{Result: map[string]string{"hash": s.nextHash().Hex()}}
+	}
+	return Response{Error: &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}}
+}
+
+// Internal method: deterministic fake transaction hash, s.mu held
+func (s *Server) nextHash() common.Hash {
+	s.txCount++
+	return crypto.Keccak256Hash([]byte("alchemytest-tx-" + strconv.Itoa(s.txCount)))
+}
+
+// Internal method: rebuild the signed message from params and recover the signer
+func recoverSigner(params map[string]interface{}, raw interface{}) (*alchemy.Signature, string, error) {
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, "", errors.New("signature is not an object")
+	}
+	sig := &alchemy.Signature{}
+	sig.R, _ = fields["r"].(string)
+	sig.S, _ = fields["s"].(string)
+	sig.V, _ = fields["v"].(string)
+	sig.Scheme, _ = fields["scheme"].(string)
+
+	signer, err := alchemy.RecoverSigner(params, sig)
+	return sig, signer, err
+}
+
+// Internal method: recover the signers of a multisig "signatures" list, rejecting an
+// empty list and addresses that sign twice
+func recoverSigners(params map[string]interface{}, raw interface{}) ([]alchemy.Signature, []string, error) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, nil, errors.New("signatures is not a non-empty list")
+	}
+	sigs := make([]alchemy.Signature, 0, len(list))
+	signers := make([]string, 0, len(list))
+	seen := map[string]bool{}
+	for i, item := range list {
+		sig, signer, err := recoverSigner(params, item)
+		if err != nil {
+			return sigs, signers, fmt.Errorf("signature %d: %w", i, err)
+		}
+		if seen[signer] {
+			return sigs, signers, fmt.Errorf("signature %d: %s signed twice", i, signer)
+		}
+		seen[signer] = true
+		sigs = append(sigs, *sig)
+		signers = append(signers, signer)
+	}
+	return sigs, signers, nil
+}
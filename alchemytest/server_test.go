@@ -0,0 +1,211 @@
+package alchemytest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+const (
+	token     = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	recipient = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+func newServer(t *testing.T) *alchemytest.Server {
+	t.Helper()
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// post sends a raw JSON-RPC request to path
+func post(t *testing.T, url, method string, params interface{}) *alchemytest.RPCError {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var reply struct {
+		Error *alchemytest.RPCError `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		t.Fatal(err)
+	}
+	return reply.Error
+}
+
+func TestMintRetriesOnceWhenRateLimited(t *testing.T) {
+	srv := newServer(t)
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.RegisterKey(&key.PublicKey)
+	client, err := srv.NewClient(alchemy.WithKey(key),
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext("mint", http.StatusTooManyRequests, 1)
+
+	tx, err := client.Mint(token, recipient, "1000", 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("mint")
+	if len(reqs) != 2 || tx.Hash == "" {
+		t.Fatalf("%d attempts, hash %q", len(reqs), tx.Hash)
+	}
+	for _, req := range reqs {
+		if req.Path != "/rpc" || req.SignatureErr != nil || req.Signer != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+			t.Fatalf("request %+v", req)
+		}
+	}
+}
+
+func TestReadRetriesOn503(t *testing.T) {
+	srv := newServer(t)
+	client, err := srv.NewClient(alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.FailNext("eth_getBalance", http.StatusServiceUnavailable, 1)
+	srv.SetBalance(recipient, big.NewInt(5))
+
+	balance, err := client.GetBalance(recipient).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Wei != "5" || len(srv.RequestsFor("eth_getBalance")) != 2 {
+		t.Fatalf("balance %+v after %d attempts", balance, len(srv.RequestsFor("eth_getBalance")))
+	}
+}
+
+func TestSignatureEnforcement(t *testing.T) {
+	srv := newServer(t)
+	registered, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without registered keys any valid signature is accepted and its signer recorded
+	open, err := srv.NewClient(alchemy.WithKey(other))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := open.Mint(token, recipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.RequestsFor("mint")[0].Signer; got != crypto.PubkeyToAddress(other.PublicKey).Hex() {
+		t.Fatalf("signer %s", got)
+	}
+
+	srv.RegisterAddress(crypto.PubkeyToAddress(registered.PublicKey).Hex())
+	err = open.Mint(token, recipient, "1", 0).Err()
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != alchemytest.CodeInvalidSignature {
+		t.Fatalf("err = %v, want an invalid signature error", err)
+	}
+
+	// Tampered params don't verify
+	srv.Reset()
+	signer, err := srv.NewClient(alchemy.WithKey(registered))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.Mint(token, recipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	params := srv.RequestsFor("mint")[0].ParamMap
+	params["methodArgs"] = []interface{}{recipient, "1000000"}
+	if rpcErr := post(t, srv.URL+"/rpc", "mint", params); rpcErr == nil || rpcErr.Code != alchemytest.CodeInvalidSignature {
+		t.Fatalf("tampered request answered with %+v", rpcErr)
+	}
+	if srv.RequestsFor("mint")[1].SignatureErr == nil {
+		t.Fatal("tampered request recorded as valid")
+	}
+}
+
+func TestProgrammedResponses(t *testing.T) {
+	srv := newServer(t)
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.SetBalance(recipient, big.NewInt(9))
+	srv.QueueResponse("eth_getBalance",
+		alchemytest.Response{Result: "0x1"},
+		alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "boom"}})
+
+	var got []string
+	for i := 0; i < 3; i++ {
+		balance, err := client.GetBalance(recipient).Result()
+		if err != nil {
+			got = append(got, err.Error())
+			continue
+		}
+		got = append(got, balance.Wei)
+	}
+	// Queued responses first, then the built-in behavior
+	if got[0] != "1" || got[1] != "GetBalance: eth_getBalance: RPC error: boom" || got[2] != "9" {
+		t.Fatalf("got %q", got)
+	}
+
+	srv.SetError("eth_getBalance", alchemytest.CodeInvalidParams, "bad")
+	if err := client.GetBalance(recipient).Err(); err == nil || err.Error() != "GetBalance: eth_getBalance: RPC error: bad" {
+		t.Fatalf("err = %v", err)
+	}
+	srv.Reset()
+	if len(srv.Requests()) != 0 {
+		t.Fatal("Reset kept requests")
+	}
+	if balance, err := client.GetBalance(recipient).Result(); err != nil || balance.Wei != "9" {
+		t.Fatalf("after Reset: %+v, %v (balances are kept)", balance, err)
+	}
+}
+
+func TestRecordedRequests(t *testing.T) {
+	srv := newServer(t)
+	client, err := srv.NewClient(alchemy.WithHeaders(map[string]string{"X-Trace": "abc"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetBalance(recipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := srv.Requests()[0]
+	if req.Path != "/" || req.Method != "eth_getBalance" || req.ParamMap != nil || req.Signature != nil {
+		t.Fatalf("request %+v", req)
+	}
+	var params []string
+	if err := json.Unmarshal(req.Params, &params); err != nil || params[0] != recipient {
+		t.Fatalf("params %s", req.Params)
+	}
+	if req.Header.Get("X-Trace") != "abc" {
+		t.Fatalf("headers %v", req.Header)
+	}
+
+	if rpcErr := post(t, srv.URL+"/rpc", "unknown_method", map[string]interface{}{}); rpcErr == nil || rpcErr.Code != alchemytest.CodeMethodNotFound {
+		t.Fatalf("unsigned unknown method answered with %+v", rpcErr)
+	}
+}
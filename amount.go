@@ -0,0 +1,242 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidAmount is returned when an amount parameter fails validation before signing
+var ErrInvalidAmount = errors.New("invalid amount")
+
+// Internal method: require a base-10 unsigned integer string (wei / base units)
+func checkAmount(name, amount string) error {
+	if amount == "" {
+		return fmt.Errorf("%w: %s is empty", ErrInvalidAmount, name)
+	}
+	for _, c := range amount {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%w: %s %q is not a base-10 integer", ErrInvalidAmount, name, amount)
+		}
+	}
+	return nil
+}
+
+// DefaultMaxAmountDigits is the default longest amount accepted by writes: the 78 digits of
+// the largest uint256
+const DefaultMaxAmountDigits = 78
+
+// ConfigMaxAmountDigits sets the longest amount the default client's writes accept (see
+// WithMaxAmountDigits)
+func ConfigMaxAmountDigits(digits int) {
+	defaultClient.maxAmountDigits = digits
+}
+
+// WithMaxAmountDigits sets the longest amount, in digits, that writes accept before
+// signing (default DefaultMaxAmountDigits). Longer amounts fail with ErrInvalidAmount.
+func WithMaxAmountDigits(digits int) Option {
+	return func(o *clientOptions) { o.maxAmountDigits = digits }
+}
+
+// Internal method: checkAmount for a write parameter, also bounding its length
+func (c *Client) checkWriteAmount(name, amount string) error {
+	if err := checkAmount(name, amount); err != nil {
+		return err
+	}
+	limit := c.maxAmountDigits
+	if limit <= 0 {
+		limit = DefaultMaxAmountDigits
+	}
+	if len(amount) > limit {
+		return fmt.Errorf("%w: %s has %d digits, at most %d allowed", ErrInvalidAmount, name, len(amount), limit)
+	}
+	return nil
+}
+
+// Internal method: scale a base-unit integer string down by decimals using exact
+// string arithmetic, trimming trailing fractional zeros ("150000000", 8 -> "1.5")
+func formatUnits(raw string, decimals uint8) (string, error) {
+	if err := checkAmount("value", raw); err != nil {
+		return "", err
+	}
+
+	digits := strings.TrimLeft(raw, "0")
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+
+	split := len(digits) - int(decimals)
+	whole, frac := digits[:split], strings.TrimRight(digits[split:], "0")
+	if frac == "" {
+		return whole, nil
+	}
+	return whole + "." + frac, nil
+}
+
+// ToBaseUnits scales a decimal token amount up to base units ("1.5", 8 -> "150000000")
+// using exact string arithmetic. Fractional digits beyond decimals are rejected unless
+// they are zeros, so no precision is ever silently lost.
+func ToBaseUnits(human string, decimals uint8) (string, error) {
+	whole, frac, hasPoint := strings.Cut(human, ".")
+	if whole == "" || (hasPoint && frac == "") || checkAmount("amount", whole) != nil ||
+		(frac != "" && checkAmount("amount", frac) != nil) {
+		return "", fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, human)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > int(decimals) {
+		return "", fmt.Errorf("%w: %q has more than %d fractional digits", ErrInvalidAmount, human, decimals)
+	}
+
+	raw := strings.TrimLeft(whole+frac+strings.Repeat("0", int(decimals)-len(frac)), "0")
+	if raw == "" {
+		return "0", nil
+	}
+	return raw, nil
+}
+
+// FromBaseUnits scales a base-unit integer string down to a decimal token amount
+// ("150000000", 8 -> "1.5"), trimming trailing fractional zeros
+func FromBaseUnits(raw string, decimals uint8) (string, error) {
+	return formatUnits(raw, decimals)
+}
+
+// MintDecimal mints a decimal amount (e.g. "1.5"), converted to base units with the
+// token's decimals (fetched once per token and cached)
+func (c *Client) MintDecimal(tokenAddress, toAddress, humanAmount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "MintDecimal", tokenAddress)
+	amount, err := c.toTokenBaseUnits(tokenAddress, humanAmount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Mint(tokenAddress, toAddress, amount, nonce, opts...)
+}
+
+// BurnDecimal burns a decimal amount from the configured account's own balance
+func (c *Client) BurnDecimal(tokenAddress, humanAmount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "BurnDecimal", tokenAddress)
+	amount, err := c.toTokenBaseUnits(tokenAddress, humanAmount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Burn(tokenAddress, amount, nonce, opts...)
+}
+
+// AdminBurnDecimal burns a decimal amount from fromAddress
+func (c *Client) AdminBurnDecimal(tokenAddress, fromAddress, humanAmount string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "AdminBurnDecimal", tokenAddress)
+	amount, err := c.toTokenBaseUnits(tokenAddress, humanAmount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.AdminBurn(tokenAddress, fromAddress, amount, nonce, opts...)
+}
+
+// Internal method: convert a decimal amount with the token's cached decimals
+func (c *Client) toTokenBaseUnits(tokenAddress, humanAmount string) (string, error) {
+	decimals, err := c.tokenDecimals(tokenAddress)
+	if err != nil {
+		return "", fmt.Errorf("get token decimals: %w", err)
+	}
+	return ToBaseUnits(humanAmount, decimals)
+}
+
+// Internal method: token decimals, fetched with GetTokenMetadata once per token
+func (c *Client) tokenDecimals(tokenAddress string) (uint8, error) {
+	key := strings.ToLower(tokenAddress)
+	if decimals, ok := c.decimalsCache.Load(key); ok {
+		return decimals.(uint8), nil
+	}
+
+	metadata, err := c.GetTokenMetadata(tokenAddress).Result()
+	if err != nil {
+		return 0, err
+	}
+	if metadata == nil {
+		return 0, fmt.Errorf("empty metadata for token %s", tokenAddress)
+	}
+	c.decimalsCache.Store(key, metadata.Decimals)
+	return metadata.Decimals, nil
+}
+
+// GetTokenBalanceDecimal gets the token balance of account scaled by the token's decimals
+// (e.g. "1.5"), with the same exact arithmetic and trimming as TokenMetadata.SupplyDecimal.
+// The decimals are fetched once per token and cached, like for MintDecimal.
+func (c *Client) GetTokenBalanceDecimal(tokenAddress, account string, opts ...CallOption) (r *ResponseHandler[string]) {
+	defer withOperation(&r, "GetTokenBalanceDecimal", tokenAddress)
+	balance := c.GetTokenBalance(tokenAddress, account, opts...)
+	if balance.err != nil {
+		return balance
+	}
+	decimals, err := c.tokenDecimals(tokenAddress)
+	if err != nil {
+		return &ResponseHandler[string]{err: fmt.Errorf("get token decimals: %w", err)}
+	}
+	scaled, err := formatUnits(balance.data, decimals)
+	if err != nil {
+		return &ResponseHandler[string]{err: fmt.Errorf("decode balance: %w", err), raw: balance.raw}
+	}
+	return &ResponseHandler[string]{data: scaled, raw: balance.raw}
+}
+
+// Internal method: canonical base-10 string of a non-negative *big.Int amount
+func bigAmount(name string, amount *big.Int) (string, error) {
+	if amount == nil {
+		return "", fmt.Errorf("%w: %s is nil", ErrInvalidAmount, name)
+	}
+	if amount.Sign() < 0 {
+		return "", fmt.Errorf("%w: %s %s is negative", ErrInvalidAmount, name, amount)
+	}
+	return amount.String(), nil
+}
+
+// MintBig is Mint with a *big.Int amount in base units
+func (c *Client) MintBig(tokenAddress, toAddress string, amount *big.Int, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "MintBig", tokenAddress)
+	value, err := bigAmount("amount", amount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Mint(tokenAddress, toAddress, value, nonce, opts...)
+}
+
+// BurnBig is Burn with a *big.Int amount in base units
+func (c *Client) BurnBig(tokenAddress string, amount *big.Int, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "BurnBig", tokenAddress)
+	value, err := bigAmount("amount", amount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Burn(tokenAddress, value, nonce, opts...)
+}
+
+// AdminBurnBig is AdminBurn with a *big.Int amount in base units
+func (c *Client) AdminBurnBig(tokenAddress, fromAddress string, amount *big.Int, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "AdminBurnBig", tokenAddress)
+	value, err := bigAmount("amount", amount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.AdminBurn(tokenAddress, fromAddress, value, nonce, opts...)
+}
+
+// SeizeBig is Seize with a *big.Int amount in base units
+func (c *Client) SeizeBig(tokenAddress, fromAddress, toAddress string, amount *big.Int, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "SeizeBig", tokenAddress)
+	value, err := bigAmount("amount", amount)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Seize(tokenAddress, fromAddress, toAddress, value, nonce, opts...)
+}
+
+// SetSupplyCapBig is SetSupplyCap with a *big.Int cap in base units
+func (c *Client) SetSupplyCapBig(tokenAddress string, supplyCap *big.Int, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "SetSupplyCapBig", tokenAddress)
+	value, err := bigAmount("cap", supplyCap)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.SetSupplyCap(tokenAddress, value, nonce, opts...)
+}
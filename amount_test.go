@@ -0,0 +1,272 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestToBaseUnits(t *testing.T) {
+	tests := []struct {
+		human    string
+		decimals uint8
+		want     string // "" means ErrInvalidAmount
+	}{
+		{"42", 0, "42"},
+		{"42.0", 0, "42"},
+		{"42.5", 0, ""},
+		{"0", 0, "0"},
+		{"1.5", 8, "150000000"},
+		{"0.00000001", 8, "1"},
+		{"0.000000001", 8, ""},
+		{"0.000000010", 8, "1"},
+		{"21000000", 8, "2100000000000000"},
+		{"1", 18, "1000000000000000000"},
+		{"0.000000001", 18, "1000000000"},
+		{"123456789012345678901234567890.123456789012345678", 18, "123456789012345678901234567890123456789012345678"},
+		{"0.0000000000000000001", 18, ""},
+		{"007.50", 8, "750000000"},
+		{"", 8, ""},
+		{".5", 8, ""},
+		{"1.", 8, ""},
+		{"-1", 8, ""},
+		{"+1", 8, ""},
+		{"1e18", 18, ""},
+		{"1,000", 8, ""},
+		{"1.2.3", 8, ""},
+		{" 1", 8, ""},
+	}
+	for _, tt := range tests {
+		got, err := alchemy.ToBaseUnits(tt.human, tt.decimals)
+		if tt.want == "" {
+			if !errors.Is(err, alchemy.ErrInvalidAmount) {
+				t.Errorf("ToBaseUnits(%q, %d) = %q, %v; want ErrInvalidAmount", tt.human, tt.decimals, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ToBaseUnits(%q, %d) = %q, %v; want %q", tt.human, tt.decimals, got, err, tt.want)
+		}
+	}
+}
+
+func TestFromBaseUnits(t *testing.T) {
+	tests := []struct {
+		raw      string
+		decimals uint8
+		want     string
+	}{
+		{"42", 0, "42"},
+		{"0", 0, "0"},
+		{"150000000", 8, "1.5"},
+		{"1", 8, "0.00000001"},
+		{"100000000", 8, "1"},
+		{"1000000000", 18, "0.000000001"},
+		{"1000000000000000000", 18, "1"},
+		{"123456789012345678901234567890123456789012345678", 18, "123456789012345678901234567890.123456789012345678"},
+		{"000150000000", 8, "1.5"},
+	}
+	for _, tt := range tests {
+		got, err := alchemy.FromBaseUnits(tt.raw, tt.decimals)
+		if err != nil || got != tt.want {
+			t.Errorf("FromBaseUnits(%q, %d) = %q, %v; want %q", tt.raw, tt.decimals, got, err, tt.want)
+		}
+		// Formatting and parsing back is lossless
+		if back, err := alchemy.ToBaseUnits(got, tt.decimals); err != nil || back != trimLeadingZeros(tt.raw) {
+			t.Errorf("round trip of %q: %q, %v", tt.raw, back, err)
+		}
+	}
+
+	for _, raw := range []string{"", "-1", "1.5", "0x10"} {
+		if _, err := alchemy.FromBaseUnits(raw, 8); !errors.Is(err, alchemy.ErrInvalidAmount) {
+			t.Errorf("FromBaseUnits(%q) err = %v", raw, err)
+		}
+	}
+}
+
+// trimLeadingZeros drops leading zeros, keeping "0"
+func trimLeadingZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+func TestMintDecimal(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Bitcoin", "symbol": "XBT", "decimals": 8, "supply": "0"})
+
+	if err := client.MintDecimal(testToken, testRecipient, "1.5", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.MintDecimal(testToken, testRecipient, "0.00000002", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.MintDecimal(testToken, testRecipient, "0.000000001", 2).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+		t.Fatalf("err = %v, want ErrInvalidAmount", err)
+	}
+
+	mints := srv.RequestsFor("mint")
+	if len(mints) != 2 {
+		t.Fatalf("%d mints sent, want 2", len(mints))
+	}
+	for i, want := range []string{"150000000", "2"} {
+		if got := fmt.Sprint(mints[i].ParamMap["methodArgs"]); got != "["+testRecipient+" "+want+"]" {
+			t.Fatalf("mint %d methodArgs = %s", i, got)
+		}
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 1 {
+		t.Fatalf("decimals fetched %d times, want once", n)
+	}
+}
+
+func TestBigAmountsMatchStringCalls(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name      string
+		str, bigs func(nonce int64) error
+	}{
+		{"mint",
+			func(n int64) error {
+				return client.Mint(testToken, testRecipient, "123456789012345678901234567890", n).Err()
+			},
+			func(n int64) error { return client.MintBig(testToken, testRecipient, huge, n).Err() }},
+		{"burn",
+			func(n int64) error { return client.Burn(testToken, "0", n).Err() },
+			func(n int64) error { return client.BurnBig(testToken, new(big.Int), n).Err() }},
+		{"adminBurn",
+			func(n int64) error { return client.AdminBurn(testToken, testRecipient, "1000000", n).Err() },
+			func(n int64) error { return client.AdminBurnBig(testToken, testRecipient, big.NewInt(1e6), n).Err() }},
+		{"seize",
+			func(n int64) error { return client.Seize(testToken, blacklistedAccount, testRecipient, "7", n).Err() },
+			func(n int64) error {
+				return client.SeizeBig(testToken, blacklistedAccount, testRecipient, big.NewInt(7), n).Err()
+			}},
+		{"setSupplyCap",
+			func(n int64) error { return client.SetSupplyCap(testToken, "123456789012345678901234567890", n).Err() },
+			func(n int64) error { return client.SetSupplyCapBig(testToken, huge, n).Err() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.Reset()
+			if err := tt.str(3); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.bigs(3); err != nil {
+				t.Fatal(err)
+			}
+			sent := srv.RequestsFor(tt.name)
+			if len(sent) != 2 {
+				t.Fatalf("%d requests sent, want 2", len(sent))
+			}
+			// Same args, nonce and checkpoint, and signatures are deterministic
+			if !bytes.Equal(sent[0].Params, sent[1].Params) {
+				t.Fatalf("payloads differ:\n%s\n%s", sent[0].Params, sent[1].Params)
+			}
+		})
+	}
+}
+
+func TestMalformedAmountsRejectedBeforeSigning(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	for _, amount := range []string{"", "-5", "+5", "1e6", "1,000", "1.5", " 5", "0x10", "５"} {
+		if err := client.Mint(testToken, testRecipient, amount, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+			t.Errorf("Mint(%q) err = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+	for _, amount := range []*big.Int{nil, big.NewInt(-1)} {
+		if err := client.MintBig(testToken, testRecipient, amount, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+			t.Errorf("MintBig(%v) err = %v, want ErrInvalidAmount", amount, err)
+		}
+		if err := client.SetSupplyCapBig(testToken, amount, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+			t.Errorf("SetSupplyCapBig(%v) err = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+	// No nonce was looked up or consumed
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}
+
+func TestWriteAmountValidation(t *testing.T) {
+	writes := map[string]func(c *alchemy.Client, amount string) error{
+		"Mint": func(c *alchemy.Client, amount string) error {
+			return c.Mint(testToken, testRecipient, amount, 0).Err()
+		},
+		"AdminBurn": func(c *alchemy.Client, amount string) error {
+			return c.AdminBurn(testToken, testRecipient, amount, 0).Err()
+		},
+		"Burn": func(c *alchemy.Client, amount string) error {
+			return c.Burn(testToken, amount, 0).Err()
+		},
+		"Seize": func(c *alchemy.Client, amount string) error {
+			return c.Seize(testToken, blacklistedAccount, testRecipient, amount, 0).Err()
+		},
+		"SetSupplyCap": func(c *alchemy.Client, amount string) error {
+			return c.SetSupplyCap(testToken, amount, 0).Err()
+		},
+		"MintBatch": func(c *alchemy.Client, amount string) error {
+			return c.MintBatch(testToken, []alchemy.MintRecipient{{To: testRecipient, Amount: amount}}, 0).Err()
+		},
+	}
+	zoo := []struct {
+		amount string
+		reason string
+	}{
+		{"", "empty"},
+		{"1e18", "not a base-10 integer"},
+		{"1_000", "not a base-10 integer"},
+		{" 100 ", "not a base-10 integer"},
+		{"100\n", "not a base-10 integer"},
+		{"-1", "not a base-10 integer"},
+		{"1.0", "not a base-10 integer"},
+		{"0x64", "not a base-10 integer"},
+		{"١٠٠", "not a base-10 integer"}, // Arabic-Indic digits
+		{strings.Repeat("9", alchemy.DefaultMaxAmountDigits+1), "79 digits, at most 78"},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			for _, bad := range zoo {
+				err := write(client, bad.amount)
+				if !errors.Is(err, alchemy.ErrInvalidAmount) || !strings.Contains(err.Error(), bad.reason) {
+					t.Errorf("%q: err = %v, want ErrInvalidAmount (%s)", bad.amount, err, bad.reason)
+				}
+			}
+			if n := len(srv.Requests()); n != 0 {
+				t.Fatalf("%d requests sent", n)
+			}
+
+			if err := write(client, strings.Repeat("9", alchemy.DefaultMaxAmountDigits)); err != nil {
+				t.Fatalf("78 digits: %v", err)
+			}
+		})
+	}
+
+	// The error names the parameter
+	_, client, _ := newTestServer(t)
+	if err := client.SetSupplyCap(testToken, "1e6", 0).Err(); err == nil || !strings.Contains(err.Error(), "cap") {
+		t.Fatalf("err = %v, want it to name cap", err)
+	}
+}
+
+func TestMaxAmountDigits(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithMaxAmountDigits(6))
+	if err := client.Mint(testToken, testRecipient, "999999", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Mint(testToken, testRecipient, "1000000", 1).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+		t.Fatalf("err = %v, want ErrInvalidAmount", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d mint requests, want 1", n)
+	}
+}
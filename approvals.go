@@ -0,0 +1,160 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EventApproval is the type of the event emitted when an owner sets a spender's allowance
+const EventApproval = "Approval"
+
+// ApprovalEvent is a decoded Approval event: Owner allowed Spender to spend Amount (raw base
+// units, the new allowance rather than an increment)
+type ApprovalEvent struct {
+	Owner    string `json:"owner"`
+	Spender  string `json:"spender"`
+	Amount   string `json:"amount"`
+	Block    int64  `json:"block"`
+	TxHash   string `json:"txHash"`
+	LogIndex int64  `json:"logIndex"`
+}
+
+// Allowance is the amount (raw base units) Spender may still spend on behalf of an owner
+type Allowance struct {
+	Spender string `json:"spender"`
+	Amount  string `json:"amount"`
+}
+
+// GetApprovalEvents gets the Approval events of owner from fromBlock to toBlock (0 means the
+// latest block), in chain order. Large ranges are queried in DefaultEventChunkSize chunks
+// like GetTokenEvents. Addresses are checksummed.
+func (c *Client) GetApprovalEvents(tokenAddress string, owner string, fromBlock, toBlock int64) (r *ResponseHandler[[]ApprovalEvent]) {
+	defer withOperation(&r, "GetApprovalEvents", tokenAddress)
+	if err := c.checkAddress("owner", owner); err != nil {
+		return &ResponseHandler[[]ApprovalEvent]{err: err}
+	}
+
+	events := c.GetTokenEvents(tokenAddress, EventFilter{
+		FromBlock:  fromBlock,
+		ToBlock:    toBlock,
+		EventTypes: []string{EventApproval},
+		Account:    owner,
+	})
+	if events.err != nil {
+		return &ResponseHandler[[]ApprovalEvent]{err: events.err}
+	}
+
+	approvals := []ApprovalEvent{}
+	for _, event := range events.data {
+		// The account filter also matches events where owner is the spender
+		if event.Type != EventApproval || !strings.EqualFold(event.Field("owner"), owner) {
+			continue
+		}
+		amount := event.Field("amount")
+		if amount == "" {
+			amount = event.Field("value")
+		}
+		if err := checkAmount("amount", amount); err != nil {
+			return &ResponseHandler[[]ApprovalEvent]{err: fmt.Errorf("decode approval at block %d: %w", event.BlockNumber, err)}
+		}
+		approvals = append(approvals, ApprovalEvent{
+			Owner:    checksummed(event.Field("owner")),
+			Spender:  checksummed(event.Field("spender")),
+			Amount:   amount,
+			Block:    event.BlockNumber,
+			TxHash:   event.TxHash,
+			LogIndex: event.LogIndex,
+		})
+	}
+
+	sort.SliceStable(approvals, func(i, j int) bool {
+		if approvals[i].Block != approvals[j].Block {
+			return approvals[i].Block < approvals[j].Block
+		}
+		return approvals[i].LogIndex < approvals[j].LogIndex
+	})
+	return &ResponseHandler[[]ApprovalEvent]{data: approvals}
+}
+
+// GetActiveAllowances lists the spenders with a non-zero allowance from owner, sorted by
+// spender address. Uses the server's get_allowances when available, otherwise replays all
+// Approval events of owner, keeping the last approved amount per spender. Replayed amounts
+// are the approved ones: spending through TransferFrom only shows when the token emits an
+// Approval for it.
+func (c *Client) GetActiveAllowances(tokenAddress, owner string) (r *ResponseHandler[[]Allowance]) {
+	defer withOperation(&r, "GetActiveAllowances", tokenAddress)
+	if err := c.checkAddress("owner", owner); err != nil {
+		return &ResponseHandler[[]Allowance]{err: err}
+	}
+
+	allowances, err := c.allowancesFromServer(tokenAddress, owner)
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return &ResponseHandler[[]Allowance]{err: err}
+		}
+		if allowances, err = c.allowancesFromEvents(tokenAddress, owner); err != nil {
+			return &ResponseHandler[[]Allowance]{err: err}
+		}
+	}
+
+	active := []Allowance{}
+	for _, allowance := range allowances {
+		if strings.TrimLeft(allowance.Amount, "0") == "" {
+			continue
+		}
+		allowance.Spender = checksummed(allowance.Spender)
+		active = append(active, allowance)
+	}
+	sort.Slice(active, func(i, j int) bool {
+		return strings.ToLower(active[i].Spender) < strings.ToLower(active[j].Spender)
+	})
+	return &ResponseHandler[[]Allowance]{data: active}
+}
+
+// Internal method: get_allowances for owner
+func (c *Client) allowancesFromServer(tokenAddress, owner string) ([]Allowance, error) {
+	result, err := c.rpcCall("get_allowances", map[string]interface{}{
+		"token": tokenAddress,
+		"owner": owner,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var allowances []Allowance
+	if err := json.Unmarshal(result, &allowances); err != nil {
+		return nil, fmt.Errorf("decode allowances: %w", err)
+	}
+	for _, allowance := range allowances {
+		if err := checkAmount("amount", allowance.Amount); err != nil {
+			return nil, fmt.Errorf("decode allowances: %w", err)
+		}
+	}
+	return allowances, nil
+}
+
+// Internal method: last approved amount per spender
+func (c *Client) allowancesFromEvents(tokenAddress, owner string) ([]Allowance, error) {
+	approvals := c.GetApprovalEvents(tokenAddress, owner, 0, 0)
+	if approvals.err != nil {
+		return nil, approvals.err
+	}
+
+	var order []string
+	latest := map[string]string{}
+	for _, approval := range approvals.data {
+		key := strings.ToLower(approval.Spender)
+		if _, seen := latest[key]; !seen {
+			order = append(order, approval.Spender)
+		}
+		latest[key] = approval.Amount
+	}
+
+	allowances := make([]Allowance, 0, len(order))
+	for _, spender := range order {
+		allowances = append(allowances, Allowance{Spender: spender, Amount: latest[strings.ToLower(spender)]})
+	}
+	return allowances, nil
+}
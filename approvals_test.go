@@ -0,0 +1,114 @@
+package alchemy_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+const (
+	spenderA = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+	spenderB = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+	spenderC = "0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65"
+	owner    = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+// approvalChain is a synthetic history across three 5000-block chunks: A is approved and
+// later reset to zero, B is raised, and another owner's approval names owner as spender
+func approvalChain() []alchemy.TokenEvent {
+	approval := func(block, logIndex int64, from, spender, amount string) alchemy.TokenEvent {
+		return alchemy.TokenEvent{Type: alchemy.EventApproval, BlockNumber: block, LogIndex: logIndex, TxHash: "0xtx",
+			Fields: map[string]interface{}{"owner": strings.ToLower(from), "spender": strings.ToLower(spender), "amount": amount}}
+	}
+	return []alchemy.TokenEvent{
+		approval(10, 0, owner, spenderA, "100"),
+		approval(10, 3, owner, spenderB, "50"),
+		{Type: alchemy.EventTransfer, BlockNumber: 4000, Fields: map[string]interface{}{"from": owner, "to": spenderC, "amount": "5"}},
+		approval(6000, 1, spenderC, owner, "999"),
+		approval(7500, 0, owner, spenderA, "0"),
+		approval(11000, 2, owner, spenderC, "10"),
+		approval(11999, 0, owner, spenderB, "75"),
+	}
+}
+
+func TestGetApprovalEvents(t *testing.T) {
+	srv := newEventLog(t, 12000, approvalChain())
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approvals, err := client.GetApprovalEvents(testToken, owner, 0, 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []alchemy.ApprovalEvent{
+		{Owner: owner, Spender: spenderA, Amount: "100", Block: 10, TxHash: "0xtx", LogIndex: 0},
+		{Owner: owner, Spender: spenderB, Amount: "50", Block: 10, TxHash: "0xtx", LogIndex: 3},
+		{Owner: owner, Spender: spenderA, Amount: "0", Block: 7500, TxHash: "0xtx", LogIndex: 0},
+		{Owner: owner, Spender: spenderC, Amount: "10", Block: 11000, TxHash: "0xtx", LogIndex: 2},
+		{Owner: owner, Spender: spenderB, Amount: "75", Block: 11999, TxHash: "0xtx", LogIndex: 0},
+	}
+	if !reflect.DeepEqual(approvals, want) {
+		t.Fatalf("got %+v\nwant %+v", approvals, want)
+	}
+
+	// A sub-range
+	approvals, err = client.GetApprovalEvents(testToken, owner, 5000, 9999).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(approvals, want[2:3]) {
+		t.Fatalf("got %+v", approvals)
+	}
+
+	if err := client.GetApprovalEvents(testToken, "0x12", 0, 0).Err(); err == nil {
+		t.Fatal("invalid owner accepted")
+	}
+}
+
+func TestGetActiveAllowancesReplaysEvents(t *testing.T) {
+	srv := newEventLog(t, 12000, approvalChain())
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowances, err := client.GetActiveAllowances(testToken, owner).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A was reset to zero, B's last approval wins
+	want := []alchemy.Allowance{{Spender: spenderC, Amount: "10"}, {Spender: spenderB, Amount: "75"}}
+	if !reflect.DeepEqual(allowances, want) {
+		t.Fatalf("got %+v\nwant %+v", allowances, want)
+	}
+}
+
+func TestGetActiveAllowancesFromServer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_allowances", []map[string]string{
+		{"spender": strings.ToLower(spenderB), "amount": "75"},
+		{"spender": spenderA, "amount": "0"},
+		{"spender": spenderC, "amount": "10"},
+	})
+
+	allowances, err := client.GetActiveAllowances(testToken, owner).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []alchemy.Allowance{{Spender: spenderC, Amount: "10"}, {Spender: spenderB, Amount: "75"}}
+	if !reflect.DeepEqual(allowances, want) {
+		t.Fatalf("got %+v\nwant %+v", allowances, want)
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatalf("%d event queries", n)
+	}
+
+	srv.SetResult("get_allowances", []map[string]string{{"spender": spenderC, "amount": "-1"}})
+	if err := client.GetActiveAllowances(testToken, owner).Err(); err == nil {
+		t.Fatal("negative allowance accepted")
+	}
+}
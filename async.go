@@ -0,0 +1,176 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// ErrNilFuture is returned by All, AllErrors, Join2 and Join3 when a future is nil
+var ErrNilFuture = errors.New("nil future")
+
+// Future is the pending result of a call started with Go
+type Future[T any] struct {
+	done   chan struct{}
+	result *ResponseHandler[T]
+}
+
+// Go runs call in a new goroutine and returns immediately, e.g. to fire several reads
+// concurrently:
+//
+//	meta := alchemy.Go(func() *alchemy.ResponseHandler[*alchemy.TokenMetadata] {
+//		return client.GetTokenMetadata(token)
+//	})
+//	cap := alchemy.Go(func() *alchemy.ResponseHandler[string] { return client.GetSupplyCap(token) })
+//	meta.Await(ctx).Success(...)
+//
+// A panic in call is recovered into a *CallbackPanicError.
+func Go[T any](call func() *ResponseHandler[T]) *Future[T] {
+	f := newFuture[T]()
+	go func() {
+		var result *ResponseHandler[T]
+		defer func() {
+			if value := recover(); value != nil {
+				result = &ResponseHandler[T]{err: &CallbackPanicError{Value: value, Stack: debug.Stack()}}
+			}
+			f.complete(result)
+		}()
+		result = call()
+	}()
+	return f
+}
+
+// Internal method: pending future, completed with complete
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// Internal method: set the result and release waiters, once
+func (f *Future[T]) complete(result *ResponseHandler[T]) {
+	if result == nil {
+		result = &ResponseHandler[T]{}
+	}
+	f.result = result
+	close(f.done)
+}
+
+// Done is closed when the call has finished
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await waits for the call to finish and returns its handler. If ctx ends first the wait
+// is abandoned and the returned handler holds ctx.Err(); the call itself keeps running to
+// completion in the background and its result can still be awaited later.
+func (f *Future[T]) Await(ctx context.Context) *ResponseHandler[T] {
+	select {
+	case <-f.done:
+		return f.result
+	case <-ctx.Done():
+		return &ResponseHandler[T]{err: ctx.Err()}
+	}
+}
+
+// Result waits for the call to finish and returns its result and error
+func (f *Future[T]) Result() (T, error) {
+	<-f.done
+	return f.result.Result()
+}
+
+// Err waits for the call to finish and returns its error
+func (f *Future[T]) Err() error {
+	<-f.done
+	return f.result.Err()
+}
+
+// Awaitable is a pending call, implemented by *Future
+type Awaitable interface {
+	Done() <-chan struct{}
+	Err() error
+}
+
+// All waits for every future and returns nil if all succeeded. It returns as soon as one
+// fails, with that error, or when ctx ends, with ctx.Err(); the remaining calls keep
+// running and can still be awaited individually. No goroutines are started. A nil future
+// fails with ErrNilFuture before anything is awaited.
+func All(ctx context.Context, futures ...Awaitable) error {
+	return awaitAll(ctx, futures, true)
+}
+
+// AllErrors waits for every future and returns the errors of all failed calls joined with
+// errors.Join, or nil. If ctx ends first, ctx.Err() is joined in and the wait is abandoned.
+func AllErrors(ctx context.Context, futures ...Awaitable) error {
+	return awaitAll(ctx, futures, false)
+}
+
+// Join2 waits for two futures and returns both results, or the first error as in All
+func Join2[A, B any](ctx context.Context, fa *Future[A], fb *Future[B]) (A, B, error) {
+	var a A
+	var b B
+	if err := All(ctx, fa, fb); err != nil {
+		return a, b, err
+	}
+	a, _ = fa.Result()
+	b, _ = fb.Result()
+	return a, b, nil
+}
+
+// Join3 waits for three futures and returns all results, or the first error as in All
+func Join3[A, B, C any](ctx context.Context, fa *Future[A], fb *Future[B], fc *Future[C]) (A, B, C, error) {
+	var a A
+	var b B
+	var c C
+	if err := All(ctx, fa, fb, fc); err != nil {
+		return a, b, c, err
+	}
+	a, _ = fa.Result()
+	b, _ = fb.Result()
+	c, _ = fc.Result()
+	return a, b, c, nil
+}
+
+// Internal method: wait on the futures' Done channels in completion order
+func awaitAll(ctx context.Context, futures []Awaitable, failFast bool) error {
+	pending := make([]Awaitable, 0, len(futures))
+	for i, f := range futures {
+		// A nil *Future in the interface is not == nil, check the pointer too
+		if f == nil || isNilPointer(f) {
+			return fmt.Errorf("%w at position %d", ErrNilFuture, i)
+		}
+		pending = append(pending, f)
+	}
+
+	var errs []error
+	for len(pending) > 0 {
+		cases := make([]reflect.SelectCase, 0, len(pending)+1)
+		for _, f := range pending {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(f.Done())})
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+
+		chosen, _, _ := reflect.Select(cases)
+		if chosen == len(pending) {
+			if failFast {
+				return ctx.Err()
+			}
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+
+		if err := pending[chosen].Err(); err != nil {
+			if failFast {
+				return err
+			}
+			errs = append(errs, err)
+		}
+		pending = append(pending[:chosen], pending[chosen+1:]...)
+	}
+	return errors.Join(errs...)
+}
+
+// Internal method: whether v holds a nil pointer
+func isNilPointer(v interface{}) bool {
+	value := reflect.ValueOf(v)
+	return value.Kind() == reflect.Pointer && value.IsNil()
+}
@@ -0,0 +1,203 @@
+package alchemy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestAllRejectsNilFutures(t *testing.T) {
+	ok := alchemy.Go(func() *alchemy.ResponseHandler[int] { return nil })
+	var typedNil *alchemy.Future[int]
+
+	for name, futures := range map[string][]alchemy.Awaitable{
+		"untyped nil": {ok, nil},
+		"typed nil":   {ok, typedNil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := alchemy.All(context.Background(), futures...); !errors.Is(err, alchemy.ErrNilFuture) {
+				t.Fatalf("All: err = %v, want ErrNilFuture", err)
+			}
+			if err := alchemy.AllErrors(context.Background(), futures...); !errors.Is(err, alchemy.ErrNilFuture) {
+				t.Fatalf("AllErrors: err = %v, want ErrNilFuture", err)
+			}
+		})
+	}
+
+	if _, _, err := alchemy.Join2[int, int](context.Background(), ok, nil); !errors.Is(err, alchemy.ErrNilFuture) {
+		t.Fatalf("Join2: err = %v, want ErrNilFuture", err)
+	}
+}
+
+func TestAllFailFastAndAllErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	release := make(chan struct{})
+	slow := alchemy.Go(func() *alchemy.ResponseHandler[int] {
+		<-release
+		return nil
+	})
+	defer close(release)
+	failA := alchemy.Go(func() *alchemy.ResponseHandler[int] { return errorHandler[int](errA) })
+
+	if err := alchemy.All(context.Background(), slow, failA); !errors.Is(err, errA) {
+		t.Fatalf("All: err = %v, want a", err)
+	}
+
+	failB := alchemy.Go(func() *alchemy.ResponseHandler[string] { return errorHandler[string](errB) })
+	err := alchemy.AllErrors(context.Background(), failA, failB)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Fatalf("AllErrors: err = %v, want a and b", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := alchemy.All(ctx, slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("All: err = %v, want the deadline", err)
+	}
+}
+
+func TestGoRecoversPanics(t *testing.T) {
+	f := alchemy.Go(func() *alchemy.ResponseHandler[int] { panic("boom") })
+	var panicErr *alchemy.CallbackPanicError
+	if err := f.Err(); !errors.As(err, &panicErr) || panicErr.Value != "boom" {
+		t.Fatalf("err = %v, want a *CallbackPanicError", err)
+	}
+}
+
+func TestGoRunsReadsInParallel(t *testing.T) {
+	const (
+		reads = 50
+		delay = 200 * time.Millisecond
+	)
+	var inFlight, peak atomic.Int64
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		time.Sleep(delay)
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+	}))
+	t.Cleanup(slow.Close)
+	client, err := alchemy.NewClient(slow.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	futures := make([]alchemy.Awaitable, reads)
+	for i := range futures {
+		futures[i] = alchemy.Go(func() *alchemy.ResponseHandler[*alchemy.BalanceInfo] {
+			return client.GetBalance(testRecipient)
+		})
+	}
+	if err := alchemy.All(context.Background(), futures...); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(start)
+
+	// Sequentially this takes reads*delay = 10s
+	if elapsed > 10*delay {
+		t.Fatalf("%d reads took %v, want them to overlap", reads, elapsed)
+	}
+	if peak.Load() < reads/2 {
+		t.Fatalf("at most %d requests in flight at once", peak.Load())
+	}
+}
+
+func TestAwaitAbandonedWithContext(t *testing.T) {
+	release := make(chan struct{})
+	f := alchemy.Go(func() *alchemy.ResponseHandler[int] {
+		<-release
+		return &alchemy.ResponseHandler[int]{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := f.Await(ctx).Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+
+	// The call keeps running and can be awaited later
+	close(release)
+	if err := f.Await(context.Background()).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJoinMixedResults(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getSupplyCap", "1000")
+	srv.SetError("getTokenMetadata", -32000, "unknown token")
+
+	balance := alchemy.Go(func() *alchemy.ResponseHandler[*alchemy.BalanceInfo] { return client.GetBalance(testRecipient) })
+	supplyCap := alchemy.Go(func() *alchemy.ResponseHandler[string] { return client.GetSupplyCap(testToken) })
+	gotBalance, gotCap, err := alchemy.Join2(context.Background(), balance, supplyCap)
+	if err != nil || gotBalance.Wei != "0" || gotCap != "1000" {
+		t.Fatalf("Join2 = %+v, %q, %v", gotBalance, gotCap, err)
+	}
+
+	meta := alchemy.Go(func() *alchemy.ResponseHandler[*alchemy.TokenMetadata] { return client.GetTokenMetadata(testToken) })
+	a, b, c, err := alchemy.Join3(context.Background(), balance, supplyCap, meta)
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) || a != nil || b != "" || c != nil {
+		t.Fatalf("Join3 = %v, %q, %v, %v; want zero values and the *RPCError", a, b, c, err)
+	}
+
+	// Results stay available individually after a failed join
+	if got, err := supplyCap.Result(); err != nil || got != "1000" {
+		t.Fatalf("supplyCap = %q, %v", got, err)
+	}
+	if err := alchemy.AllErrors(context.Background(), balance, supplyCap, meta); !errors.As(err, &rpcErr) {
+		t.Fatalf("AllErrors = %v", err)
+	}
+}
+
+func TestAwaitingLeaksNoGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	errFail := errors.New("fail")
+	for i := 0; i < 100; i++ {
+		release := make(chan struct{})
+		slow := alchemy.Go(func() *alchemy.ResponseHandler[int] {
+			<-release
+			return &alchemy.ResponseHandler[int]{}
+		})
+		fail := alchemy.Go(func() *alchemy.ResponseHandler[int] { return errorHandler[int](errFail) })
+
+		// Fail-fast returns while slow is still pending, and a cancelled wait returns early
+		if err := alchemy.All(context.Background(), slow, fail); !errors.Is(err, errFail) {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		alchemy.AllErrors(ctx, slow, fail)
+		close(release)
+		slow.Err()
+	}
+
+	// Only the calls' own goroutines were started, and they have all finished
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines, %d before", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
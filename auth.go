@@ -0,0 +1,69 @@
+package alchemy
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Credential values are only ever written to request headers, never included in errors or logs.
+
+// ConfigBearerToken sends "Authorization: Bearer <token>" with every HTTP and WebSocket
+// request. An empty token disables bearer authentication.
+func ConfigBearerToken(token string) {
+	defaultClient.setBearerToken(token)
+}
+
+// ConfigTokenProvider sets a bearer token source that is called for every request, for
+// short-lived credentials that are refreshed at runtime. A provider error fails the request.
+func ConfigTokenProvider(provider func() (string, error)) {
+	defaultClient.authMu.Lock()
+	defer defaultClient.authMu.Unlock()
+	defaultClient.tokenProvider = provider
+}
+
+// ConfigAPIKeyHeader sends header name with value on every request, e.g.
+// ConfigAPIKeyHeader("X-API-Key", key). An empty value removes the header.
+func ConfigAPIKeyHeader(name, value string) {
+	c := defaultClient
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	name = http.CanonicalHeaderKey(name)
+	if value == "" {
+		delete(c.apiKeyHeaders, name)
+		return
+	}
+	c.apiKeyHeaders[name] = value
+}
+
+// Internal method: set or clear a static bearer token
+func (c *Client) setBearerToken(token string) {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	if token == "" {
+		c.tokenProvider = nil
+		return
+	}
+	c.tokenProvider = func() (string, error) { return token, nil }
+}
+
+// Internal method: add the configured credentials to header
+func (c *Client) applyAuth(header http.Header) error {
+	c.authMu.RLock()
+	provider := c.tokenProvider
+	for name, value := range c.apiKeyHeaders {
+		header.Set(name, value)
+	}
+	c.authMu.RUnlock()
+
+	if provider == nil {
+		return nil
+	}
+	token, err := provider()
+	if err != nil {
+		return fmt.Errorf("auth token provider: %w", err)
+	}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
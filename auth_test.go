@@ -0,0 +1,100 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestBearerTokenOnEveryRequest(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	srv, client, _ := newTestServer(t, alchemy.WithBearerToken("s3cr3t-token"), alchemy.WithLogger(logger))
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.Requests()
+	methods := map[string]bool{}
+	for _, req := range reqs {
+		methods[req.Method] = true
+		if got := req.Header.Get("Authorization"); got != "Bearer s3cr3t-token" {
+			t.Fatalf("%s %s: Authorization = %q", req.Path, req.Method, got)
+		}
+	}
+	// The pre-flight checkpoint lookup is a node call the caller never sees
+	for _, method := range []string{"eth_blockNumber", "mint", "eth_getBalance"} {
+		if !methods[method] {
+			t.Fatalf("no %s request among %v", method, methods)
+		}
+	}
+	if logs.Len() == 0 {
+		t.Fatal("nothing logged at debug level")
+	}
+	if strings.Contains(logs.String(), "s3cr3t-token") {
+		t.Fatalf("token logged:\n%s", logs.String())
+	}
+}
+
+func TestTokenProviderRefreshes(t *testing.T) {
+	var calls atomic.Int64
+	provider := func() (string, error) {
+		return fmt.Sprintf("token-%d", calls.Add(1)), nil
+	}
+	srv, client, _ := newTestServer(t, alchemy.WithTokenProvider(provider))
+
+	for i := 0; i < 2; i++ {
+		if err := client.GetBalance(testRecipient).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reqs := srv.RequestsFor("eth_getBalance")
+	first, second := reqs[0].Header.Get("Authorization"), reqs[1].Header.Get("Authorization")
+	if !strings.HasPrefix(first, "Bearer token-") || first == second {
+		t.Fatalf("Authorization %q then %q, want a fresh token per request", first, second)
+	}
+}
+
+func TestTokenProviderErrorFailsRequest(t *testing.T) {
+	errExpired := errors.New("credentials expired")
+	srv, client, _ := newTestServer(t, alchemy.WithTokenProvider(func() (string, error) { return "", errExpired }))
+
+	if err := client.GetBalance(testRecipient).Err(); !errors.Is(err, errExpired) {
+		t.Fatalf("err = %v, want the provider error", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent without credentials", n)
+	}
+}
+
+func TestAPIKeyHeader(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	useDefaultClient(t, client)
+
+	alchemy.ConfigAPIKeyHeader("x-api-key", "k1")
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	alchemy.ConfigAPIKeyHeader("X-API-Key", "")
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := srv.RequestsFor("eth_getBalance")
+	if got := reqs[0].Header.Get("X-Api-Key"); got != "k1" {
+		t.Fatalf("X-Api-Key = %q", got)
+	}
+	if got := reqs[1].Header.Get("X-Api-Key"); got != "" {
+		t.Fatalf("X-Api-Key = %q after removal", got)
+	}
+}
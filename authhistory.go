@@ -0,0 +1,187 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// authorityHistoryPageSize is the number of changes requested per get_authority_history page
+const authorityHistoryPageSize = 100
+
+// Authority change actions
+const (
+	AuthorityGrant    = "grant"
+	AuthorityRevoke   = "revoke"
+	AuthorityRenounce = "renounce" // a revoke the account signed itself
+)
+
+// AuthorityFilter selects authority changes, empty fields don't filter
+type AuthorityFilter struct {
+	Role      Role
+	Account   string // the account granted or losing the role
+	FromBlock int64
+	ToBlock   int64 // 0 means the latest block
+}
+
+// AuthorityChange is one grant, revoke or renounce of a role
+type AuthorityChange struct {
+	Action   string `json:"action"` // AuthorityGrant, AuthorityRevoke or AuthorityRenounce
+	Role     Role   `json:"role"`   // role name; role hashes unknown to the SDK are kept as sent
+	Account  string `json:"account"`
+	Signer   string `json:"signer"` // checksummed address that signed the change
+	Block    int64  `json:"block"`
+	TxHash   string `json:"txHash"`
+	LogIndex int64  `json:"logIndex"`
+}
+
+// authorityHistoryPage is one page of get_authority_history
+type authorityHistoryPage struct {
+	Changes    []AuthorityChange `json:"changes"`
+	NextCursor string            `json:"nextCursor"` // empty on the last page
+}
+
+// GetAuthorityHistory lists the role grants, revokes and renounces of a token matching
+// filter, oldest first (by block, then log index), e.g. as an audit trail. Uses the server's
+// get_authority_history when available, fetching every page, otherwise decodes the
+// AuthorityGranted and AuthorityRevoked events of the block range; a revoke signed by the
+// account itself is reported as a renounce.
+func (c *Client) GetAuthorityHistory(tokenAddress string, filter AuthorityFilter) (r *ResponseHandler[[]AuthorityChange]) {
+	defer withOperation(&r, "GetAuthorityHistory", tokenAddress)
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return &ResponseHandler[[]AuthorityChange]{err: err}
+	}
+	if filter.Account != "" {
+		if err := c.checkAddress("account", filter.Account); err != nil {
+			return &ResponseHandler[[]AuthorityChange]{err: err}
+		}
+	}
+	if filter.Role != "" {
+		if err := filter.Role.Validate(); err != nil {
+			return &ResponseHandler[[]AuthorityChange]{err: err}
+		}
+	}
+	if filter.FromBlock < 0 || filter.ToBlock < 0 || (filter.ToBlock != 0 && filter.ToBlock < filter.FromBlock) {
+		return &ResponseHandler[[]AuthorityChange]{err: fmt.Errorf("invalid block range %d-%d", filter.FromBlock, filter.ToBlock)}
+	}
+
+	changes, err := c.authorityHistoryFromServer(tokenAddress, filter)
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return &ResponseHandler[[]AuthorityChange]{err: err}
+		}
+		if changes, err = c.authorityHistoryFromEvents(tokenAddress, filter); err != nil {
+			return &ResponseHandler[[]AuthorityChange]{err: err}
+		}
+	}
+
+	matching := []AuthorityChange{}
+	for _, change := range changes {
+		if name := roleName(change.Role.String()); name != "" {
+			change.Role = Role(name)
+		}
+		change.Action = strings.ToLower(change.Action)
+		change.Account = checksummed(change.Account)
+		change.Signer = checksummed(change.Signer)
+		if filter.Role != "" && change.Role != filter.Role {
+			continue
+		}
+		if filter.Account != "" && !strings.EqualFold(change.Account, filter.Account) {
+			continue
+		}
+		matching = append(matching, change)
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		if matching[i].Block != matching[j].Block {
+			return matching[i].Block < matching[j].Block
+		}
+		return matching[i].LogIndex < matching[j].LogIndex
+	})
+	return &ResponseHandler[[]AuthorityChange]{data: matching}
+}
+
+// Internal method: all pages of get_authority_history
+func (c *Client) authorityHistoryFromServer(tokenAddress string, filter AuthorityFilter) ([]AuthorityChange, error) {
+	params := map[string]interface{}{
+		"token": tokenAddress,
+		"limit": authorityHistoryPageSize,
+	}
+	if filter.Role != "" {
+		params["role"] = filter.Role.String()
+	}
+	if filter.Account != "" {
+		params["account"] = filter.Account
+	}
+	if filter.FromBlock != 0 {
+		params["fromBlock"] = filter.FromBlock
+	}
+	if filter.ToBlock != 0 {
+		params["toBlock"] = filter.ToBlock
+	}
+
+	changes := []AuthorityChange{}
+	cursor := ""
+	for {
+		params["cursor"] = cursor
+		result, err := c.rpcCall("get_authority_history", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var page authorityHistoryPage
+		if err := json.Unmarshal(result, &page); err != nil {
+			return nil, fmt.Errorf("decode authority history: %w", err)
+		}
+		changes = append(changes, page.Changes...)
+
+		if page.NextCursor == "" {
+			return changes, nil
+		}
+		if page.NextCursor == cursor {
+			return nil, fmt.Errorf("decode authority history: cursor %q repeated", cursor)
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// Internal method: rebuild the history from AuthorityGranted and AuthorityRevoked events
+func (c *Client) authorityHistoryFromEvents(tokenAddress string, filter AuthorityFilter) ([]AuthorityChange, error) {
+	events := c.GetTokenEvents(tokenAddress, EventFilter{
+		FromBlock:  filter.FromBlock,
+		ToBlock:    filter.ToBlock,
+		EventTypes: []string{EventAuthorityGranted, EventAuthorityRevoked},
+		Account:    filter.Account,
+	})
+	if events.err != nil {
+		return nil, events.err
+	}
+
+	changes := make([]AuthorityChange, 0, len(events.data))
+	for _, event := range events.data {
+		change := AuthorityChange{
+			Role:     Role(event.Field("role")),
+			Account:  event.Field("account"),
+			Signer:   event.Field("sender"),
+			Block:    event.BlockNumber,
+			TxHash:   event.TxHash,
+			LogIndex: event.LogIndex,
+		}
+		if change.Signer == "" {
+			change.Signer = event.Field("authority")
+		}
+		switch event.Type {
+		case EventAuthorityGranted:
+			change.Action = AuthorityGrant
+		case EventAuthorityRevoked:
+			change.Action = AuthorityRevoke
+			if strings.EqualFold(change.Signer, change.Account) {
+				change.Action = AuthorityRenounce
+			}
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
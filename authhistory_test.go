@@ -0,0 +1,161 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// loadAuthorityChanges reads the fixture of five changes as the server sends them:
+// lower-case addresses and one role as its AccessControl hash
+func loadAuthorityChanges(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile("testdata/authority_history.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes []map[string]interface{}
+	if err := json.Unmarshal(data, &changes); err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+const (
+	historyMaster = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	historyAlice  = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	historyBob    = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+)
+
+// wantAuthorityChanges is the decoded fixture
+var wantAuthorityChanges = []alchemy.AuthorityChange{
+	{Action: alchemy.AuthorityGrant, Role: alchemy.RoleMint, Account: historyAlice, Signer: historyMaster, Block: 100,
+		TxHash: "0x1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f801", LogIndex: 0},
+	{Action: alchemy.AuthorityGrant, Role: alchemy.RoleBurn, Account: historyBob, Signer: historyMaster, Block: 100,
+		TxHash: "0x1a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f802", LogIndex: 3},
+	{Action: alchemy.AuthorityRevoke, Role: alchemy.RoleMint, Account: historyAlice, Signer: historyMaster, Block: 250,
+		TxHash: "0x2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f80213", LogIndex: 1},
+	{Action: alchemy.AuthorityGrant, Role: alchemy.RoleMint, Account: historyBob, Signer: historyMaster, Block: 300,
+		TxHash: "0x3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8021324", LogIndex: 0},
+	{Action: alchemy.AuthorityRenounce, Role: alchemy.RoleBurn, Account: historyBob, Signer: historyBob, Block: 410,
+		TxHash: "0x4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f802132435", LogIndex: 2},
+}
+
+func TestGetAuthorityHistoryFromServer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	fixture := loadAuthorityChanges(t)
+	srv.QueueResponse("get_authority_history",
+		alchemytest.Response{Result: map[string]interface{}{"changes": fixture[:3], "nextCursor": "c3"}},
+		alchemytest.Response{Result: map[string]interface{}{"changes": fixture[3:], "nextCursor": ""}},
+	)
+
+	changes, err := client.GetAuthorityHistory(testToken, alchemy.AuthorityFilter{}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, wantAuthorityChanges) {
+		t.Fatalf("got %+v\nwant %+v", changes, wantAuthorityChanges)
+	}
+	requests := srv.RequestsFor("get_authority_history")
+	if len(requests) != 2 {
+		t.Fatalf("%d pages requested, want 2", len(requests))
+	}
+	for i, want := range []string{"", "c3"} {
+		if got := fmt.Sprint(requests[i].ParamMap["cursor"]); got != want {
+			t.Fatalf("page %d cursor %q, want %q", i, got, want)
+		}
+	}
+
+	// The filter is sent, and applied to servers ignoring it
+	srv.SetResult("get_authority_history", map[string]interface{}{"changes": fixture})
+	filter := alchemy.AuthorityFilter{Role: alchemy.RoleMint, Account: historyBob, FromBlock: 50, ToBlock: 500}
+	changes, err = client.GetAuthorityHistory(testToken, filter).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, wantAuthorityChanges[3:4]) {
+		t.Fatalf("filtered %+v", changes)
+	}
+	params := srv.RequestsFor("get_authority_history")[2].ParamMap
+	if params["role"] != "MINT_ROLE" || params["account"] != historyBob || fmt.Sprint(params["fromBlock"]) != "50" || fmt.Sprint(params["toBlock"]) != "500" {
+		t.Fatalf("params %v", params)
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatalf("%d event queries", n)
+	}
+}
+
+func TestGetAuthorityHistoryFromEvents(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(600)
+	srv.SetError("get_authority_history", alchemytest.CodeMethodNotFound, "method not found")
+
+	// Newest first; the renounce is a revoke the account sent itself
+	var events []map[string]interface{}
+	for _, change := range loadAuthorityChanges(t) {
+		eventType := alchemy.EventAuthorityGranted
+		if change["action"] != "grant" {
+			eventType = alchemy.EventAuthorityRevoked
+		}
+		events = append([]map[string]interface{}{{
+			"type": eventType, "blockNumber": change["block"], "txHash": change["txHash"], "logIndex": change["logIndex"],
+			"fields": map[string]interface{}{"role": change["role"], "account": change["account"], "sender": change["signer"]},
+		}}, events...)
+	}
+	srv.SetResult("get_token_events", events)
+
+	changes, err := client.GetAuthorityHistory(testToken, alchemy.AuthorityFilter{}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, wantAuthorityChanges) {
+		t.Fatalf("got %+v\nwant %+v", changes, wantAuthorityChanges)
+	}
+
+	changes, err = client.GetAuthorityHistory(testToken, alchemy.AuthorityFilter{Role: alchemy.RoleBurn, FromBlock: 90, ToBlock: 450}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, []alchemy.AuthorityChange{wantAuthorityChanges[1], wantAuthorityChanges[4]}) {
+		t.Fatalf("burn changes %+v", changes)
+	}
+	query := srv.RequestsFor("get_token_events")[1].ParamMap
+	if fmt.Sprint(query["eventTypes"]) != "[AuthorityGranted AuthorityRevoked]" || fmt.Sprint(query["fromBlock"]) != "90" {
+		t.Fatalf("event query %v", query)
+	}
+}
+
+func TestGetAuthorityHistoryErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	for name, filter := range map[string]alchemy.AuthorityFilter{
+		"reversed range": {FromBlock: 10, ToBlock: 5},
+		"negative block": {FromBlock: -1},
+		"bad account":    {Account: "0x12"},
+		"bad role":       {Role: "mint_role"},
+	} {
+		if err := client.GetAuthorityHistory(testToken, filter).Err(); err == nil {
+			t.Fatalf("%s accepted", name)
+		}
+	}
+	if len(srv.Requests()) != 0 {
+		t.Fatal("invalid filters sent")
+	}
+
+	srv.SetError("get_authority_history", -32000, "token not found")
+	if err := client.GetAuthorityHistory(testToken, alchemy.AuthorityFilter{}).Err(); err == nil {
+		t.Fatal("server error not returned")
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatal("fell back to events after a server error")
+	}
+
+	srv.SetResult("get_authority_history", map[string]interface{}{"changes": []interface{}{}, "nextCursor": "same"})
+	if err := client.GetAuthorityHistory(testToken, alchemy.AuthorityFilter{}).Err(); err == nil {
+		t.Fatal("repeated cursor accepted")
+	}
+}
@@ -0,0 +1,177 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// AuthorityError is returned by writes the server rejected because the signer lacks a role
+// on the token, and by writes stopped by the authority preflight (see
+// WithAuthorityPreflight). It matches ErrPermissionDenied with errors.Is; for server
+// rejections errors.As still yields the *RPCError.
+type AuthorityError struct {
+	Token        string // checksummed token address
+	RequiredRole string // e.g. "MINT_ROLE", empty when neither the server nor the method tells
+	Signer       string // checksummed address of the configured key, empty without a key
+	Err          error  // the server's error, nil for preflight failures
+}
+
+func (e *AuthorityError) Error() string {
+	signer := e.Signer
+	if signer == "" {
+		signer = "signer"
+	}
+	role := e.RequiredRole
+	if role == "" {
+		role = "the required role"
+	}
+	msg := fmt.Sprintf("%s lacks %s on token %s", signer, role, e.Token)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *AuthorityError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes errors.Is(err, ErrPermissionDenied) match preflight failures too
+func (e *AuthorityError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// methodRoles is the role each built-in write requires, used for the preflight and when the
+// server doesn't name the missing role. Seize is left out: tokens differ in what it needs.
+var methodRoles = map[string]Role{
+	"mint":                    RoleMint,
+	"burn":                    RoleBurn,
+	"adminBurn":               RoleBurn,
+	"pause":                   RolePause,
+	"unpause":                 RolePause,
+	"addToBlacklist":          RoleBlacklist,
+	"removeFromBlacklist":     RoleBlacklist,
+	"grantAuthority":          RoleMaster,
+	"revokeAuthority":         RoleMaster,
+	"transferMasterAuthority": RoleMaster,
+	"updateMetadata":          RoleMaster,
+	"setSupplyCap":            RoleMaster,
+	"retireToken":             RoleMaster,
+}
+
+// roleHashes maps the keccak256 role identifiers of AccessControl contracts back to role
+// names. The all-zero DEFAULT_ADMIN_ROLE is the master role.
+var roleHashes = func() map[string]Role {
+	hashes := map[string]Role{"0x" + strings.Repeat("0", 64): RoleMaster}
+	for _, role := range knownRoles {
+		hashes["0x"+fmt.Sprintf("%x", crypto.Keccak256([]byte(role)))] = role
+	}
+	return hashes
+}()
+
+// roleInMessage finds role names ("MINT_ROLE") and role hashes in error messages, e.g.
+// "AccessControl: account 0x... is missing role 0x9f2d..."
+var roleInMessage = regexp.MustCompile(`\b[A-Z][A-Z0-9]*_ROLE\b|0x[0-9a-fA-F]{64}\b`)
+
+// ConfigAuthorityPreflight switches the authority preflight of the default client on or
+// off (see WithAuthorityPreflight)
+func ConfigAuthorityPreflight(enabled bool) {
+	defaultClient.authorityCheck = enabled
+}
+
+// WithAuthorityPreflight checks with HasAuthority that the signer holds the role a built-in
+// write needs (MINT_ROLE for mint, ...) before signing it, and fails with an AuthorityError
+// without sending the write otherwise, so a missing role doesn't consume a nonce. Costs one
+// read per write; writes through CallWrite with other methods aren't checked.
+func WithAuthorityPreflight() Option {
+	return func(o *clientOptions) { o.authorityCheck = true }
+}
+
+// Internal method: fail with an AuthorityError when the signer lacks the role method needs
+func (c *Client) checkAuthority(tokenAddress, method string, opts ...CallOption) error {
+	role, ok := methodRoles[method]
+	if !ok || !c.authorityCheck {
+		return nil
+	}
+	signer, err := c.signerAddress(c.newCallConfig(opts).context())
+	if err != nil {
+		return err
+	}
+	held := c.HasAuthority(tokenAddress, role, signer, opts...)
+	if held.err != nil {
+		return fmt.Errorf("authority preflight: %w", held.err)
+	}
+	if !held.data {
+		return &AuthorityError{Token: checksummed(tokenAddress), RequiredRole: role.String(), Signer: signer}
+	}
+	return nil
+}
+
+// Internal method: turn a permission-denied rejection of method into an AuthorityError
+func (c *Client) authorityError(ctx context.Context, tokenAddress, method string, err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || classifyRPCError(rpcErr) != ErrPermissionDenied {
+		return err
+	}
+	var authErr *AuthorityError
+	if errors.As(err, &authErr) {
+		return err
+	}
+
+	role := requiredRole(rpcErr)
+	if role == "" {
+		role = methodRoles[method].String()
+	}
+	signer, _ := c.signerAddress(ctx) // the write was signed, so this only fails in odd setups
+	return &AuthorityError{Token: checksummed(tokenAddress), RequiredRole: role, Signer: signer, Err: err}
+}
+
+// Internal method: the role named by the error data or else the message, "" if none
+func requiredRole(e *RPCError) string {
+	if len(e.Data) > 0 {
+		var data struct {
+			RequiredRole string `json:"requiredRole"`
+			Role         string `json:"role"`
+		}
+		var text string
+		if json.Unmarshal(e.Data, &data) == nil {
+			text = data.RequiredRole
+			if text == "" {
+				text = data.Role
+			}
+		} else if json.Unmarshal(e.Data, &text) != nil {
+			text = ""
+		}
+		if role := roleName(text); role != "" {
+			return role
+		}
+	}
+
+	for _, match := range roleInMessage.FindAllString(e.Message, -1) {
+		if role := roleName(match); role != "" {
+			return role
+		}
+	}
+	return ""
+}
+
+// Internal method: role name for a name or hash, "" for anything else
+func roleName(text string) string {
+	text = strings.TrimSpace(text)
+	if role, ok := roleHashes[strings.ToLower(text)]; ok {
+		return role.String()
+	}
+	if strings.HasPrefix(strings.ToLower(text), "0x") {
+		return "" // an unknown role hash, or an account
+	}
+	if text == "" || Role(text).Validate() != nil {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,118 @@
+package alchemy_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAuthorityErrorFromServer(t *testing.T) {
+	mintRoleHash := crypto.Keccak256Hash([]byte("MINT_ROLE")).Hex()
+	tests := []struct {
+		name     string
+		method   string
+		rpcErr   alchemytest.RPCError
+		wantRole string
+	}{
+		{"plain message", "mint", alchemytest.RPCError{Code: -32000, Message: "unauthorized"}, "MINT_ROLE"},
+		{"role in message", "burn", alchemytest.RPCError{Code: -32000, Message: "signer is missing role PAUSE_ROLE"}, "PAUSE_ROLE"},
+		{"data object", "mint", alchemytest.RPCError{Code: -32003, Message: "permission denied",
+			Data: map[string]interface{}{"requiredRole": "BLACKLIST_ROLE"}}, "BLACKLIST_ROLE"},
+		{"data string", "mint", alchemytest.RPCError{Code: -32000, Message: "not authorized", Data: "BURN_ROLE"}, "BURN_ROLE"},
+		{"access control hash", "pause", alchemytest.RPCError{Code: 3,
+			Message: "execution reverted: AccessControl: account 0x70997970c51812dc3a010c7d01b50e0d17dc79c8 is missing role " + mintRoleHash}, "MINT_ROLE"},
+		{"unknown method and role", "customWrite", alchemytest.RPCError{Code: -32000, Message: "access denied"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, key := newTestServer(t)
+			srv.QueueResponse(tt.method, alchemytest.Response{Error: &tt.rpcErr})
+
+			err := client.CallWrite(testToken, tt.method, []interface{}{}, 1).Err()
+			var authErr *alchemy.AuthorityError
+			if !errors.As(err, &authErr) {
+				t.Fatalf("err = %v, want an *AuthorityError", err)
+			}
+			if authErr.RequiredRole != tt.wantRole {
+				t.Fatalf("RequiredRole = %q, want %q", authErr.RequiredRole, tt.wantRole)
+			}
+			if want := crypto.PubkeyToAddress(key.PublicKey).Hex(); authErr.Signer != want {
+				t.Fatalf("Signer = %q, want %q", authErr.Signer, want)
+			}
+			if authErr.Token != testToken {
+				t.Fatalf("Token = %q", authErr.Token)
+			}
+			if !errors.Is(err, alchemy.ErrPermissionDenied) {
+				t.Fatal("not ErrPermissionDenied")
+			}
+			var rpcErr *alchemy.RPCError
+			if !errors.As(err, &rpcErr) || rpcErr.Code != tt.rpcErr.Code {
+				t.Fatalf("RPCError not kept: %v", rpcErr)
+			}
+		})
+	}
+}
+
+func TestAuthorityErrorOnlyForPermissionDenied(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("mint", -32000, "insufficient balance")
+
+	err := client.Mint(testToken, testRecipient, "1", 1).Err()
+	var authErr *alchemy.AuthorityError
+	if errors.As(err, &authErr) || errors.Is(err, alchemy.ErrPermissionDenied) {
+		t.Fatalf("err = %v mapped to a permission error", err)
+	}
+	if !errors.Is(err, alchemy.ErrInsufficientBalance) {
+		t.Fatalf("err = %v, want ErrInsufficientBalance", err)
+	}
+}
+
+func TestAuthorityPreflight(t *testing.T) {
+	srv, client, key := newTestServer(t, alchemy.WithAuthorityPreflight())
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	srv.SetResult("getAuthorities", []string{testRecipient})
+
+	err := client.Mint(testToken, testRecipient, "1", 1).Err()
+	var authErr *alchemy.AuthorityError
+	if !errors.As(err, &authErr) || authErr.RequiredRole != "MINT_ROLE" || authErr.Signer != signer || authErr.Err != nil {
+		t.Fatalf("err = %v, want a preflight *AuthorityError", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 0 {
+		t.Fatalf("%d mint requests sent", n)
+	}
+	reqs := srv.RequestsFor("getAuthorities")
+	if len(reqs) != 1 {
+		t.Fatalf("%d role checks", len(reqs))
+	}
+
+	// Holding the role lets the write through
+	srv.SetResult("getAuthorities", []string{strings.ToLower(signer)})
+	if err := client.Mint(testToken, testRecipient, "1", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d mint requests sent", n)
+	}
+
+	// Methods without a known role aren't checked
+	if err := client.CallWrite(testToken, "customWrite", []interface{}{}, 2).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("getAuthorities")); n != 2 {
+		t.Fatalf("%d role checks, want 2", n)
+	}
+
+	// A failing check doesn't send the write either
+	srv.SetError("getAuthorities", -32000, "unavailable")
+	if err := client.Pause(testToken, 3).Err(); err == nil || !strings.Contains(err.Error(), "authority preflight") {
+		t.Fatalf("err = %v", err)
+	}
+	if n := len(srv.RequestsFor("pause")); n != 0 {
+		t.Fatalf("%d pause requests sent", n)
+	}
+}
@@ -0,0 +1,74 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestTransferMasterAuthorityIrreversibly(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	for _, bad := range []string{"", "0x1234", "0x0000000000000000000000000000000000000000"} {
+		if err := client.TransferMasterAuthorityIrreversibly(testToken, bad, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAddress) {
+			t.Fatalf("%q: err = %v, want ErrInvalidAddress", bad, err)
+		}
+	}
+
+	if err := client.TransferMasterAuthorityIrreversibly(testToken, testRecipient, 3).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.TransferMasterAuthority(testToken, testRecipient, 4).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("transferMasterAuthority")
+	if len(reqs) != 2 {
+		t.Fatalf("got %d requests, want 2", len(reqs))
+	}
+	for _, req := range reqs {
+		if got := fmt.Sprint(req.ParamMap["methodArgs"]); got != "["+testRecipient+"]" {
+			t.Fatalf("methodArgs = %s", got)
+		}
+	}
+}
+
+func TestRenounceAuthority(t *testing.T) {
+	srv, client, key := newTestServer(t)
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	if err := client.RenounceAuthority(testToken, alchemy.RoleMint, 5).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("renounceAuthority")
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	if got := fmt.Sprint(reqs[0].ParamMap["methodArgs"]); got != "[MINT_ROLE "+signer+"]" {
+		t.Fatalf("methodArgs = %s, want the role and %s", got, signer)
+	}
+	if reqs[0].Signer != signer {
+		t.Fatalf("signed by %s", reqs[0].Signer)
+	}
+
+	if err := client.RenounceAuthority(testToken, "mint_role", 6).Err(); !errors.Is(err, alchemy.ErrInvalidRole) {
+		t.Fatalf("err = %v, want ErrInvalidRole", err)
+	}
+
+	// MASTER_ROLE only with ForceRenounce
+	if err := client.RenounceAuthority(testToken, alchemy.RoleMaster, 6).Err(); !errors.Is(err, alchemy.ErrMasterRenounce) {
+		t.Fatalf("err = %v, want ErrMasterRenounce", err)
+	}
+	if n := len(srv.RequestsFor("renounceAuthority")); n != 1 {
+		t.Fatalf("%d requests after a refused renounce", n)
+	}
+	if err := client.RenounceAuthority(testToken, alchemy.RoleMaster, 6, alchemy.ForceRenounce()).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs = srv.RequestsFor("renounceAuthority")
+	if got := fmt.Sprint(reqs[1].ParamMap["methodArgs"]); len(reqs) != 2 || got != "[MASTER_ROLE "+signer+"]" {
+		t.Fatalf("methodArgs = %s", got)
+	}
+}
@@ -0,0 +1,56 @@
+package alchemy
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Internal method: balance in wei, gwei and ETH (1 ETH = 10^18 wei) with exact decimal strings
+func newBalanceInfo(wei *big.Int) *BalanceInfo {
+	raw := wei.String()
+	gwei, _ := formatUnits(raw, 9)
+	eth, _ := formatUnits(raw, 18)
+	return &BalanceInfo{Wei: raw, Gwei: gwei, Eth: eth}
+}
+
+// WeiInt returns the balance in wei, nil if Wei isn't a valid integer
+func (b *BalanceInfo) WeiInt() *big.Int {
+	wei, ok := new(big.Int).SetString(b.Wei, 10)
+	if !ok {
+		return nil
+	}
+	return wei
+}
+
+// Format returns the ETH balance with exactly decimals fractional digits, rounded half up
+// (e.g. Format(4) of 1234567800000000000000 wei is "1234.5678"). Returns "" if Wei isn't
+// a valid integer.
+func (b *BalanceInfo) Format(decimals int) string {
+	wei := b.WeiInt()
+	if wei == nil || wei.Sign() < 0 {
+		return ""
+	}
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	// Round to the requested precision in wei, then render the kept digits exactly
+	if decimals < 18 {
+		unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(18-decimals)), nil)
+		half := new(big.Int).Rsh(unit, 1)
+		wei.Add(wei, half)
+		wei.Quo(wei, unit)
+	} else {
+		wei.Mul(wei, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-18)), nil))
+	}
+
+	digits := wei.String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	if decimals == 0 {
+		return digits
+	}
+	split := len(digits) - decimals
+	return digits[:split] + "." + digits[split:]
+}
@@ -0,0 +1,87 @@
+package alchemy_test
+
+import (
+	"math/big"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func mustBig(t *testing.T, s string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("bad integer %q", s)
+	}
+	return n
+}
+
+func TestGetBalanceExact(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	tests := []struct {
+		wei, gwei, eth string
+	}{
+		{"0", "0", "0"},
+		{"1", "0.000000001", "0.000000000000000001"},
+		{"999999999999999999", "999999999.999999999", "0.999999999999999999"},
+		{"1000000000000000000", "1000000000", "1"},
+		{"1234567800000000000000", "1234567800000", "1234.5678"},
+		// 2^53 + 1 is the first integer float64 can't represent
+		{"9007199254740993", "9007199.254740993", "0.009007199254740993"},
+		{"1000000000000000000000000000001", "1000000000000000000000.000000001", "1000000000000.000000000000000001"},
+	}
+	for _, tt := range tests {
+		srv.SetBalance(testRecipient, mustBig(t, tt.wei))
+		got, err := client.GetBalance(testRecipient).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Wei != tt.wei || got.Gwei != tt.gwei || got.Eth != tt.eth {
+			t.Errorf("balance %s = %+v, want gwei %s eth %s", tt.wei, got, tt.gwei, tt.eth)
+		}
+		if got.WeiInt().String() != tt.wei {
+			t.Errorf("WeiInt() = %s, want %s", got.WeiInt(), tt.wei)
+		}
+	}
+}
+
+func TestBalanceFormat(t *testing.T) {
+	tests := []struct {
+		wei      string
+		decimals int
+		want     string
+	}{
+		{"0", 2, "0.00"},
+		{"0", 0, "0"},
+		{"1234567800000000000000", 4, "1234.5678"},
+		{"1234567800000000000000", 2, "1234.57"},
+		{"1234567800000000000000", 0, "1235"},
+		{"1500000000000000000", 0, "2"},
+		{"1499999999999999999", 0, "1"},
+		// Half up at the last kept digit
+		{"50000000000000", 4, "0.0001"},
+		{"49999999999999", 4, "0.0000"},
+		{"999999999999999999", 4, "1.0000"},
+		{"999999999999999999", 18, "0.999999999999999999"},
+		{"1", 20, "0.00000000000000000100"},
+		{"9007199254740993", 18, "0.009007199254740993"},
+		{"123456789012345678901234567890", 3, "123456789012.346"},
+		{"1000000000000000000", -1, "1"},
+	}
+	for _, tt := range tests {
+		b := &alchemy.BalanceInfo{Wei: tt.wei}
+		if got := b.Format(tt.decimals); got != tt.want {
+			t.Errorf("Format(%d) of %s wei = %q, want %q", tt.decimals, tt.wei, got, tt.want)
+		}
+	}
+
+	for _, wei := range []string{"", "-1", "1.5", "abc"} {
+		b := &alchemy.BalanceInfo{Wei: wei}
+		if got := b.Format(2); got != "" {
+			t.Errorf("Format of %q = %q, want empty", wei, got)
+		}
+	}
+	if (&alchemy.BalanceInfo{Wei: "nope"}).WeiInt() != nil {
+		t.Error("WeiInt of invalid Wei should be nil")
+	}
+}
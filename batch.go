@@ -0,0 +1,282 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MintRecipient is a single entry of a batch mint
+type MintRecipient struct {
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+// BatchItemStatus is the outcome of a single batch entry
+type BatchItemStatus string
+
+const (
+	// BatchItemSubmitted means the entry was accepted by the server
+	BatchItemSubmitted BatchItemStatus = "submitted"
+	// BatchItemFailed means the entry was sent and the server (or network) returned an error
+	BatchItemFailed BatchItemStatus = "failed"
+	// BatchItemSkipped means the entry was never sent because an earlier entry failed
+	BatchItemSkipped BatchItemStatus = "skipped"
+)
+
+// BatchItemResult reports what happened to one recipient
+type BatchItemResult struct {
+	Index     int
+	Recipient MintRecipient
+	Nonce     int64 // nonce the entry was sent with, the planned one when skipped
+	Status    BatchItemStatus
+	Hash      string
+	Err       error
+}
+
+// BatchResult contains per-recipient outcomes in input order
+type BatchResult struct {
+	Items     []BatchItemResult
+	Submitted int
+	Failed    int
+	Skipped   int
+	// NonceGaps lists the nonces left unused below the highest nonce sent, ascending. The
+	// server holds later nonces until they are used, e.g. by resubmitting failed entries.
+	NonceGaps []int64
+}
+
+// Err joins the errors of all failed entries, nil if none failed
+func (b *BatchResult) Err() error {
+	var errs []error
+	for _, item := range b.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("recipient %d (%s): %w", item.Index, item.Recipient.To, item.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BatchOption configures MintBatch
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	concurrency     int
+	continueOnError bool
+	serverBatch     bool
+	callOpts        []CallOption
+}
+
+// WithBatchConcurrency bounds the number of in-flight submissions (default 1).
+// Values above 1 require a server that tolerates nonces arriving out of order.
+func WithBatchConcurrency(n int) BatchOption {
+	return func(c *batchConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithContinueOnError keeps submitting after a failed entry instead of skipping the rest
+func WithContinueOnError() BatchOption {
+	return func(c *batchConfig) {
+		c.continueOnError = true
+	}
+}
+
+// WithServerBatch submits all entries in a single server-side batch call (mintBatch,
+// updateBlacklistBatch) using startNonce
+func WithServerBatch() BatchOption {
+	return func(c *batchConfig) {
+		c.serverBatch = true
+	}
+}
+
+// WithBatchCallOptions applies call options such as AllowZeroAddress or WithContext to
+// every submission of the batch
+func WithBatchCallOptions(opts ...CallOption) BatchOption {
+	return func(c *batchConfig) {
+		c.callOpts = append(c.callOpts, opts...)
+	}
+}
+
+// MintBatch mints to many recipients. Recipient i is submitted with nonce startNonce+i.
+// By default entries are submitted sequentially and the first failure stops the batch;
+// every entry is reported in the result as submitted, failed or skipped. With
+// WithContinueOnError, the nonce of an entry the server rejected is reused by the next
+// entry; nonces that stay unused are reported in BatchResult.NonceGaps.
+func (c *Client) MintBatch(tokenAddress string, recipients []MintRecipient, startNonce int64, opts ...BatchOption) (r *ResponseHandler[*BatchResult]) {
+	defer withOperation(&r, "MintBatch", tokenAddress)
+	cfg := batchConfig{concurrency: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Validate everything before any network traffic, with the guards of Mint
+	for i, recipient := range recipients {
+		name := fmt.Sprintf("recipients[%d].To", i)
+		if err := c.checkTarget(name, recipient.To, cfg.callOpts); err != nil {
+			return &ResponseHandler[*BatchResult]{err: err}
+		}
+		if strings.EqualFold(recipient.To, tokenAddress) {
+			return &ResponseHandler[*BatchResult]{err: fmt.Errorf("%w: %s is the token contract itself", ErrInvalidAddress, name)}
+		}
+		if err := c.checkWriteAmount(fmt.Sprintf("recipients[%d].Amount", i), recipient.Amount); err != nil {
+			return &ResponseHandler[*BatchResult]{err: err}
+		}
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, len(recipients))}
+	for i, recipient := range recipients {
+		result.Items[i] = BatchItemResult{
+			Index:     i,
+			Recipient: recipient,
+			Nonce:     startNonce + int64(i),
+			Status:    BatchItemSkipped,
+		}
+	}
+
+	if cfg.serverBatch {
+		c.mintBatchOnServer(tokenAddress, startNonce, cfg, result)
+	} else {
+		result.NonceGaps = c.mintBatchSequential(tokenAddress, startNonce, cfg, result)
+	}
+
+	for _, item := range result.Items {
+		switch item.Status {
+		case BatchItemSubmitted:
+			result.Submitted++
+		case BatchItemFailed:
+			result.Failed++
+		case BatchItemSkipped:
+			result.Skipped++
+		}
+	}
+
+	return &ResponseHandler[*BatchResult]{data: result}
+}
+
+// Internal method: submit the whole batch as one mintBatch call, args flattened as to,amount pairs
+func (c *Client) mintBatchOnServer(tokenAddress string, nonce int64, cfg batchConfig, result *BatchResult) {
+	args := make([]interface{}, 0, 2*len(result.Items))
+	for _, item := range result.Items {
+		args = append(args, item.Recipient.To, item.Recipient.Amount)
+	}
+
+	call := c.CallWrite(tokenAddress, "mintBatch", args, nonce, cfg.callOpts...)
+	for i := range result.Items {
+		item := &result.Items[i]
+		item.Nonce = nonce
+		if call.err != nil {
+			item.Status = BatchItemFailed
+			item.Err = call.err
+		} else {
+			item.Status = BatchItemSubmitted
+			if call.data != nil {
+				item.Hash = call.data.Hash
+			}
+		}
+	}
+}
+
+// Internal method: submit entries one Mint at a time with bounded concurrency, returning
+// the nonce gaps
+func (c *Client) mintBatchSequential(tokenAddress string, startNonce int64, cfg batchConfig, result *BatchResult) []int64 {
+	return runBatch(len(result.Items), startNonce, cfg, func(i int, nonce int64) error {
+		item := &result.Items[i]
+		item.Nonce = nonce
+		call := c.Mint(tokenAddress, item.Recipient.To, item.Recipient.Amount, nonce, cfg.callOpts...)
+		if call.err != nil {
+			item.Status = BatchItemFailed
+			item.Err = call.err
+			return call.err
+		}
+		item.Status = BatchItemSubmitted
+		if call.data != nil {
+			item.Hash = call.data.Hash
+		}
+		return nil
+	})
+}
+
+// Internal method: run submit for entries 0..n-1 in order with cfg.concurrency workers,
+// handing out nonces from startNonce. submit sends entry i with nonce and records its
+// outcome. The nonce of a submission that can't have used it (see nonceUnused) goes to the
+// next entry. After a failure, entries not yet started are never submitted (left skipped)
+// unless cfg.continueOnError. Returns the released nonces no later entry took that lie
+// below the highest nonce still held, ascending.
+func runBatch(n int, startNonce int64, cfg batchConfig, submit func(i int, nonce int64) error) []int64 {
+	var (
+		mu      sync.Mutex
+		stopped bool
+		next    = startNonce
+		free    []int64 // released nonces, ascending
+		held    = map[int64]bool{}
+		wg      sync.WaitGroup
+	)
+	isStopped := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return stopped
+	}
+	acquire := func() int64 {
+		mu.Lock()
+		defer mu.Unlock()
+		nonce := next
+		if len(free) > 0 {
+			nonce, free = free[0], free[1:]
+		} else {
+			next++
+		}
+		held[nonce] = true
+		return nonce
+	}
+	failed := func(nonce int64, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if nonceUnused(err) {
+			delete(held, nonce)
+			at, _ := slices.BinarySearch(free, nonce)
+			free = slices.Insert(free, at, nonce)
+		}
+		if !cfg.continueOnError {
+			stopped = true
+		}
+	}
+
+	indexes := make(chan int)
+	for w := 0; w < cfg.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if isStopped() {
+					continue // leave as skipped
+				}
+				nonce := acquire()
+				if err := submit(i, nonce); err != nil {
+					failed(nonce, err)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n && !isStopped(); i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	highest := startNonce - 1
+	for nonce := range held {
+		highest = max(highest, nonce)
+	}
+	var gaps []int64
+	for _, nonce := range free {
+		if nonce < highest {
+			gaps = append(gaps, nonce)
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,181 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func batchRecipients(n int) []alchemy.MintRecipient {
+	recipients := make([]alchemy.MintRecipient, n)
+	for i := range recipients {
+		recipients[i] = alchemy.MintRecipient{To: testRecipient, Amount: fmt.Sprint(i + 1)}
+	}
+	return recipients
+}
+
+func TestMintBatchStopsAtFailure(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	ok := alchemytest.Response{Result: map[string]string{"hash": "0x01"}}
+	srv.QueueResponse("mint", ok, ok, alchemytest.Response{Status: http.StatusBadGateway})
+
+	result, err := client.MintBatch(testToken, batchRecipients(5), 10).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Submitted != 2 || result.Failed != 1 || result.Skipped != 2 {
+		t.Fatalf("submitted %d, failed %d, skipped %d", result.Submitted, result.Failed, result.Skipped)
+	}
+	wantStatus := []alchemy.BatchItemStatus{alchemy.BatchItemSubmitted, alchemy.BatchItemSubmitted,
+		alchemy.BatchItemFailed, alchemy.BatchItemSkipped, alchemy.BatchItemSkipped}
+	for i, item := range result.Items {
+		if item.Status != wantStatus[i] || item.Nonce != int64(10+i) {
+			t.Fatalf("item %d: status %s nonce %d", i, item.Status, item.Nonce)
+		}
+	}
+	var unexpected *alchemy.UnexpectedResponseError
+	if !errors.As(result.Err(), &unexpected) {
+		t.Fatalf("Err() = %v, want the 502", result.Err())
+	}
+	if n := len(srv.RequestsFor("mint")); n != 3 {
+		t.Fatalf("%d mints sent, want 3", n)
+	}
+}
+
+func TestMintBatchContinueOnError(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("mint", alchemytest.Response{Result: map[string]string{"hash": "0x01"}},
+		alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "insufficient balance"}})
+
+	result, err := client.MintBatch(testToken, batchRecipients(4), 0, alchemy.WithContinueOnError()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Submitted != 3 || result.Failed != 1 || result.Skipped != 0 {
+		t.Fatalf("submitted %d, failed %d, skipped %d", result.Submitted, result.Failed, result.Skipped)
+	}
+	if !errors.Is(result.Items[1].Err, alchemy.ErrInsufficientBalance) {
+		t.Fatalf("item 1 err = %v", result.Items[1].Err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 4 {
+		t.Fatalf("%d mints sent, want 4", n)
+	}
+	// The rejected nonce is reused by the next entry, so the sequence has no gap
+	if got := sentNonces(srv); got != "[0 1 1 2]" {
+		t.Fatalf("nonces sent %s, want [0 1 1 2]", got)
+	}
+	for i, want := range []int64{0, 1, 1, 2} {
+		if result.Items[i].Nonce != want {
+			t.Fatalf("item %d nonce %d, want %d", i, result.Items[i].Nonce, want)
+		}
+	}
+	if len(result.NonceGaps) != 0 {
+		t.Fatalf("gaps %v", result.NonceGaps)
+	}
+}
+
+func TestMintBatchContinueOnTransportError(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	// The server may have processed the request behind a 502, so its nonce isn't reused
+	srv.QueueResponse("mint", alchemytest.Response{Result: map[string]string{"hash": "0x01"}},
+		alchemytest.Response{Status: http.StatusBadGateway})
+
+	result, err := client.MintBatch(testToken, batchRecipients(4), 0, alchemy.WithContinueOnError()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Submitted != 3 || result.Failed != 1 || result.Items[1].Nonce != 1 {
+		t.Fatalf("submitted %d, failed %d, item 1 nonce %d", result.Submitted, result.Failed, result.Items[1].Nonce)
+	}
+	if got := sentNonces(srv); got != "[0 1 2 3]" {
+		t.Fatalf("nonces sent %s, want [0 1 2 3]", got)
+	}
+}
+
+func TestMintBatchNonceGaps(t *testing.T) {
+	// The first request with nonce 0 is rejected once the other worker has taken nonce 1,
+	// so nothing reuses 0 before the batch ends
+	var rejected atomic.Bool
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(&http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var req struct {
+			Method string
+			ID     json.RawMessage
+			Params struct{ Nonce json.Number }
+		}
+		if json.Unmarshal(body, &req) == nil && req.Method == "mint" && req.Params.Nonce == "0" && rejected.CompareAndSwap(false, true) {
+			time.Sleep(50 * time.Millisecond)
+			reply := `{"jsonrpc":"2.0","id":` + string(req.ID) + `,"error":{"code":-32000,"message":"insufficient balance"}}`
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}},
+				Body: io.NopCloser(strings.NewReader(reply)), Request: r}, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}))
+
+	result, err := client.MintBatch(testToken, batchRecipients(2), 0,
+		alchemy.WithContinueOnError(), alchemy.WithBatchConcurrency(2)).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Submitted != 1 || result.Failed != 1 {
+		t.Fatalf("submitted %d, failed %d", result.Submitted, result.Failed)
+	}
+	if fmt.Sprint(result.NonceGaps) != "[0]" {
+		t.Fatalf("gaps %v, want [0] (nonces sent %s)", result.NonceGaps, sentNonces(srv))
+	}
+}
+
+// sentNonces lists the nonces of the mints srv received, in order
+func sentNonces(srv *alchemytest.Server) string {
+	var nonces []string
+	for _, req := range srv.RequestsFor("mint") {
+		nonces = append(nonces, fmt.Sprint(req.ParamMap["nonce"]))
+	}
+	return "[" + strings.Join(nonces, " ") + "]"
+}
+
+func TestMintBatchValidatesBeforeSending(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	recipients := batchRecipients(3)
+	recipients[2].Amount = "1.5"
+
+	if err := client.MintBatch(testToken, recipients, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+		t.Fatalf("err = %v, want ErrInvalidAmount", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}
+
+func TestMintBatchOnServer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	result, err := client.MintBatch(testToken, batchRecipients(2), 7, alchemy.WithServerBatch()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Submitted != 2 {
+		t.Fatalf("submitted %d, want 2", result.Submitted)
+	}
+	req := srv.RequestsFor("mintBatch")[0]
+	if got := fmt.Sprint(req.ParamMap["methodArgs"]); got != "["+testRecipient+" 1 "+testRecipient+" 2]" {
+		t.Fatalf("methodArgs = %s", got)
+	}
+	if fmt.Sprint(req.ParamMap["nonce"]) != "7" {
+		t.Fatalf("nonce = %v", req.ParamMap["nonce"])
+	}
+}
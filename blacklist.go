@@ -0,0 +1,230 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrBlacklistConflict is returned when an address is both added and removed in one batch
+var ErrBlacklistConflict = errors.New("address both added to and removed from blacklist")
+
+// BlacklistAction is what a batch entry does to an address
+type BlacklistAction string
+
+const (
+	// BlacklistAdd blacklists the address
+	BlacklistAdd BlacklistAction = "add"
+	// BlacklistRemove takes the address off the blacklist
+	BlacklistRemove BlacklistAction = "remove"
+)
+
+// AddressPage is one page of an address listing
+type AddressPage struct {
+	Addresses  []string `json:"addresses"`
+	NextCursor string   `json:"nextCursor"` // empty on the last page
+}
+
+// HasMore reports whether another page can be fetched with NextCursor
+func (p *AddressPage) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// BlacklistItemResult reports what happened to one address
+type BlacklistItemResult struct {
+	Index   int
+	Address string
+	Action  BlacklistAction
+	Nonce   int64 // nonce the entry was sent with, the planned one when skipped
+	Status  BatchItemStatus
+	Hash    string
+	Err     error
+}
+
+// BlacklistBatchResult contains per-address outcomes, additions first, then removals
+type BlacklistBatchResult struct {
+	Items     []BlacklistItemResult
+	Submitted int
+	Failed    int
+	Skipped   int
+	NonceGaps []int64 // nonces left unused below the highest nonce sent, as in BatchResult
+}
+
+// Err joins the errors of all failed entries, nil if none failed
+func (b *BlacklistBatchResult) Err() error {
+	var errs []error
+	for _, item := range b.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", item.Action, item.Address, item.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetBlacklist lists the blacklisted addresses of a token. Pass an empty cursor for the
+// first page and AddressPage.NextCursor afterwards; limit 0 uses the server default page size.
+func (c *Client) GetBlacklist(tokenAddress string, cursor string, limit int, opts ...CallOption) (r *ResponseHandler[*AddressPage]) {
+	defer withOperation(&r, "GetBlacklist", tokenAddress)
+	if limit < 0 {
+		return &ResponseHandler[*AddressPage]{err: fmt.Errorf("invalid limit %d", limit)}
+	}
+
+	result := ClientCallRead[*AddressPage](c, tokenAddress, "getBlacklist", []interface{}{cursor, limit}, opts...)
+	if result.err != nil {
+		return result
+	}
+
+	page := result.data
+	if page == nil {
+		page = &AddressPage{}
+	}
+	if page.Addresses == nil {
+		page.Addresses = []string{}
+	}
+	for i := range page.Addresses {
+		page.Addresses[i] = checksummed(page.Addresses[i])
+	}
+	return &ResponseHandler[*AddressPage]{data: page, raw: result.raw}
+}
+
+// RemoveFromBlacklist removes account from blacklist
+func (c *Client) RemoveFromBlacklist(tokenAddress, accountAddress string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "RemoveFromBlacklist", tokenAddress)
+	if err := c.checkAddress("accountAddress", accountAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.CallWrite(tokenAddress, "removeFromBlacklist", []interface{}{accountAddress}, nonce, opts...)
+}
+
+// UpdateBlacklistBatch adds and removes many addresses. Repeated addresses within add or
+// remove are applied once; an address in both fails with ErrBlacklistConflict before
+// anything is sent. When the server supports updateBlacklistBatch (or WithServerBatch is
+// given) the whole delta is one call with startNonce. Otherwise additions, then removals,
+// are submitted one by one with nonces startNonce, startNonce+1, ... and the batch options
+// apply as in MintBatch, nonce reuse and gaps included. Every address is reported as
+// submitted, failed or skipped.
+func (c *Client) UpdateBlacklistBatch(tokenAddress string, add []string, remove []string, startNonce int64, opts ...BatchOption) (r *ResponseHandler[*BlacklistBatchResult]) {
+	defer withOperation(&r, "UpdateBlacklistBatch", tokenAddress)
+	cfg := batchConfig{concurrency: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	entries, err := c.blacklistDelta(add, remove)
+	if err != nil {
+		return &ResponseHandler[*BlacklistBatchResult]{err: err}
+	}
+
+	result := &BlacklistBatchResult{Items: entries}
+	for i := range result.Items {
+		result.Items[i].Nonce = startNonce + int64(i)
+	}
+
+	if cfg.serverBatch || c.SupportsMethod("updateBlacklistBatch") {
+		c.updateBlacklistOnServer(tokenAddress, startNonce, cfg, result)
+	} else {
+		result.NonceGaps = c.updateBlacklistSequential(tokenAddress, startNonce, cfg, result)
+	}
+
+	for _, item := range result.Items {
+		switch item.Status {
+		case BatchItemSubmitted:
+			result.Submitted++
+		case BatchItemFailed:
+			result.Failed++
+		case BatchItemSkipped:
+			result.Skipped++
+		}
+	}
+
+	return &ResponseHandler[*BlacklistBatchResult]{data: result}
+}
+
+// Internal method: validate add and remove and turn them into skipped entries, additions
+// first, each address once
+func (c *Client) blacklistDelta(add, remove []string) ([]BlacklistItemResult, error) {
+	seen := map[common.Address]BlacklistAction{}
+	var entries []BlacklistItemResult
+	collect := func(name string, addresses []string, action BlacklistAction) error {
+		for i, address := range addresses {
+			address = strings.TrimSpace(address)
+			if err := c.checkAddress(fmt.Sprintf("%s[%d]", name, i), address); err != nil {
+				return err
+			}
+			parsed := common.HexToAddress(address)
+			if previous, ok := seen[parsed]; ok {
+				if previous != action {
+					return fmt.Errorf("%w: %s", ErrBlacklistConflict, parsed.Hex())
+				}
+				continue
+			}
+			seen[parsed] = action
+			entries = append(entries, BlacklistItemResult{
+				Index:   len(entries),
+				Address: parsed.Hex(),
+				Action:  action,
+				Status:  BatchItemSkipped,
+			})
+		}
+		return nil
+	}
+
+	if err := collect("add", add, BlacklistAdd); err != nil {
+		return nil, err
+	}
+	if err := collect("remove", remove, BlacklistRemove); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Internal method: submit the whole delta as one updateBlacklistBatch call, args flattened
+// as action,address pairs
+func (c *Client) updateBlacklistOnServer(tokenAddress string, nonce int64, cfg batchConfig, result *BlacklistBatchResult) {
+	args := make([]interface{}, 0, 2*len(result.Items))
+	for _, item := range result.Items {
+		args = append(args, string(item.Action), item.Address)
+	}
+
+	call := c.CallWrite(tokenAddress, "updateBlacklistBatch", args, nonce, cfg.callOpts...)
+	for i := range result.Items {
+		item := &result.Items[i]
+		item.Nonce = nonce
+		if call.err != nil {
+			item.Status = BatchItemFailed
+			item.Err = call.err
+		} else {
+			item.Status = BatchItemSubmitted
+			if call.data != nil {
+				item.Hash = call.data.Hash
+			}
+		}
+	}
+}
+
+// Internal method: submit entries one AddToBlacklist / RemoveFromBlacklist at a time,
+// returning the nonce gaps
+func (c *Client) updateBlacklistSequential(tokenAddress string, startNonce int64, cfg batchConfig, result *BlacklistBatchResult) []int64 {
+	return runBatch(len(result.Items), startNonce, cfg, func(i int, nonce int64) error {
+		item := &result.Items[i]
+		item.Nonce = nonce
+		var call *ResponseHandler[*TransactionResult]
+		if item.Action == BlacklistAdd {
+			call = c.AddToBlacklist(tokenAddress, item.Address, nonce, cfg.callOpts...)
+		} else {
+			call = c.RemoveFromBlacklist(tokenAddress, item.Address, nonce, cfg.callOpts...)
+		}
+		if call.err != nil {
+			item.Status = BatchItemFailed
+			item.Err = call.err
+			return call.err
+		}
+		item.Status = BatchItemSubmitted
+		if call.data != nil {
+			item.Hash = call.data.Hash
+		}
+		return nil
+	})
+}
@@ -0,0 +1,193 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+var sanctioned = []string{
+	"0x90F79bf6EB2c4f870365E785982E1f101E93b906",
+	"0x15d34AAf54267DB7D7c367839AAf71A00a2C6A65",
+	"0x9965507D1a55bcC2695C58ba16FB37d819B0A4dc",
+	"0x976EA74026E726554dB657fA54763abd0C3a0aa9",
+}
+
+func TestUpdateBlacklistBatchDelta(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	add := []string{sanctioned[0], strings.ToLower(sanctioned[0]), " " + sanctioned[1] + " "}
+	remove := []string{sanctioned[2], sanctioned[2]}
+	result, err := client.UpdateBlacklistBatch(testToken, add, remove, 10).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		method, address string
+	}{
+		{"addToBlacklist", sanctioned[0]},
+		{"addToBlacklist", sanctioned[1]},
+		{"removeFromBlacklist", sanctioned[2]},
+	}
+	if len(result.Items) != len(want) || result.Submitted != len(want) {
+		t.Fatalf("%d items, %d submitted, want %d", len(result.Items), result.Submitted, len(want))
+	}
+	var sent []alchemytest.Request
+	for _, req := range srv.Requests() {
+		if req.Method == "addToBlacklist" || req.Method == "removeFromBlacklist" {
+			sent = append(sent, req)
+		}
+	}
+	for i, w := range want {
+		item := result.Items[i]
+		if item.Index != i || item.Address != w.address || item.Nonce != int64(10+i) || item.Hash == "" {
+			t.Fatalf("item %d: %+v", i, item)
+		}
+		req := sent[i]
+		if req.Method != w.method || fmt.Sprint(req.ParamMap["methodArgs"]) != "["+w.address+"]" || fmt.Sprint(req.ParamMap["nonce"]) != fmt.Sprint(10+i) {
+			t.Fatalf("request %d: %s %v nonce %v", i, req.Method, req.ParamMap["methodArgs"], req.ParamMap["nonce"])
+		}
+	}
+	if result.Items[2].Action != alchemy.BlacklistRemove {
+		t.Fatalf("item 2 action %s", result.Items[2].Action)
+	}
+}
+
+func TestUpdateBlacklistBatchRejectedUpFront(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	tests := []struct {
+		name        string
+		add, remove []string
+		want        error
+	}{
+		{"add and remove", []string{sanctioned[0], sanctioned[1]}, []string{strings.ToLower(sanctioned[1])}, alchemy.ErrBlacklistConflict},
+		{"invalid address", []string{sanctioned[0]}, []string{sanctioned[1], "0x1234"}, alchemy.ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.UpdateBlacklistBatch(testToken, tt.add, tt.remove, 0).Err(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if n := len(srv.RequestsFor("addToBlacklist")) + len(srv.RequestsFor("removeFromBlacklist")); n != 0 {
+		t.Fatalf("%d writes sent", n)
+	}
+}
+
+func TestUpdateBlacklistBatchPartialFailure(t *testing.T) {
+	failSecond := func(srv *alchemytest.Server) {
+		srv.QueueResponse("addToBlacklist",
+			alchemytest.Response{Result: map[string]string{"hash": "0x1"}},
+			alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "execution reverted"}},
+		)
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		srv, client, _ := newTestServer(t)
+		failSecond(srv)
+		result, err := client.UpdateBlacklistBatch(testToken, sanctioned[:3], sanctioned[3:], 0).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses := []alchemy.BatchItemStatus{alchemy.BatchItemSubmitted, alchemy.BatchItemFailed, alchemy.BatchItemSkipped, alchemy.BatchItemSkipped}
+		for i, item := range result.Items {
+			if item.Status != statuses[i] {
+				t.Fatalf("item %d status %s, want %s", i, item.Status, statuses[i])
+			}
+		}
+		if result.Submitted != 1 || result.Failed != 1 || result.Skipped != 2 {
+			t.Fatalf("submitted %d, failed %d, skipped %d", result.Submitted, result.Failed, result.Skipped)
+		}
+		if err := result.Err(); err == nil || !strings.Contains(err.Error(), "add "+sanctioned[1]) {
+			t.Fatalf("Err() = %v", err)
+		}
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		srv, client, _ := newTestServer(t)
+		failSecond(srv)
+		result, err := client.UpdateBlacklistBatch(testToken, sanctioned[:3], sanctioned[3:], 0, alchemy.WithContinueOnError()).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Submitted != 3 || result.Failed != 1 || result.Items[1].Err == nil {
+			t.Fatalf("submitted %d, failed %d", result.Submitted, result.Failed)
+		}
+		if n := len(srv.RequestsFor("removeFromBlacklist")); n != 1 {
+			t.Fatalf("%d removals sent, want 1", n)
+		}
+	})
+}
+
+func TestUpdateBlacklistBatchOnServer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_server_info", map[string]interface{}{"version": "2.1.0", "methods": []string{"updateBlacklistBatch"}})
+
+	result, err := client.UpdateBlacklistBatch(testToken, sanctioned[:2], sanctioned[2:3], 7).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("updateBlacklistBatch")
+	if len(reqs) != 1 || len(srv.RequestsFor("addToBlacklist")) != 0 {
+		t.Fatalf("%d batch calls, want the delta in one", len(reqs))
+	}
+	want := fmt.Sprintf("[add %s add %s remove %s]", sanctioned[0], sanctioned[1], sanctioned[2])
+	if got := fmt.Sprint(reqs[0].ParamMap["methodArgs"]); got != want {
+		t.Fatalf("methodArgs = %s, want %s", got, want)
+	}
+	for _, item := range result.Items {
+		if item.Nonce != 7 || item.Status != alchemy.BatchItemSubmitted {
+			t.Fatalf("item %+v", item)
+		}
+	}
+
+	// One failed call fails every address
+	srv.SetError("updateBlacklistBatch", -32000, "execution reverted")
+	result, err = client.UpdateBlacklistBatch(testToken, sanctioned[:2], nil, 8).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Failed != 2 {
+		t.Fatalf("failed %d, want 2", result.Failed)
+	}
+}
+
+func TestGetBlacklistPages(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("getBlacklist",
+		alchemytest.Response{Result: map[string]interface{}{"addresses": []string{strings.ToLower(sanctioned[0]), strings.ToLower(sanctioned[1])}, "nextCursor": "c1"}},
+		alchemytest.Response{Result: map[string]interface{}{"addresses": []string{sanctioned[2]}}},
+	)
+
+	var listed []string
+	cursor := ""
+	for {
+		page, err := client.GetBlacklist(testToken, cursor, 2).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed = append(listed, page.Addresses...)
+		if !page.HasMore() {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if fmt.Sprint(listed) != fmt.Sprint(sanctioned[:3]) {
+		t.Fatalf("listed %v", listed)
+	}
+	if got := fmt.Sprint(srv.RequestsFor("getBlacklist")[1].ParamMap["methodArgs"]); got != "[c1 2]" {
+		t.Fatalf("second page methodArgs = %s", got)
+	}
+	if err := client.GetBlacklist(testToken, "", -1).Err(); err == nil {
+		t.Fatal("negative limit accepted")
+	}
+}
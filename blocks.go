@@ -0,0 +1,324 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultBlockPollInterval is the polling interval used when the node has no WebSocket support
+const DefaultBlockPollInterval = 2 * time.Second
+
+// ConfigNodeWebSocketURL sets the WebSocket URL of the Ethereum node.
+// By default it is derived from the node URL (http -> ws, https -> wss).
+func ConfigNodeWebSocketURL(url string) {
+	defaultClient.nodeWSURL = url
+}
+
+// ErrBlockNotFound is returned when the node has no block for the requested number or hash.
+// It matches ErrNotFound.
+var ErrBlockNotFound = fmt.Errorf("block %w", ErrNotFound)
+
+// BlockHeader is a new chain head
+type BlockHeader struct {
+	Number     int64
+	Hash       string
+	ParentHash string
+	Timestamp  time.Time
+}
+
+// WithPollInterval sets the polling interval used by SubscribeNewBlocks when
+// the node doesn't support eth_subscribe (default DefaultBlockPollInterval)
+func WithPollInterval(interval time.Duration) SubscribeOption {
+	return func(c *subscribeConfig) {
+		if interval > 0 {
+			c.pollInterval = interval
+		}
+	}
+}
+
+// WithBuffer sets the channel buffer size of a subscription
+func WithBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		if n > 0 {
+			c.buffer = n
+		}
+	}
+}
+
+// SubscribeNewBlocks streams new block headers using eth_subscribe("newHeads") when the
+// node supports WebSocket, otherwise by polling. Backpressure: the channel is bounded
+// (WithBuffer) and when a slow consumer lets it fill up the oldest undelivered header is
+// dropped, so the channel always holds the most recent heads. The channel is closed when
+// ctx is cancelled.
+func (c *Client) SubscribeNewBlocks(ctx context.Context, opts ...SubscribeOption) (_ <-chan BlockHeader, err error) {
+	defer withOperationErr(&err, "SubscribeNewBlocks", "")
+	cfg := defaultSubscribeConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	headers := make(chan BlockHeader, cfg.buffer)
+
+	endpoint := c.nodeWSURL
+	if endpoint == "" {
+		endpoint, _ = toWebSocketURL(c.nodeEndpoint())
+	}
+
+	var raw <-chan json.RawMessage
+	if endpoint != "" {
+		raw, _ = c.subscribe(ctx, endpoint, "eth", []interface{}{"newHeads"}, cfg)
+	}
+
+	if raw == nil {
+		// No WebSocket support, fall back to polling; fail now if the node isn't reachable at all
+		latest, err := c.getLatestHeader()
+		if err != nil {
+			return nil, err
+		}
+		err = c.goBackground(ctx, func(ctx context.Context) {
+			c.pollNewBlocks(ctx, latest, cfg, headers)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return headers, nil
+	}
+
+	err = c.goBackground(ctx, func(context.Context) {
+		defer close(headers)
+		for result := range raw {
+			header, err := decodeBlockHeader(result)
+			if err != nil {
+				cfg.onError(err)
+				continue
+			}
+			sendDropOldest(headers, header)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return headers, nil
+}
+
+// Internal method: poll for new heads, emitting every block after last in order
+func (c *Client) pollNewBlocks(ctx context.Context, last BlockHeader, cfg subscribeConfig, headers chan BlockHeader) {
+	defer close(headers)
+
+	ticker := time.NewTicker(cfg.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latest, err := c.getLatestHeader()
+		if err != nil {
+			cfg.onError(err)
+			continue
+		}
+
+		if err := c.emitNewBlocks(&last, latest, headers); err != nil {
+			// last stays at the last delivered block, the gap is fetched again on the next tick
+			cfg.onError(err)
+		}
+	}
+}
+
+// Internal method: emit every block after *last up to latest in order, moving *last to each
+// delivered block; stops at the first block that can't be fetched
+func (c *Client) emitNewBlocks(last *BlockHeader, latest BlockHeader, headers chan BlockHeader) error {
+	for n := last.Number + 1; n < latest.Number; n++ {
+		header, err := c.getHeaderByNumber(n)
+		if err != nil {
+			return err
+		}
+		sendDropOldest(headers, header)
+		*last = header
+	}
+	if latest.Number > last.Number {
+		sendDropOldest(headers, latest)
+		*last = latest
+	}
+	return nil
+}
+
+// Internal method: non-blocking send that drops the oldest buffered value when full.
+// Only safe with a single sender per channel.
+func sendDropOldest[T any](ch chan T, value T) {
+	for {
+		select {
+		case ch <- value:
+			return
+		default:
+		}
+		select {
+		case <-ch:
+		default:
+		}
+	}
+}
+
+// rpcBlockHeader is the hex-encoded header as returned by the node
+type rpcBlockHeader struct {
+	Number     string `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// Internal method: decode a node header into native types
+func decodeBlockHeader(data []byte) (BlockHeader, error) {
+	var raw rpcBlockHeader
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return BlockHeader{}, fmt.Errorf("decode block header: %w", err)
+	}
+	return raw.toHeader()
+}
+
+func (h rpcBlockHeader) toHeader() (BlockHeader, error) {
+	number, err := parseHexQuantity(h.Number)
+	if err != nil {
+		return BlockHeader{}, fmt.Errorf("block number: %w", err)
+	}
+	timestamp, err := parseHexQuantity(h.Timestamp)
+	if err != nil {
+		return BlockHeader{}, fmt.Errorf("block timestamp: %w", err)
+	}
+	return BlockHeader{
+		Number:     int64(number),
+		Hash:       h.Hash,
+		ParentHash: h.ParentHash,
+		Timestamp:  time.Unix(int64(timestamp), 0).UTC(),
+	}, nil
+}
+
+// Internal method: get the latest block header
+func (c *Client) getLatestHeader() (BlockHeader, error) {
+	return c.getHeader("latest")
+}
+
+// Internal method: get a block header by number
+func (c *Client) getHeaderByNumber(n int64) (BlockHeader, error) {
+	return c.getHeader(fmt.Sprintf("0x%x", n))
+}
+
+// Internal method: eth_getBlockByNumber without transactions
+func (c *Client) getHeader(tag string) (BlockHeader, error) {
+	result, err := c.nodeCall("eth_getBlockByNumber", []interface{}{tag, false})
+	if err != nil {
+		return BlockHeader{}, err
+	}
+	if isNullResult(result) {
+		return BlockHeader{}, fmt.Errorf("%w: %s", ErrBlockNotFound, tag)
+	}
+	return decodeBlockHeader(result)
+}
+
+// Block is a block with hex quantities decoded. TransactionHashes is always filled,
+// Transactions only when the block was requested with fullTxs.
+type Block struct {
+	Number            int64
+	Hash              string
+	ParentHash        string
+	Timestamp         time.Time
+	GasUsed           uint64
+	GasLimit          uint64
+	TransactionHashes []string
+	Transactions      []*Transaction
+}
+
+// GetBlockByNumber gets a block by number, with full transaction objects when fullTxs is set
+func (c *Client) GetBlockByNumber(n int64, fullTxs bool) (r *ResponseHandler[*Block]) {
+	defer withOperation(&r, "GetBlockByNumber", "")
+	return c.getBlock("eth_getBlockByNumber", fmt.Sprintf("0x%x", n), fullTxs)
+}
+
+// GetBlockByHash gets a block by hash, with full transaction objects when fullTxs is set
+func (c *Client) GetBlockByHash(hash string, fullTxs bool) (r *ResponseHandler[*Block]) {
+	defer withOperation(&r, "GetBlockByHash", "")
+	return c.getBlock("eth_getBlockByHash", hash, fullTxs)
+}
+
+// Internal method: fetch and decode a block, ErrBlockNotFound on a null result
+func (c *Client) getBlock(method, id string, fullTxs bool) *ResponseHandler[*Block] {
+	if err := c.checkChain(); err != nil {
+		return &ResponseHandler[*Block]{err: err}
+	}
+
+	result, err := c.nodeCall(method, []interface{}{id, fullTxs})
+	if err != nil {
+		return &ResponseHandler[*Block]{err: err}
+	}
+	if isNullResult(result) {
+		return &ResponseHandler[*Block]{err: fmt.Errorf("%w: %s", ErrBlockNotFound, id)}
+	}
+
+	var raw struct {
+		rpcBlockHeader
+		GasUsed      string            `json:"gasUsed"`
+		GasLimit     string            `json:"gasLimit"`
+		Transactions []json.RawMessage `json:"transactions"`
+	}
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return &ResponseHandler[*Block]{err: fmt.Errorf("decode block: %w", err)}
+	}
+
+	header, err := raw.toHeader()
+	if err != nil {
+		return &ResponseHandler[*Block]{err: err}
+	}
+	block := &Block{
+		Number:     header.Number,
+		Hash:       header.Hash,
+		ParentHash: header.ParentHash,
+		Timestamp:  header.Timestamp,
+	}
+	if raw.GasUsed != "" {
+		if block.GasUsed, err = parseHexQuantity(raw.GasUsed); err != nil {
+			return &ResponseHandler[*Block]{err: fmt.Errorf("block gasUsed: %w", err)}
+		}
+	}
+	if raw.GasLimit != "" {
+		if block.GasLimit, err = parseHexQuantity(raw.GasLimit); err != nil {
+			return &ResponseHandler[*Block]{err: fmt.Errorf("block gasLimit: %w", err)}
+		}
+	}
+
+	for _, item := range raw.Transactions {
+		if !fullTxs {
+			var hash string
+			if err := json.Unmarshal(item, &hash); err != nil {
+				return &ResponseHandler[*Block]{err: fmt.Errorf("decode transaction hash: %w", err)}
+			}
+			block.TransactionHashes = append(block.TransactionHashes, hash)
+			continue
+		}
+
+		var rawTx rpcTransaction
+		if err := json.Unmarshal(item, &rawTx); err != nil {
+			return &ResponseHandler[*Block]{err: fmt.Errorf("decode transaction: %w", err)}
+		}
+		tx, err := rawTx.toTransaction()
+		if err != nil {
+			return &ResponseHandler[*Block]{err: err}
+		}
+		block.Transactions = append(block.Transactions, tx)
+		block.TransactionHashes = append(block.TransactionHashes, tx.Hash)
+	}
+
+	return &ResponseHandler[*Block]{data: block}
+}
+
+// Internal method: JSON null or missing result
+func isNullResult(result json.RawMessage) bool {
+	trimmed := strings.TrimSpace(string(result))
+	return trimmed == "" || trimmed == "null"
+}
@@ -0,0 +1,195 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func header(n int64) alchemytest.Response {
+	return alchemytest.Response{Result: map[string]string{
+		"number":     fmt.Sprintf("0x%x", n),
+		"hash":       fmt.Sprintf("0x%064x", n),
+		"parentHash": fmt.Sprintf("0x%064x", n-1),
+		"timestamp":  "0x0",
+	}}
+}
+
+func TestPolledBlocksNotSkippedOnFetchError(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("eth_getBlockByNumber",
+		header(1),            // head at subscription time
+		header(4), header(2), // first tick: 2 is delivered...
+		alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "unavailable"}}, // ...3 fails
+		header(4), header(3), // second tick: the gap is fetched again
+	)
+	srv.SetResponse("eth_getBlockByNumber", header(4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	headers, err := client.SubscribeNewBlocks(ctx,
+		alchemy.WithPollInterval(10*time.Millisecond),
+		alchemy.OnSubscriptionError(func(err error) { errs <- err }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int64
+	timeout := time.After(5 * time.Second)
+	for len(got) < 3 {
+		select {
+		case h := <-headers:
+			got = append(got, h.Number)
+		case <-timeout:
+			t.Fatalf("got blocks %v, want [2 3 4]", got)
+		}
+	}
+	if fmt.Sprint(got) != "[2 3 4]" {
+		t.Fatalf("got blocks %v, want [2 3 4]", got)
+	}
+	if len(errs) == 0 {
+		t.Fatal("fetch error not reported")
+	}
+}
+
+func TestPolledBlocksDropOldestForSlowConsumer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("eth_getBlockByNumber",
+		header(1),                                  // head at subscription time
+		header(6), header(2), header(3), header(4), // first tick: 2..6 into a 2-slot buffer
+		header(5),
+	)
+	srv.SetResponse("eth_getBlockByNumber", header(6))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	headers, err := client.SubscribeNewBlocks(ctx, alchemy.WithPollInterval(5*time.Millisecond), alchemy.WithBuffer(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the next tick has asked for the head, the first tick has emitted everything
+	deadline := time.Now().Add(5 * time.Second)
+	for len(srv.RequestsFor("eth_getBlockByNumber")) < 7 {
+		if time.Now().After(deadline) {
+			t.Fatal("polling did not catch up")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The producer never blocked, and the buffer kept the most recent heads
+	if got := []int64{(<-headers).Number, (<-headers).Number}; fmt.Sprint(got) != "[5 6]" {
+		t.Fatalf("got blocks %v, want [5 6]", got)
+	}
+	select {
+	case h := <-headers:
+		t.Fatalf("unexpected block %d", h.Number)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// nodeBlock is an eth_getBlockBy* result of block 0x1b4 with the given transactions
+func nodeBlock(transactions ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"number":       "0x1b4",
+		"hash":         "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae",
+		"parentHash":   "0xe99e022112df268087ea7eafaf4790497fd21dbeeb6bd7a1721df161a6657a54",
+		"timestamp":    "0x55ba467c",
+		"gasUsed":      "0x5208",
+		"gasLimit":     "0x1c9c380",
+		"transactions": transactions,
+	}
+}
+
+func TestGetBlockByNumber(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_getBlockByNumber", nodeBlock("0xabc", "0xdef"))
+
+	block, err := client.GetBlockByNumber(436, false).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Number != 436 || block.GasUsed != 21000 || block.GasLimit != 30000000 ||
+		!block.Timestamp.Equal(time.Unix(0x55ba467c, 0)) {
+		t.Fatalf("got %+v", block)
+	}
+	if block.Hash != "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae" ||
+		block.ParentHash != "0xe99e022112df268087ea7eafaf4790497fd21dbeeb6bd7a1721df161a6657a54" {
+		t.Fatalf("hashes %s, %s", block.Hash, block.ParentHash)
+	}
+	if fmt.Sprint(block.TransactionHashes) != "[0xabc 0xdef]" || block.Transactions != nil {
+		t.Fatalf("transactions %v, %v", block.TransactionHashes, block.Transactions)
+	}
+	if params := string(srv.RequestsFor("eth_getBlockByNumber")[0].Params); params != `["0x1b4",false]` {
+		t.Fatalf("params %s", params)
+	}
+}
+
+func TestGetBlockByHashFullTransactions(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	mined := nodeTransaction("0x1b4")
+	mined["blockHash"] = "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+	mined["transactionIndex"] = "0x0"
+	srv.SetResult("eth_getBlockByHash", nodeBlock(mined))
+
+	hash := "0xdc0818cf78f21a8e70579cb46a43643f78291264dda342ae31049421c82d21ae"
+	block, err := client.GetBlockByHash(hash, true).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(block.Transactions) != 1 || fmt.Sprint(block.TransactionHashes) != "[0xabc]" {
+		t.Fatalf("transactions %+v, hashes %v", block.Transactions, block.TransactionHashes)
+	}
+	tx := block.Transactions[0]
+	if tx.Pending || tx.BlockNumber != 436 || tx.Nonce != 7 || tx.Value.String() != "1000000000000000000" {
+		t.Fatalf("transaction %+v", tx)
+	}
+	if params := string(srv.RequestsFor("eth_getBlockByHash")[0].Params); params != `["`+hash+`",true]` {
+		t.Fatalf("params %s", params)
+	}
+}
+
+func TestGetBlockNotFound(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_getBlockByNumber", nil)
+	srv.SetResult("eth_getBlockByHash", nil)
+
+	for name, err := range map[string]error{
+		"number": client.GetBlockByNumber(1<<40, false).Err(),
+		"hash":   client.GetBlockByHash("0x01", true).Err(),
+	} {
+		if !errors.Is(err, alchemy.ErrBlockNotFound) || !errors.Is(err, alchemy.ErrNotFound) {
+			t.Fatalf("%s: err = %v, want ErrBlockNotFound", name, err)
+		}
+	}
+}
+
+func TestGetBlockDecodeErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	for _, tt := range []struct {
+		field, value string
+	}{
+		{"number", "436"},
+		{"gasUsed", "0xzz"},
+		{"gasLimit", "lots"},
+	} {
+		block := nodeBlock()
+		block[tt.field] = tt.value
+		srv.SetResult("eth_getBlockByNumber", block)
+		if err := client.GetBlockByNumber(436, false).Err(); err == nil {
+			t.Fatalf("%s %q accepted", tt.field, tt.value)
+		}
+	}
+
+	// Full transaction objects where hashes were asked for
+	srv.SetResult("eth_getBlockByNumber", nodeBlock(nodeTransaction(nil)))
+	if err := client.GetBlockByNumber(436, false).Err(); err == nil {
+		t.Fatal("transaction object decoded as a hash")
+	}
+}
@@ -0,0 +1,224 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BulkItem is one operation of a bulk run
+type BulkItem struct {
+	Op   Operation
+	Read bool // read-only call (e.g. getTokenMetadata), run concurrently without a nonce
+	// IdempotencyKey sent with a write. When empty a key unique to the run and the item is
+	// generated, so identical operations in separate runs are never deduplicated.
+	IdempotencyKey string
+}
+
+// BulkItemResult reports what happened to one item
+type BulkItemResult struct {
+	Index          int
+	PayloadHash    string          // Keccak256 of the operation (method, token, args), stable across runs
+	IdempotencyKey string          // sent with a write; resend a failed item with it to retry safely
+	Status         BatchItemStatus // skipped means never sent
+	Result         json.RawMessage // raw result of a read
+	Tx             *TransactionResult
+	Err            error
+}
+
+// BulkProgress is reported after every completed item
+type BulkProgress struct {
+	Done      int // submitted writes and completed reads
+	Failed    int
+	Remaining int
+}
+
+// BulkSummary contains per-item outcomes in input order
+type BulkSummary struct {
+	Items     []BulkItemResult
+	Submitted int
+	Failed    int
+	Skipped   int  // never sent, e.g. because the run was cancelled
+	Cancelled bool // ctx ended before every item was sent
+}
+
+// Err joins the errors of all failed items, nil if none failed
+func (s *BulkSummary) Err() error {
+	var errs []error
+	for _, item := range s.Items {
+		if item.Err != nil {
+			errs = append(errs, fmt.Errorf("item %d (%s): %w", item.Index, item.PayloadHash, item.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BulkOption configures a BulkRunner
+type BulkOption func(*BulkRunner)
+
+// WithBulkWorkers bounds the number of concurrent reads (default 4)
+func WithBulkWorkers(n int) BulkOption {
+	return func(b *BulkRunner) {
+		if n > 0 {
+			b.workers = n
+		}
+	}
+}
+
+// WithBulkProgress calls fn after every completed item. Calls are serialized.
+func WithBulkProgress(fn func(BulkProgress)) BulkOption {
+	return func(b *BulkRunner) { b.progress = fn }
+}
+
+// BulkRunner executes many operations: reads concurrently up to a worker limit, writes
+// one at a time in input order through a TxQueue, which assigns the nonces
+type BulkRunner struct {
+	client   *Client
+	workers  int
+	progress func(BulkProgress)
+}
+
+// NewBulkRunner creates a runner submitting through c
+func (c *Client) NewBulkRunner(opts ...BulkOption) *BulkRunner {
+	b := &BulkRunner{client: c, workers: 4}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	return b
+}
+
+// Run executes items until all are done or ctx ends. After cancellation no new item is
+// sent; in-flight requests complete and the rest are reported as skipped.
+func (b *BulkRunner) Run(ctx context.Context, items []BulkItem) *BulkSummary {
+	summary := &BulkSummary{Items: make([]BulkItemResult, len(items))}
+	runKey := NewIdempotencyKey()
+	var reads, writes []int
+	for i, item := range items {
+		summary.Items[i] = BulkItemResult{Index: i, PayloadHash: operationHash(item.Op), Status: BatchItemSkipped}
+		if item.Read {
+			reads = append(reads, i)
+			continue
+		}
+		writes = append(writes, i)
+		summary.Items[i].IdempotencyKey = item.IdempotencyKey
+		if item.IdempotencyKey == "" {
+			summary.Items[i].IdempotencyKey = fmt.Sprintf("%s-%d", runKey, i)
+		}
+	}
+
+	var mu sync.Mutex
+	done, failed := 0, 0
+	record := func(i int, result BulkItemResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		summary.Items[i] = result
+		if result.Err != nil {
+			failed++
+		} else {
+			done++
+		}
+		if b.progress != nil {
+			b.progress(BulkProgress{Done: done, Failed: failed, Remaining: len(items) - done - failed})
+		}
+	}
+
+	var wg sync.WaitGroup
+
+	// Reads: bounded worker pool
+	indexes := make(chan int)
+	for w := 0; w < b.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				record(i, b.read(i, items[i], summary.Items[i].PayloadHash))
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(indexes)
+		for _, i := range reads {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Writes: sequential through the queue
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		queue := b.client.NewTxQueue()
+		defer queue.Shutdown(context.Background())
+		for _, i := range writes {
+			if ctx.Err() != nil {
+				return
+			}
+			record(i, b.write(queue, i, items[i], summary.Items[i]))
+		}
+	}()
+
+	wg.Wait()
+
+	for _, item := range summary.Items {
+		switch item.Status {
+		case BatchItemSubmitted:
+			summary.Submitted++
+		case BatchItemFailed:
+			summary.Failed++
+		case BatchItemSkipped:
+			summary.Skipped++
+		}
+	}
+	summary.Cancelled = summary.Skipped > 0 && ctx.Err() != nil
+	return summary
+}
+
+// Internal method: run a read item
+func (b *BulkRunner) read(i int, item BulkItem, hash string) BulkItemResult {
+	result := BulkItemResult{Index: i, PayloadHash: hash, Status: BatchItemSubmitted}
+	call := ClientCallRead[json.RawMessage](b.client, item.Op.Token, item.Op.Method, item.Op.Args)
+	result.Result, result.Err = call.Result()
+	if result.Err != nil {
+		result.Status = BatchItemFailed
+	}
+	return result
+}
+
+// Internal method: submit a write item with the key assigned by Run and wait for it
+func (b *BulkRunner) write(queue *TxQueue, i int, item BulkItem, pending BulkItemResult) BulkItemResult {
+	result := pending
+	result.Status = BatchItemSubmitted
+	result.Tx, result.Err = queue.Enqueue(item.Op, WithIdempotencyKey(pending.IdempotencyKey)).Result()
+	if result.Err != nil {
+		result.Status = BatchItemFailed
+	}
+	return result
+}
+
+// Internal method: stable hash of an operation, independent of nonce and checkpoint
+func operationHash(op Operation) string {
+	args := op.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+	message, err := buildSortedMessage(map[string]interface{}{
+		"method":     op.Method,
+		"methodArgs": args,
+		"token":      op.Token,
+	})
+	if err != nil {
+		message = fmt.Sprintf("%s,%v,%s", op.Method, args, op.Token)
+	}
+	return crypto.Keccak256Hash([]byte(message)).Hex()
+}
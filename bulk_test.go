@@ -0,0 +1,145 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestBulkRunnerIdempotencyKeysUniquePerRun(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	op := alchemy.MintOperation(testToken, testRecipient, "100")
+	items := []alchemy.BulkItem{{Op: op}, {Op: op}, {Op: op, IdempotencyKey: "caller-key"}}
+
+	runner := client.NewBulkRunner()
+	first := runner.Run(context.Background(), items)
+	second := runner.Run(context.Background(), items)
+	for _, summary := range []*alchemy.BulkSummary{first, second} {
+		if err := summary.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, req := range srv.RequestsFor("mint") {
+		key, _ := req.ParamMap["idempotencyKey"].(string)
+		if key == "" {
+			t.Fatal("write sent without an idempotency key")
+		}
+		if key == "caller-key" {
+			continue
+		}
+		if seen[key] {
+			t.Fatalf("idempotency key %q reused", key)
+		}
+		seen[key] = true
+	}
+	if len(seen) != 4 {
+		t.Fatalf("got %d generated keys, want 4", len(seen))
+	}
+
+	for i, item := range first.Items {
+		if item.IdempotencyKey == "" || item.IdempotencyKey == item.PayloadHash {
+			t.Fatalf("item %d key %q", i, item.IdempotencyKey)
+		}
+		if item.Tx == nil || item.Tx.IdempotencyKey != item.IdempotencyKey {
+			t.Fatalf("item %d: result key doesn't match the reported key", i)
+		}
+	}
+	if first.Items[2].IdempotencyKey != "caller-key" {
+		t.Fatalf("explicit key replaced by %q", first.Items[2].IdempotencyKey)
+	}
+}
+
+func TestBulkRunnerValidatesWrites(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	summary := client.NewBulkRunner().Run(context.Background(), []alchemy.BulkItem{
+		{Op: alchemy.MintOperation(testToken, testRecipient, "1e18")},
+		{Op: alchemy.AddToBlacklistOperation(testToken, "not-an-address")},
+	})
+	if summary.Failed != 2 {
+		t.Fatalf("Failed = %d, want 2", summary.Failed)
+	}
+	if !errors.Is(summary.Items[0].Err, alchemy.ErrInvalidAmount) || !errors.Is(summary.Items[1].Err, alchemy.ErrInvalidAddress) {
+		t.Fatalf("errors %v, %v", summary.Items[0].Err, summary.Items[1].Err)
+	}
+	if n := len(srv.RequestsFor("mint")) + len(srv.RequestsFor("addToBlacklist")); n != 0 {
+		t.Fatalf("%d invalid writes sent", n)
+	}
+}
+
+func TestBulkRunnerCancelHalfway(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	items := make([]alchemy.BulkItem, 10)
+	for i := range items {
+		items[i] = alchemy.BulkItem{Op: alchemy.MintOperation(testToken, testRecipient, fmt.Sprint(i+1))}
+	}
+	items[2].Op = alchemy.MintOperation(testToken, testRecipient, "-3")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var reports []alchemy.BulkProgress
+	runner := client.NewBulkRunner(alchemy.WithBulkProgress(func(p alchemy.BulkProgress) {
+		reports = append(reports, p)
+		if p.Done+p.Failed == 5 {
+			cancel()
+		}
+	}))
+	summary := runner.Run(ctx, items)
+
+	if !summary.Cancelled || summary.Submitted != 4 || summary.Failed != 1 || summary.Skipped != 5 {
+		t.Fatalf("summary: cancelled %v, submitted %d, failed %d, skipped %d",
+			summary.Cancelled, summary.Submitted, summary.Failed, summary.Skipped)
+	}
+	if len(reports) != 5 {
+		t.Fatalf("%d progress reports, want 5", len(reports))
+	}
+	if last := reports[4]; last.Done != 4 || last.Failed != 1 || last.Remaining != 5 {
+		t.Fatalf("last progress %+v", last)
+	}
+
+	for i, item := range summary.Items {
+		if item.Index != i || item.PayloadHash == "" || item.IdempotencyKey == "" {
+			t.Fatalf("item %d: %+v", i, item)
+		}
+		switch {
+		case i == 2:
+			if item.Status != alchemy.BatchItemFailed || !errors.Is(item.Err, alchemy.ErrInvalidAmount) {
+				t.Fatalf("item 2: status %s, err %v", item.Status, item.Err)
+			}
+		case i < 5:
+			if item.Status != alchemy.BatchItemSubmitted || item.Tx == nil {
+				t.Fatalf("item %d: status %s, tx %v", i, item.Status, item.Tx)
+			}
+		default:
+			if item.Status != alchemy.BatchItemSkipped || item.Tx != nil || item.Err != nil {
+				t.Fatalf("item %d: status %s, err %v", i, item.Status, item.Err)
+			}
+		}
+	}
+
+	// Only the submitted items reached the server, in order
+	mints := srv.RequestsFor("mint")
+	if len(mints) != 4 {
+		t.Fatalf("%d mints sent, want 4", len(mints))
+	}
+	for i, want := range []string{"1", "2", "4", "5"} {
+		if got := fmt.Sprint(mints[i].ParamMap["methodArgs"]); got != "["+testRecipient+" "+want+"]" {
+			t.Fatalf("mint %d methodArgs = %s", i, got)
+		}
+	}
+
+	// Payload hashes identify the operation across runs, so skipped items can be matched up
+	rerun := client.NewBulkRunner().Run(context.Background(), items[5:])
+	if err := rerun.Err(); err != nil {
+		t.Fatal(err)
+	}
+	for i, item := range rerun.Items {
+		if item.PayloadHash != summary.Items[5+i].PayloadHash {
+			t.Fatalf("hash of item %d changed between runs", 5+i)
+		}
+	}
+}
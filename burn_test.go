@@ -0,0 +1,42 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestBurn(t *testing.T) {
+	srv, client, key := newTestServer(t)
+
+	if _, err := client.Burn(testToken, "1000", 2).Result(); err != nil {
+		t.Fatal(err)
+	}
+	req := srv.RequestsFor("burn")[0]
+	if got := fmt.Sprint(req.ParamMap["methodArgs"]); got != "[1000]" {
+		t.Fatalf("methodArgs = %s, want only the amount", got)
+	}
+	if req.SignatureErr != nil {
+		t.Fatal(req.SignatureErr)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey).Hex(); req.Signer != want {
+		t.Fatalf("signed by %s, want the configured key %s", req.Signer, want)
+	}
+}
+
+func TestBurnValidatesAmount(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	for _, amount := range []string{"", "-5", "1.5", "1e18", "abc"} {
+		if err := client.Burn(testToken, amount, 0).Err(); !errors.Is(err, alchemy.ErrInvalidAmount) {
+			t.Errorf("Burn(%q) err = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}
@@ -0,0 +1,83 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidMethod is returned when a contract call has no method name
+var ErrInvalidMethod = errors.New("invalid method")
+
+// CallRead calls ClientCallRead on the default client
+func CallRead[T any](tokenAddress, method string, args []interface{}, opts ...CallOption) *ResponseHandler[T] {
+	return ClientCallRead[T](defaultClient, tokenAddress, method, args, opts...)
+}
+
+// ClientCallRead calls a read-only token method that has no SDK wrapper yet and decodes the
+// result into T (json.RawMessage to keep it undecoded). Reads are signed like writes, with
+// nonce 0.
+//
+// Each arg is sent as a positional JSON value and signed in its canonical form: strings
+// as-is, integers (any Go integer type, *big.Int or an integer json.Number) in base 10 and
+// booleans as true/false. Pass amounts as base-unit strings or *big.Int and addresses as
+// hex strings. Floats, maps, slices and other types are rejected with ErrUnsupportedValue
+// before anything is signed or sent.
+func ClientCallRead[T any](c *Client, tokenAddress, method string, args []interface{}, opts ...CallOption) (r *ResponseHandler[T]) {
+	defer withOperation(&r, "ClientCallRead", tokenAddress)
+	args, err := checkCallArgs(method, args)
+	if err != nil {
+		return &ResponseHandler[T]{err: err}
+	}
+	return dynamicCallWithType[T](c, tokenAddress, method, args, 0, opts...)
+}
+
+// CallWrite sends a signed state-changing token method that has no SDK wrapper yet. Args
+// are encoded as for ClientCallRead; the call options (idempotency keys, confirmations,
+// nonce retries) and cache invalidation apply as for the named wrappers, which are built
+// on it.
+func (c *Client) CallWrite(tokenAddress, method string, args []interface{}, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "CallWrite", tokenAddress)
+	args, err := checkCallArgs(method, args)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	cfg := c.newCallConfig(opts)
+	if err := c.checkTokenContract(cfg.context(), tokenAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	if err := c.checkAuthority(tokenAddress, method, cfg.inherited()); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	opts = append(opts[:len(opts):len(opts)], func(cfg *callConfig) { cfg.write = true })
+	result := dynamicCallWithType[*TransactionResult](c, tokenAddress, method, args, nonce, opts...)
+	if result.err != nil {
+		result.err = c.authorityError(cfg.context(), tokenAddress, method, result.err)
+	}
+	return result
+}
+
+// Internal method: validate a method name and its args before signing; nil args become empty
+func checkCallArgs(method string, args []interface{}) ([]interface{}, error) {
+	if method == "" {
+		return nil, fmt.Errorf("%w: empty method name", ErrInvalidMethod)
+	}
+	if args == nil {
+		return []interface{}{}, nil
+	}
+	for i, arg := range args {
+		if arg == nil {
+			continue // sent as null, left out of the signed message
+		}
+		if _, err := formatMessageValue(arg); err != nil {
+			return nil, fmt.Errorf("args[%d]: %w", i, err)
+		}
+	}
+	if _, err := json.Marshal(args); err != nil {
+		return nil, fmt.Errorf("%w: args are not JSON-encodable: %v", ErrUnsupportedValue, err)
+	}
+	return args, nil
+}
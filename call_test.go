@@ -0,0 +1,119 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// unencodable passes as a string but fails to marshal
+type unencodable string
+
+func (unencodable) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("no JSON form")
+}
+
+// unsupportedArgs can't be sent as call args
+var unsupportedArgs = map[string]interface{}{
+	"float":       1.5,
+	"map":         map[string]string{"a": "b"},
+	"slice":       []string{"a"},
+	"struct":      struct{ A int }{1},
+	"channel":     make(chan int),
+	"nil big.Int": (*big.Int)(nil),
+	"fraction":    json.Number("1.5"),
+	"unencodable": unencodable("x"),
+}
+
+func TestCallReadDecodesResult(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getFreezeInfo", map[string]interface{}{"frozen": true, "until": 1700000000, "reason": "audit"})
+
+	type freezeInfo struct {
+		Frozen bool   `json:"frozen"`
+		Until  int64  `json:"until"`
+		Reason string `json:"reason"`
+	}
+	args := []interface{}{testRecipient, int64(7), big.NewInt(1000), json.Number("42"), true, nil}
+	info, err := alchemy.ClientCallRead[freezeInfo](client, testToken, "getFreezeInfo", args).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info != (freezeInfo{Frozen: true, Until: 1700000000, Reason: "audit"}) {
+		t.Fatalf("got %+v", info)
+	}
+
+	req := srv.RequestsFor("getFreezeInfo")[0]
+	if req.SignatureErr != nil || req.Signature == nil {
+		t.Fatalf("read not signed: %v", req.SignatureErr)
+	}
+	sent, _ := json.Marshal(req.ParamMap["methodArgs"])
+	if string(sent) != `["`+testRecipient+`",7,1000,42,true,null]` {
+		t.Fatalf("methodArgs %s", sent)
+	}
+	if n := req.ParamMap["nonce"]; n == nil || n.(json.Number) != "0" {
+		t.Fatalf("nonce %v, want 0", n)
+	}
+
+	// Scalars, undecoded results and nil args
+	srv.SetResult("frozenCount", "12")
+	if n, err := alchemy.ClientCallRead[string](client, testToken, "frozenCount", nil).Result(); err != nil || n != "12" {
+		t.Fatalf("got %q, %v", n, err)
+	}
+	raw, err := alchemy.ClientCallRead[json.RawMessage](client, testToken, "getFreezeInfo", nil).Result()
+	if err != nil || !json.Valid(raw) || len(raw) == 0 {
+		t.Fatalf("raw %s, %v", raw, err)
+	}
+}
+
+func TestCallReadDecodeMismatch(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("frozenCount", "twelve")
+
+	err := alchemy.ClientCallRead[int64](client, testToken, "frozenCount", nil).Err()
+	if err == nil {
+		t.Fatal("string decoded into int64")
+	}
+	if op := operationError(t, err); op.Op != "ClientCallRead" || op.Token != testToken {
+		t.Fatalf("op %+v", op)
+	}
+}
+
+func TestCallReadDefaultClient(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	useDefaultClient(t, client)
+	srv.SetResult("isFrozen", true)
+
+	frozen, err := alchemy.CallRead[bool](testToken, "isFrozen", []interface{}{testRecipient}).Result()
+	if err != nil || !frozen {
+		t.Fatalf("got %v, %v", frozen, err)
+	}
+}
+
+func TestCallRejectsArgsBeforeSending(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	for name, arg := range unsupportedArgs {
+		t.Run(name, func(t *testing.T) {
+			args := []interface{}{testRecipient, arg}
+			read := alchemy.ClientCallRead[bool](client, testToken, "isFrozen", args).Err()
+			write := client.CallWrite(testToken, "freeze", args, 1).Err()
+			for _, err := range []error{read, write} {
+				if !errors.Is(err, alchemy.ErrUnsupportedValue) {
+					t.Fatalf("err = %v, want ErrUnsupportedValue", err)
+				}
+			}
+		})
+	}
+	if err := alchemy.ClientCallRead[bool](client, testToken, "", nil).Err(); !errors.Is(err, alchemy.ErrInvalidMethod) {
+		t.Fatalf("read err = %v, want ErrInvalidMethod", err)
+	}
+	if err := client.CallWrite(testToken, "", nil, 1).Err(); !errors.Is(err, alchemy.ErrInvalidMethod) {
+		t.Fatalf("write err = %v, want ErrInvalidMethod", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent for rejected calls", n)
+	}
+}
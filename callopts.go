@@ -0,0 +1,117 @@
+package alchemy
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// CallOption configures a single write request
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	idempotencyKey  string
+	nonceRetries    int
+	maxResponseSize int64 // 0 means the configured maximum
+
+	confirmations       int // wait for this many confirmations after a write, 0 doesn't wait
+	confirmationTimeout time.Duration
+
+	block *int64 // reads evaluated at this block (WithBlock), nil means latest
+
+	endpoint EndpointRole // WithEndpoint, 0 routes by method
+
+	signerName string // WithSignerKey, empty for the client's key
+
+	write  bool // state-changing call, retried only when it can't have been processed
+	dryRun bool // simulation (Simulate), signed so the request can't be replayed as the write
+
+	insecureWebhookURL bool // RegisterWebhook accepts http callback URLs
+	forceRenounce      bool // RenounceAuthority may renounce MASTER_ROLE
+	forceRetire        bool // RetireToken may retire a token with supply left
+	uniqueSymbol       bool // CreateToken refuses symbols already in use
+
+	memo string // signed memo of the *WithMemo writes, empty for none
+
+	tags map[string]string // WithTag labels, never sent
+	ctx  context.Context   // WithContext, nil for context.Background
+
+	allowZeroAddress bool // writes accept the zero address as recipient or authority
+}
+
+// Internal method: apply call options
+func (c *Client) newCallConfig(opts []CallOption) *callConfig {
+	cfg := &callConfig{
+		nonceRetries:        c.nonceRetries,
+		confirmations:       c.confirmations,
+		confirmationTimeout: DefaultConfirmationTimeout,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(cfg)
+		}
+	}
+	return cfg
+}
+
+// WithContext runs the call's requests under ctx: canceling it or reaching its deadline
+// aborts the request in flight and the retry backoff (see RetryPolicy.MaxElapsedTime).
+// Calls without it run under context.Background.
+func WithContext(ctx context.Context) CallOption {
+	return func(c *callConfig) {
+		c.ctx = ctx
+	}
+}
+
+// WithIdempotencyKey sends key with the request so the server can deduplicate retries.
+// The key is part of the signed message. Pass the same key when retrying a request
+// whose outcome is unknown; the key is echoed back in the result.
+func WithIdempotencyKey(key string) CallOption {
+	return func(c *callConfig) {
+		c.idempotencyKey = key
+	}
+}
+
+// WithGeneratedIdempotencyKey generates a random (UUID v4) idempotency key for the request.
+// Read it back from the result's IdempotencyKey field to retry safely.
+func WithGeneratedIdempotencyKey() CallOption {
+	return func(c *callConfig) {
+		c.idempotencyKey = NewIdempotencyKey()
+	}
+}
+
+// NewIdempotencyKey returns a random UUID v4 suitable for WithIdempotencyKey
+func NewIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("alchemy: generate idempotency key: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// idempotent is implemented by results that carry the request idempotency key
+type idempotent interface {
+	setIdempotencyKey(key string)
+}
+
+func (r *TransactionResult) setIdempotencyKey(key string) {
+	if r != nil && key != "" {
+		r.IdempotencyKey = key
+	}
+}
+
+func (r *TokenIssueResult) setIdempotencyKey(key string) {
+	if r != nil && key != "" {
+		r.IdempotencyKey = key
+	}
+}
+
+// Internal method: record the key on results that support it
+func setIdempotencyKey(result interface{}, key string) {
+	if r, ok := result.(idempotent); ok {
+		r.setIdempotencyKey(key)
+	}
+}
@@ -0,0 +1,134 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrWrongChain is returned when the endpoint's chain ID doesn't match the expected one
+var ErrWrongChain = errors.New("endpoint is on the wrong chain")
+
+// ConfigExpectedChainID makes every operation verify that the node reports this chain ID
+// before doing anything else (the node's chain ID is fetched once and cached). 0 disables the check.
+func ConfigExpectedChainID(id uint64) {
+	defaultClient.chainMu.Lock()
+	defer defaultClient.chainMu.Unlock()
+	defaultClient.expectedChain = id
+}
+
+// ConfigChainID sets the chain ID used for signing explicitly instead of fetching it via eth_chainId
+func ConfigChainID(id uint64) {
+	defaultClient.chainMu.Lock()
+	defer defaultClient.chainMu.Unlock()
+	defaultClient.chainID = id
+}
+
+// ConfigChainIDSigning enables adding a chainId key to the signed params.
+// Leave disabled for servers that don't expect it.
+func ConfigChainIDSigning(enabled bool) {
+	defaultClient.chainMu.Lock()
+	defer defaultClient.chainMu.Unlock()
+	defaultClient.chainIDSigning = enabled
+}
+
+// GetChainID gets the chain ID of the configured node (cached after the first call)
+func (c *Client) GetChainID() (r *ResponseHandler[uint64]) {
+	defer withOperation(&r, "GetChainID", "")
+	id, err := c.getNodeChainID(context.Background())
+	if err != nil {
+		return &ResponseHandler[uint64]{err: err}
+	}
+	return &ResponseHandler[uint64]{data: id}
+}
+
+// Internal method: verify the endpoint is on the expected chain, if one is configured
+func (c *Client) checkChain() error {
+	return c.checkChainContext(context.Background())
+}
+
+// Internal method: checkChain as part of the operation ctx belongs to
+func (c *Client) checkChainContext(ctx context.Context) error {
+	c.chainMu.Lock()
+	expected := c.expectedChain
+	c.chainMu.Unlock()
+
+	if expected == 0 {
+		return nil
+	}
+
+	id, err := c.getNodeChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("verify chain id: %w", err)
+	}
+	if id != expected {
+		return fmt.Errorf("%w: expected chain id %d, endpoint reports %d", ErrWrongChain, expected, id)
+	}
+	return nil
+}
+
+// Internal method: chain ID to sign with, explicit configuration wins over the node value
+func (c *Client) signingChainID(ctx context.Context) (uint64, error) {
+	c.chainMu.Lock()
+	id := c.chainID
+	c.chainMu.Unlock()
+
+	if id != 0 {
+		return id, nil
+	}
+	return c.getNodeChainID(ctx)
+}
+
+// Internal method: get chain ID from the node, cached
+func (c *Client) getNodeChainID(ctx context.Context) (uint64, error) {
+	c.chainMu.Lock()
+	cached := c.nodeChainID
+	c.chainMu.Unlock()
+
+	if cached != 0 {
+		return cached, nil
+	}
+
+	result, err := c.nodeCallContext(ctx, "eth_chainId", []interface{}{})
+	if err != nil {
+		return 0, err
+	}
+
+	var hexID string
+	if err := json.Unmarshal(result, &hexID); err != nil {
+		return 0, fmt.Errorf("invalid eth_chainId result: %w", err)
+	}
+
+	id, err := parseHexQuantity(hexID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid eth_chainId result: %w", err)
+	}
+
+	c.chainMu.Lock()
+	c.nodeChainID = id
+	c.chainMu.Unlock()
+
+	return id, nil
+}
+
+// Internal method: drop the cached node chain ID (endpoint changed)
+func (c *Client) resetChainIDCache() {
+	c.chainMu.Lock()
+	defer c.chainMu.Unlock()
+	c.nodeChainID = 0
+}
+
+// parseHexQuantity parses a 0x-prefixed hex quantity as returned by eth_* methods
+func parseHexQuantity(s string) (uint64, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return 0, fmt.Errorf("missing 0x prefix in %q", s)
+	}
+	value, err := strconv.ParseUint(s[2:], 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex quantity %q: %w", s, err)
+	}
+	return value, nil
+}
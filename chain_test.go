@@ -0,0 +1,129 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestChainIDSigning(t *testing.T) {
+	srv, client1, key := newTestServer(t, alchemy.WithChainIDSigning(), alchemy.WithChainID(1))
+	client5, err := srv.NewClient(alchemy.WithKey(key), alchemy.WithChainIDSigning(), alchemy.WithChainID(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, client := range []*alchemy.Client{client1, client5} {
+		if err := client.Mint(testToken, testRecipient, "100", 0).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mints := srv.RequestsFor("mint")
+	for i, want := range []string{"1", "5"} {
+		if got := fmt.Sprint(mints[i].ParamMap["chainId"]); got != want {
+			t.Fatalf("request %d chainId = %s, want %s", i, got, want)
+		}
+		if mints[i].SignatureErr != nil {
+			t.Fatalf("request %d: %v", i, mints[i].SignatureErr)
+		}
+	}
+	if *mints[0].Signature == *mints[1].Signature {
+		t.Fatal("chain 1 and chain 5 signatures are equal")
+	}
+	if mints[0].Signer != mints[1].Signer {
+		t.Fatalf("signers %s and %s differ", mints[0].Signer, mints[1].Signer)
+	}
+}
+
+func TestChainIDSigningUsesNodeChainID(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithChainIDSigning())
+	srv.SetChainID(137)
+
+	if err := client.Pause(testToken, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(srv.RequestsFor("pause")[0].ParamMap["chainId"]); got != "137" {
+		t.Fatalf("chainId = %s, want 137", got)
+	}
+}
+
+func TestChainIDNotSignedByDefault(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	if err := client.Pause(testToken, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.RequestsFor("pause")[0].ParamMap["chainId"]; ok {
+		t.Fatal("chainId sent without WithChainIDSigning")
+	}
+}
+
+func TestGetChainIDParsesAndCaches(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_chainId", "0xAa36A7")
+
+	for i := 0; i < 3; i++ {
+		id, err := client.GetChainID().Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != 11155111 {
+			t.Fatalf("chain id %d, want 11155111", id)
+		}
+	}
+	if n := len(srv.RequestsFor("eth_chainId")); n != 1 {
+		t.Fatalf("eth_chainId called %d times, want 1", n)
+	}
+}
+
+func TestGetChainIDInvalidResults(t *testing.T) {
+	for _, result := range []interface{}{"1", "0x", "0xzz", 1, nil} {
+		t.Run(fmt.Sprint(result), func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetResult("eth_chainId", result)
+			if err := client.GetChainID().Err(); err == nil {
+				t.Fatal("invalid result accepted")
+			}
+		})
+	}
+}
+
+func TestExpectedChainID(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithExpectedChainID(5))
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); !errors.Is(err, alchemy.ErrWrongChain) {
+		t.Fatalf("err = %v, want ErrWrongChain", err)
+	}
+	if err := client.GetSupplyCap(testToken).Err(); !errors.Is(err, alchemy.ErrWrongChain) {
+		t.Fatalf("read: err = %v, want ErrWrongChain", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 0 {
+		t.Fatalf("%d mints sent to the wrong chain", n)
+	}
+	if n := len(srv.RequestsFor("eth_chainId")); n != 1 {
+		t.Fatalf("eth_chainId called %d times, want 1", n)
+	}
+
+	srv5, client5, _ := newTestServer(t, alchemy.WithExpectedChainID(5))
+	srv5.SetChainID(5)
+	if err := client5.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExpectedChainIDWithoutEthChainID(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithExpectedChainID(1))
+	srv.SetError("eth_chainId", alchemytest.CodeMethodNotFound, "the method eth_chainId does not exist")
+
+	err := client.Mint(testToken, testRecipient, "1", 0).Err()
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != alchemytest.CodeMethodNotFound {
+		t.Fatalf("err = %v, want the method-not-found error", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 0 {
+		t.Fatal("mint sent although the chain couldn't be verified")
+	}
+}
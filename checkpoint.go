@@ -0,0 +1,43 @@
+package alchemy
+
+import "fmt"
+
+// WithBlock evaluates a read at block n instead of the latest block, so several reads
+// (metadata, balances, holders, events) can be taken at one consistent height, typically
+// from CurrentCheckpoint. Token service reads send n as the "block" request param; GetBalance
+// passes it to eth_getBalance as the block tag. Ignored by writes.
+func WithBlock(n int64) CallOption {
+	return func(c *callConfig) {
+		c.block = &n
+	}
+}
+
+// CurrentCheckpoint gets the latest block number, to pin a series of reads with WithBlock
+func (c *Client) CurrentCheckpoint() (r *ResponseHandler[int64]) {
+	defer withOperation(&r, "CurrentCheckpoint", "")
+	n, err := c.getBlockNumber()
+	if err != nil {
+		return &ResponseHandler[int64]{err: err}
+	}
+	return &ResponseHandler[int64]{data: n}
+}
+
+// GetTokenBalance gets the token balance of account in base units
+func (c *Client) GetTokenBalance(tokenAddress, account string, opts ...CallOption) (r *ResponseHandler[string]) {
+	defer withOperation(&r, "GetTokenBalance", tokenAddress)
+	if err := c.checkAddress("account", account); err != nil {
+		return &ResponseHandler[string]{err: err}
+	}
+	return ClientCallRead[string](c, tokenAddress, "balanceOf", []interface{}{account}, opts...)
+}
+
+// Internal method: eth_* block tag for the pinned block, "latest" when none is set
+func blockTag(cfg *callConfig) (string, error) {
+	if cfg.block == nil {
+		return "latest", nil
+	}
+	if *cfg.block < 0 {
+		return "", fmt.Errorf("invalid block %d", *cfg.block)
+	}
+	return fmt.Sprintf("0x%x", *cfg.block), nil
+}
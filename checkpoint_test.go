@@ -0,0 +1,96 @@
+package alchemy_test
+
+import (
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestWithBlockSentWithReadsOnly(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getSupplyCap", "1000")
+	srv.SetResult("mint", map[string]interface{}{"wouldSucceed": true})
+
+	if err := client.GetSupplyCap(testToken, alchemy.WithBlock(42)).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(srv.RequestsFor("getSupplyCap")[0].ParamMap["block"]); got != "42" {
+		t.Fatalf("read block = %v, want 42", got)
+	}
+
+	if err := client.Pause(testToken, 0, alchemy.WithBlock(42)).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "1"), 0, alchemy.WithBlock(42)).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, method := range []string{"pause", "mint"} {
+		req := srv.RequestsFor(method)[0]
+		if _, ok := req.ParamMap["block"]; ok {
+			t.Fatalf("%s signed with a block param", method)
+		}
+		if req.SignatureErr != nil {
+			t.Fatal(req.SignatureErr)
+		}
+	}
+
+	if err := client.Pause(testToken, 1, alchemy.WithBlock(-1)).Err(); err != nil {
+		t.Fatalf("write failed on an ignored block: %v", err)
+	}
+	if err := client.GetSupplyCap(testToken, alchemy.WithBlock(-1)).Err(); err == nil {
+		t.Fatal("negative block accepted for a read")
+	}
+}
+
+func TestPinnedReadsCarryBlock(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(77)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": "100"})
+	srv.SetResult("balanceOf", "100")
+	srv.SetResult("getTokenHolders", map[string]interface{}{"holders": []interface{}{}, "decimals": 2})
+	srv.SetResult("get_token_events", []interface{}{})
+
+	n, err := client.CurrentCheckpoint().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 77 {
+		t.Fatalf("CurrentCheckpoint = %d, want 77", n)
+	}
+	srv.SetBlockNumber(80) // blocks keep coming during the scan
+
+	pinned := alchemy.WithBlock(n)
+	calls := map[string]error{
+		"GetTokenMetadata": client.GetTokenMetadata(testToken, pinned).Err(),
+		"GetTokenBalance":  client.GetTokenBalance(testToken, testRecipient, pinned).Err(),
+		"GetTokenHolders":  client.GetTokenHolders(testToken, "", 10, pinned).Err(),
+		"GetTokenEvents":   client.GetTokenEvents(testToken, alchemy.EventFilter{FromBlock: 70}, pinned).Err(),
+		"GetBalance":       client.GetBalance(testRecipient, pinned).Err(),
+	}
+	for name, err := range calls {
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	for _, method := range []string{"getTokenMetadata", "balanceOf", "getTokenHolders"} {
+		reqs := srv.RequestsFor(method)
+		if len(reqs) != 1 {
+			t.Fatalf("%d %s requests, want 1", len(reqs), method)
+		}
+		if got := fmt.Sprint(reqs[0].ParamMap["block"]); got != "77" {
+			t.Fatalf("%s block = %s, want 77", method, got)
+		}
+		if reqs[0].SignatureErr != nil {
+			t.Fatalf("%s: %v", method, reqs[0].SignatureErr)
+		}
+	}
+	// Events stop at the pinned block rather than the head
+	if got := fmt.Sprint(srv.RequestsFor("get_token_events")[0].ParamMap["toBlock"]); got != "77" {
+		t.Fatalf("events toBlock = %s, want 77", got)
+	}
+	if got := string(srv.RequestsFor("eth_getBalance")[0].Params); got != `["`+testRecipient+`","0x4d"]` {
+		t.Fatalf("eth_getBalance params %s", got)
+	}
+}
@@ -0,0 +1,494 @@
+package alchemy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the HTTP request timeout used when none is configured
+const DefaultTimeout = 30 * time.Second
+
+// Client holds the endpoint, credentials and transport settings used by SDK calls.
+// Clients are independent of each other; the package-level functions use the default
+// client, which the Config* functions modify. A Client is safe for concurrent use once
+// configured.
+type Client struct {
+	baseURL    string
+	nodeURL    string // Ethereum node URL, baseURL when empty
+	serviceURL string // token service URL, baseURL + "/rpc" when empty
+	wsURL      string // token service WebSocket URL, derived from the service URL when empty
+	nodeWSURL  string // node WebSocket URL, derived from the node URL when empty
+
+	readNodeURL    string // replica node URL for reads, see WithReadURL
+	readServiceURL string // replica token service URL for reads
+
+	coalesceReads bool        // see WithReadCoalescing
+	flights       flightGroup // coalesced reads in flight
+
+	privateKey  string            // hex key, parsed on use; ignored when signer or key is set
+	key         *ecdsa.PrivateKey // parsed key, preferred over privateKey; ignored when signer is set
+	signer      Signer
+	vFormat     VFormat
+	sigEncoding SignatureEncoding
+
+	signersMu sync.RWMutex
+	signers   map[string]Signer // WithNamedSigner, by name
+
+	httpClient *http.Client
+	customHTTP bool // httpClient was supplied by the caller and is never rebuilt
+	timeout    time.Duration
+	retry      RetryPolicy
+	logger     *slog.Logger
+
+	// Transport settings, guarded by transportMu
+	transportMu sync.Mutex
+	tlsConfig   *tls.Config
+	proxyURL    *url.URL
+	tuning      TransportTuning
+
+	// Request headers, guarded by headersMu
+	headersMu     sync.RWMutex
+	staticHeaders map[string]string
+	userAgent     string
+
+	// Credentials, guarded by authMu
+	authMu        sync.RWMutex
+	tokenProvider func() (string, error)
+	apiKeyHeaders map[string]string
+
+	// Chain ID settings, guarded by chainMu
+	chainMu        sync.Mutex
+	chainID        uint64 // explicitly configured chain ID, 0 means fetch from node
+	chainIDSigning bool   // mix chainId into the signed params
+	nodeChainID    uint64 // cached eth_chainId result
+	expectedChain  uint64 // when set, operations fail unless the node reports this chain ID
+
+	transport Transport
+
+	// get_server_info cache, guarded by serverInfoMu
+	serverInfoMu         sync.Mutex
+	serverInfo           *ServerInfo // cached get_server_info result
+	serverInfoGeneration uint64      // bumped by every cache reset
+	serverInfoFlight     flightGroup // the get_server_info request in flight
+
+	nonceRetries     int   // resync-and-retry attempts on nonce errors, 0 disables
+	maxResponseSize  int64 // response body cap
+	compressMinBytes int   // gzip request bodies at least this large, 0 disables
+	gzipHosts        sync.Map
+	decimalsCache    sync.Map      // lower-cased token address -> uint8 decimals
+	lenientAddresses bool          // skip EIP-55 checksum verification of address params
+	confirmations    int           // default WithConfirmations for writes
+	confirmationPoll time.Duration // receipt polling interval, 0 means DefaultBlockPollInterval
+	strictDecoding   bool          // reject unknown and missing result fields
+	authorityCheck   bool          // verify the signer's role before signing writes
+	tokenRules       TokenRules    // CreateToken input bounds
+	maxAmountDigits  int           // longest write amount, 0 means DefaultMaxAmountDigits
+	expectedSigner   string        // address the signing key must derive to, checked by Verify
+
+	// IsPaused cache, guarded by pausedMu
+	pausedMu         sync.Mutex
+	pausedTTL        time.Duration          // 0 disables the cache
+	pausedCache      map[string]pausedEntry // lower-cased token address -> entry
+	pausedGeneration uint64                 // bumped by every pause/unpause
+
+	metadataCache metadataCache // GetTokenMetadata results, see WithMetadataCache
+
+	caseSensitiveSymbols bool // FindTokensBySymbol tells "usdx" from "USDX"
+
+	// Failover, see WithNodeURLs
+	nodeSet       *endpointSet // nil without failover
+	serviceSet    *endpointSet // nil without failover
+	failoverProbe time.Duration
+	endpointHook  func(EndpointEvent)
+
+	// Node lag guard, guarded by headMu
+	headMu      sync.Mutex
+	lagGuard    bool          // check the node isn't syncing or stalled before signing writes
+	stallWindow time.Duration // block number unchanged this long means stalled, 0 disables
+	head        headWatch     // last block number seen advancing
+
+	// Token address verification, guarded by contractMu
+	contractMu         sync.Mutex
+	verifyTokenAddress bool
+	contractCache      map[string]contractEntry // lower-cased address -> entry
+
+	life lifecycle // requests, queues and subscriptions Close waits for
+}
+
+// Option configures a Client created by NewClient
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	privateKey    string
+	key           *ecdsa.PrivateKey
+	signer        Signer
+	httpClient    *http.Client
+	timeout       time.Duration
+	retry         *RetryPolicy
+	logger        *slog.Logger
+	headers       map[string]string
+	nodeURL       string
+	serviceURL    string
+	userAgent     string
+	bearerToken   string
+	tokenProvider func() (string, error)
+	tls           *TLSOptions
+	proxy         string
+	chainID       uint64
+	chainSigning  bool
+	expectedChain uint64
+	vFormat       VFormat
+	sigEncoding   SignatureEncoding
+	transport     Transport
+
+	lenientAddresses bool
+	confirmationPoll time.Duration
+	tuning           *TransportTuning
+	pausedTTL        time.Duration
+	metadataTTL      time.Duration
+	metadataSize     int
+
+	verifyTokenAddress bool
+	strictDecoding     bool
+	authorityCheck     bool
+	tokenRules         TokenRules
+	maxAmountDigits    int
+	expectedSigner     string
+	preflight          bool
+	caseSensitive      bool
+	lagGuard           bool
+	stallWindow        time.Duration
+	readNodeURL        string
+	readServiceURL     string
+	coalesceReads      bool
+	signers            map[string]Signer
+	nodeURLs           []string
+	serviceURLs        []string
+	probeInterval      time.Duration
+	endpointHook       func(EndpointEvent)
+}
+
+// WithPrivateKey signs requests with a hex private key (with or without 0x prefix)
+func WithPrivateKey(hexKey string) Option {
+	return func(o *clientOptions) { o.privateKey = hexKey }
+}
+
+// WithKey signs requests with an already parsed private key, e.g. one loaded from a vault,
+// without a round trip through hex
+func WithKey(key *ecdsa.PrivateKey) Option {
+	return func(o *clientOptions) { o.key = key }
+}
+
+// WithSigner signs requests with signer instead of an in-memory private key
+func WithSigner(signer Signer) Option {
+	return func(o *clientOptions) { o.signer = signer }
+}
+
+// WithHTTPClient sends requests with httpClient. The client is used as-is: its timeout,
+// TLS and proxy settings apply, and WithTimeout, WithTLS and WithProxy can't be combined.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *clientOptions) { o.httpClient = httpClient }
+}
+
+// WithTimeout sets the HTTP request timeout (default DefaultTimeout)
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) { o.timeout = timeout }
+}
+
+// WithRetryPolicy retries requests that failed before reaching the server (see RetryPolicy)
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) { o.retry = &policy }
+}
+
+// WithLogger logs each request (method, endpoint, attempt, duration, error) at debug
+// level. Headers, credentials and signatures are never logged.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *clientOptions) { o.logger = logger }
+}
+
+// WithHeaders sends static headers with every request (see ConfigHeader)
+func WithHeaders(headers map[string]string) Option {
+	return func(o *clientOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string, len(headers))
+		}
+		for name, value := range headers {
+			o.headers[name] = value
+		}
+	}
+}
+
+// WithNodeURL sets a separate Ethereum node URL (see ConfigNodeURL)
+func WithNodeURL(url string) Option {
+	return func(o *clientOptions) { o.nodeURL = url }
+}
+
+// WithServiceURL sets a separate token service URL (see ConfigServiceURL)
+func WithServiceURL(url string) Option {
+	return func(o *clientOptions) { o.serviceURL = url }
+}
+
+// WithUserAgent overrides the default User-Agent (see ConfigUserAgent)
+func WithUserAgent(ua string) Option {
+	return func(o *clientOptions) { o.userAgent = ua }
+}
+
+// WithBearerToken authenticates with a static bearer token (see ConfigBearerToken)
+func WithBearerToken(token string) Option {
+	return func(o *clientOptions) { o.bearerToken = token }
+}
+
+// WithTokenProvider authenticates with a refreshable bearer token (see ConfigTokenProvider)
+func WithTokenProvider(provider func() (string, error)) Option {
+	return func(o *clientOptions) { o.tokenProvider = provider }
+}
+
+// WithTLS configures TLS for all connections (see ConfigTLS)
+func WithTLS(opts TLSOptions) Option {
+	return func(o *clientOptions) { o.tls = &opts }
+}
+
+// WithProxy routes traffic through a proxy (see ConfigProxy)
+func WithProxy(rawURL string) Option {
+	return func(o *clientOptions) { o.proxy = rawURL }
+}
+
+// WithChainID sets the chain ID used for signing (see ConfigChainID)
+func WithChainID(id uint64) Option {
+	return func(o *clientOptions) { o.chainID = id }
+}
+
+// WithChainIDSigning adds the chain ID to the signed params (see ConfigChainIDSigning)
+func WithChainIDSigning() Option {
+	return func(o *clientOptions) { o.chainSigning = true }
+}
+
+// WithExpectedChainID verifies the node's chain ID before operations (see ConfigExpectedChainID)
+func WithExpectedChainID(id uint64) Option {
+	return func(o *clientOptions) { o.expectedChain = id }
+}
+
+// WithVFormat selects the V encoding expected by the server (see ConfigVFormat)
+func WithVFormat(format VFormat) Option {
+	return func(o *clientOptions) { o.vFormat = format }
+}
+
+// NewClient creates a client for endpoint, the URL serving the Ethereum node with the
+// token service at endpoint + "/rpc" (override with WithNodeURL/WithServiceURL).
+// Options are order-independent; conflicting or invalid combinations return an error.
+func NewClient(endpoint string, opts ...Option) (*Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	c, err := newClientWithOptions(endpoint, &o)
+	if err != nil || !o.preflight {
+		return c, err
+	}
+	if report := c.Verify(context.Background()); !report.OK() {
+		return nil, &PreflightError{Report: report}
+	}
+	return c, nil
+}
+
+// Internal method: validate the options and build the client
+func newClientWithOptions(endpoint string, o *clientOptions) (*Client, error) {
+	if endpoint == "" {
+		return nil, errors.New("alchemy: endpoint is required")
+	}
+	if (o.privateKey != "" && o.signer != nil) || (o.key != nil && (o.privateKey != "" || o.signer != nil)) {
+		return nil, errors.New("alchemy: WithPrivateKey, WithKey and WithSigner are mutually exclusive")
+	}
+	if o.bearerToken != "" && o.tokenProvider != nil {
+		return nil, errors.New("alchemy: WithBearerToken and WithTokenProvider are mutually exclusive")
+	}
+	if o.httpClient != nil && (o.timeout != 0 || o.tls != nil || o.proxy != "" || o.tuning != nil) {
+		return nil, errors.New("alchemy: WithHTTPClient can't be combined with WithTimeout, WithTLS, WithProxy or WithTransportTuning; configure the provided client instead")
+	}
+	if o.httpClient != nil && o.transport != nil {
+		return nil, errors.New("alchemy: WithHTTPClient and WithTransport are mutually exclusive")
+	}
+	if o.timeout < 0 {
+		return nil, fmt.Errorf("alchemy: invalid timeout %v", o.timeout)
+	}
+	if o.retry != nil {
+		if err := o.retry.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateEndpointURLs(o.nodeURLs, o.serviceURLs); err != nil {
+		return nil, err
+	}
+
+	c := newClient(endpoint)
+	c.nodeURL = o.nodeURL
+	c.serviceURL = o.serviceURL
+	c.readNodeURL = o.readNodeURL
+	c.readServiceURL = o.readServiceURL
+	c.coalesceReads = o.coalesceReads
+	c.vFormat = o.vFormat
+	c.sigEncoding = o.sigEncoding
+	c.logger = o.logger
+	c.chainID = o.chainID
+	c.chainIDSigning = o.chainSigning
+	c.expectedChain = o.expectedChain
+	c.lenientAddresses = o.lenientAddresses
+	c.confirmationPoll = o.confirmationPoll
+	c.pausedTTL = max(o.pausedTTL, 0)
+	c.metadataCache.configure(o.metadataTTL, o.metadataSize)
+	c.verifyTokenAddress = o.verifyTokenAddress
+	c.strictDecoding = o.strictDecoding
+	c.authorityCheck = o.authorityCheck
+	c.tokenRules = o.tokenRules
+	c.maxAmountDigits = o.maxAmountDigits
+	c.expectedSigner = o.expectedSigner
+	c.caseSensitiveSymbols = o.caseSensitive
+	c.lagGuard = o.lagGuard
+	c.stallWindow = max(o.stallWindow, 0)
+	c.nodeSet = newNodeSet(o.nodeURLs)
+	c.serviceSet = newServiceSet(o.serviceURLs)
+	c.failoverProbe = o.probeInterval
+	c.endpointHook = o.endpointHook
+
+	if o.privateKey != "" {
+		signer, err := NewPrivateKeySigner(o.privateKey)
+		if err != nil {
+			return nil, err
+		}
+		c.signer = signer
+	}
+	c.key = o.key
+	if o.signer != nil {
+		c.signer = o.signer
+	}
+	for name, signer := range o.signers {
+		c.setNamedSigner(name, signer)
+	}
+	if o.retry != nil {
+		c.retry = *o.retry
+	}
+	if o.transport != nil {
+		c.transport = o.transport
+	}
+
+	for name, value := range o.headers {
+		c.setHeader(name, value)
+	}
+	if o.userAgent != "" {
+		c.userAgent = o.userAgent
+	}
+	if o.bearerToken != "" {
+		c.setBearerToken(o.bearerToken)
+	}
+	if o.tokenProvider != nil {
+		c.tokenProvider = o.tokenProvider
+	}
+
+	if o.httpClient != nil {
+		c.httpClient = o.httpClient
+		c.customHTTP = true
+		return c, nil
+	}
+
+	if o.timeout > 0 {
+		c.timeout = o.timeout
+	}
+	if o.tls != nil {
+		cfg, err := o.tls.build()
+		if err != nil {
+			return nil, err
+		}
+		c.tlsConfig = cfg
+	}
+	if o.proxy != "" {
+		proxy, err := parseProxyURL(o.proxy)
+		if err != nil {
+			return nil, err
+		}
+		c.proxyURL = proxy
+	}
+	if o.tuning != nil {
+		if o.tuning.MaxIdleConnsPerHost < 0 || o.tuning.IdleConnTimeout < 0 || o.tuning.DialTimeout < 0 {
+			return nil, fmt.Errorf("alchemy: invalid transport tuning %+v", *o.tuning)
+		}
+		c.tuning = *o.tuning
+	}
+	c.rebuildTransport()
+
+	return c, nil
+}
+
+// Internal method: client with default settings
+func newClient(endpoint string) *Client {
+	c := &Client{
+		baseURL:         endpoint,
+		vFormat:         VFormatEthereum,
+		timeout:         DefaultTimeout,
+		retry:           RetryPolicy{MaxAttempts: 1},
+		staticHeaders:   map[string]string{},
+		userAgent:       defaultUserAgent,
+		apiKeyHeaders:   map[string]string{},
+		maxResponseSize: DefaultMaxResponseSize,
+	}
+	c.transport = &httpTransport{client: c}
+	c.rebuildTransport()
+	return c
+}
+
+// defaultClient is used by the package-level functions
+var defaultClient = newClient("http://localhost:8545")
+
+// DefaultClient returns the client used by the package-level functions
+func DefaultClient() *Client {
+	return defaultClient
+}
+
+// SetDefaultClient replaces the client used by the package-level functions and the
+// Config* functions, e.g. with one built by NewClient
+func SetDefaultClient(c *Client) {
+	if c != nil {
+		defaultClient = c
+	}
+}
+
+// Internal method: the signer of the operation ctx belongs to: the WithSignerKey one, or
+// the configured key, parsing the private key if needed
+func (c *Client) getSigner(ctx context.Context) (Signer, error) {
+	if name := signerName(ctx); name != "" {
+		return c.namedSigner(name)
+	}
+	if c.signer != nil {
+		return c.signer, nil
+	}
+	if c.key != nil {
+		return NewKeySigner(c.key), nil
+	}
+	return NewPrivateKeySigner(c.privateKey)
+}
+
+// Internal method: effective Ethereum node URL
+func (c *Client) nodeEndpoint() string {
+	if c.nodeURL != "" {
+		return c.nodeURL
+	}
+	return c.baseURL
+}
+
+// Internal method: effective token service URL
+func (c *Client) serviceEndpoint() string {
+	if c.serviceURL != "" {
+		return c.serviceURL
+	}
+	return c.baseURL + "/rpc"
+}
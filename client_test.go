@@ -0,0 +1,217 @@
+package alchemy_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// methodsOf is the set of methods a server received
+func methodsOf(srv *alchemytest.Server) map[string]bool {
+	methods := map[string]bool{}
+	for _, req := range srv.Requests() {
+		methods[req.Method] = true
+	}
+	return methods
+}
+
+func TestSeparateNodeAndServiceEndpoints(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, service := alchemytest.NewServer(), alchemytest.NewServer()
+	t.Cleanup(node.Close)
+	t.Cleanup(service.Close)
+	service.RegisterKey(&key.PublicKey)
+	node.SetBlockNumber(777)
+
+	client, err := alchemy.NewClient("http://unused.invalid", alchemy.WithKey(key),
+		alchemy.WithNodeURL(node.URL), alchemy.WithServiceURL(service.URL+"/rpc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CreateToken("Dollar", "USDX", 6, testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	nodeMethods, serviceMethods := methodsOf(node), methodsOf(service)
+	for _, method := range []string{"eth_getBalance", "eth_blockNumber"} {
+		if !nodeMethods[method] || serviceMethods[method] {
+			t.Fatalf("%s: node %v, service %v", method, nodeMethods[method], serviceMethods[method])
+		}
+	}
+	for _, method := range []string{"create_token", "mint"} {
+		if !serviceMethods[method] || nodeMethods[method] {
+			t.Fatalf("%s: node %v, service %v", method, nodeMethods[method], serviceMethods[method])
+		}
+	}
+	// The checkpoint signed into the write came from the node
+	if got := service.RequestsFor("mint")[0].ParamMap["recentCheckpoint"]; fmt.Sprint(got) != "777" {
+		t.Fatalf("recentCheckpoint = %v, want the node's block 777", got)
+	}
+}
+
+func TestSingleEndpointLayout(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range srv.Requests() {
+		want := "/rpc"
+		if req.Method == "eth_blockNumber" || req.Method == "eth_getCode" || req.Method == "eth_chainId" {
+			want = "/"
+		}
+		if req.Path != want {
+			t.Fatalf("%s sent to %s, want %s", req.Method, req.Path, want)
+		}
+	}
+}
+
+func TestNodeURLOnlyKeepsDefaultService(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, base := alchemytest.NewServer(), alchemytest.NewServer()
+	t.Cleanup(node.Close)
+	t.Cleanup(base.Close)
+	base.RegisterKey(&key.PublicKey)
+
+	client, err := alchemy.NewClient(base.URL, alchemy.WithKey(key), alchemy.WithNodeURL(node.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(base.RequestsFor("mint")) != 1 || len(base.RequestsFor("eth_blockNumber")) != 0 {
+		t.Fatalf("base server got %v", methodsOf(base))
+	}
+	if len(node.RequestsFor("eth_blockNumber")) == 0 {
+		t.Fatal("checkpoint not fetched from the node")
+	}
+}
+
+func TestNewClientConflicts(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hexKey := fmt.Sprintf("%x", crypto.FromECDSA(key))
+	signer := alchemy.NewKeySigner(key)
+	tests := []struct {
+		name string
+		opts []alchemy.Option
+	}{
+		{"key and signer", []alchemy.Option{alchemy.WithPrivateKey(hexKey), alchemy.WithSigner(signer)}},
+		{"parsed key and hex key", []alchemy.Option{alchemy.WithKey(key), alchemy.WithPrivateKey(hexKey)}},
+		{"parsed key and signer", []alchemy.Option{alchemy.WithSigner(signer), alchemy.WithKey(key)}},
+		{"bearer token and provider", []alchemy.Option{alchemy.WithBearerToken("t"),
+			alchemy.WithTokenProvider(func() (string, error) { return "t", nil })}},
+		{"http client and timeout", []alchemy.Option{alchemy.WithHTTPClient(&http.Client{}), alchemy.WithTimeout(time.Second)}},
+		{"http client and proxy", []alchemy.Option{alchemy.WithProxy("http://proxy.internal:3128"), alchemy.WithHTTPClient(&http.Client{})}},
+		{"negative timeout", []alchemy.Option{alchemy.WithTimeout(-time.Second)}},
+		{"invalid retry policy", []alchemy.Option{alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: -1})}},
+		{"invalid private key", []alchemy.Option{alchemy.WithPrivateKey("zz")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := alchemy.NewClient("http://localhost:8545", tt.opts...); err == nil {
+				t.Fatal("conflicting options accepted")
+			}
+			// Order doesn't matter
+			reversed := make([]alchemy.Option, len(tt.opts))
+			for i, opt := range tt.opts {
+				reversed[len(tt.opts)-1-i] = opt
+			}
+			if _, err := alchemy.NewClient("http://localhost:8545", reversed...); err == nil {
+				t.Fatal("conflicting options accepted in reverse order")
+			}
+		})
+	}
+
+	if _, err := alchemy.NewClient(""); err == nil {
+		t.Fatal("empty endpoint accepted")
+	}
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.FailNext("eth_getBalance", http.StatusServiceUnavailable, 1)
+
+	// No retries by default
+	if err := client.GetBalance(testRecipient).Err(); err == nil {
+		t.Fatal("503 retried without a retry policy")
+	}
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	req := srv.RequestsFor("eth_getBalance")[1]
+	if req.Header.Get("User-Agent") != "alchemy-chain-go-sdk/"+alchemy.Version || req.Header.Get("Authorization") != "" {
+		t.Fatalf("default headers %v", req.Header)
+	}
+}
+
+func TestNewClientOptionsApply(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	t.Cleanup(slow.Close)
+	client, err := alchemy.NewClient(slow.URL, alchemy.WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetBalance(testRecipient).Err(); !alchemy.IsTransient(err) {
+		t.Fatalf("err = %v, want a timeout", err)
+	}
+
+	srv, retrying, _ := newTestServer(t, alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	srv.FailNext("eth_getBalance", http.StatusServiceUnavailable, 1)
+	if err := retrying.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatalf("503 not retried: %v", err)
+	}
+
+	var used atomic.Bool
+	httpClient := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		used.Store(true)
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	custom, err := srv.NewClient(alchemy.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := custom.GetBalance(testRecipient).Err(); err != nil || !used.Load() {
+		t.Fatalf("err %v, custom HTTP client used %v", err, used.Load())
+	}
+
+	// Package-level functions behave the same with a client built by NewClient
+	useDefaultClient(t, retrying)
+	srv.FailNext("eth_getBalance", http.StatusServiceUnavailable, 1)
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatalf("default client: %v", err)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,214 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by calls made after Close
+var ErrClientClosed = errors.New("client closed")
+
+// queueDrainKey marks the context of a TxQueue submission, let through while Close drains
+// the queue
+type queueDrainKey struct{}
+
+// lifecycle tracks the work Close waits for, guarded by mu
+type lifecycle struct {
+	mu     sync.Mutex
+	closed bool          // set by Close: new calls fail with ErrClientClosed
+	done   chan struct{} // closed when the first Close has returned
+	err    error         // result of the first Close
+
+	active int           // requests in flight
+	idle   chan struct{} // closed when active drops to 0 while Close waits, else nil
+	queues map[*TxQueue]struct{}
+
+	background sync.WaitGroup // subscription and polling goroutines
+
+	// stop ends subscriptions and polling when Close starts; abort cancels the requests
+	// still in flight when Close's ctx ends first
+	stop, abort             context.Context
+	stopCancel, abortCancel context.CancelFunc
+}
+
+// Internal method: create the contexts on first use, l.mu held
+func (l *lifecycle) init() {
+	if l.stop == nil {
+		l.stop, l.stopCancel = context.WithCancel(context.Background())
+		l.abort, l.abortCancel = context.WithCancel(context.Background())
+	}
+}
+
+// Close shuts the client down for a graceful service stop. It stops accepting new
+// operations, then waits until queued writes (NewTxQueue) are submitted and requests in
+// flight have finished, and closes subscriptions with a WebSocket close frame. If ctx
+// ends first, writes still queued fail with ErrQueueClosed, requests in flight are
+// canceled and ctx.Err() is returned. Afterwards every call fails with ErrClientClosed.
+// Calling Close again waits for the first Close and returns its result.
+func (c *Client) Close(ctx context.Context) error {
+	l := &c.life
+	l.mu.Lock()
+	if l.done != nil {
+		done := l.done
+		l.mu.Unlock()
+		select {
+		case <-done:
+			return l.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	l.init()
+	l.closed = true
+	l.done = make(chan struct{})
+	queues := make([]*TxQueue, 0, len(l.queues))
+	for q := range l.queues {
+		queues = append(queues, q)
+	}
+	l.mu.Unlock()
+
+	l.stopCancel() // subscriptions and polling loops end
+	err := c.drain(ctx, queues)
+	if err != nil {
+		l.abortCancel()
+	}
+	if c.httpClient != nil {
+		c.httpClient.CloseIdleConnections()
+	}
+
+	l.err = err
+	close(l.done)
+	return err
+}
+
+// Internal method: wait for the queues, the background goroutines and the requests in
+// flight, in that order, or until ctx ends
+func (c *Client) drain(ctx context.Context, queues []*TxQueue) error {
+	l := &c.life
+	for _, q := range queues {
+		if err := q.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		l.background.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	if l.active > 0 && l.idle == nil {
+		l.idle = make(chan struct{})
+	}
+	idle := l.idle
+	l.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Internal method: whether Close has been called
+func (c *Client) isClosed() bool {
+	c.life.mu.Lock()
+	defer c.life.mu.Unlock()
+	return c.life.closed
+}
+
+// Internal method: register a request, returning its context (canceled if Close gives
+// up waiting) and the function to call when it is done. Fails with ErrClientClosed once
+// the client is closed, except for the submissions of a draining TxQueue.
+func (c *Client) beginRequest(ctx context.Context) (context.Context, func(), error) {
+	l := &c.life
+	l.mu.Lock()
+	if l.closed && ctx.Value(queueDrainKey{}) == nil {
+		l.mu.Unlock()
+		return nil, nil, ErrClientClosed
+	}
+	l.init()
+	l.active++
+	abort := l.abort
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stopAbort := context.AfterFunc(abort, cancel)
+	return ctx, func() {
+		stopAbort()
+		cancel()
+		l.mu.Lock()
+		l.active--
+		if l.active == 0 && l.idle != nil {
+			close(l.idle)
+			l.idle = nil
+		}
+		l.mu.Unlock()
+	}, nil
+}
+
+// Internal method: run fn in a goroutine Close waits for, with a context canceled when
+// ctx ends or Close starts. Fails with ErrClientClosed once the client is closed.
+func (c *Client) goBackground(ctx context.Context, fn func(ctx context.Context)) error {
+	ctx, cancel, err := c.backgroundContext(ctx)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer c.life.background.Done()
+		defer cancel()
+		fn(ctx)
+	}()
+	return nil
+}
+
+// Internal method: context of background work, canceled when ctx ends or Close starts;
+// the caller must call c.life.background.Done and cancel when the work has finished
+func (c *Client) backgroundContext(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	l := &c.life
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, nil, ErrClientClosed
+	}
+	l.init()
+	l.background.Add(1)
+	ctx, cancel := context.WithCancel(ctx)
+	stopAfter := context.AfterFunc(l.stop, cancel)
+	return ctx, func() {
+		stopAfter()
+		cancel()
+	}, nil
+}
+
+// Internal method: track q so Close drains it; false once the client is closed
+func (c *Client) registerQueue(q *TxQueue) bool {
+	l := &c.life
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return false
+	}
+	if l.queues == nil {
+		l.queues = map[*TxQueue]struct{}{}
+	}
+	l.queues[q] = struct{}{}
+	return true
+}
+
+// Internal method: forget a stopped queue
+func (c *Client) unregisterQueue(q *TxQueue) {
+	c.life.mu.Lock()
+	defer c.life.mu.Unlock()
+	delete(c.life.queues, q)
+}
@@ -0,0 +1,245 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/gorilla/websocket"
+)
+
+// sdkGoroutines returns the ids of the goroutines running SDK or WebSocket code, so a test
+// can tell the goroutines it started from those left by earlier tests
+func sdkGoroutines() map[string]string {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	goroutines := map[string]string{}
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if !strings.Contains(stack, "/alchemy-chain-go-sdk.") && !strings.Contains(stack, "gorilla/websocket") {
+			continue
+		}
+		id, _, _ := strings.Cut(strings.TrimPrefix(stack, "goroutine "), " ")
+		goroutines[id] = stack
+	}
+	return goroutines
+}
+
+// checkNoLeaks fails the test if goroutines not in before still run SDK code
+func checkNoLeaks(t *testing.T, before map[string]string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var leaked []string
+		for id, stack := range sdkGoroutines() {
+			if _, ok := before[id]; !ok {
+				leaked = append(leaked, stack)
+			}
+		}
+		if len(leaked) == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines leaked:\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newHoldingWebSocketServer accepts one subscription, keeps the connection open and
+// reports the close code the client hangs up with
+func newHoldingWebSocketServer(t *testing.T) (*httptest.Server, <-chan int) {
+	t.Helper()
+	codes := make(chan int, 1)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req map[string]interface{}
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req["id"], "result": "0x1"})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					codes <- closeErr.Code
+				} else {
+					codes <- -1
+				}
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, codes
+}
+
+// gatedTransport passes requests through, holding each mint for hold (or, when hold is
+// 0, until the request is canceled) and reporting its start on started
+func gatedTransport(hold time.Duration, started chan<- struct{}) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"method":"mint"`)) {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			if hold == 0 {
+				<-r.Context().Done()
+				return nil, r.Context().Err()
+			}
+			time.Sleep(hold)
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+}
+
+// drained waits for ch to be closed
+func drained[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("channel not closed")
+		}
+	}
+}
+
+func TestCloseDrainsAndStops(t *testing.T) {
+	before := sdkGoroutines()
+	ws, closeCodes := newHoldingWebSocketServer(t)
+	started := make(chan struct{}, 1)
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(gatedTransport(100*time.Millisecond, started)))
+	srv.SetResponse("eth_getBlockByNumber", header(1))
+	useDefaultClient(t, client)
+	alchemy.ConfigWebSocketURL("ws" + strings.TrimPrefix(ws.URL, "http"))
+
+	events, err := client.SubscribeTokenEvents(context.Background(), testToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fake node has no WebSocket endpoint: new blocks are polled
+	blocks, err := client.SubscribeNewBlocks(context.Background(), alchemy.WithPollInterval(5*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue := client.NewTxQueue()
+	queued := queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "1"))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The queued write was submitted before Close returned
+	select {
+	case <-queued.Done():
+	default:
+		t.Fatal("Close returned before the queued write completed")
+	}
+	if err := queued.Err(); err != nil {
+		t.Fatalf("queued write: %v", err)
+	}
+	select {
+	case code := <-closeCodes:
+		if code != websocket.CloseNormalClosure {
+			t.Fatalf("subscription closed with code %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription connection not closed")
+	}
+	drained(t, events)
+	drained(t, blocks)
+
+	calls := map[string]func() error{
+		"read":  func() error { return client.GetSupplyCap(testToken).Err() },
+		"write": func() error { return client.Mint(testToken, testRecipient, "1", 0).Err() },
+		"enqueue": func() error {
+			return queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "1")).Err()
+		},
+		"new queue": func() error {
+			return client.NewTxQueue().Enqueue(alchemy.MintOperation(testToken, testRecipient, "1")).Err()
+		},
+		"subscribe": func() error {
+			_, err := client.SubscribeTokenEvents(context.Background(), testToken)
+			return err
+		},
+		"subscribe blocks": func() error {
+			_, err := client.SubscribeNewBlocks(context.Background())
+			return err
+		},
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, alchemy.ErrClientClosed) {
+			t.Fatalf("%s after Close: err = %v, want ErrClientClosed", name, err)
+		}
+	}
+
+	// Closing again is safe, also concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Close(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	checkNoLeaks(t, before)
+}
+
+func TestCloseGivesUpWhenContextEnds(t *testing.T) {
+	before := sdkGoroutines()
+	started := make(chan struct{}, 1)
+	_, client, _ := newTestServer(t, alchemy.WithHTTPClient(gatedTransport(0, started)))
+
+	queue := client.NewTxQueue()
+	inFlight := queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "1"))
+	pending := queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "2"))
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := client.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Close = %v, want DeadlineExceeded", err)
+	}
+	// The stuck request is canceled and the write never sent fails
+	if err := inFlight.Await(context.Background()).Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("in-flight write: err = %v, want canceled", err)
+	}
+	if err := pending.Err(); !errors.Is(err, alchemy.ErrQueueClosed) {
+		t.Fatalf("pending write: err = %v, want ErrQueueClosed", err)
+	}
+	if err := client.Close(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second Close = %v, want the first result", err)
+	}
+
+	checkNoLeaks(t, before)
+}
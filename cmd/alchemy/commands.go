@@ -0,0 +1,348 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// connFlags are the connection flags every command takes
+type connFlags struct {
+	endpoint   string
+	serviceURL string
+	keyFile    string
+	timeout    time.Duration
+}
+
+// writeFlags are the flags of commands that send a transaction
+type writeFlags struct {
+	nonce  int64
+	dryRun bool
+}
+
+// newFlagSet creates the flag set of a command with the connection flags registered
+func (e *env) newFlagSet(name string) (*flag.FlagSet, *connFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	conn := &connFlags{}
+	fs.StringVar(&conn.endpoint, "endpoint", e.getenv(envEndpoint), "node URL; the token service is at <endpoint>/rpc unless --service-url is set (default $"+envEndpoint+")")
+	fs.StringVar(&conn.serviceURL, "service-url", "", "token service URL")
+	fs.StringVar(&conn.keyFile, "key-file", "", "file holding a hex private key or an encrypted JSON keystore (default: $"+envPrivateKey+")")
+	fs.DurationVar(&conn.timeout, "timeout", alchemy.DefaultTimeout, "HTTP request timeout")
+	return fs, conn
+}
+
+// addWriteFlags registers --nonce and --dry-run
+func addWriteFlags(fs *flag.FlagSet) *writeFlags {
+	w := &writeFlags{}
+	fs.Int64Var(&w.nonce, "nonce", -1, "nonce to sign with (default: the next nonce reported by the server)")
+	fs.BoolVar(&w.dryRun, "dry-run", false, "simulate the operation instead of sending it")
+	return w
+}
+
+// parse parses args, rejecting positional arguments and missing required flags
+func (e *env) parse(fs *flag.FlagSet, args []string, required ...string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(e.stderr)
+			fs.PrintDefaults()
+		}
+		return &usageError{msg: fmt.Sprintf("%s: %v", fs.Name(), err)}
+	}
+	if fs.NArg() > 0 {
+		return &usageError{msg: fmt.Sprintf("%s: unexpected argument %q", fs.Name(), fs.Arg(0))}
+	}
+	for _, name := range required {
+		if fs.Lookup(name).Value.String() == "" {
+			return &usageError{msg: fmt.Sprintf("%s: --%s is required", fs.Name(), name)}
+		}
+	}
+	return nil
+}
+
+// client creates the SDK client; withKey loads the signing key, which every token service
+// call needs
+func (e *env) client(conn *connFlags, withKey bool) (*alchemy.Client, *ecdsa.PrivateKey, error) {
+	if conn.endpoint == "" {
+		return nil, nil, &usageError{msg: "--endpoint or $" + envEndpoint + " is required"}
+	}
+
+	opts := []alchemy.Option{alchemy.WithTimeout(conn.timeout)}
+	if conn.serviceURL != "" {
+		opts = append(opts, alchemy.WithServiceURL(conn.serviceURL))
+	}
+
+	var key *ecdsa.PrivateKey
+	if withKey {
+		var err error
+		if key, err = loadKey(conn.keyFile, e.getenv); err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, alchemy.WithKey(key))
+	}
+
+	c, err := alchemy.NewClient(conn.endpoint, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, key, nil
+}
+
+// submit sends op with send, or simulates it with --dry-run. Without --nonce the nonce is
+// fetched from the server.
+func (w *writeFlags) submit(c *alchemy.Client, op alchemy.Operation, send func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult]) (interface{}, error) {
+	nonce := w.nonce
+	if nonce < 0 {
+		next, err := c.GetNonce(op.Token).Result()
+		if err != nil {
+			return nil, fmt.Errorf("get nonce: %w", err)
+		}
+		nonce = next
+	}
+
+	if w.dryRun {
+		simulation, err := c.Simulate(op, nonce).Result()
+		if err != nil {
+			return nil, err
+		}
+		if !simulation.WouldSucceed {
+			if simulation.RevertReason != "" {
+				return simulation, fmt.Errorf("%w: %s", errWouldRevert, simulation.RevertReason)
+			}
+			return simulation, errWouldRevert
+		}
+		return simulation, nil
+	}
+
+	tx, err := send(nonce).Result()
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func runCreateToken(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("create-token")
+	name := fs.String("name", "", "token name")
+	symbol := fs.String("symbol", "", "token symbol")
+	decimals := fs.Int("decimals", 18, "token decimals")
+	master := fs.String("master-authority", "", "master authority address (default: the signer)")
+	if err := e.parse(fs, args, "name", "symbol"); err != nil {
+		return nil, err
+	}
+	if *decimals < 0 || *decimals > 255 {
+		return nil, &usageError{msg: fmt.Sprintf("create-token: --decimals must be between 0 and 255, got %d", *decimals)}
+	}
+
+	c, key, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	if *master == "" {
+		*master = crypto.PubkeyToAddress(key.PublicKey).Hex()
+	}
+
+	issued, err := c.CreateToken(*name, *symbol, int32(*decimals), *master).Result()
+	if err != nil {
+		return nil, err
+	}
+	return issued, nil
+}
+
+func runMint(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("mint")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	to := fs.String("to", "", "recipient address")
+	amount := fs.String("amount", "", "amount in base units")
+	if err := e.parse(fs, args, "token", "to", "amount"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.submit(c, alchemy.MintOperation(*token, *to, *amount), func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.Mint(*token, *to, *amount, nonce)
+	})
+}
+
+func runBurn(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("burn")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	amount := fs.String("amount", "", "amount in base units")
+	if err := e.parse(fs, args, "token", "amount"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.submit(c, alchemy.BurnOperation(*token, *amount), func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.Burn(*token, *amount, nonce)
+	})
+}
+
+func runPause(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("pause")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	if err := e.parse(fs, args, "token"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.submit(c, alchemy.PauseOperation(*token), func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.Pause(*token, nonce)
+	})
+}
+
+func runUnpause(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("unpause")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	if err := e.parse(fs, args, "token"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.submit(c, alchemy.UnpauseOperation(*token), func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.Unpause(*token, nonce)
+	})
+}
+
+func runBlacklistAdd(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("blacklist add")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	account := fs.String("account", "", "account to blacklist")
+	if err := e.parse(fs, args, "token", "account"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	return w.submit(c, alchemy.AddToBlacklistOperation(*token, *account), func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.AddToBlacklist(*token, *account, nonce)
+	})
+}
+
+func runBlacklistRemove(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("blacklist remove")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	account := fs.String("account", "", "account to remove from the blacklist")
+	if err := e.parse(fs, args, "token", "account"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	op := alchemy.Operation{Token: *token, Method: "removeFromBlacklist", Args: []interface{}{*account}}
+	return w.submit(c, op, func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.RemoveFromBlacklist(*token, *account, nonce)
+	})
+}
+
+func runMetadataGet(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("metadata get")
+	token := fs.String("token", "", "token address")
+	if err := e.parse(fs, args, "token"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := c.GetTokenMetadata(*token).Result()
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+func runMetadataUpdate(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("metadata update")
+	w := addWriteFlags(fs)
+	token := fs.String("token", "", "token address")
+	name := fs.String("name", "", "new token name")
+	symbol := fs.String("symbol", "", "new token symbol")
+	if err := e.parse(fs, args, "token", "name", "symbol"); err != nil {
+		return nil, err
+	}
+
+	c, _, err := e.client(conn, true)
+	if err != nil {
+		return nil, err
+	}
+	op := alchemy.Operation{Token: *token, Method: "updateMetadata", Args: []interface{}{*name, *symbol}}
+	return w.submit(c, op, func(nonce int64) *alchemy.ResponseHandler[*alchemy.TransactionResult] {
+		return c.UpdateMetadata(*token, *name, *symbol, nonce)
+	})
+}
+
+// tokenBalance is the output of balance --token
+type tokenBalance struct {
+	Address  string `json:"address"`
+	Token    string `json:"token"`
+	Balance  string `json:"balance"`  // base units
+	Decimals uint8  `json:"decimals"` // token decimals
+	Amount   string `json:"amount"`   // Balance scaled by Decimals, exact
+}
+
+func runBalance(e *env, args []string) (interface{}, error) {
+	fs, conn := e.newFlagSet("balance")
+	address := fs.String("address", "", "account address")
+	token := fs.String("token", "", "token address (default: the ETH balance)")
+	if err := e.parse(fs, args, "address"); err != nil {
+		return nil, err
+	}
+
+	// ETH balances come from the node, which needs no signature
+	c, _, err := e.client(conn, *token != "")
+	if err != nil {
+		return nil, err
+	}
+	if *token == "" {
+		balance, err := c.GetBalance(*address).Result()
+		if err != nil {
+			return nil, err
+		}
+		return balance, nil
+	}
+
+	balance, err := c.GetTokenBalance(*token, *address).Result()
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := c.GetTokenMetadata(*token).Result()
+	if err != nil {
+		return nil, err
+	}
+	amount, err := alchemy.FromBaseUnits(balance, metadata.Decimals)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenBalance{Address: *address, Token: *token, Balance: balance, Decimals: metadata.Decimals, Amount: amount}, nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// loadKey reads the signing key from keyFile, or from $ALCHEMY_PRIVATE_KEY when keyFile is
+// empty
+func loadKey(keyFile string, getenv func(string) string) (*ecdsa.PrivateKey, error) {
+	if keyFile == "" {
+		hexKey := getenv(envPrivateKey)
+		if hexKey == "" {
+			return nil, &usageError{msg: "no signing key: set $" + envPrivateKey + " or use --key-file"}
+		}
+		key, err := parseHexKey(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("$%s: %w", envPrivateKey, err)
+		}
+		return key, nil
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		key, err := decryptKeystore(data, getenv(envKeystorePassword))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyFile, err)
+		}
+		return key, nil
+	}
+	key, err := parseHexKey(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", keyFile, err)
+	}
+	return key, nil
+}
+
+// parseHexKey parses a hex private key with or without 0x prefix. The key itself is never
+// part of the error.
+func parseHexKey(hexKey string) (*ecdsa.PrivateKey, error) {
+	hexKey = strings.TrimPrefix(strings.TrimSpace(hexKey), "0x")
+	key, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, errors.New("invalid private key")
+	}
+	return key, nil
+}
+
+// keystoreFile is an encrypted key in the Web3 Secret Storage (version 3) format written by
+// geth and most wallets
+type keystoreFile struct {
+	Version int `json:"version"`
+	Crypto  struct {
+		Cipher       string `json:"cipher"`
+		CipherText   string `json:"ciphertext"`
+		CipherParams struct {
+			IV string `json:"iv"`
+		} `json:"cipherparams"`
+		KDF       string          `json:"kdf"`
+		KDFParams json.RawMessage `json:"kdfparams"`
+		MAC       string          `json:"mac"`
+	} `json:"crypto"`
+}
+
+// decryptKeystore decrypts a version 3 keystore with password. Both the scrypt and the
+// pbkdf2 (hmac-sha256) key derivations are supported, with aes-128-ctr.
+func decryptKeystore(data []byte, password string) (*ecdsa.PrivateKey, error) {
+	var ks keystoreFile
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore: %w", err)
+	}
+	if ks.Version != 3 {
+		return nil, fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	if ks.Crypto.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("unsupported keystore cipher %q", ks.Crypto.Cipher)
+	}
+
+	derived, err := deriveKeystoreKey(ks.Crypto.KDF, ks.Crypto.KDFParams, password)
+	if err != nil {
+		return nil, err
+	}
+	if len(derived) < 32 {
+		return nil, errors.New("invalid keystore: dklen must be at least 32")
+	}
+
+	cipherText, err := hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.New("invalid keystore: malformed ciphertext")
+	}
+	mac, err := hex.DecodeString(ks.Crypto.MAC)
+	if err != nil {
+		return nil, errors.New("invalid keystore: malformed mac")
+	}
+	if subtle.ConstantTimeCompare(crypto.Keccak256(derived[16:32], cipherText), mac) != 1 {
+		return nil, errors.New("wrong keystore password (set $" + envKeystorePassword + ")")
+	}
+
+	iv, err := hex.DecodeString(ks.Crypto.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.New("invalid keystore: malformed iv")
+	}
+	block, err := aes.NewCipher(derived[:16])
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(cipherText))
+	cipher.NewCTR(block, iv).XORKeyStream(plain, cipherText)
+
+	key, err := crypto.ToECDSA(plain)
+	if err != nil {
+		return nil, errors.New("invalid keystore: decrypted key is not a valid private key")
+	}
+	return key, nil
+}
+
+// deriveKeystoreKey derives the keystore encryption key from password
+func deriveKeystoreKey(kdf string, rawParams json.RawMessage, password string) ([]byte, error) {
+	var params struct {
+		Salt  string `json:"salt"`
+		DKLen int    `json:"dklen"`
+		N     int    `json:"n"` // scrypt
+		R     int    `json:"r"`
+		P     int    `json:"p"`
+		C     int    `json:"c"` // pbkdf2
+		PRF   string `json:"prf"`
+	}
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return nil, fmt.Errorf("invalid keystore kdfparams: %w", err)
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, errors.New("invalid keystore: malformed salt")
+	}
+
+	switch kdf {
+	case "scrypt":
+		return scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	case "pbkdf2":
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported keystore prf %q", params.PRF)
+		}
+		if params.C <= 0 {
+			return nil, errors.New("invalid keystore: pbkdf2 iteration count must be positive")
+		}
+		return pbkdf2.Key(sha256.New, password, salt, params.C, params.DKLen)
+	default:
+		return nil, fmt.Errorf("unsupported keystore kdf %q", kdf)
+	}
+}
@@ -0,0 +1,190 @@
+// Command alchemy runs common token operations against an Alchemy Chain deployment without
+// writing a Go program, e.g. pausing a token or blacklisting an address during an incident.
+//
+// Usage:
+//
+//	alchemy <command> [flags]
+//
+// Commands:
+//
+//	create-token      --name --symbol [--decimals] [--master-authority]
+//	mint              --token --to --amount [--nonce] [--dry-run]
+//	burn              --token --amount [--nonce] [--dry-run]
+//	pause             --token [--nonce] [--dry-run]
+//	unpause           --token [--nonce] [--dry-run]
+//	blacklist add     --token --account [--nonce] [--dry-run]
+//	blacklist remove  --token --account [--nonce] [--dry-run]
+//	metadata get      --token
+//	metadata update   --token --name --symbol [--nonce] [--dry-run]
+//	balance           --address [--token]
+//
+// Every command takes --endpoint (default $ALCHEMY_ENDPOINT) and --service-url. The signing
+// key is never passed as a flag: it is read from $ALCHEMY_PRIVATE_KEY, or from the file given
+// with --key-file, which holds either a hex key or an encrypted JSON keystore whose password
+// is read from $ALCHEMY_KEYSTORE_PASSWORD.
+//
+// Results are printed to stdout as JSON. Errors are printed to stderr as a JSON object with
+// an "error" message and, for errors returned by the server, its RPC "code". Without
+// --nonce, writes use the nonce reported by the server. --dry-run simulates the write
+// instead of sending it.
+//
+// Exit status: 0 on success, 1 on failure (including a simulation that would revert),
+// 2 on usage errors, 3 when the server returned an RPC error.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// Exit statuses
+const (
+	exitOK    = 0
+	exitFail  = 1
+	exitUsage = 2
+	exitRPC   = 3
+)
+
+// Environment variables
+const (
+	envEndpoint         = "ALCHEMY_ENDPOINT"
+	envPrivateKey       = "ALCHEMY_PRIVATE_KEY"
+	envKeystorePassword = "ALCHEMY_KEYSTORE_PASSWORD"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Getenv, os.Stdout, os.Stderr))
+}
+
+// env gives the command access to its environment and output streams
+type env struct {
+	getenv func(string) string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a subcommand; run returns the value to print as the result
+type command struct {
+	usage string
+	run   func(e *env, args []string) (interface{}, error)
+}
+
+// commands maps command names, including the two-word ones, to their implementations
+var commands = map[string]command{
+	"create-token":     {"--name --symbol [--decimals] [--master-authority]", runCreateToken},
+	"mint":             {"--token --to --amount [--nonce] [--dry-run]", runMint},
+	"burn":             {"--token --amount [--nonce] [--dry-run]", runBurn},
+	"pause":            {"--token [--nonce] [--dry-run]", runPause},
+	"unpause":          {"--token [--nonce] [--dry-run]", runUnpause},
+	"blacklist add":    {"--token --account [--nonce] [--dry-run]", runBlacklistAdd},
+	"blacklist remove": {"--token --account [--nonce] [--dry-run]", runBlacklistRemove},
+	"metadata get":     {"--token", runMetadataGet},
+	"metadata update":  {"--token --name --symbol [--nonce] [--dry-run]", runMetadataUpdate},
+	"balance":          {"--address [--token]", runBalance},
+}
+
+// usageError is a bad command line; it exits with exitUsage
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// errWouldRevert is returned after printing a simulation that would fail
+var errWouldRevert = errors.New("simulation: operation would revert")
+
+// run executes the command line args and returns the exit status
+func run(args []string, getenv func(string) string, stdout, stderr io.Writer) int {
+	e := &env{getenv: getenv, stdout: stdout, stderr: stderr}
+
+	name, rest, ok := lookupCommand(args)
+	if !ok {
+		if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			printUsage(stderr)
+			return exitUsage
+		}
+		return e.fail(&usageError{msg: fmt.Sprintf("unknown command %q, run alchemy help", strings.Join(args, " "))})
+	}
+
+	result, err := commands[name].run(e, rest)
+	if result != nil {
+		if err := writeJSON(stdout, result); err != nil {
+			return e.fail(err)
+		}
+	}
+	if err != nil {
+		return e.fail(err)
+	}
+	return exitOK
+}
+
+// lookupCommand finds the command named by the first one or two args
+func lookupCommand(args []string) (string, []string, bool) {
+	if len(args) >= 2 {
+		if _, ok := commands[args[0]+" "+args[1]]; ok {
+			return args[0] + " " + args[1], args[2:], true
+		}
+	}
+	if len(args) >= 1 {
+		if _, ok := commands[args[0]]; ok {
+			return args[0], args[1:], true
+		}
+	}
+	return "", nil, false
+}
+
+// printUsage lists the commands
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: alchemy <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-18s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "The key is read from $%s or --key-file, never from a flag.\n", envPrivateKey)
+}
+
+// fail prints err as JSON to stderr and returns its exit status
+func (e *env) fail(err error) int {
+	out := struct {
+		Error string `json:"error"`
+		Code  *int   `json:"code,omitempty"`
+	}{Error: err.Error()}
+
+	status := exitFail
+	var usage *usageError
+	var rpcErr *alchemy.RPCError
+	switch {
+	case errors.As(err, &usage):
+		status = exitUsage
+	case errors.As(err, &rpcErr):
+		out.Code = &rpcErr.Code
+		status = exitRPC
+	}
+
+	writeJSON(e.stderr, out)
+	return status
+}
+
+// writeJSON prints v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	testToken     = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	testRecipient = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+// cli runs the command line against a fake server
+type cli struct {
+	srv *alchemytest.Server
+	key *ecdsa.PrivateKey
+	env map[string]string
+}
+
+// newCLI starts a fake server accepting a fresh key, exposed in $ALCHEMY_PRIVATE_KEY
+func newCLI(t *testing.T) *cli {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.RegisterKey(&key.PublicKey)
+
+	return &cli{srv: srv, key: key, env: map[string]string{
+		envEndpoint:   srv.URL,
+		envPrivateKey: hex.EncodeToString(crypto.FromECDSA(key)),
+	}}
+}
+
+// run executes args and returns the exit status, stdout and stderr
+func (c *cli) run(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, func(name string) string { return c.env[name] }, &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+// errorOutput decodes the JSON error printed to stderr
+func errorOutput(t *testing.T, stderr string) (string, *int) {
+	t.Helper()
+	var out struct {
+		Error string `json:"error"`
+		Code  *int   `json:"code"`
+	}
+	if err := json.Unmarshal([]byte(stderr), &out); err != nil {
+		t.Fatalf("stderr is not a JSON error: %q", stderr)
+	}
+	return out.Error, out.Code
+}
+
+func TestUsageErrors(t *testing.T) {
+	c := newCLI(t)
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown command", []string{"freeze"}, `unknown command "freeze"`},
+		{"incomplete command", []string{"blacklist"}, `unknown command "blacklist"`},
+		{"key as flag", []string{"pause", "--token", testToken, "--private-key", "abc"}, "flag provided but not defined: -private-key"},
+		{"missing flag", []string{"mint", "--token", testToken, "--amount", "1"}, "mint: --to is required"},
+		{"positional argument", []string{"pause", testToken}, "unexpected argument"},
+		{"bad nonce", []string{"pause", "--token", testToken, "--nonce", "x"}, `invalid value "x" for flag -nonce`},
+		{"bad decimals", []string{"create-token", "--name", "N", "--symbol", "S", "--decimals", "256"}, "--decimals must be between 0 and 255"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, stdout, stderr := c.run(tt.args...)
+			if status != exitUsage {
+				t.Fatalf("status = %d, want %d (stderr %q)", status, exitUsage, stderr)
+			}
+			if stdout != "" {
+				t.Fatalf("stdout = %q", stdout)
+			}
+			if msg, _ := errorOutput(t, stderr); !strings.Contains(msg, tt.want) {
+				t.Fatalf("error = %q, want it to contain %q", msg, tt.want)
+			}
+		})
+	}
+	if n := len(c.srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+
+	status, _, stderr := c.run("help")
+	if status != exitUsage || !strings.Contains(stderr, "blacklist remove") {
+		t.Fatalf("status %d, usage %q", status, stderr)
+	}
+}
+
+func TestNoEndpointOrKey(t *testing.T) {
+	c := newCLI(t)
+	delete(c.env, envPrivateKey)
+	status, _, stderr := c.run("pause", "--token", testToken)
+	if msg, _ := errorOutput(t, stderr); status != exitUsage || !strings.Contains(msg, "no signing key") {
+		t.Fatalf("status %d, error %q", status, msg)
+	}
+
+	delete(c.env, envEndpoint)
+	status, _, stderr = c.run("balance", "--address", testRecipient)
+	if msg, _ := errorOutput(t, stderr); status != exitUsage || !strings.Contains(msg, "--endpoint") {
+		t.Fatalf("status %d, error %q", status, msg)
+	}
+}
+
+func TestMetadataGet(t *testing.T) {
+	c := newCLI(t)
+	c.srv.SetResult("getTokenMetadata", map[string]interface{}{
+		"name": "Test Dollar", "symbol": "TUSD", "decimals": 6, "supply": "1000000", "isPaused": true,
+	})
+
+	status, stdout, stderr := c.run("metadata", "get", "--token", testToken)
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	want := `{
+  "name": "Test Dollar",
+  "symbol": "TUSD",
+  "decimals": 6,
+  "supply": "1000000",
+  "isPaused": true
+}
+`
+	if stdout != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+	if req := c.srv.RequestsFor("getTokenMetadata")[0]; req.Signer != crypto.PubkeyToAddress(c.key.PublicKey).Hex() {
+		t.Fatalf("signed by %s", req.Signer)
+	}
+}
+
+func TestBalance(t *testing.T) {
+	c := newCLI(t)
+	wei, _ := new(big.Int).SetString("1500000000000000001", 10)
+	c.srv.SetBalance(testRecipient, wei)
+
+	// The ETH balance needs no key
+	delete(c.env, envPrivateKey)
+	status, stdout, stderr := c.run("balance", "--address", testRecipient)
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	want := `{
+  "wei": "1500000000000000001",
+  "gwei": "1500000000.000000001",
+  "eth": "1.500000000000000001"
+}
+`
+	if stdout != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+}
+
+func TestTokenBalance(t *testing.T) {
+	c := newCLI(t)
+	c.srv.SetResult("balanceOf", "1500000")
+	c.srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test Dollar", "symbol": "TUSD", "decimals": 6, "supply": "1500000"})
+
+	status, stdout, stderr := c.run("balance", "--address", testRecipient, "--token", testToken)
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	var got tokenBalance
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := tokenBalance{Address: testRecipient, Token: testToken, Balance: "1500000", Decimals: 6, Amount: "1.5"}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMint(t *testing.T) {
+	c := newCLI(t)
+	c.srv.SetResult("get_nonce", 7)
+
+	status, stdout, stderr := c.run("mint", "--token", testToken, "--to", testRecipient, "--amount", "250")
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	var tx struct {
+		Hash  string `json:"hash"`
+		Nonce int64  `json:"nonce"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash == "" || tx.Nonce != 7 {
+		t.Fatalf("output %s", stdout)
+	}
+
+	req := c.srv.RequestsFor("mint")[0]
+	if req.SignatureErr != nil || req.Signer != crypto.PubkeyToAddress(c.key.PublicKey).Hex() {
+		t.Fatalf("signer %s, signature error %v", req.Signer, req.SignatureErr)
+	}
+	if got := fmt.Sprint(req.ParamMap["methodArgs"]); got != "["+testRecipient+" 250]" {
+		t.Fatalf("methodArgs = %s", got)
+	}
+
+	// An explicit nonce skips get_nonce
+	c.srv.Reset()
+	if status, _, stderr := c.run("pause", "--token", testToken, "--nonce", "3"); status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	if n := len(c.srv.RequestsFor("get_nonce")); n != 0 {
+		t.Fatalf("get_nonce called %d times", n)
+	}
+	if got := fmt.Sprint(c.srv.RequestsFor("pause")[0].ParamMap["nonce"]); got != "3" {
+		t.Fatalf("nonce = %s", got)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	c := newCLI(t)
+
+	c.srv.SetResult("addToBlacklist", map[string]interface{}{"wouldSucceed": true, "gasUsed": 21000})
+	status, stdout, stderr := c.run("blacklist", "add", "--token", testToken, "--account", testRecipient, "--dry-run")
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	want := `{
+  "wouldSucceed": true,
+  "gasUsed": 21000
+}
+`
+	if stdout != want {
+		t.Fatalf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+	if req := c.srv.RequestsFor("addToBlacklist")[0]; req.ParamMap["dryRun"] != true {
+		t.Fatalf("params %v", req.ParamMap)
+	}
+
+	c.srv.SetResult("unpause", map[string]interface{}{"wouldSucceed": false, "revertReason": "not paused"})
+	status, stdout, stderr = c.run("unpause", "--token", testToken, "--dry-run")
+	if status != exitFail {
+		t.Fatalf("status = %d, want %d", status, exitFail)
+	}
+	if !strings.Contains(stdout, `"revertReason": "not paused"`) {
+		t.Fatalf("stdout = %q", stdout)
+	}
+	if msg, code := errorOutput(t, stderr); code != nil || !strings.Contains(msg, "would revert: not paused") {
+		t.Fatalf("error %q, code %v", msg, code)
+	}
+}
+
+func TestRPCErrorExitCode(t *testing.T) {
+	c := newCLI(t)
+	c.srv.SetError("pause", -32003, "missing role PAUSE")
+
+	status, stdout, stderr := c.run("pause", "--token", testToken)
+	if status != exitRPC {
+		t.Fatalf("status = %d, want %d", status, exitRPC)
+	}
+	if stdout != "" {
+		t.Fatalf("stdout = %q", stdout)
+	}
+	msg, code := errorOutput(t, stderr)
+	if code == nil || *code != -32003 || !strings.Contains(msg, "missing role PAUSE") {
+		t.Fatalf("error %q, code %v", msg, code)
+	}
+}
+
+func TestCreateToken(t *testing.T) {
+	c := newCLI(t)
+
+	status, stdout, stderr := c.run("create-token", "--name", "Test Dollar", "--symbol", "TUSD", "--decimals", "6")
+	if status != exitOK {
+		t.Fatalf("status %d: %s", status, stderr)
+	}
+	if !strings.Contains(stdout, `"token": "0x`) {
+		t.Fatalf("stdout = %q", stdout)
+	}
+	req := c.srv.RequestsFor("create_token")[0]
+	if got := fmt.Sprint(req.ParamMap["masterAuthority"]); got != crypto.PubkeyToAddress(c.key.PublicKey).Hex() {
+		t.Fatalf("master authority %s, want the signer", got)
+	}
+}
+
+func TestKeyFile(t *testing.T) {
+	c := newCLI(t)
+	hexKey := c.env[envPrivateKey]
+	delete(c.env, envPrivateKey)
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	files := map[string]string{
+		"hex":             write("key.hex", "0x"+hexKey+"\n"),
+		"scrypt keystore": write("scrypt.json", encryptKeystore(t, c.key, "correct horse", "scrypt")),
+		"pbkdf2 keystore": write("pbkdf2.json", encryptKeystore(t, c.key, "correct horse", "pbkdf2")),
+	}
+	c.env[envKeystorePassword] = "correct horse"
+
+	for name, path := range files {
+		t.Run(name, func(t *testing.T) {
+			c.srv.Reset()
+			if status, _, stderr := c.run("pause", "--token", testToken, "--nonce", "0", "--key-file", path); status != exitOK {
+				t.Fatalf("status %d: %s", status, stderr)
+			}
+			if req := c.srv.RequestsFor("pause")[0]; req.Signer != crypto.PubkeyToAddress(c.key.PublicKey).Hex() {
+				t.Fatalf("signed by %s", req.Signer)
+			}
+		})
+	}
+
+	t.Run("wrong password", func(t *testing.T) {
+		c.env[envKeystorePassword] = "battery staple"
+		status, _, stderr := c.run("pause", "--token", testToken, "--key-file", files["scrypt keystore"])
+		if msg, _ := errorOutput(t, stderr); status != exitFail || !strings.Contains(msg, "wrong keystore password") {
+			t.Fatalf("status %d, error %q", status, msg)
+		}
+	})
+
+	t.Run("key not in errors", func(t *testing.T) {
+		path := write("bad.hex", "zz"+hexKey[2:])
+		status, _, stderr := c.run("pause", "--token", testToken, "--key-file", path)
+		if status != exitFail || strings.Contains(stderr, hexKey[2:]) {
+			t.Fatalf("status %d, stderr %q", status, stderr)
+		}
+	})
+}
+
+// encryptKeystore writes key as a version 3 keystore with cheap KDF parameters
+func encryptKeystore(t *testing.T, key *ecdsa.PrivateKey, password, kdf string) string {
+	t.Helper()
+	salt := bytes.Repeat([]byte{0x5a}, 32)
+	iv := bytes.Repeat([]byte{0x01}, aes.BlockSize)
+
+	var derived []byte
+	var params map[string]interface{}
+	var err error
+	switch kdf {
+	case "scrypt":
+		derived, err = scrypt.Key([]byte(password), salt, 16, 8, 1, 32)
+		params = map[string]interface{}{"n": 16, "r": 8, "p": 1, "dklen": 32, "salt": hex.EncodeToString(salt)}
+	default:
+		derived, err = pbkdf2.Key(sha256.New, password, salt, 2, 32)
+		params = map[string]interface{}{"c": 2, "prf": "hmac-sha256", "dklen": 32, "salt": hex.EncodeToString(salt)}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := aes.NewCipher(derived[:16])
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := crypto.FromECDSA(key)
+	cipherText := make([]byte, len(plain))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, plain)
+
+	data, err := json.Marshal(map[string]interface{}{
+		"version": 3,
+		"address": strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex()[2:]),
+		"crypto": map[string]interface{}{
+			"cipher":       "aes-128-ctr",
+			"ciphertext":   hex.EncodeToString(cipherText),
+			"cipherparams": map[string]string{"iv": hex.EncodeToString(iv)},
+			"kdf":          kdf,
+			"kdfparams":    params,
+			"mac":          hex.EncodeToString(crypto.Keccak256(derived[16:32], cipherText)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
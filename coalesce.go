@@ -0,0 +1,101 @@
+package alchemy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// coalescedMethods are the idempotent reads WithReadCoalescing shares between callers
+var coalescedMethods = map[string]bool{
+	"eth_blockNumber":  true,
+	"getTokenMetadata": true,
+	"paused":           true,
+	"balanceOf":        true,
+}
+
+// ConfigReadCoalescing switches read coalescing of the default client on or off (see
+// WithReadCoalescing)
+func ConfigReadCoalescing(enabled bool) {
+	defaultClient.coalesceReads = enabled
+}
+
+// WithReadCoalescing shares one in-flight request between concurrent identical reads:
+// GetTokenMetadata, IsPaused, GetTokenBalance and the eth_blockNumber lookups. Requests are
+// identical when they go to the same endpoint with the same method and params, including the
+// block tag and the recentCheckpoint they are signed with; every caller gets the same result
+// or error. The request is forgotten as soon as it completes, so a later call sends a new
+// one. Writes are never coalesced. A caller whose own context ends stops waiting; when the
+// caller that sent the shared request gives up, the others send theirs.
+func WithReadCoalescing() Option {
+	return func(o *clientOptions) { o.coalesceReads = true }
+}
+
+// flightGroup tracks the coalesced requests in flight
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is one shared request, result and err are set before done is closed
+type flight struct {
+	done   chan struct{}
+	result json.RawMessage
+	err    error
+}
+
+// Internal method: the coalescing key of a request, false if it must not be coalesced
+func (c *Client) coalesceKey(ctx context.Context, endpoint, method string, params interface{}, limit int64) (string, bool) {
+	if !c.coalesceReads || !coalescedMethods[method] || isWriteRequest(ctx) {
+		return "", false
+	}
+	encoded, err := json.Marshal(params) // maps are encoded with sorted keys
+	if err != nil {
+		return "", false
+	}
+	return endpoint + "\x00" + method + "\x00" + strconv.FormatInt(limit, 10) + "\x00" + string(encoded), true
+}
+
+// Internal method: run send for key, or wait for the identical request in flight
+func (g *flightGroup) do(ctx context.Context, key string, send func() (json.RawMessage, error)) (json.RawMessage, error) {
+	for {
+		g.mu.Lock()
+		f, ok := g.flights[key]
+		if !ok {
+			f = &flight{done: make(chan struct{})}
+			if g.flights == nil {
+				g.flights = map[string]*flight{}
+			}
+			g.flights[key] = f
+			g.mu.Unlock()
+			g.lead(key, f, send)
+			return f.result, f.err
+		}
+		g.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if f.err != nil && ctx.Err() == nil && (errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded)) {
+			continue // the sender gave up, not the server
+		}
+		return bytes.Clone(f.result), f.err
+	}
+}
+
+// Internal method: send the shared request and release key, even if send panics
+func (g *flightGroup) lead(key string, f *flight, send func() (json.RawMessage, error)) {
+	defer func() {
+		g.mu.Lock()
+		delete(g.flights, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+	f.err = errors.New("coalesced request panicked")
+	f.result, f.err = send()
+}
@@ -0,0 +1,182 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// slowTransport holds requests for method for delay, counting them, so that concurrent
+// callers overlap
+func slowTransport(method string, delay time.Duration, count *atomic.Int32) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"method":"`+method+`"`)) {
+			count.Add(1)
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return nil, r.Context().Err()
+			}
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+}
+
+// concurrently runs call n times at once and returns the errors
+func concurrently(n int, call func() error) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = call()
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestReadCoalescing(t *testing.T) {
+	tests := []struct {
+		method string
+		call   func(*alchemy.Client) error
+	}{
+		{"getTokenMetadata", func(c *alchemy.Client) error { return c.GetTokenMetadata(testToken).Err() }},
+		{"paused", func(c *alchemy.Client) error { return c.IsPaused(testToken).Err() }},
+		{"balanceOf", func(c *alchemy.Client) error { return c.GetTokenBalance(testToken, testRecipient).Err() }},
+		{"eth_blockNumber", func(c *alchemy.Client) error { return c.CurrentCheckpoint().Err() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var upstream atomic.Int32
+			srv, client, _ := newTestServer(t, alchemy.WithReadCoalescing(),
+				alchemy.WithHTTPClient(slowTransport(tt.method, 200*time.Millisecond, &upstream)))
+			srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test", "symbol": "TST", "decimals": 6})
+			srv.SetResult("paused", false)
+			srv.SetResult("balanceOf", "100")
+
+			for i, err := range concurrently(100, func() error { return tt.call(client) }) {
+				if err != nil {
+					t.Fatalf("call %d: %v", i, err)
+				}
+			}
+			if n := upstream.Load(); n != 1 {
+				t.Fatalf("%d upstream %s requests for 100 concurrent calls, want 1", n, tt.method)
+			}
+		})
+	}
+}
+
+func TestReadCoalescingSharesErrors(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithReadCoalescing(),
+		alchemy.WithHTTPClient(slowTransport("balanceOf", 100*time.Millisecond, &upstream)))
+	srv.SetError("balanceOf", -32000, "execution reverted")
+
+	for i, err := range concurrently(20, func() error { return client.GetTokenBalance(testToken, testRecipient).Err() }) {
+		if opErr := operationError(t, err); opErr.Op != "GetTokenBalance" || opErr.Method != "balanceOf" {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if n := upstream.Load(); n != 1 {
+		t.Fatalf("%d upstream requests, want 1", n)
+	}
+}
+
+func TestReadCoalescingKeys(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithReadCoalescing(),
+		alchemy.WithHTTPClient(slowTransport("balanceOf", 100*time.Millisecond, &upstream)))
+	srv.SetResult("balanceOf", "100")
+
+	// Different block tags and accounts are different reads
+	var i atomic.Int32
+	concurrently(4, func() error {
+		switch i.Add(1) {
+		case 1:
+			return client.GetTokenBalance(testToken, testRecipient).Err()
+		case 2:
+			return client.GetTokenBalance(testToken, testRecipient, alchemy.WithBlock(5)).Err()
+		case 3:
+			return client.GetTokenBalance(testToken, thirdToken).Err()
+		default:
+			return client.GetTokenBalance(testToken, testRecipient, alchemy.WithBlock(5)).Err()
+		}
+	})
+	if n := upstream.Load(); n != 3 {
+		t.Fatalf("%d upstream requests, want 3", n)
+	}
+
+	// The key is released once the request completed
+	if err := client.GetTokenBalance(testToken, testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := upstream.Load(); n != 4 {
+		t.Fatalf("%d upstream requests, want a fresh one", n)
+	}
+}
+
+func TestReadCoalescingSkipsWrites(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithReadCoalescing(),
+		alchemy.WithHTTPClient(slowTransport("mint", 50*time.Millisecond, &upstream)))
+
+	concurrently(10, func() error { return client.Mint(testToken, testRecipient, "5", 0).Err() })
+	if n := len(srv.RequestsFor("mint")); n != 10 {
+		t.Fatalf("%d mints, want 10", n)
+	}
+}
+
+func TestReadCoalescingOff(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(slowTransport("balanceOf", 50*time.Millisecond, &upstream)))
+	srv.SetResult("balanceOf", "100")
+
+	concurrently(10, func() error { return client.GetTokenBalance(testToken, testRecipient).Err() })
+	if n := upstream.Load(); n != 10 {
+		t.Fatalf("%d upstream requests without coalescing, want 10", n)
+	}
+}
+
+func TestReadCoalescingSenderGivesUp(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithReadCoalescing(),
+		alchemy.WithHTTPClient(slowTransport("balanceOf", 200*time.Millisecond, &upstream)))
+	srv.SetResult("balanceOf", "100")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	senderErr := make(chan error, 1)
+	go func() {
+		senderErr <- client.GetTokenBalance(testToken, testRecipient, alchemy.WithContext(ctx)).Err()
+	}()
+	for upstream.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Joins the sender's request, then sends its own when the sender's context ends
+	balance, err := client.GetTokenBalance(testToken, testRecipient).Result()
+	if err != nil || balance != "100" {
+		t.Fatalf("balance %q, %v", balance, err)
+	}
+	if err := <-senderErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("sender err = %v, want its deadline", err)
+	}
+	if n := upstream.Load(); n != 2 {
+		t.Fatalf("%d upstream requests, want 2", n)
+	}
+}
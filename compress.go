@@ -0,0 +1,91 @@
+package alchemy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ConfigRequestCompression gzips request bodies of at least minBytes once the server has
+// advertised gzip support (an Accept-Encoding response header containing gzip). 0 disables
+// request compression, the default. Responses are always requested and decoded with gzip.
+func ConfigRequestCompression(minBytes int) {
+	if minBytes < 0 {
+		minBytes = 0
+	}
+	defaultClient.compressMinBytes = minBytes
+}
+
+// Internal method: gzip the request body when enabled and supported by the host
+func (c *Client) compressRequest(req *http.Request, body []byte) error {
+	if c.compressMinBytes == 0 || len(body) < c.compressMinBytes {
+		return nil
+	}
+	if _, ok := c.gzipHosts.Load(req.URL.Host); !ok {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(body); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	compressed := buf.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(compressed))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(compressed)), nil
+	}
+	req.ContentLength = int64(len(compressed))
+	req.Header.Set("Content-Encoding", "gzip")
+	return nil
+}
+
+// Internal method: remember gzip support and transparently decode a gzipped response body
+func (c *Client) decompressResponse(resp *http.Response) error {
+	if strings.Contains(strings.ToLower(resp.Header.Get("Accept-Encoding")), "gzip") {
+		c.gzipHosts.Store(resp.Request.URL.Host, true)
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(resp.Header.Get("Content-Encoding")), "gzip") {
+		return nil
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		drainAndClose(resp.Body)
+		return fmt.Errorf("decode gzip response: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: gz, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipBody decodes a gzipped response body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err != nil && err != io.EOF {
+		err = fmt.Errorf("decode gzip response: %w", err)
+	}
+	return n, err
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	io.Copy(io.Discard, io.LimitReader(b.body, maxDrainBytes)) // trailing bytes after the gzip stream
+	return b.body.Close()
+}
@@ -0,0 +1,148 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// gzipped compresses data
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write(data)
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// newGzipNode answers eth_getBalance with body, gzip-encoded and streamed in small flushed
+// chunks (chunked transfer encoding)
+func newGzipNode(t *testing.T, body []byte) (*httptest.Server, *http.Header) {
+	t.Helper()
+	var lastHeader http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHeader = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		for len(body) > 0 {
+			n := min(len(body), 7)
+			w.Write(body[:n])
+			w.(http.Flusher).Flush()
+			body = body[n:]
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &lastHeader
+}
+
+func TestGzipChunkedResponse(t *testing.T) {
+	reply := []byte(`{"jsonrpc":"2.0","id":1,"result":"0xde0b6b3a7640000"}`)
+	srv, header := newGzipNode(t, gzipped(t, reply))
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance, err := client.GetBalance(testRecipient).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Wei != "1000000000000000000" {
+		t.Fatalf("balance %+v", balance)
+	}
+	if got := header.Get("Accept-Encoding"); !strings.Contains(got, "gzip") {
+		t.Fatalf("Accept-Encoding = %q", got)
+	}
+}
+
+func TestGzipCorruptResponse(t *testing.T) {
+	stream := gzipped(t, []byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	for i := 12; i < len(stream)-8; i++ {
+		stream[i] ^= 0xff // keep the gzip header, garble the deflate data
+	}
+	tests := map[string][]byte{
+		"corrupt data": stream,
+		"bad header":   []byte("definitely not gzip"),
+		"truncated":    stream[:len(stream)/2],
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv, _ := newGzipNode(t, body)
+			client, err := alchemy.NewClient(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			done := make(chan error, 1)
+			go func() { done <- client.GetBalance(testRecipient).Err() }()
+			select {
+			case err := <-done:
+				if err == nil || !strings.Contains(err.Error(), "gzip") {
+					t.Fatalf("err = %v, want a gzip decoding error", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("request hung on a corrupt gzip stream")
+			}
+		})
+	}
+}
+
+func TestGzipRequestCompression(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		encodings []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		mu.Unlock()
+		w.Header().Set("Accept-Encoding", "gzip")
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useDefaultClient(t, client)
+	alchemy.ConfigRequestCompression(1)
+
+	// The first request learns that the server accepts gzip, the second one uses it
+	for i := 0; i < 2; i++ {
+		if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Fatalf("request encodings %q, want [\"\" gzip]", encodings)
+	}
+}
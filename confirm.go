@@ -0,0 +1,226 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultConfirmationTimeout bounds the wait of writes sent with WithConfirmations
+const DefaultConfirmationTimeout = 5 * time.Minute
+
+var (
+	// ErrTransactionReverted is matched by *RevertedError
+	ErrTransactionReverted = errors.New("transaction reverted")
+	// ErrConfirmationTimeout is returned when a transaction didn't reach the requested
+	// number of confirmations in time. The transaction may still confirm later.
+	ErrConfirmationTimeout = errors.New("timed out waiting for confirmations")
+)
+
+// RevertedError is returned when a transaction was mined but reverted. It matches
+// ErrTransactionReverted, unlike *RevertError which reports a failed simulation.
+type RevertedError struct {
+	Receipt *Receipt
+}
+
+func (e *RevertedError) Error() string {
+	msg := fmt.Sprintf("transaction %s reverted in block %d", e.Receipt.TransactionHash, e.Receipt.BlockNumber)
+	if e.Receipt.RevertReason != "" {
+		msg += ": " + e.Receipt.RevertReason
+	}
+	return msg
+}
+
+func (e *RevertedError) Is(target error) bool {
+	return target == ErrTransactionReverted
+}
+
+// ConfigConfirmations makes every write wait for n confirmations (see WithConfirmations).
+// 0, the default, returns as soon as the server has accepted the request.
+func ConfigConfirmations(n int) {
+	if n < 0 {
+		n = 0
+	}
+	defaultClient.confirmations = n
+}
+
+// WithConfirmationPollInterval sets how often receipts are polled while waiting for
+// confirmations (default DefaultBlockPollInterval)
+func WithConfirmationPollInterval(interval time.Duration) Option {
+	return func(o *clientOptions) { o.confirmationPoll = interval }
+}
+
+// WithConfirmations makes a write block after submission until its receipt has n
+// confirmations (1 means mined). The result then carries the Receipt. The wait is bounded
+// by WithConfirmationTimeout; a revert fails with a *RevertedError, a timeout with
+// ErrConfirmationTimeout. On either failure the result still holds the submitted hash.
+func WithConfirmations(n int) CallOption {
+	return func(c *callConfig) {
+		if n >= 0 {
+			c.confirmations = n
+		}
+	}
+}
+
+// WithConfirmationTimeout bounds the wait of WithConfirmations (default DefaultConfirmationTimeout)
+func WithConfirmationTimeout(timeout time.Duration) CallOption {
+	return func(c *callConfig) {
+		if timeout > 0 {
+			c.confirmationTimeout = timeout
+		}
+	}
+}
+
+// WaitForConfirmation polls until the transaction's receipt has the given number of
+// confirmations (at least 1) and returns the receipt. A reverted transaction fails with a
+// *RevertedError as soon as it is mined. When ctx ends first the error matches
+// ErrConfirmationTimeout (deadline) or context.Canceled.
+func (c *Client) WaitForConfirmation(ctx context.Context, hash string, confirmations int) (r *ResponseHandler[*Receipt]) {
+	defer withOperation(&r, "WaitForConfirmation", "")
+	if confirmations < 1 {
+		return &ResponseHandler[*Receipt]{err: fmt.Errorf("invalid confirmations %d", confirmations)}
+	}
+
+	interval := c.confirmationPoll
+	if interval <= 0 {
+		interval = DefaultBlockPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var have int64
+	stopped := func() *ResponseHandler[*Receipt] {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return &ResponseHandler[*Receipt]{err: fmt.Errorf("%w: %s has %d of %d", ErrConfirmationTimeout, hash, have, confirmations)}
+		}
+		return &ResponseHandler[*Receipt]{err: fmt.Errorf("wait for %s: %w", hash, ctx.Err())}
+	}
+	for {
+		// The polls run on ctx, so a hung node doesn't hold the wait past its deadline
+		receipt, err := c.getReceiptContext(ctx, hash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return stopped()
+			}
+			return &ResponseHandler[*Receipt]{err: err}
+		}
+		if receipt != nil {
+			if !receipt.Succeeded() {
+				return &ResponseHandler[*Receipt]{data: receipt, err: &RevertedError{Receipt: receipt}}
+			}
+			latest, err := c.getBlockNumberContext(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return stopped()
+				}
+				return &ResponseHandler[*Receipt]{err: err}
+			}
+			have = max(latest-receipt.BlockNumber+1, 0)
+			if have >= int64(confirmations) {
+				return &ResponseHandler[*Receipt]{data: receipt}
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return stopped()
+		}
+	}
+}
+
+// confirmable is implemented by write results that can wait for their receipt
+type confirmable interface {
+	txHash() string
+	setReceipt(receipt *Receipt)
+}
+
+func (r *TransactionResult) txHash() string {
+	if r == nil {
+		return ""
+	}
+	return r.Hash
+}
+
+func (r *TransactionResult) setReceipt(receipt *Receipt) { r.Receipt = receipt }
+
+func (r *TokenIssueResult) txHash() string {
+	if r == nil {
+		return ""
+	}
+	return r.Hash
+}
+
+func (r *TokenIssueResult) setReceipt(receipt *Receipt) {
+	r.Receipt = receipt
+	if receipt != nil && r.BlockNumber == 0 {
+		r.BlockNumber = receipt.BlockNumber
+	}
+}
+
+// Internal method: wait for the confirmations requested by cfg on a write result
+func (c *Client) awaitConfirmations(result interface{}, cfg *callConfig) error {
+	tx, ok := result.(confirmable)
+	if !ok || tx.txHash() == "" || cfg.confirmations == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cfg.context(), cfg.confirmationTimeout)
+	defer cancel()
+	receipt := c.WaitForConfirmation(ctx, tx.txHash(), cfg.confirmations)
+	tx.setReceipt(receipt.data)
+	return receipt.err
+}
+
+// tokenReadyAttempts bounds how often CreateTokenAndWait polls the new token's metadata:
+// nodes behind a load balancer may not serve it for a block or two after the receipt
+const tokenReadyAttempts = 10
+
+// CreateTokenAndWait creates a token and returns once its creation is mined and the token
+// answers GetTokenMetadata. The result carries the Receipt and the creation BlockNumber.
+// A reverted creation fails with a *RevertedError; ctx bounds the whole call, requests included.
+func (c *Client) CreateTokenAndWait(ctx context.Context, name, symbol string, decimals int32, masterAuthority string, opts ...CallOption) (r *ResponseHandler[*TokenIssueResult]) {
+	defer withOperation(&r, "CreateTokenAndWait", "")
+	// ctx also bounds each request, so a hung node can't hold the call past its deadline
+	opts = append(opts[:len(opts):len(opts)], WithContext(ctx))
+	created := c.CreateToken(name, symbol, decimals, masterAuthority, opts...)
+	if created.err != nil {
+		return created
+	}
+	result := created.data
+
+	if result.Receipt == nil {
+		receipt := c.WaitForConfirmation(ctx, result.Hash, 1)
+		result.setReceipt(receipt.data)
+		if receipt.err != nil {
+			return &ResponseHandler[*TokenIssueResult]{data: result, err: receipt.err}
+		}
+	}
+	if result.Token == "" && result.Receipt.ContractAddress != "" {
+		result.Token = checksummed(result.Receipt.ContractAddress)
+	}
+	if result.Token == "" {
+		return &ResponseHandler[*TokenIssueResult]{data: result, err: fmt.Errorf("token address of %s unknown", result.Hash)}
+	}
+
+	interval := c.confirmationPoll
+	if interval <= 0 {
+		interval = DefaultBlockPollInterval
+	}
+	for attempt := 1; ; attempt++ {
+		metadata := c.GetTokenMetadata(result.Token, WithContext(ctx))
+		if metadata.err == nil {
+			return &ResponseHandler[*TokenIssueResult]{data: result}
+		}
+		if !errors.Is(metadata.err, ErrNotFound) || attempt == tokenReadyAttempts {
+			return &ResponseHandler[*TokenIssueResult]{data: result, err: fmt.Errorf("token %s not ready: %w", result.Token, metadata.err)}
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return &ResponseHandler[*TokenIssueResult]{data: result, err: fmt.Errorf("token %s not ready: %w", result.Token, ctx.Err())}
+		}
+	}
+}
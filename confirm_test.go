@@ -0,0 +1,275 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+const confirmHash = "0x8a9f0c1e2d3b4a5968778695a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5"
+
+// nodeReceipt is an eth_getTransactionReceipt result mined in block with status "0x1" or "0x0"
+func nodeReceipt(block, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"transactionHash": confirmHash,
+		"blockNumber":     block,
+		"blockHash":       "0xbeef",
+		"status":          status,
+		"gasUsed":         "0x5208",
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	// Pending for two polls, then mined in block 10 while the head moves from 10 to 12
+	srv.QueueResponse("eth_getTransactionReceipt", alchemytest.Response{Result: nil}, alchemytest.Response{Result: nil})
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.QueueResponse("eth_blockNumber",
+		alchemytest.Response{Result: "0xa"}, alchemytest.Response{Result: "0xa"}, alchemytest.Response{Result: "0xb"})
+	srv.SetBlockNumber(12)
+
+	receipt, err := client.WaitForConfirmation(context.Background(), confirmHash, 3).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.TransactionHash != confirmHash || receipt.BlockNumber != 10 || !receipt.Succeeded() {
+		t.Fatalf("receipt %+v", receipt)
+	}
+	if n := len(srv.RequestsFor("eth_blockNumber")); n != 4 {
+		t.Fatalf("%d head polls, want 4 (10, 10, 11, 12)", n)
+	}
+	if n := len(srv.RequestsFor("eth_getTransactionReceipt")); n != 6 {
+		t.Fatalf("%d receipt polls, want 6", n)
+	}
+
+	if err := client.WaitForConfirmation(context.Background(), confirmHash, 0).Err(); err == nil {
+		t.Fatal("0 confirmations accepted")
+	}
+}
+
+func TestWaitForConfirmationReverted(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	reverted := nodeReceipt("0xa", "0x0")
+	reverted["revertReason"] = "insufficient balance"
+	srv.SetResult("eth_getTransactionReceipt", reverted)
+
+	// A revert fails at once, without waiting for the other confirmations
+	receipt, err := client.WaitForConfirmation(context.Background(), confirmHash, 5).Result()
+	var revertedErr *alchemy.RevertedError
+	if !errors.As(err, &revertedErr) || !errors.Is(err, alchemy.ErrTransactionReverted) {
+		t.Fatalf("err = %v, want a *RevertedError", err)
+	}
+	if revertedErr.Receipt.RevertReason != "insufficient balance" || receipt == nil || receipt.BlockNumber != 10 {
+		t.Fatalf("receipt %+v, error receipt %+v", receipt, revertedErr.Receipt)
+	}
+	if errors.Is(err, alchemy.ErrConfirmationTimeout) {
+		t.Fatalf("revert reported as a timeout: %v", err)
+	}
+}
+
+func TestWaitForConfirmationTimeout(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := client.WaitForConfirmation(ctx, confirmHash, 1).Err()
+	if !errors.Is(err, alchemy.ErrConfirmationTimeout) || errors.Is(err, alchemy.ErrTransactionReverted) {
+		t.Fatalf("err = %v, want ErrConfirmationTimeout", err)
+	}
+
+	// A cancellation is not a timeout
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	err = client.WaitForConfirmation(ctx, confirmHash, 1).Err()
+	if !errors.Is(err, context.Canceled) || errors.Is(err, alchemy.ErrConfirmationTimeout) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestWaitForConfirmationHungNode(t *testing.T) {
+	var polls atomic.Int32
+	_, client, _ := newTestServer(t,
+		alchemy.WithHTTPClient(slowTransport("eth_getTransactionReceipt", time.Minute, &polls)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := client.WaitForConfirmation(ctx, confirmHash, 1).Err()
+	if !errors.Is(err, alchemy.ErrConfirmationTimeout) {
+		t.Fatalf("err = %v, want ErrConfirmationTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second || polls.Load() == 0 {
+		t.Fatalf("returned after %v and %d polls, want the deadline to cut the hung poll", elapsed, polls.Load())
+	}
+}
+
+func TestWithConfirmations(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.SetBlockNumber(11)
+
+	result, err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithConfirmations(2)).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hash == "" || result.Receipt == nil || result.Receipt.BlockNumber != 10 {
+		t.Fatalf("result %+v", result)
+	}
+
+	// Without confirmations nothing is polled
+	before := len(srv.RequestsFor("eth_getTransactionReceipt"))
+	result, err = client.Mint(testToken, testRecipient, "1", 0).Result()
+	if err != nil || result.Receipt != nil || len(srv.RequestsFor("eth_getTransactionReceipt")) != before {
+		t.Fatalf("result %+v, err = %v: receipt polled without WithConfirmations", result, err)
+	}
+
+	// Not enough confirmations before the timeout: the hash is still returned
+	result, err = client.Mint(testToken, testRecipient, "1", 0,
+		alchemy.WithConfirmations(5), alchemy.WithConfirmationTimeout(50*time.Millisecond)).Result()
+	if !errors.Is(err, alchemy.ErrConfirmationTimeout) || result == nil || result.Hash == "" {
+		t.Fatalf("result %+v, err = %v, want ErrConfirmationTimeout with the hash", result, err)
+	}
+}
+
+func TestWithConfirmationsReverted(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x0"))
+
+	result, err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithConfirmations(1)).Result()
+	var reverted *alchemy.RevertedError
+	if !errors.As(err, &reverted) || result == nil || result.Hash == "" || result.Receipt == nil {
+		t.Fatalf("result %+v, err = %v, want a *RevertedError with the receipt", result, err)
+	}
+	if op := operationError(t, err); op.Op != "Mint" {
+		t.Fatalf("op %q", op.Op)
+	}
+}
+
+func TestConfigConfirmations(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	useDefaultClient(t, client)
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.SetBlockNumber(10)
+
+	alchemy.ConfigConfirmations(1)
+	result, err := alchemy.Mint(testToken, testRecipient, "1", 0).Result()
+	if err != nil || result.Receipt == nil {
+		t.Fatalf("result %+v, err = %v, want the receipt", result, err)
+	}
+
+	// A per-call option wins over the default
+	result, err = alchemy.Mint(testToken, testRecipient, "1", 0, alchemy.WithConfirmations(0)).Result()
+	if err != nil || result.Receipt != nil {
+		t.Fatalf("result %+v, err = %v, want no wait", result, err)
+	}
+
+	alchemy.ConfigConfirmations(0)
+	before := len(srv.RequestsFor("eth_getTransactionReceipt"))
+	if err := alchemy.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("eth_getTransactionReceipt")); n != before {
+		t.Fatalf("%d receipt polls after ConfigConfirmations(0)", n-before)
+	}
+}
+
+// tokenNotFound is the error of a node that doesn't serve a new token yet
+var tokenNotFound = alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "token not found"}}
+
+func TestCreateTokenAndWait(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.SetBlockNumber(10)
+	// The node behind the balancer lags the receipt by two polls
+	srv.QueueResponse("getTokenMetadata", tokenNotFound, tokenNotFound)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test", "symbol": "TST", "decimals": 6})
+
+	result, err := client.CreateTokenAndWait(context.Background(), "Test", "TST", 6, testRecipient).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Token == "" || result.Receipt == nil || result.BlockNumber != 10 {
+		t.Fatalf("result %+v", result)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 3 {
+		t.Fatalf("%d metadata polls, want 3", n)
+	}
+}
+
+func TestCreateTokenAndWaitNotReady(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.SetBlockNumber(10)
+	srv.SetResponse("getTokenMetadata", tokenNotFound)
+
+	result, err := client.CreateTokenAndWait(context.Background(), "Test", "TST", 6, testRecipient).Result()
+	if !errors.Is(err, alchemy.ErrNotFound) || result == nil || result.Token == "" {
+		t.Fatalf("result %+v, err = %v, want ErrNotFound with the token", result, err)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 10 {
+		t.Fatalf("%d metadata polls, want 10", n)
+	}
+
+	// Other errors are not retried
+	srv.SetError("getTokenMetadata", -32000, "unauthorized")
+	before := len(srv.RequestsFor("getTokenMetadata"))
+	if err := client.CreateTokenAndWait(context.Background(), "Test", "TST", 6, testRecipient).Err(); !errors.Is(err, alchemy.ErrPermissionDenied) {
+		t.Fatalf("err = %v, want ErrPermissionDenied", err)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")) - before; n != 1 {
+		t.Fatalf("%d metadata polls, want 1", n)
+	}
+}
+
+func TestCreateTokenAndWaitReverted(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x0"))
+
+	result, err := client.CreateTokenAndWait(context.Background(), "Test", "TST", 6, testRecipient).Result()
+	var reverted *alchemy.RevertedError
+	if !errors.As(err, &reverted) || result == nil || result.Hash == "" || result.Receipt == nil {
+		t.Fatalf("result %+v, err = %v, want a *RevertedError with the receipt", result, err)
+	}
+	if op := operationError(t, err); op.Op != "CreateTokenAndWait" {
+		t.Fatalf("op %q", op.Op)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 0 {
+		t.Fatalf("%d metadata polls of a reverted creation", n)
+	}
+}
+
+func TestCreateTokenAndWaitContext(t *testing.T) {
+	// A cancelled ctx stops the creation before it is sent
+	srv, client, _ := newTestServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.CreateTokenAndWait(ctx, "Test", "TST", 6, testRecipient).Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if n := len(srv.RequestsFor("create_token")); n != 0 {
+		t.Fatalf("%d creations sent with a cancelled context", n)
+	}
+
+	// A hung metadata read is cut at the deadline
+	var polls atomic.Int32
+	srv, client, _ = newTestServer(t, alchemy.WithConfirmationPollInterval(time.Millisecond),
+		alchemy.WithHTTPClient(slowTransport("getTokenMetadata", time.Minute, &polls)))
+	srv.SetResult("eth_getTransactionReceipt", nodeReceipt("0xa", "0x1"))
+	srv.SetBlockNumber(10)
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := client.CreateTokenAndWait(ctx, "Test", "TST", 6, testRecipient).Result()
+	if !errors.Is(err, context.DeadlineExceeded) || result == nil || result.Token == "" {
+		t.Fatalf("result %+v, err = %v, want the deadline", result, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second || polls.Load() != 1 {
+		t.Fatalf("returned after %v and %d polls", elapsed, polls.Load())
+	}
+}
@@ -0,0 +1,107 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotAContract is returned by writes to a token address without contract code when
+// token address verification is enabled
+var ErrNotAContract = errors.New("address is not a contract")
+
+// contractMissTTL bounds how long a "no code" result is trusted: a token created a moment
+// ago gets its code with the next block
+const contractMissTTL = 5 * time.Second
+
+// contractEntry is a cached IsContract result; contracts are cached for good
+type contractEntry struct {
+	isContract bool
+	expires    time.Time // misses only
+}
+
+// ConfigTokenAddressVerification makes the default client check that the token address
+// is a contract before every write (see WithTokenAddressVerification)
+func ConfigTokenAddressVerification(enabled bool) {
+	c := defaultClient
+	c.contractMu.Lock()
+	defer c.contractMu.Unlock()
+	c.verifyTokenAddress = enabled
+}
+
+// WithTokenAddressVerification checks that the token address has contract code before
+// every write and fails with ErrNotAContract otherwise, so a mistyped holder address
+// doesn't consume a nonce. Results are cached per address; "no code" only briefly.
+func WithTokenAddressVerification() Option {
+	return func(o *clientOptions) { o.verifyTokenAddress = true }
+}
+
+// IsContract reports whether address has contract code at the latest block
+// (eth_getCode). Not cached.
+func (c *Client) IsContract(address string) (r *ResponseHandler[bool]) {
+	defer withOperation(&r, "IsContract", "")
+	if err := c.checkAddress("address", address); err != nil {
+		return &ResponseHandler[bool]{err: err}
+	}
+	isContract, err := c.isContract(context.Background(), address)
+	if err != nil {
+		return &ResponseHandler[bool]{err: err}
+	}
+	return &ResponseHandler[bool]{data: isContract}
+}
+
+// Internal method: IsContract as part of the operation ctx belongs to
+func (c *Client) isContract(ctx context.Context, address string) (bool, error) {
+	if err := c.checkChainContext(ctx); err != nil {
+		return false, err
+	}
+
+	result, err := c.nodeCallContext(ctx, "eth_getCode", []interface{}{address, "latest"})
+	if err != nil {
+		return false, err
+	}
+	var code string
+	if err := json.Unmarshal(result, &code); err != nil {
+		return false, fmt.Errorf("decode code: %w", err)
+	}
+	code = strings.TrimPrefix(strings.TrimPrefix(code, "0x"), "0X")
+	return code != "", nil
+}
+
+// Internal method: fail unless tokenAddress is a contract, when verification is enabled
+func (c *Client) checkTokenContract(ctx context.Context, tokenAddress string) error {
+	key := strings.ToLower(tokenAddress)
+	c.contractMu.Lock()
+	enabled := c.verifyTokenAddress
+	entry, cached := c.contractCache[key]
+	c.contractMu.Unlock()
+	if !enabled {
+		return nil
+	}
+
+	if !cached || (!entry.isContract && !time.Now().Before(entry.expires)) {
+		isContract, err := c.isContract(ctx, tokenAddress)
+		if err != nil {
+			return fmt.Errorf("verify token address: %w", err)
+		}
+		entry = contractEntry{isContract: isContract}
+		if !isContract {
+			entry.expires = time.Now().Add(contractMissTTL)
+		}
+
+		c.contractMu.Lock()
+		if c.contractCache == nil {
+			c.contractCache = map[string]contractEntry{}
+		}
+		c.contractCache[key] = entry
+		c.contractMu.Unlock()
+	}
+
+	if !entry.isContract {
+		return fmt.Errorf("%w: %s has no code", ErrNotAContract, tokenAddress)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package alchemy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestIsContract(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetCode(testToken, []byte{0x60, 0x80, 0x60, 0x40})
+
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{testToken, true},
+		{testRecipient, false}, // empty code
+	}
+	for _, tt := range tests {
+		got, err := client.IsContract(tt.address).Result()
+		if err != nil || got != tt.want {
+			t.Errorf("IsContract(%s) = %v, %v; want %v", tt.address, got, err, tt.want)
+		}
+	}
+	if err := client.IsContract("0x1234").Err(); !errors.Is(err, alchemy.ErrInvalidAddress) {
+		t.Errorf("err = %v, want ErrInvalidAddress", err)
+	}
+	if got := string(srv.RequestsFor("eth_getCode")[0].Params); got != `["`+testToken+`","latest"]` {
+		t.Errorf("eth_getCode params %s", got)
+	}
+}
+
+func TestTokenAddressVerification(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithTokenAddressVerification())
+	srv.SetCode(testToken, []byte{0x60, 0x80})
+
+	// Contracts are verified once
+	for nonce := int64(0); nonce < 3; nonce++ {
+		if err := client.Mint(testToken, testRecipient, "1", nonce).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(srv.RequestsFor("eth_getCode")); n != 1 {
+		t.Fatalf("%d code lookups, want 1", n)
+	}
+
+	// Writes to a holder address fail before signing
+	srv.Reset()
+	for i := 0; i < 2; i++ {
+		if err := client.Pause(testRecipient, 0).Err(); !errors.Is(err, alchemy.ErrNotAContract) {
+			t.Fatalf("err = %v, want ErrNotAContract", err)
+		}
+	}
+	if n := len(srv.RequestsFor("pause")); n != 0 {
+		t.Fatalf("%d pauses sent", n)
+	}
+	if n := len(srv.RequestsFor("eth_getCode")); n != 1 {
+		t.Fatalf("%d code lookups, want the miss cached", n)
+	}
+
+	// Reads aren't checked
+	srv.SetResult("getSupplyCap", "10")
+	if err := client.GetSupplyCap(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the option nothing is looked up
+	srv, plain, _ := newTestServer(t)
+	if err := plain.Pause(testRecipient, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("eth_getCode")); n != 0 {
+		t.Fatalf("%d code lookups without verification", n)
+	}
+}
+
+func TestTokenAddressVerificationMissExpires(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits for the negative cache TTL")
+	}
+	srv, client, _ := newTestServer(t, alchemy.WithTokenAddressVerification())
+
+	// Just created: the code only shows up with the next block
+	if err := client.Pause(testToken, 0).Err(); !errors.Is(err, alchemy.ErrNotAContract) {
+		t.Fatalf("err = %v, want ErrNotAContract", err)
+	}
+	srv.SetCode(testToken, []byte{0x60, 0x80})
+	time.Sleep(5*time.Second + 100*time.Millisecond)
+	if err := client.Pause(testToken, 0).Err(); err != nil {
+		t.Fatalf("contract not seen after the miss expired: %v", err)
+	}
+	if n := len(srv.RequestsFor("eth_getCode")); n != 2 {
+		t.Fatalf("%d code lookups, want 2", n)
+	}
+}
@@ -0,0 +1,139 @@
+package alchemy
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrDuplicateRecipient is reported for a CSV row whose address already appeared earlier in the file
+var ErrDuplicateRecipient = errors.New("duplicate recipient")
+
+// RowError describes a rejected CSV row. Line is the 1-based line number in the input.
+type RowError struct {
+	Line int
+	Row  []string
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// ParseRecipientsCSV reads address,amount rows for MintBatch. Amounts are decimal token
+// amounts scaled to base units with decimals. The separator is a comma or a semicolon,
+// detected from the first line, and a leading header row is skipped: address, recipient,
+// to or account followed by amount or value, in any case. Invalid rows (bad address, bad or zero amount, duplicate address) are reported
+// as RowErrors and left out; the returned error is only set when the input itself cannot
+// be read or parsed as CSV.
+func ParseRecipientsCSV(r io.Reader, decimals uint8) ([]MintRecipient, []RowError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // byte order mark written by Excel
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectSeparator(data)
+	reader.FieldsPerRecord = -1 // column count checked per row
+	reader.TrimLeadingSpace = true
+
+	var (
+		recipients []MintRecipient
+		rowErrs    []RowError
+		seen       = map[common.Address]int{} // address -> first line
+		first      = true
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first {
+			first = false
+			if isRecipientsHeader(record) {
+				continue
+			}
+		}
+
+		reject := func(err error) {
+			rowErrs = append(rowErrs, RowError{Line: line, Row: record, Err: err})
+		}
+
+		if len(record) != 2 {
+			reject(fmt.Errorf("expected 2 columns (address, amount), got %d", len(record)))
+			continue
+		}
+		address, amount := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+
+		if err := validateAddress(address, true); err != nil {
+			reject(err)
+			continue
+		}
+		parsed := common.HexToAddress(address)
+		if parsed == (common.Address{}) {
+			reject(fmt.Errorf("%w: zero address", ErrInvalidAddress))
+			continue
+		}
+		if firstLine, ok := seen[parsed]; ok {
+			reject(fmt.Errorf("%w: %s already on line %d", ErrDuplicateRecipient, parsed.Hex(), firstLine))
+			continue
+		}
+
+		raw, err := ToBaseUnits(amount, decimals)
+		if err != nil {
+			reject(err)
+			continue
+		}
+		if raw == "0" {
+			reject(fmt.Errorf("%w: amount is zero", ErrInvalidAmount))
+			continue
+		}
+
+		seen[parsed] = line
+		recipients = append(recipients, MintRecipient{To: parsed.Hex(), Amount: raw})
+	}
+
+	return recipients, rowErrs, nil
+}
+
+// Internal method: semicolon if the first line has more semicolons than commas
+func detectSeparator(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// Column names accepted in a header row
+var (
+	recipientsAddressHeaders = []string{"address", "recipient", "to", "account"}
+	recipientsAmountHeaders  = []string{"amount", "value"}
+)
+
+// Internal method: whether the first row is a header, i.e. has two columns named after
+// the address and the amount. Anything else is parsed as data, so a mistyped address on
+// the first line is reported like on any other line.
+func isRecipientsHeader(record []string) bool {
+	if len(record) != 2 {
+		return false
+	}
+	return slices.Contains(recipientsAddressHeaders, strings.ToLower(strings.TrimSpace(record[0]))) &&
+		slices.Contains(recipientsAmountHeaders, strings.ToLower(strings.TrimSpace(record[1])))
+}
@@ -0,0 +1,64 @@
+package alchemy_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+const (
+	csvAddrA = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	csvAddrB = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+)
+
+func TestParseRecipientsCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		recipients int
+		errLines   []int
+		wantErr    error
+	}{
+		{"header", "address,amount\n" + csvAddrA + ",1.5\n", 1, nil, nil},
+		{"header case and spaces", "\xef\xbb\xbf Recipient ; VALUE\n" + csvAddrA + ";1\n", 1, nil, nil},
+		{"no header", csvAddrA + ",1\n" + csvAddrB + ",2\n", 2, nil, nil},
+		{"typo in first address", "70997970C51812dc3A010C7d01b50e0d17dc79C8,1\n" + csvAddrB + ",2\n", 1, []int{1}, alchemy.ErrInvalidAddress},
+		{"first row with a name", "alice,1\n" + csvAddrB + ",2\n", 1, []int{1}, alchemy.ErrInvalidAddress},
+		{"unknown header", "wallet,tokens\n" + csvAddrA + ",1\n", 1, []int{1}, alchemy.ErrInvalidAddress},
+		{"duplicate", csvAddrA + ",1\n" + strings.ToLower(csvAddrA) + ",2\n", 1, []int{2}, alchemy.ErrDuplicateRecipient},
+		{"zero amount", csvAddrA + ",0\n", 0, []int{1}, alchemy.ErrInvalidAmount},
+		{"too precise", csvAddrA + ",0.0000001\n", 0, []int{1}, alchemy.ErrInvalidAmount},
+		{"zero address", "0x0000000000000000000000000000000000000000,1\n", 0, []int{1}, alchemy.ErrInvalidAddress},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipients, rowErrs, err := alchemy.ParseRecipientsCSV(strings.NewReader(tt.input), 6)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(recipients) != tt.recipients {
+				t.Fatalf("got %d recipients %+v, want %d", len(recipients), recipients, tt.recipients)
+			}
+			if len(rowErrs) != len(tt.errLines) {
+				t.Fatalf("got row errors %v, want lines %v", rowErrs, tt.errLines)
+			}
+			for i, rowErr := range rowErrs {
+				if rowErr.Line != tt.errLines[i] || !errors.Is(&rowErr, tt.wantErr) {
+					t.Fatalf("row error %v, want line %d matching %v", &rowErr, tt.errLines[i], tt.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRecipientsCSVScalesAmounts(t *testing.T) {
+	recipients, _, err := alchemy.ParseRecipientsCSV(strings.NewReader(csvAddrA+",1.5\n"), 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recipients[0].Amount != "1500000" || recipients[0].To != csvAddrA {
+		t.Fatalf("got %+v", recipients[0])
+	}
+}
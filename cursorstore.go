@@ -0,0 +1,123 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileCursorStore is a CursorStore persisting all cursors in one JSON file, so a watcher
+// resumes where it stopped after a restart. Every Save replaces the file atomically (write to
+// a temporary file in the same directory, fsync, rename): a crash leaves either the previous
+// or the new cursors, never a truncated file. One store (one process) per file.
+type FileCursorStore struct {
+	path string
+
+	mu      sync.Mutex
+	cursors map[string]EventCursor // nil until the file has been read
+}
+
+// NewFileCursorStore creates a store backed by path. The file is created on the first Save;
+// its directory must exist.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// Load implements CursorStore
+func (s *FileCursorStore) Load(key string) (EventCursor, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.read(); err != nil {
+		return EventCursor{}, false, err
+	}
+	cursor, ok := s.cursors[key]
+	return cursor, ok, nil
+}
+
+// Save implements CursorStore
+func (s *FileCursorStore) Save(key string, cursor EventCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.read(); err != nil {
+		return err
+	}
+
+	previous, existed := s.cursors[key]
+	s.cursors[key] = cursor
+	if err := s.write(); err != nil {
+		// Keep memory consistent with the file
+		if existed {
+			s.cursors[key] = previous
+		} else {
+			delete(s.cursors, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Internal method: read the file on first use, s.mu held
+func (s *FileCursorStore) read() error {
+	if s.cursors != nil {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.cursors = map[string]EventCursor{}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	cursors := map[string]EventCursor{}
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return fmt.Errorf("decode cursor file %s: %w", s.path, err)
+	}
+	s.cursors = cursors
+	return nil
+}
+
+// Internal method: atomically replace the file with the current cursors, s.mu held
+func (s *FileCursorStore) write() error {
+	data, err := json.MarshalIndent(s.cursors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir, base := filepath.Split(s.path)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself; not supported on every platform
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestFileCursorStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	store := alchemy.NewFileCursorStore(path)
+	if _, ok, err := store.Load("a"); ok || err != nil {
+		t.Fatalf("missing file: ok %v, err %v", ok, err)
+	}
+	if err := store.Save("a", alchemy.EventCursor{Block: 5, LogIndex: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("b", alchemy.EventCursor{Block: 7, LogIndex: alchemy.EndOfBlock}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("a", alchemy.EventCursor{Block: 6, LogIndex: 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new process reads what the previous one saved
+	reopened := alchemy.NewFileCursorStore(path)
+	for key, want := range map[string]alchemy.EventCursor{
+		"a": {Block: 6, LogIndex: 0},
+		"b": {Block: 7, LogIndex: alchemy.EndOfBlock},
+	} {
+		got, ok, err := reopened.Load(key)
+		if err != nil || !ok || got != want {
+			t.Fatalf("%s: got %+v (ok %v, err %v), want %+v", key, got, ok, err, want)
+		}
+	}
+
+	// Only the cursor file is left behind, no temporary files
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "cursors.json" {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Fatalf("directory holds %v", names)
+	}
+}
+
+func TestFileCursorStoreErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte(`{"a": {"block": 1`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := alchemy.NewFileCursorStore(corrupt).Load("a"); err == nil {
+		t.Fatal("corrupt file loaded without error")
+	}
+
+	store := alchemy.NewFileCursorStore(filepath.Join(dir, "missing", "cursors.json"))
+	if err := store.Save("a", alchemy.EventCursor{Block: 1}); err == nil {
+		t.Fatal("save into a missing directory succeeded")
+	}
+	if _, ok, err := store.Load("a"); ok || err != nil {
+		t.Fatalf("failed save is visible: ok %v, err %v", ok, err)
+	}
+}
+
+var errCrash = errors.New("process killed")
+
+// crashingStore simulates a process killed after the handler processed the event at
+// crashAt but before its cursor was written
+type crashingStore struct {
+	alchemy.CursorStore
+	crashAt alchemy.EventCursor
+}
+
+func (s crashingStore) Save(key string, cursor alchemy.EventCursor) error {
+	if cursor == s.crashAt {
+		return errCrash
+	}
+	return s.CursorStore.Save(key, cursor)
+}
+
+func TestWatchTokenEventsAtLeastOnceAcrossCrashes(t *testing.T) {
+	var chain []alchemy.TokenEvent
+	for block := int64(1); block <= 8; block++ {
+		for i := int64(0); i < block%3; i++ {
+			chain = append(chain, alchemy.TokenEvent{Type: "Mint", BlockNumber: block, LogIndex: i})
+		}
+	}
+	srv := newEventLog(t, 8, chain)
+	id := func(event alchemy.TokenEvent) string {
+		return fmt.Sprintf("%d/%d", event.BlockNumber, event.LogIndex)
+	}
+
+	for crash, crashed := range chain {
+		t.Run(id(crashed), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cursors.json")
+			var handled []string
+			watch := func(store alchemy.CursorStore, stopAfter int) error {
+				client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL))
+				if err != nil {
+					t.Fatal(err)
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				return client.WatchTokenEvents(ctx, testToken, 1, func(event alchemy.TokenEvent) error {
+					handled = append(handled, id(event))
+					if len(handled) == stopAfter {
+						cancel()
+					}
+					return nil
+				}, alchemy.WithCursorStore(store), alchemy.WithWatchChunkSize(3), alchemy.WithWatchInterval(time.Millisecond))
+			}
+
+			at := alchemy.EventCursor{Block: crashed.BlockNumber, LogIndex: crashed.LogIndex}
+			if err := watch(crashingStore{alchemy.NewFileCursorStore(path), at}, -1); !errors.Is(err, errCrash) {
+				t.Fatalf("first run: err = %v, want the crash", err)
+			}
+
+			// Restart with a fresh store on the same file: the crashed event is handled again
+			if err := watch(alchemy.NewFileCursorStore(path), len(chain)+1); !errors.Is(err, context.Canceled) {
+				t.Fatalf("second run: err = %v (handled %v)", err, handled)
+			}
+
+			var want []string
+			for _, event := range chain[:crash+1] {
+				want = append(want, id(event))
+			}
+			for _, event := range chain[crash:] {
+				want = append(want, id(event))
+			}
+			if fmt.Sprint(handled) != fmt.Sprint(want) {
+				t.Fatalf("handled %v\nwant    %v", handled, want)
+			}
+		})
+	}
+}
+
+func TestWatchTokenEventsCursorTooOld(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_server_info", map[string]interface{}{"version": "1.4.0", "eventRetentionBlocks": 100})
+	srv.SetBlockNumber(1000) // blocks 901-1000 are retained
+	srv.SetResult("get_token_events", []interface{}{})
+	key := strings.ToLower(testToken)
+	noop := func(alchemy.TokenEvent) error { return nil }
+
+	store := alchemy.NewMemoryCursorStore()
+	store.Save(key, alchemy.EventCursor{Block: 899, LogIndex: alchemy.EndOfBlock})
+	err := client.WatchTokenEvents(context.Background(), testToken, 1, noop, alchemy.WithCursorStore(store))
+	var tooOld *alchemy.CursorTooOldError
+	if !errors.Is(err, alchemy.ErrCursorTooOld) || !errors.As(err, &tooOld) {
+		t.Fatalf("err = %v, want ErrCursorTooOld", err)
+	}
+	if tooOld.OldestBlock != 901 || tooOld.Cursor.Block != 899 {
+		t.Fatalf("got %+v", tooOld)
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatalf("%d event queries after detecting the old cursor", n)
+	}
+
+	// A cursor whose next block is retained, and a first run without a cursor, proceed
+	for name, store := range map[string]*alchemy.MemoryCursorStore{"retained": alchemy.NewMemoryCursorStore(), "no cursor": alchemy.NewMemoryCursorStore()} {
+		if name == "retained" {
+			store.Save(key, alchemy.EventCursor{Block: 900, LogIndex: alchemy.EndOfBlock})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		err := client.WatchTokenEvents(ctx, testToken, 1, noop, alchemy.WithCursorStore(store), alchemy.WithWatchInterval(10*time.Millisecond))
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("%s: err = %v, want the watcher to run until the deadline", name, err)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package alchemy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrSchemaMismatch is returned in strict decoding mode when a result has fields the SDK
+// doesn't know or lacks fields it requires
+var ErrSchemaMismatch = errors.New("response does not match the expected schema")
+
+// requiredResultFields lists the JSON fields strict decoding insists on per result type
+var requiredResultFields = map[reflect.Type][]string{
+	reflect.TypeOf(TransactionResult{}): {"hash"},
+	reflect.TypeOf(TokenIssueResult{}):  {"hash", "token"},
+	reflect.TypeOf(TokenMetadata{}):     {"name", "symbol", "decimals"},
+}
+
+// ConfigStrictDecoding switches strict decoding of the default client on or off (see
+// WithStrictDecoding)
+func ConfigStrictDecoding(enabled bool) {
+	defaultClient.strictDecoding = enabled
+}
+
+// WithStrictDecoding rejects results with unknown fields or without required fields
+// (hash, token, name/symbol/decimals) with ErrSchemaMismatch, instead of decoding them to
+// zero values. Off by default so that servers can add fields without breaking clients.
+func WithStrictDecoding() Option {
+	return func(o *clientOptions) { o.strictDecoding = true }
+}
+
+// Internal method: decode a result into v, strictly when configured
+func (c *Client) decodeResult(data json.RawMessage, v interface{}) error {
+	if !c.strictDecoding {
+		return json.Unmarshal(data, v)
+	}
+	return decodeStrict(data, v)
+}
+
+// Internal method: decode with unknown fields rejected and required fields checked
+func decodeStrict(data json.RawMessage, v interface{}) error {
+	target := indirectType(reflect.TypeOf(v))
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte("null")) && (target.Kind() == reflect.String || requiredResultFields[target] != nil) {
+		return fmt.Errorf("%w: missing result", ErrSchemaMismatch)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if strings.HasPrefix(err.Error(), "json: unknown field") {
+			return fmt.Errorf("%w: %v", ErrSchemaMismatch, err)
+		}
+		return err
+	}
+	if len(trimmed) == 0 || trimmed[0] != '{' || target.Kind() != reflect.Struct {
+		return nil // e.g. a bare hash string
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	// Custom UnmarshalJSON methods decode without DisallowUnknownFields, check their keys here
+	if reflect.PointerTo(target).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		known := jsonFieldNames(target)
+		var unknown []string
+		for name := range fields {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return fmt.Errorf("%w: unknown field %q", ErrSchemaMismatch, unknown[0])
+		}
+	}
+	for _, name := range requiredResultFields[target] {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("%w: missing field %q", ErrSchemaMismatch, name)
+		}
+	}
+	return nil
+}
+
+// Internal method: t with all pointer levels removed
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Internal method: the JSON names of the encoded fields of struct type t
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	createToken := func(c *alchemy.Client) error { return c.CreateToken("Euro", "EURX", 2, testRecipient).Err() }
+	mint := func(c *alchemy.Client) error { return c.Mint(testToken, testRecipient, "1", 0).Err() }
+	metadata := func(c *alchemy.Client) error { return c.GetTokenMetadata(testToken).Err() }
+	balance := func(c *alchemy.Client) error { return c.GetBalance(testRecipient).Err() }
+
+	tests := []struct {
+		name       string
+		method     string
+		result     string
+		call       func(*alchemy.Client) error
+		strictFail bool
+	}{
+		{"token exact", "create_token", `{"hash":"0x1","token":"` + testToken + `"}`, createToken, false},
+		{"token bare hash", "create_token", `"0x1"`, createToken, false},
+		{"token extra field", "create_token", `{"hash":"0x1","token":"` + testToken + `","gasUsed":"0x1"}`, createToken, true},
+		{"token missing field", "create_token", `{"hash":"0x1"}`, createToken, true},
+		{"token renamed field", "create_token", `{"hash":"0x1","tokenAddress":"` + testToken + `"}`, createToken, true},
+
+		{"tx exact", "mint", `{"hash":"0x1","blockNumber":5}`, mint, false},
+		{"tx bare hash", "mint", `"0x1"`, mint, false},
+		{"tx extra field", "mint", `{"hash":"0x1","gasUsed":"0x1"}`, mint, true},
+		{"tx missing field", "mint", `{"blockNumber":5}`, mint, true},
+		{"tx renamed field", "mint", `{"txHash":"0x1"}`, mint, true},
+		{"tx null", "mint", `null`, mint, true},
+
+		{"metadata exact", "getTokenMetadata", `{"name":"Euro","symbol":"EURX","decimals":2,"supply":"5"}`, metadata, false},
+		{"metadata extra field", "getTokenMetadata", `{"name":"Euro","symbol":"EURX","decimals":2,"supply":"5","website":"x"}`, metadata, true},
+		{"metadata missing field", "getTokenMetadata", `{"name":"Euro","symbol":"EURX","supply":"5"}`, metadata, true},
+		{"metadata renamed field", "getTokenMetadata", `{"name":"Euro","ticker":"EURX","decimals":2,"supply":"5"}`, metadata, true},
+
+		{"balance", "eth_getBalance", `"0x10"`, balance, false},
+		{"balance null", "eth_getBalance", `null`, balance, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, strict := range []bool{false, true} {
+				var opts []alchemy.Option
+				if strict {
+					opts = append(opts, alchemy.WithStrictDecoding())
+				}
+				srv, client, _ := newTestServer(t, opts...)
+				srv.SetResponse(tt.method, alchemytest.Response{Result: json.RawMessage(tt.result)})
+
+				err := tt.call(client)
+				if strict && tt.strictFail {
+					if !errors.Is(err, alchemy.ErrSchemaMismatch) {
+						t.Fatalf("strict: err = %v, want ErrSchemaMismatch", err)
+					}
+					continue
+				}
+				if errors.Is(err, alchemy.ErrSchemaMismatch) {
+					t.Fatalf("strict=%v: %v", strict, err)
+				}
+				// Lax decoding of a null balance still fails, just not as a schema error
+				if err != nil && tt.method != "eth_getBalance" {
+					t.Fatalf("strict=%v: %v", strict, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLaxDecodingZeroValues(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", json.RawMessage(`{"name":"Euro","symbol":"EURX","decimals":2,"totalSupply":"5"}`))
+
+	meta, err := client.GetTokenMetadata(testToken).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The renamed field is silently dropped, which is what strict mode guards against
+	if meta.Supply != "" || meta.Name != "Euro" {
+		t.Fatalf("metadata %+v", meta)
+	}
+}
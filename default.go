@@ -0,0 +1,457 @@
+package alchemy
+
+import (
+	"context"
+	"io"
+	"math/big"
+)
+
+// Package-level functions delegate to the default client (see DefaultClient).
+
+// CreateToken calls Client.CreateToken on the default client
+func CreateToken(name, symbol string, decimals int32, masterAuthority string, opts ...CallOption) *ResponseHandler[*TokenIssueResult] {
+	return defaultClient.CreateToken(name, symbol, decimals, masterAuthority, opts...)
+}
+
+// GetTokenMetadata calls Client.GetTokenMetadata on the default client
+func GetTokenMetadata(tokenAddress string, opts ...CallOption) *ResponseHandler[*TokenMetadata] {
+	return defaultClient.GetTokenMetadata(tokenAddress, opts...)
+}
+
+// CallWrite calls Client.CallWrite on the default client
+func CallWrite(tokenAddress, method string, args []interface{}, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.CallWrite(tokenAddress, method, args, nonce, opts...)
+}
+
+// UpdateMetadata calls Client.UpdateMetadata on the default client
+func UpdateMetadata(tokenAddress, newName, newSymbol string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.UpdateMetadata(tokenAddress, newName, newSymbol, nonce, opts...)
+}
+
+// Mint calls Client.Mint on the default client
+func Mint(tokenAddress, toAddress, amount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.Mint(tokenAddress, toAddress, amount, nonce, opts...)
+}
+
+// GrantAuthority calls Client.GrantAuthority on the default client
+func GrantAuthority(tokenAddress string, role Role, account string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.GrantAuthority(tokenAddress, role, account, nonce, opts...)
+}
+
+// GrantCustomAuthority calls Client.GrantCustomAuthority on the default client
+func GrantCustomAuthority(tokenAddress, role, account string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.GrantCustomAuthority(tokenAddress, role, account, nonce, opts...)
+}
+
+// RenounceAuthority calls Client.RenounceAuthority on the default client
+func RenounceAuthority(tokenAddress string, role Role, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.RenounceAuthority(tokenAddress, role, nonce, opts...)
+}
+
+// RevokeAuthority calls Client.RevokeAuthority on the default client
+func RevokeAuthority(tokenAddress string, role Role, account string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.RevokeAuthority(tokenAddress, role, account, nonce, opts...)
+}
+
+// RevokeCustomAuthority calls Client.RevokeCustomAuthority on the default client
+func RevokeCustomAuthority(tokenAddress, role, account string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.RevokeCustomAuthority(tokenAddress, role, account, nonce, opts...)
+}
+
+// GetAuthorities calls Client.GetAuthorities on the default client
+func GetAuthorities(tokenAddress string, role Role, opts ...CallOption) *ResponseHandler[[]string] {
+	return defaultClient.GetAuthorities(tokenAddress, role, opts...)
+}
+
+// HasAuthority calls Client.HasAuthority on the default client
+func HasAuthority(tokenAddress string, role Role, account string, opts ...CallOption) *ResponseHandler[bool] {
+	return defaultClient.HasAuthority(tokenAddress, role, account, opts...)
+}
+
+// TransferMasterAuthorityIrreversibly calls Client.TransferMasterAuthorityIrreversibly on
+// the default client
+func TransferMasterAuthorityIrreversibly(tokenAddress, newMasterAuthority string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.TransferMasterAuthorityIrreversibly(tokenAddress, newMasterAuthority, nonce, opts...)
+}
+
+// TransferMasterAuthority calls Client.TransferMasterAuthorityIrreversibly on the default client
+//
+// Deprecated: use TransferMasterAuthorityIrreversibly.
+func TransferMasterAuthority(tokenAddress, newMasterAuthority string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.TransferMasterAuthorityIrreversibly(tokenAddress, newMasterAuthority, nonce, opts...)
+}
+
+// AdminBurn calls Client.AdminBurn on the default client
+func AdminBurn(tokenAddress, fromAddress, amount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.AdminBurn(tokenAddress, fromAddress, amount, nonce, opts...)
+}
+
+// Burn calls Client.Burn on the default client
+func Burn(tokenAddress, amount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.Burn(tokenAddress, amount, nonce, opts...)
+}
+
+// MintWithMemo calls Client.MintWithMemo on the default client
+func MintWithMemo(tokenAddress, toAddress, amount, memo string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.MintWithMemo(tokenAddress, toAddress, amount, memo, nonce, opts...)
+}
+
+// BurnWithMemo calls Client.BurnWithMemo on the default client
+func BurnWithMemo(tokenAddress, amount, memo string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.BurnWithMemo(tokenAddress, amount, memo, nonce, opts...)
+}
+
+// AdminBurnWithMemo calls Client.AdminBurnWithMemo on the default client
+func AdminBurnWithMemo(tokenAddress, fromAddress, amount, memo string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.AdminBurnWithMemo(tokenAddress, fromAddress, amount, memo, nonce, opts...)
+}
+
+// Seize calls Client.Seize on the default client
+func Seize(tokenAddress, fromAddress, toAddress, amount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.Seize(tokenAddress, fromAddress, toAddress, amount, nonce, opts...)
+}
+
+// SetSupplyCap calls Client.SetSupplyCap on the default client
+func SetSupplyCap(tokenAddress, supplyCap string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.SetSupplyCap(tokenAddress, supplyCap, nonce, opts...)
+}
+
+// GetSupplyCap calls Client.GetSupplyCap on the default client
+func GetSupplyCap(tokenAddress string, opts ...CallOption) *ResponseHandler[string] {
+	return defaultClient.GetSupplyCap(tokenAddress, opts...)
+}
+
+// Pause calls Client.Pause on the default client
+func Pause(tokenAddress string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.Pause(tokenAddress, nonce, opts...)
+}
+
+// Unpause calls Client.Unpause on the default client
+func Unpause(tokenAddress string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.Unpause(tokenAddress, nonce, opts...)
+}
+
+// RetireToken calls Client.RetireToken on the default client
+func RetireToken(tokenAddress, confirmSymbol string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.RetireToken(tokenAddress, confirmSymbol, nonce, opts...)
+}
+
+// AddToBlacklist calls Client.AddToBlacklist on the default client
+func AddToBlacklist(tokenAddress, accountAddress string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.AddToBlacklist(tokenAddress, accountAddress, nonce, opts...)
+}
+
+// RemoveFromBlacklist calls Client.RemoveFromBlacklist on the default client
+func RemoveFromBlacklist(tokenAddress, accountAddress string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.RemoveFromBlacklist(tokenAddress, accountAddress, nonce, opts...)
+}
+
+// GetBlacklist calls Client.GetBlacklist on the default client
+func GetBlacklist(tokenAddress string, cursor string, limit int, opts ...CallOption) *ResponseHandler[*AddressPage] {
+	return defaultClient.GetBlacklist(tokenAddress, cursor, limit, opts...)
+}
+
+// UpdateBlacklistBatch calls Client.UpdateBlacklistBatch on the default client
+func UpdateBlacklistBatch(tokenAddress string, add []string, remove []string, startNonce int64, opts ...BatchOption) *ResponseHandler[*BlacklistBatchResult] {
+	return defaultClient.UpdateBlacklistBatch(tokenAddress, add, remove, startNonce, opts...)
+}
+
+// GetBalance calls Client.GetBalance on the default client
+func GetBalance(address string, opts ...CallOption) *ResponseHandler[*BalanceInfo] {
+	return defaultClient.GetBalance(address, opts...)
+}
+
+// MintBatch calls Client.MintBatch on the default client
+func MintBatch(tokenAddress string, recipients []MintRecipient, startNonce int64, opts ...BatchOption) *ResponseHandler[*BatchResult] {
+	return defaultClient.MintBatch(tokenAddress, recipients, startNonce, opts...)
+}
+
+// SubscribeNewBlocks calls Client.SubscribeNewBlocks on the default client
+func SubscribeNewBlocks(ctx context.Context, opts ...SubscribeOption) (<-chan BlockHeader, error) {
+	return defaultClient.SubscribeNewBlocks(ctx, opts...)
+}
+
+// GetBlockByNumber calls Client.GetBlockByNumber on the default client
+func GetBlockByNumber(n int64, fullTxs bool) *ResponseHandler[*Block] {
+	return defaultClient.GetBlockByNumber(n, fullTxs)
+}
+
+// GetBlockByHash calls Client.GetBlockByHash on the default client
+func GetBlockByHash(hash string, fullTxs bool) *ResponseHandler[*Block] {
+	return defaultClient.GetBlockByHash(hash, fullTxs)
+}
+
+// GetSyncStatus calls Client.GetSyncStatus on the default client
+func GetSyncStatus() *ResponseHandler[*SyncStatus] {
+	return defaultClient.GetSyncStatus()
+}
+
+// GetChainID calls Client.GetChainID on the default client
+func GetChainID() *ResponseHandler[uint64] {
+	return defaultClient.GetChainID()
+}
+
+// GetTokenEvents calls Client.GetTokenEvents on the default client
+func GetTokenEvents(tokenAddress string, filter EventFilter, opts ...CallOption) *ResponseHandler[[]TokenEvent] {
+	return defaultClient.GetTokenEvents(tokenAddress, filter, opts...)
+}
+
+// SubscribeTokenEvents calls Client.SubscribeTokenEvents on the default client
+func SubscribeTokenEvents(ctx context.Context, tokenAddress string, opts ...SubscribeOption) (<-chan TokenEvent, error) {
+	return defaultClient.SubscribeTokenEvents(ctx, tokenAddress, opts...)
+}
+
+// GetGasPrice calls Client.GetGasPrice on the default client
+func GetGasPrice() *ResponseHandler[*big.Int] {
+	return defaultClient.GetGasPrice()
+}
+
+// EstimateFee calls Client.EstimateFee on the default client
+func EstimateFee(op Operation) *ResponseHandler[*FeeEstimate] {
+	return defaultClient.EstimateFee(op)
+}
+
+// HealthCheck calls Client.HealthCheck on the default client
+func HealthCheck(ctx context.Context) *HealthReport {
+	return defaultClient.HealthCheck(ctx)
+}
+
+// Ping calls Client.Ping on the default client
+func Ping(ctx context.Context) error {
+	return defaultClient.Ping(ctx)
+}
+
+// GetTransactions calls Client.GetTransactions on the default client
+func GetTransactions(filter TxFilter) *ResponseHandler[*TxPage] {
+	return defaultClient.GetTransactions(filter)
+}
+
+// GetNonce calls Client.GetNonce on the default client
+func GetNonce(tokenAddress string) *ResponseHandler[int64] {
+	return defaultClient.GetNonce(tokenAddress)
+}
+
+// GetServerInfo calls Client.GetServerInfo on the default client
+func GetServerInfo() *ResponseHandler[*ServerInfo] {
+	return defaultClient.GetServerInfo()
+}
+
+// SupportsMethod calls Client.SupportsMethod on the default client
+func SupportsMethod(name string) bool {
+	return defaultClient.SupportsMethod(name)
+}
+
+// Simulate calls Client.Simulate on the default client
+func Simulate(op Operation, nonce int64, opts ...CallOption) *ResponseHandler[*SimulationResult] {
+	return defaultClient.Simulate(op, nonce, opts...)
+}
+
+// ListTokens calls Client.ListTokens on the default client
+func ListTokens(cursor string, limit int) *ResponseHandler[*TokenPage] {
+	return defaultClient.ListTokens(cursor, limit)
+}
+
+// ListTokensFiltered calls Client.ListTokensFiltered on the default client
+func ListTokensFiltered(filter TokenFilter, cursor string, limit int) *ResponseHandler[*TokenPage] {
+	return defaultClient.ListTokensFiltered(filter, cursor, limit)
+}
+
+// FindTokensBySymbol calls Client.FindTokensBySymbol on the default client
+func FindTokensBySymbol(symbol string) *ResponseHandler[[]TokenSummary] {
+	return defaultClient.FindTokensBySymbol(symbol)
+}
+
+// GetPausedTokens calls Client.GetPausedTokens on the default client
+func GetPausedTokens(cursor string, limit int) *ResponseHandler[*TokenPage] {
+	return defaultClient.GetPausedTokens(cursor, limit)
+}
+
+// RefreshTokenMetadata calls Client.RefreshTokenMetadata on the default client
+func RefreshTokenMetadata(tokenAddress string) *ResponseHandler[*TokenMetadata] {
+	return defaultClient.RefreshTokenMetadata(tokenAddress)
+}
+
+// IsContract calls Client.IsContract on the default client
+func IsContract(address string) *ResponseHandler[bool] {
+	return defaultClient.IsContract(address)
+}
+
+// GetTokenHolders calls Client.GetTokenHolders on the default client
+func GetTokenHolders(tokenAddress string, cursor string, limit int, opts ...CallOption) *ResponseHandler[*HolderPage] {
+	return defaultClient.GetTokenHolders(tokenAddress, cursor, limit, opts...)
+}
+
+// GetTransactionByHash calls Client.GetTransactionByHash on the default client
+func GetTransactionByHash(hash string) *ResponseHandler[*Transaction] {
+	return defaultClient.GetTransactionByHash(hash)
+}
+
+// GetTransactionReceipt calls Client.GetTransactionReceipt on the default client
+func GetTransactionReceipt(hash string) *ResponseHandler[*Receipt] {
+	return defaultClient.GetTransactionReceipt(hash)
+}
+
+// GetTransactionStatus calls Client.GetTransactionStatus on the default client
+func GetTransactionStatus(hash string) *ResponseHandler[TxStatus] {
+	return defaultClient.GetTransactionStatus(hash)
+}
+
+// WatchTokenEvents calls Client.WatchTokenEvents on the default client
+func WatchTokenEvents(ctx context.Context, tokenAddress string, fromBlock int64, handler func(TokenEvent) error, opts ...WatchOption) error {
+	return defaultClient.WatchTokenEvents(ctx, tokenAddress, fromBlock, handler, opts...)
+}
+
+// MintDecimal calls Client.MintDecimal on the default client
+func MintDecimal(tokenAddress, toAddress, humanAmount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.MintDecimal(tokenAddress, toAddress, humanAmount, nonce, opts...)
+}
+
+// BurnDecimal calls Client.BurnDecimal on the default client
+func BurnDecimal(tokenAddress, humanAmount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.BurnDecimal(tokenAddress, humanAmount, nonce, opts...)
+}
+
+// AdminBurnDecimal calls Client.AdminBurnDecimal on the default client
+func AdminBurnDecimal(tokenAddress, fromAddress, humanAmount string, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.AdminBurnDecimal(tokenAddress, fromAddress, humanAmount, nonce, opts...)
+}
+
+// MintBig calls Client.MintBig on the default client
+func MintBig(tokenAddress, toAddress string, amount *big.Int, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.MintBig(tokenAddress, toAddress, amount, nonce, opts...)
+}
+
+// BurnBig calls Client.BurnBig on the default client
+func BurnBig(tokenAddress string, amount *big.Int, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.BurnBig(tokenAddress, amount, nonce, opts...)
+}
+
+// AdminBurnBig calls Client.AdminBurnBig on the default client
+func AdminBurnBig(tokenAddress, fromAddress string, amount *big.Int, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.AdminBurnBig(tokenAddress, fromAddress, amount, nonce, opts...)
+}
+
+// SeizeBig calls Client.SeizeBig on the default client
+func SeizeBig(tokenAddress, fromAddress, toAddress string, amount *big.Int, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.SeizeBig(tokenAddress, fromAddress, toAddress, amount, nonce, opts...)
+}
+
+// SetSupplyCapBig calls Client.SetSupplyCapBig on the default client
+func SetSupplyCapBig(tokenAddress string, supplyCap *big.Int, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.SetSupplyCapBig(tokenAddress, supplyCap, nonce, opts...)
+}
+
+// NewTxQueue calls Client.NewTxQueue on the default client
+func NewTxQueue() *TxQueue {
+	return defaultClient.NewTxQueue()
+}
+
+// NewBulkRunner calls Client.NewBulkRunner on the default client
+func NewBulkRunner(opts ...BulkOption) *BulkRunner {
+	return defaultClient.NewBulkRunner(opts...)
+}
+
+// SnapshotBalances calls Client.SnapshotBalances on the default client
+func SnapshotBalances(ctx context.Context, tokenAddress string, atBlock int64, w io.Writer, opts ...SnapshotOption) error {
+	return defaultClient.SnapshotBalances(ctx, tokenAddress, atBlock, w, opts...)
+}
+
+// GetTokenMetadataAt calls Client.GetTokenMetadataAt on the default client
+func GetTokenMetadataAt(tokenAddress string, n int64) *ResponseHandler[*TokenMetadata] {
+	return defaultClient.GetTokenMetadataAt(tokenAddress, n)
+}
+
+// GetSupplyHistory calls Client.GetSupplyHistory on the default client
+func GetSupplyHistory(tokenAddress string, fromBlock, toBlock int64, interval int64) *ResponseHandler[[]SupplyPoint] {
+	return defaultClient.GetSupplyHistory(tokenAddress, fromBlock, toBlock, interval)
+}
+
+// WaitForConfirmation calls Client.WaitForConfirmation on the default client
+func WaitForConfirmation(ctx context.Context, hash string, confirmations int) *ResponseHandler[*Receipt] {
+	return defaultClient.WaitForConfirmation(ctx, hash, confirmations)
+}
+
+// CreateTokenAndWait calls Client.CreateTokenAndWait on the default client
+func CreateTokenAndWait(ctx context.Context, name, symbol string, decimals int32, masterAuthority string, opts ...CallOption) *ResponseHandler[*TokenIssueResult] {
+	return defaultClient.CreateTokenAndWait(ctx, name, symbol, decimals, masterAuthority, opts...)
+}
+
+// CurrentCheckpoint calls Client.CurrentCheckpoint on the default client
+func CurrentCheckpoint() *ResponseHandler[int64] {
+	return defaultClient.CurrentCheckpoint()
+}
+
+// GetTokenBalance calls Client.GetTokenBalance on the default client
+func GetTokenBalance(tokenAddress, account string, opts ...CallOption) *ResponseHandler[string] {
+	return defaultClient.GetTokenBalance(tokenAddress, account, opts...)
+}
+
+// GetTokenBalanceDecimal calls Client.GetTokenBalanceDecimal on the default client
+func GetTokenBalanceDecimal(tokenAddress, account string, opts ...CallOption) *ResponseHandler[string] {
+	return defaultClient.GetTokenBalanceDecimal(tokenAddress, account, opts...)
+}
+
+// IsPaused calls Client.IsPaused on the default client
+func IsPaused(tokenAddress string, opts ...CallOption) *ResponseHandler[bool] {
+	return defaultClient.IsPaused(tokenAddress, opts...)
+}
+
+// GetMetadataHistory calls Client.GetMetadataHistory on the default client
+func GetMetadataHistory(tokenAddress string) *ResponseHandler[[]MetadataChange] {
+	return defaultClient.GetMetadataHistory(tokenAddress)
+}
+
+// GetAuthorityHistory calls Client.GetAuthorityHistory on the default client
+func GetAuthorityHistory(tokenAddress string, filter AuthorityFilter) *ResponseHandler[[]AuthorityChange] {
+	return defaultClient.GetAuthorityHistory(tokenAddress, filter)
+}
+
+// GetApprovalEvents calls Client.GetApprovalEvents on the default client
+func GetApprovalEvents(tokenAddress string, owner string, fromBlock, toBlock int64) *ResponseHandler[[]ApprovalEvent] {
+	return defaultClient.GetApprovalEvents(tokenAddress, owner, fromBlock, toBlock)
+}
+
+// GetActiveAllowances calls Client.GetActiveAllowances on the default client
+func GetActiveAllowances(tokenAddress, owner string) *ResponseHandler[[]Allowance] {
+	return defaultClient.GetActiveAllowances(tokenAddress, owner)
+}
+
+// RegisterWebhook calls Client.RegisterWebhook on the default client
+func RegisterWebhook(callbackURL string, events []string, secret string, opts ...CallOption) *ResponseHandler[*Webhook] {
+	return defaultClient.RegisterWebhook(callbackURL, events, secret, opts...)
+}
+
+// ListWebhooks calls Client.ListWebhooks on the default client
+func ListWebhooks() *ResponseHandler[[]Webhook] {
+	return defaultClient.ListWebhooks()
+}
+
+// DeleteWebhook calls Client.DeleteWebhook on the default client
+func DeleteWebhook(id string) *ResponseHandler[struct{}] {
+	return defaultClient.DeleteWebhook(id)
+}
+
+// Close calls Client.Close on the default client
+func Close(ctx context.Context) error {
+	return defaultClient.Close(ctx)
+}
+
+// Verify calls Client.Verify on the default client
+func Verify(ctx context.Context) *VerifyReport {
+	return defaultClient.Verify(ctx)
+}
+
+// SignerNames calls Client.SignerNames on the default client
+func SignerNames() []string {
+	return defaultClient.SignerNames()
+}
+
+// BuildUnsignedRequest calls Client.BuildUnsignedRequest on the default client
+func BuildUnsignedRequest(tokenAddress, method string, args []interface{}, nonce int64, opts ...CallOption) *ResponseHandler[*MultisigRequest] {
+	return defaultClient.BuildUnsignedRequest(tokenAddress, method, args, nonce, opts...)
+}
+
+// SubmitMultisig calls Client.SubmitMultisig on the default client
+func SubmitMultisig(req *MultisigRequest, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	return defaultClient.SubmitMultisig(req, opts...)
+}
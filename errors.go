@@ -0,0 +1,63 @@
+package alchemy
+
+import (
+	"errors"
+	"strings"
+)
+
+// Failure classes of server rejections, matched with errors.Is. ErrTokenPaused (see
+// alchemy.go) is one of them too. Errors returned by the node and the token service
+// (*RPCError) match the class their message falls into; errors.As still yields the
+// *RPCError with the original code and message.
+var (
+	ErrBlacklisted         = errors.New("account is blacklisted")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrNonceConflict       = errors.New("nonce conflict")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrNotFound            = errors.New("not found")
+)
+
+// rpcErrorClasses maps server error messages onto the failure classes. The token service
+// reports all of these with the generic server error code (-32000), so the class is taken
+// from the message: the first class with a fragment contained in the lower-cased message
+// wins. Keep more specific classes (paused, blacklisted) before generic ones (not found).
+var rpcErrorClasses = []struct {
+	class     error
+	fragments []string
+}{
+	{ErrTokenPaused, []string{"token is paused", "token paused", "contract is paused", "contract paused", "enforcedpause", "pausable: paused"}},
+	{ErrBlacklisted, []string{"blacklisted", "in blacklist", "on blacklist", "on the blacklist"}},
+	{ErrInsufficientBalance, []string{"insufficient balance", "insufficient funds", "exceeds balance"}},
+	{ErrInvalidSignature, []string{"invalid signature", "signature mismatch", "bad signature", "signature verification failed", "invalid signer"}},
+	{ErrNonceConflict, append(usedNonceMarkers[:len(usedNonceMarkers):len(usedNonceMarkers)], nonceErrorMarkers...)},
+	{ErrPermissionDenied, []string{"unauthorized", "not authorized", "permission denied", "access denied", "missing role", "lacks role", "accesscontrol"}},
+	{ErrNotFound, []string{"not found", "does not exist", "unknown token"}},
+}
+
+// Internal method: failure class of a JSON-RPC error, nil when none applies
+func classifyRPCError(e *RPCError) error {
+	// Unknown RPC methods are a client/server mismatch, not a missing resource
+	if e.Code == -32601 {
+		return nil
+	}
+
+	msg := strings.ToLower(e.Message)
+	for _, entry := range rpcErrorClasses {
+		for _, fragment := range entry.fragments {
+			if strings.Contains(msg, fragment) {
+				if entry.class == ErrNotFound && strings.Contains(msg, "method") {
+					return nil
+				}
+				return entry.class
+			}
+		}
+	}
+	return nil
+}
+
+// Is makes errors.Is(err, ErrTokenPaused) and the other failure classes match
+func (e *RPCError) Is(target error) bool {
+	class := classifyRPCError(e)
+	return class != nil && class == target
+}
@@ -0,0 +1,60 @@
+package alchemy_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestRPCErrorClasses(t *testing.T) {
+	tests := []struct {
+		code    int
+		message string
+		want    error
+	}{
+		{-32000, "execution reverted: Pausable: paused", alchemy.ErrTokenPaused},
+		{-32000, "Token is paused", alchemy.ErrTokenPaused},
+		{-32000, "account 0xabc is blacklisted", alchemy.ErrBlacklisted},
+		{-32000, "ERC20: transfer amount exceeds balance", alchemy.ErrInsufficientBalance},
+		{-32000, "signature verification failed", alchemy.ErrInvalidSignature},
+		{-32000, "nonce too low", alchemy.ErrNonceConflict},
+		{-32000, "nonce has already been used", alchemy.ErrNonceConflict},
+		{-32000, "unknown token", alchemy.ErrNotFound},
+		{-32601, "method not found", nil},
+		{-32000, "method does not exist", nil},
+		{-32000, "something else", nil},
+	}
+	classes := []error{alchemy.ErrTokenPaused, alchemy.ErrBlacklisted, alchemy.ErrInsufficientBalance,
+		alchemy.ErrInvalidSignature, alchemy.ErrNonceConflict, alchemy.ErrNotFound}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			err := error(&alchemy.RPCError{Code: tt.code, Message: tt.message})
+			for _, class := range classes {
+				if got := errors.Is(err, class); got != (class == tt.want) {
+					t.Errorf("errors.Is(%v) = %v", class, got)
+				}
+			}
+		})
+	}
+}
+
+func TestBurnPausedOnlyFromRPCError(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	srv.SetError("burn", -32000, "execution reverted: Pausable: paused")
+	err := client.Burn(testToken, "1", 0).Err()
+	var rpcErr *alchemy.RPCError
+	if !errors.Is(err, alchemy.ErrTokenPaused) || !errors.As(err, &rpcErr) {
+		t.Fatalf("err = %v, want ErrTokenPaused from an *RPCError", err)
+	}
+
+	// A gateway page that happens to mention pausing is not a paused token
+	srv.SetResponse("burn", alchemytest.Response{Status: http.StatusBadGateway, Body: "upstream paused for maintenance"})
+	if err := client.Burn(testToken, "1", 0).Err(); errors.Is(err, alchemy.ErrTokenPaused) {
+		t.Fatalf("err = %v matched ErrTokenPaused", err)
+	}
+}
@@ -0,0 +1,207 @@
+package alchemy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Token event types
+const (
+	EventTransfer          = "Transfer"
+	EventMint              = "Mint"
+	EventBurn              = "Burn"
+	EventAdminBurn         = "AdminBurn"
+	EventSeize             = "Seize"
+	EventPause             = "Pause"
+	EventUnpause           = "Unpause"
+	EventBlacklistAdded    = "BlacklistAdded"
+	EventBlacklistRemoved  = "BlacklistRemoved"
+	EventAuthorityGranted  = "AuthorityGranted"
+	EventAuthorityRevoked  = "AuthorityRevoked"
+	EventMetadataUpdated   = "MetadataUpdated"
+	EventMasterTransferred = "MasterAuthorityTransferred"
+)
+
+// DefaultEventChunkSize is the number of blocks queried per request when none is configured
+const DefaultEventChunkSize int64 = 5000
+
+// EventFilter selects token events
+type EventFilter struct {
+	FromBlock  int64
+	ToBlock    int64    // 0 means the latest block
+	EventTypes []string // empty means all types
+	Account    string   // only events involving this account, empty means any
+	ChunkSize  int64    // blocks per request, 0 means DefaultEventChunkSize
+	// MaxResponseSize raises the response size limit per request, 0 means the configured maximum
+	MaxResponseSize int64
+}
+
+// TokenEvent is a decoded token event
+type TokenEvent struct {
+	Type        string                 `json:"type"`
+	BlockNumber int64                  `json:"blockNumber"`
+	TxHash      string                 `json:"txHash"`
+	LogIndex    int64                  `json:"logIndex"`
+	Fields      map[string]interface{} `json:"fields"`         // numbers decoded as json.Number
+	Memo        string                 `json:"memo,omitempty"` // memo of a *WithMemo write, "" if none
+}
+
+// Field returns a decoded field as a string, "" if absent
+func (e TokenEvent) Field(name string) string {
+	value, ok := e.Fields[name]
+	if !ok || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// GetTokenEvents queries token events. Large block ranges are split into
+// ChunkSize-block requests; events are returned in chain order. WithBlock caps ToBlock
+// at that block, so the result is consistent with other reads pinned to it.
+func (c *Client) GetTokenEvents(tokenAddress string, filter EventFilter, opts ...CallOption) (r *ResponseHandler[[]TokenEvent]) {
+	defer withOperation(&r, "GetTokenEvents", tokenAddress)
+	chunkSize := filter.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultEventChunkSize
+	}
+	if filter.FromBlock < 0 {
+		return &ResponseHandler[[]TokenEvent]{err: fmt.Errorf("invalid fromBlock %d", filter.FromBlock)}
+	}
+
+	cfg := c.newCallConfig(opts)
+	ctx := cfg.context()
+	toBlock := filter.ToBlock
+	if pinned := cfg.block; pinned != nil && (toBlock == 0 || toBlock > *pinned) {
+		toBlock = *pinned
+	}
+	if toBlock == 0 {
+		latest, err := c.getBlockNumberContext(ctx)
+		if err != nil {
+			return &ResponseHandler[[]TokenEvent]{err: err}
+		}
+		toBlock = latest
+	}
+	if toBlock < filter.FromBlock {
+		return &ResponseHandler[[]TokenEvent]{err: fmt.Errorf("invalid block range %d-%d", filter.FromBlock, toBlock)}
+	}
+
+	events := []TokenEvent{}
+	for from := filter.FromBlock; from <= toBlock; from += chunkSize {
+		to := from + chunkSize - 1
+		if to > toBlock {
+			to = toBlock
+		}
+
+		chunk, err := c.queryTokenEvents(ctx, tokenAddress, filter, from, to)
+		if err != nil {
+			return &ResponseHandler[[]TokenEvent]{err: fmt.Errorf("events %d-%d: %w", from, to, err)}
+		}
+		events = append(events, chunk...)
+	}
+
+	return &ResponseHandler[[]TokenEvent]{data: events}
+}
+
+// Internal method: single get_token_events request for [from, to]
+func (c *Client) queryTokenEvents(ctx context.Context, tokenAddress string, filter EventFilter, from, to int64) ([]TokenEvent, error) {
+	params := map[string]interface{}{
+		"token":     tokenAddress,
+		"fromBlock": from,
+		"toBlock":   to,
+	}
+	if len(filter.EventTypes) > 0 {
+		params["eventTypes"] = filter.EventTypes
+	}
+	if filter.Account != "" {
+		params["account"] = filter.Account
+	}
+
+	result, err := c.rpcCallContext(ctx, "get_token_events", params, filter.MaxResponseSize)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTokenEvents(result)
+}
+
+// Internal method: decode an event list keeping numeric fields exact
+func decodeTokenEvents(data []byte) ([]TokenEvent, error) {
+	var events []TokenEvent
+	if len(bytes.TrimSpace(data)) == 0 {
+		return events, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&events); err != nil {
+		return nil, fmt.Errorf("decode events: %w", err)
+	}
+	for i := range events {
+		events[i].fillMemo()
+	}
+	return events, nil
+}
+
+// SubscribeTokenEvents streams token events over WebSocket. The channel is closed when ctx is
+// cancelled or when reconnecting fails (reported through OnSubscriptionError). After a reconnect
+// the subscription is re-established, events emitted while disconnected are not replayed
+// (use WatchTokenEvents for gap-free delivery).
+func (c *Client) SubscribeTokenEvents(ctx context.Context, tokenAddress string, opts ...SubscribeOption) (_ <-chan TokenEvent, err error) {
+	defer withOperationErr(&err, "SubscribeTokenEvents", tokenAddress)
+	cfg := defaultSubscribeConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	endpoint, err := c.serviceWebSocketURL()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.subscribe(ctx, endpoint, "token", []interface{}{"events", map[string]interface{}{"token": tokenAddress}}, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan TokenEvent, cfg.buffer)
+	err = c.goBackground(ctx, func(ctx context.Context) {
+		defer close(events)
+		for result := range raw {
+			event, err := decodeTokenEvent(result)
+			if err != nil {
+				cfg.onError(err)
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Internal method: decode a single event keeping numeric fields exact
+func decodeTokenEvent(data []byte) (TokenEvent, error) {
+	var event TokenEvent
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&event); err != nil {
+		return event, fmt.Errorf("decode event: %w", err)
+	}
+	event.fillMemo()
+	return event, nil
+}
+
+// Internal method: take the memo from the event fields when the server puts it there
+func (e *TokenEvent) fillMemo() {
+	if e.Memo == "" {
+		e.Memo = e.Field("memo")
+	}
+}
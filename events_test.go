@@ -0,0 +1,88 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestGetTokenEventsDecodesMintAndBlacklist(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_token_events", json.RawMessage(`[
+		{"type":"Mint","blockNumber":101,"txHash":"0xaa","logIndex":0,
+		 "fields":{"to":"`+testRecipient+`","amount":123456789012345678901234567890}},
+		{"type":"BlacklistAdded","blockNumber":102,"txHash":"0xbb","logIndex":3,
+		 "fields":{"account":"`+blacklistedAccount+`"}}
+	]`))
+
+	events, err := client.GetTokenEvents(testToken, alchemy.EventFilter{FromBlock: 100, ToBlock: 110}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("%d events, want 2", len(events))
+	}
+
+	mint := events[0]
+	if mint.Type != alchemy.EventMint || mint.BlockNumber != 101 || mint.TxHash != "0xaa" {
+		t.Fatalf("mint event %+v", mint)
+	}
+	if mint.Field("to") != testRecipient || mint.Field("amount") != "123456789012345678901234567890" {
+		t.Fatalf("mint fields %v", mint.Fields)
+	}
+	if _, ok := mint.Fields["amount"].(json.Number); !ok {
+		t.Fatalf("amount decoded as %T, want json.Number", mint.Fields["amount"])
+	}
+
+	blacklisted := events[1]
+	if blacklisted.Type != alchemy.EventBlacklistAdded || blacklisted.LogIndex != 3 || blacklisted.Field("account") != blacklistedAccount {
+		t.Fatalf("blacklist event %+v", blacklisted)
+	}
+	if blacklisted.Field("amount") != "" {
+		t.Fatalf("absent field = %q", blacklisted.Field("amount"))
+	}
+}
+
+func TestGetTokenEventsChunksRange(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(2500)
+	event := func(block int) alchemytest.Response {
+		return alchemytest.Response{Result: []map[string]interface{}{{"type": "Mint", "blockNumber": block}}}
+	}
+	srv.QueueResponse("get_token_events", event(500), event(1500), event(2200))
+
+	filter := alchemy.EventFilter{FromBlock: 0, ChunkSize: 1000, EventTypes: []string{alchemy.EventMint}, Account: testRecipient}
+	events, err := client.GetTokenEvents(testToken, filter).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 3 || events[0].BlockNumber != 500 || events[2].BlockNumber != 2200 {
+		t.Fatalf("events %+v", events)
+	}
+
+	var ranges []string
+	for _, req := range srv.RequestsFor("get_token_events") {
+		ranges = append(ranges, fmt.Sprintf("%v-%v", req.ParamMap["fromBlock"], req.ParamMap["toBlock"]))
+		if fmt.Sprint(req.ParamMap["eventTypes"]) != "[Mint]" || req.ParamMap["account"] != testRecipient {
+			t.Fatalf("filter not sent: %v", req.ParamMap)
+		}
+	}
+	if fmt.Sprint(ranges) != "[0-999 1000-1999 2000-2500]" {
+		t.Fatalf("ranges = %v", ranges)
+	}
+}
+
+func TestGetTokenEventsChunkFailure(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("get_token_events",
+		alchemytest.Response{Result: []interface{}{}},
+		alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "range too large"}})
+
+	err := client.GetTokenEvents(testToken, alchemy.EventFilter{FromBlock: 1, ToBlock: 20, ChunkSize: 10}).Err()
+	if err == nil || err.Error() != "GetTokenEvents "+testToken+": get_token_events: events 11-20: RPC error: range too large" {
+		t.Fatalf("err = %v", err)
+	}
+}
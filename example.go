@@ -79,7 +79,7 @@ func main() {
 				})
 
 			// 4. Grant authority
-			alchemy.GrantAuthority(tokenAddress, "MINT_ROLE", "0x1234567890123456789012345678901234567890", 2).
+			alchemy.GrantAuthority(tokenAddress, alchemy.RoleMint, "0x1234567890123456789012345678901234567890", 2).
 				Success(func(response *alchemy.TransactionResult) {
 					fmt.Printf("🔐 Authority granted successfully: %s\n", response.Hash)
 				}).
@@ -97,7 +97,7 @@ func main() {
 				})
 
 			// 6. Revoke authority
-			alchemy.RevokeAuthority(tokenAddress, "MINT_ROLE", "0x1234567890123456789012345678901234567890", 4).
+			alchemy.RevokeAuthority(tokenAddress, alchemy.RoleMint, "0x1234567890123456789012345678901234567890", 4).
 				Success(func(response *alchemy.TransactionResult) {
 					fmt.Printf("🔒 Authority revoked successfully: %s\n", response.Hash)
 				}).
@@ -114,8 +114,17 @@ func main() {
 					fmt.Printf("Token burn failed: %v\n", err)
 				})
 
-			// 8. Pause contract
-			alchemy.Pause(tokenAddress, 6).
+			// 8. Burn own tokens
+			alchemy.Burn(tokenAddress, "100000000000000000", 6).
+				Success(func(response *alchemy.TransactionResult) {
+					fmt.Printf("🔥 Own tokens burned successfully: %s\n", response.Hash)
+				}).
+				Error(func(err error) {
+					fmt.Printf("Own token burn failed: %v\n", err)
+				})
+
+			// 9. Pause contract
+			alchemy.Pause(tokenAddress, 7).
 				Success(func(response *alchemy.TransactionResult) {
 					fmt.Printf("⏸️ Contract paused successfully: %s\n", response.Hash)
 				}).
@@ -123,8 +132,8 @@ func main() {
 					fmt.Printf("Contract pause failed: %v\n", err)
 				})
 
-			// 9. Unpause contract
-			alchemy.Unpause(tokenAddress, 7).
+			// 10. Unpause contract
+			alchemy.Unpause(tokenAddress, 8).
 				Success(func(response *alchemy.TransactionResult) {
 					fmt.Printf("▶️ Contract unpaused successfully: %s\n", response.Hash)
 				}).
@@ -132,8 +141,8 @@ func main() {
 					fmt.Printf("Contract unpause failed: %v\n", err)
 				})
 
-			// 10. Add to blacklist
-			alchemy.AddToBlacklist(tokenAddress, "0x9999999999999999999999999999999999999999", 8).
+			// 11. Add to blacklist
+			alchemy.AddToBlacklist(tokenAddress, "0x9999999999999999999999999999999999999999", 9).
 				Success(func(response *alchemy.TransactionResult) {
 					fmt.Printf("🚫 Added to blacklist successfully: %s\n", response.Hash)
 				}).
@@ -141,6 +150,15 @@ func main() {
 					fmt.Printf("Blacklist addition failed: %v\n", err)
 				})
 
+			// 12. Seize funds from the blacklisted account
+			alchemy.Seize(tokenAddress, "0x9999999999999999999999999999999999999999", "0x1234567890123456789012345678901234567890", "100000000000000000", 10).
+				Success(func(response *alchemy.TransactionResult) {
+					fmt.Printf("🧲 Funds seized successfully: %s\n", response.Hash)
+				}).
+				Error(func(err error) {
+					fmt.Printf("Seize failed: %v\n", err)
+				})
+
 			fmt.Printf("\n🎉 Complete workflow demonstration finished! Token address: %s\n", tokenAddress)
 		}).
 		Error(func(err error) {
@@ -0,0 +1,256 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultFailoverProbeInterval is how often a demoted endpoint is probed when none is configured
+const DefaultFailoverProbeInterval = 30 * time.Second
+
+// EndpointEventType is the kind of an EndpointEvent
+type EndpointEventType int
+
+const (
+	EndpointServed   EndpointEventType = iota // a request succeeded on Endpoint
+	EndpointDemoted                           // Endpoint failed with Err and is skipped while others are healthy
+	EndpointPromoted                          // a probe of the demoted Endpoint succeeded, it is used again
+)
+
+func (t EndpointEventType) String() string {
+	switch t {
+	case EndpointServed:
+		return "served"
+	case EndpointDemoted:
+		return "demoted"
+	default:
+		return "promoted"
+	}
+}
+
+// EndpointEvent reports which endpoint served a request and changes of endpoint health
+type EndpointEvent struct {
+	Type     EndpointEventType
+	Endpoint string
+	Method   string // the request's JSON-RPC method, or the probe's for EndpointPromoted
+	Err      error  // the failure that demoted the endpoint
+}
+
+// ConfigNodeURLs sets an ordered list of Ethereum node URLs for the default client (see
+// WithNodeURLs). An empty list restores the single Config URL.
+func ConfigNodeURLs(urls ...string) {
+	ConfigNodeURL(firstURL(urls))
+	defaultClient.nodeSet = newNodeSet(urls)
+}
+
+// ConfigServiceURLs sets an ordered list of token service URLs for the default client (see
+// WithServiceURLs). An empty list restores the single Config URL + "/rpc".
+func ConfigServiceURLs(urls ...string) {
+	ConfigServiceURL(firstURL(urls))
+	defaultClient.serviceSet = newServiceSet(urls)
+}
+
+// WithNodeURLs sets an ordered list of Ethereum node URLs with failover. Requests go to the
+// first healthy one. An endpoint failing a request with an error the retry policy would
+// retry (see RetryPolicy) is demoted and the request moves on to the next one right away;
+// for writes that means only errors raised before the request was sent, so a write is never
+// submitted twice. A demoted endpoint is probed (eth_blockNumber) at most every
+// WithFailoverProbeInterval while requests flow, and used again in its place once a probe
+// succeeds. When all endpoints are demoted they are tried in order anyway. The endpoints
+// must serve the same chain. WebSocket subscriptions, HealthCheck and Verify use the first.
+func WithNodeURLs(urls ...string) Option {
+	return func(o *clientOptions) {
+		o.nodeURL = firstURL(urls)
+		o.nodeURLs = urls
+	}
+}
+
+// WithServiceURLs sets an ordered list of token service URLs with failover, as WithNodeURLs
+// does for the node. Demoted endpoints are probed with get_server_info.
+func WithServiceURLs(urls ...string) Option {
+	return func(o *clientOptions) {
+		o.serviceURL = firstURL(urls)
+		o.serviceURLs = urls
+	}
+}
+
+// WithFailoverProbeInterval sets how often a demoted endpoint is probed (default
+// DefaultFailoverProbeInterval)
+func WithFailoverProbeInterval(interval time.Duration) Option {
+	return func(o *clientOptions) { o.probeInterval = interval }
+}
+
+// WithEndpointHook calls hook with the endpoint serving each successful request and when
+// endpoints are demoted or promoted, e.g. for debugging failover. It runs synchronously on
+// the request path, so it must be fast.
+func WithEndpointHook(hook func(EndpointEvent)) Option {
+	return func(o *clientOptions) { o.endpointHook = hook }
+}
+
+// endpointSet is an ordered list of equivalent endpoints with their health
+type endpointSet struct {
+	urls          []string
+	probeMethod   string      // cheap request answered by a healthy endpoint
+	probeParams   interface{} // its params
+	requireResult bool        // the probe must succeed, not only get a JSON-RPC response
+
+	mu      sync.Mutex
+	demoted map[string]time.Time // url -> time of the next probe
+	probing map[string]bool      // urls with a probe in flight
+}
+
+// Internal method: endpoint set of urls, nil for fewer than two
+func newEndpointSet(urls []string, probeMethod string, probeParams interface{}, requireResult bool) *endpointSet {
+	if len(urls) < 2 {
+		return nil
+	}
+	return &endpointSet{
+		urls:          append([]string(nil), urls...),
+		probeMethod:   probeMethod,
+		probeParams:   probeParams,
+		requireResult: requireResult,
+		demoted:       map[string]time.Time{},
+		probing:       map[string]bool{},
+	}
+}
+
+// Internal method: node endpoint set, probed with eth_blockNumber
+func newNodeSet(urls []string) *endpointSet {
+	return newEndpointSet(urls, "eth_blockNumber", []interface{}{}, true)
+}
+
+// Internal method: token service endpoint set, probed with get_server_info; older servers
+// rejecting it still answer
+func newServiceSet(urls []string) *endpointSet {
+	return newEndpointSet(urls, "get_server_info", map[string]interface{}{}, false)
+}
+
+// Internal method: first of urls, "" for none
+func firstURL(urls []string) string {
+	if len(urls) == 0 {
+		return ""
+	}
+	return urls[0]
+}
+
+// Internal method: the failover set whose primary is endpoint, nil if there is none
+func (c *Client) failoverSet(endpoint string) *endpointSet {
+	for _, set := range []*endpointSet{c.nodeSet, c.serviceSet} {
+		if set != nil && set.urls[0] == endpoint {
+			return set
+		}
+	}
+	return nil
+}
+
+// Internal method: send one attempt of a request to endpoint or, if endpoint is the
+// primary of a failover set, to the first of its endpoints that takes it
+func (c *Client) callEndpoints(ctx context.Context, endpoint, method string, params interface{}) (json.RawMessage, error) {
+	set := c.failoverSet(endpoint)
+	if set == nil {
+		result, err := c.transport.Call(ctx, endpoint, method, params)
+		if err == nil {
+			c.endpointEvent(EndpointEvent{Type: EndpointServed, Endpoint: endpoint, Method: method})
+		}
+		return result, err
+	}
+
+	var lastErr error
+	for _, url := range c.endpointOrder(set) {
+		result, err := c.transport.Call(ctx, url, method, params)
+		if err == nil {
+			c.endpointEvent(EndpointEvent{Type: EndpointServed, Endpoint: url, Method: method})
+			return result, nil
+		}
+		if !isRetryableRequestError(ctx, err) {
+			return nil, err // possibly processed: another endpoint could apply a write twice
+		}
+		c.demoteEndpoint(set, url, method, err)
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Internal method: healthy endpoints in configured order, then the demoted ones as a last
+// resort. Starts a probe of each demoted endpoint that is due.
+func (c *Client) endpointOrder(set *endpointSet) []string {
+	now := time.Now()
+	set.mu.Lock()
+	defer set.mu.Unlock()
+
+	order := make([]string, 0, len(set.urls))
+	var demoted []string
+	for _, url := range set.urls {
+		next, ok := set.demoted[url]
+		if !ok {
+			order = append(order, url)
+			continue
+		}
+		demoted = append(demoted, url)
+		if !set.probing[url] && !now.Before(next) {
+			set.demoted[url] = now.Add(c.probeInterval())
+			if c.goBackground(context.Background(), func(ctx context.Context) { c.probeEndpoint(ctx, set, url) }) == nil {
+				set.probing[url] = true
+			}
+		}
+	}
+	return append(order, demoted...)
+}
+
+// Internal method: mark url as failed, probed again after the probe interval
+func (c *Client) demoteEndpoint(set *endpointSet, url, method string, err error) {
+	set.mu.Lock()
+	_, already := set.demoted[url]
+	set.demoted[url] = time.Now().Add(c.probeInterval())
+	set.mu.Unlock()
+	if !already {
+		c.endpointEvent(EndpointEvent{Type: EndpointDemoted, Endpoint: url, Method: method, Err: err})
+	}
+}
+
+// Internal method: probe a demoted endpoint, promoting it when it answers
+func (c *Client) probeEndpoint(ctx context.Context, set *endpointSet, url string) {
+	_, err := c.pingEndpoint(ctx, url, set.probeMethod, set.probeParams, set.requireResult)
+
+	set.mu.Lock()
+	delete(set.probing, url)
+	_, demoted := set.demoted[url]
+	promoted := err == nil && demoted
+	if promoted {
+		delete(set.demoted, url)
+	}
+	set.mu.Unlock()
+	if promoted {
+		c.endpointEvent(EndpointEvent{Type: EndpointPromoted, Endpoint: url, Method: set.probeMethod})
+	}
+}
+
+// Internal method: the configured probe interval or the default
+func (c *Client) probeInterval() time.Duration {
+	if c.failoverProbe > 0 {
+		return c.failoverProbe
+	}
+	return DefaultFailoverProbeInterval
+}
+
+// Internal method: report an event to the endpoint hook, if any
+func (c *Client) endpointEvent(event EndpointEvent) {
+	if c.endpointHook != nil {
+		c.endpointHook(event)
+	}
+}
+
+// Internal method: validate failover URL lists
+func validateEndpointURLs(lists ...[]string) error {
+	for _, urls := range lists {
+		for _, url := range urls {
+			if url == "" {
+				return errors.New("alchemy: empty endpoint URL")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package alchemy_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// failover is a client over two test servers, a and b, whose transport fails every request
+// to a with the error set by down
+type failover struct {
+	a, b   *alchemytest.Server
+	client *alchemy.Client
+	down   atomic.Pointer[error]
+
+	mu     sync.Mutex
+	events []alchemy.EndpointEvent
+}
+
+func newFailover(t *testing.T, opts ...alchemy.Option) *failover {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &failover{a: failoverServer(t, key), b: failoverServer(t, key)}
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if errp := f.down.Load(); errp != nil && strings.HasPrefix(f.a.URL, "http://"+r.URL.Host) {
+			return nil, *errp
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	f.client, err = alchemy.NewClient(f.a.URL, append([]alchemy.Option{
+		alchemy.WithKey(key),
+		alchemy.WithHTTPClient(&http.Client{Transport: transport}),
+		alchemy.WithNodeURLs(f.a.URL, f.b.URL),
+		alchemy.WithServiceURLs(f.a.URL+"/rpc", f.b.URL+"/rpc"),
+		alchemy.WithEndpointHook(func(e alchemy.EndpointEvent) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.events = append(f.events, e)
+		}),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.client.Close(context.Background()) })
+	return f
+}
+
+func failoverServer(t *testing.T, key *ecdsa.PrivateKey) *alchemytest.Server {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.RegisterKey(&key.PublicKey)
+	return srv
+}
+
+// fail makes requests to a fail with err, or succeed again for nil
+func (f *failover) fail(err error) {
+	if err == nil {
+		f.down.Store(nil)
+		return
+	}
+	f.down.Store(&err)
+}
+
+// takeEvents returns the hook events of a type other than EndpointServed seen so far and
+// forgets all events
+func (f *failover) takeEvents() (changes []alchemy.EndpointEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, e := range f.events {
+		if e.Type != alchemy.EndpointServed {
+			changes = append(changes, e)
+		}
+	}
+	f.events = nil
+	return changes
+}
+
+// onService returns the events of token service endpoints
+func onService(events []alchemy.EndpointEvent) (matched []alchemy.EndpointEvent) {
+	for _, e := range events {
+		if strings.HasSuffix(e.Endpoint, "/rpc") {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// lastServed returns the endpoint of the last EndpointServed event
+func (f *failover) lastServed() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.events) - 1; i >= 0; i-- {
+		if f.events[i].Type == alchemy.EndpointServed {
+			return f.events[i].Endpoint
+		}
+	}
+	return ""
+}
+
+func TestFailoverDemotesAndPromotes(t *testing.T) {
+	f := newFailover(t, alchemy.WithFailoverProbeInterval(50*time.Millisecond))
+	f.a.SetResult("getSupplyCap", "1000")
+	f.b.SetResult("getSupplyCap", "2000")
+
+	if cap, _ := f.client.GetSupplyCap(testToken).Result(); cap != "1000" || f.lastServed() != f.a.URL+"/rpc" {
+		t.Fatalf("cap %q from %s, want 1000 from a", cap, f.lastServed())
+	}
+
+	f.fail(refused)
+	if cap, err := f.client.GetSupplyCap(testToken).Result(); err != nil || cap != "2000" {
+		t.Fatalf("cap %q, %v, want 2000 from b", cap, err)
+	}
+	events := onService(f.takeEvents())
+	if len(events) != 1 || events[0].Type != alchemy.EndpointDemoted || events[0].Endpoint != f.a.URL+"/rpc" ||
+		events[0].Method != "getSupplyCap" || !errors.Is(events[0].Err, refused.Err) {
+		t.Fatalf("events %+v, want a demoted", events)
+	}
+
+	// Demoted, a isn't tried again before its probe is due
+	requests := len(f.a.Requests())
+	f.fail(nil)
+	for range 3 {
+		if cap, _ := f.client.GetSupplyCap(testToken).Result(); cap != "2000" {
+			t.Fatalf("cap %q, want 2000 from b while a is demoted", cap)
+		}
+	}
+	if n := len(f.a.Requests()); n != requests {
+		t.Fatalf("%d requests to demoted a", n-requests)
+	}
+
+	// The first request after the interval starts the probe, a serves once it succeeded
+	time.Sleep(60 * time.Millisecond)
+	f.client.GetSupplyCap(testToken)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(onService(f.takeEvents())) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("a never promoted")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(f.a.RequestsFor("get_server_info")) != 1 {
+		t.Fatal("a promoted without a probe")
+	}
+	if cap, _ := f.client.GetSupplyCap(testToken).Result(); cap != "1000" {
+		t.Fatalf("cap %q, want 1000 from promoted a", cap)
+	}
+}
+
+func TestFailoverPromotedEvent(t *testing.T) {
+	f := newFailover(t, alchemy.WithFailoverProbeInterval(time.Millisecond))
+	f.fail(refused)
+	if err := f.client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	f.fail(nil)
+	time.Sleep(5 * time.Millisecond)
+	f.client.GetBalance(testRecipient)
+
+	deadline := time.Now().Add(5 * time.Second)
+	var events []alchemy.EndpointEvent
+	for len(events) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("events %+v, want demoted then promoted", events)
+		}
+		events = append(events, f.takeEvents()...)
+		time.Sleep(time.Millisecond)
+	}
+	if events[0].Type != alchemy.EndpointDemoted || events[1].Type != alchemy.EndpointPromoted ||
+		events[1].Endpoint != f.a.URL || events[1].Method != "eth_blockNumber" || events[1].Err != nil {
+		t.Fatalf("events %+v, want a demoted then promoted by eth_blockNumber", events)
+	}
+	if events[1].Type.String() != "promoted" {
+		t.Fatalf("type %q", events[1].Type)
+	}
+}
+
+func TestFailoverWrites(t *testing.T) {
+	f := newFailover(t)
+
+	// The connection broke after the write was sent: it may have been applied, so it isn't
+	// sent to b
+	f.fail(reset)
+	err := f.client.Mint(testToken, testRecipient, "5", 0).Err()
+	if !errors.Is(err, reset.Err) {
+		t.Fatalf("err = %v, want the connection reset", err)
+	}
+	if n := len(f.b.RequestsFor("mint")); n != 0 {
+		t.Fatalf("%d mints on b after a reset", n)
+	}
+
+	// a refused the connection: nothing was sent, b takes the write once
+	f.fail(refused)
+	if err := f.client.Mint(testToken, testRecipient, "5", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.b.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d mints on b, want 1", n)
+	}
+	if n := len(f.a.RequestsFor("mint")); n != 0 {
+		t.Fatalf("%d mints reached a", n)
+	}
+}
+
+func TestFailoverAllDown(t *testing.T) {
+	f := newFailover(t)
+	f.b.Close()
+	f.fail(refused)
+
+	if err := f.client.GetBalance(testRecipient).Err(); err == nil {
+		t.Fatal("no error with every endpoint down")
+	}
+	// Both were demoted, the next request still tries them in order
+	if events := f.takeEvents(); len(events) != 2 || events[0].Endpoint != f.a.URL || events[1].Endpoint != f.b.URL {
+		t.Fatalf("events %+v, want a and b demoted", events)
+	}
+	f.fail(nil)
+	if err := f.client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatalf("err = %v, want a tried though demoted", err)
+	}
+	if served := f.lastServed(); served != f.a.URL {
+		t.Fatalf("served by %s, want a", served)
+	}
+}
+
+func TestFailoverOptions(t *testing.T) {
+	if _, err := alchemy.NewClient("http://localhost:8545", alchemy.WithNodeURLs("http://a", "")); err == nil {
+		t.Fatal("empty URL accepted")
+	}
+
+	// A single URL is the plain endpoint
+	srv, _, _ := newTestServer(t)
+	var served []string
+	client, err := alchemy.NewClient("http://unused.invalid",
+		alchemy.WithNodeURLs(srv.URL),
+		alchemy.WithEndpointHook(func(e alchemy.EndpointEvent) { served = append(served, e.Endpoint) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(served) != 1 || served[0] != srv.URL {
+		t.Fatalf("served %v, want %s", served, srv.URL)
+	}
+}
+
+func TestFailoverProbeNoLeak(t *testing.T) {
+	before := sdkGoroutines()
+	f := newFailover(t, alchemy.WithFailoverProbeInterval(time.Millisecond))
+	f.fail(refused)
+	f.client.GetBalance(testRecipient)
+	time.Sleep(5 * time.Millisecond)
+	f.client.GetBalance(testRecipient) // starts a probe, which fails
+	if err := f.client.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	checkNoLeaks(t, before)
+}
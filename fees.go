@@ -0,0 +1,218 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Operation describes a token write for estimation, without a nonce
+type Operation struct {
+	Token  string
+	Method string
+	Args   []interface{}
+}
+
+// MintOperation describes Mint(token, to, amount)
+func MintOperation(token, to, amount string) Operation {
+	return Operation{Token: token, Method: "mint", Args: []interface{}{to, amount}}
+}
+
+// BurnOperation describes Burn(token, amount)
+func BurnOperation(token, amount string) Operation {
+	return Operation{Token: token, Method: "burn", Args: []interface{}{amount}}
+}
+
+// AdminBurnOperation describes AdminBurn(token, from, amount)
+func AdminBurnOperation(token, from, amount string) Operation {
+	return Operation{Token: token, Method: "adminBurn", Args: []interface{}{from, amount}}
+}
+
+// GrantAuthorityOperation describes GrantAuthority(token, role, account)
+func GrantAuthorityOperation(token string, role Role, account string) Operation {
+	return Operation{Token: token, Method: "grantAuthority", Args: []interface{}{role.String(), account}}
+}
+
+// RevokeAuthorityOperation describes RevokeAuthority(token, role, account)
+func RevokeAuthorityOperation(token string, role Role, account string) Operation {
+	return Operation{Token: token, Method: "revokeAuthority", Args: []interface{}{role.String(), account}}
+}
+
+// AddToBlacklistOperation describes AddToBlacklist(token, account)
+func AddToBlacklistOperation(token, account string) Operation {
+	return Operation{Token: token, Method: "addToBlacklist", Args: []interface{}{account}}
+}
+
+// FeeEstimate is the estimated cost of an operation
+type FeeEstimate struct {
+	Gas      uint64
+	GasPrice *big.Int
+	TotalWei *big.Int
+	TotalEth string // exact decimal
+}
+
+// RevertError is returned when the estimated call would revert
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	if e.Reason == "" {
+		return "execution reverted"
+	}
+	return "execution reverted: " + e.Reason
+}
+
+// ErrWouldRevert matches any *RevertError via errors.Is
+var ErrWouldRevert = errors.New("execution reverted")
+
+// Is makes errors.Is(err, ErrWouldRevert) match
+func (e *RevertError) Is(target error) bool {
+	return target == ErrWouldRevert
+}
+
+// GetGasPrice gets the current gas price in wei from the node
+func (c *Client) GetGasPrice() (r *ResponseHandler[*big.Int]) {
+	defer withOperation(&r, "GetGasPrice", "")
+	price, err := c.getGasPrice()
+	if err != nil {
+		return &ResponseHandler[*big.Int]{err: err}
+	}
+	return &ResponseHandler[*big.Int]{data: price}
+}
+
+// EstimateFee estimates the gas and cost of an operation signed by the configured key via the
+// server's estimate_fee RPC. If the operation would revert the error is a *RevertError carrying
+// the reason, so misconfigured operations are caught before a nonce is used.
+func (c *Client) EstimateFee(op Operation) (r *ResponseHandler[*FeeEstimate]) {
+	defer withOperation(&r, "EstimateFee", op.Token)
+	if err := c.checkChain(); err != nil {
+		return &ResponseHandler[*FeeEstimate]{err: err}
+	}
+
+	from, err := c.signerAddress(context.Background())
+	if err != nil {
+		return &ResponseHandler[*FeeEstimate]{err: err}
+	}
+
+	args := op.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+	result, err := c.rpcCall("estimate_fee", map[string]interface{}{
+		"from":       from,
+		"token":      op.Token,
+		"method":     op.Method,
+		"methodArgs": args,
+	})
+	if err != nil {
+		return &ResponseHandler[*FeeEstimate]{err: asRevertError(err)}
+	}
+
+	var raw struct {
+		Gas      json.RawMessage `json:"gas"` // number, decimal string or hex string
+		GasPrice string          `json:"gasPrice"`
+	}
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return &ResponseHandler[*FeeEstimate]{err: err}
+	}
+
+	estimate := &FeeEstimate{}
+	if estimate.Gas, err = parseQuantity(string(raw.Gas)); err != nil {
+		return &ResponseHandler[*FeeEstimate]{err: fmt.Errorf("estimate gas: %w", err)}
+	}
+
+	// Servers that don't quote a gas price get the node's current price
+	if raw.GasPrice != "" {
+		price, ok := new(big.Int).SetString(raw.GasPrice, 0)
+		if !ok {
+			return &ResponseHandler[*FeeEstimate]{err: fmt.Errorf("invalid gasPrice %q", raw.GasPrice)}
+		}
+		estimate.GasPrice = price
+	} else {
+		if estimate.GasPrice, err = c.getGasPrice(); err != nil {
+			return &ResponseHandler[*FeeEstimate]{err: err}
+		}
+	}
+
+	estimate.TotalWei = new(big.Int).Mul(new(big.Int).SetUint64(estimate.Gas), estimate.GasPrice)
+	estimate.TotalEth, _ = formatUnits(estimate.TotalWei.String(), 18)
+
+	return &ResponseHandler[*FeeEstimate]{data: estimate}
+}
+
+// Internal method: eth_gasPrice
+func (c *Client) getGasPrice() (*big.Int, error) {
+	result, err := c.nodeCall("eth_gasPrice", []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var hexPrice string
+	if err := json.Unmarshal(result, &hexPrice); err != nil {
+		return nil, fmt.Errorf("invalid eth_gasPrice result: %w", err)
+	}
+	return parseHexBig(hexPrice)
+}
+
+// Internal method: parse a decimal or 0x-hex quantity (or a quoted one)
+func parseQuantity(s string) (uint64, error) {
+	s = strings.Trim(s, `"`)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return parseHexQuantity(s)
+	}
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok || !value.IsUint64() {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return value.Uint64(), nil
+}
+
+// revertErrorCode is the JSON-RPC error code nodes use for a call that reverted
+const revertErrorCode = 3
+
+// Internal method: turn a JSON-RPC revert into a *RevertError. Nodes report one with code 3
+// and the ABI-encoded revert data in data; servers without data say "execution reverted:
+// reason". Any other error, transport failures included, is returned unchanged.
+func asRevertError(err error) error {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	index := strings.Index(strings.ToLower(rpcErr.Message), "execution reverted")
+	if rpcErr.Code != revertErrorCode && index < 0 {
+		return err
+	}
+
+	if reason, ok := revertDataReason(rpcErr.Data); ok {
+		return &RevertError{Reason: reason}
+	}
+	if index < 0 {
+		return &RevertError{Reason: strings.TrimSpace(rpcErr.Message)}
+	}
+	reason := strings.TrimSpace(strings.TrimPrefix(rpcErr.Message[index+len("execution reverted"):], ":"))
+	return &RevertError{Reason: reason}
+}
+
+// Internal method: the reason encoded in revert data ("0x08c379a0..." for Error(string),
+// Panic(uint256) too), false when data carries none
+func revertDataReason(data json.RawMessage) (string, bool) {
+	var encoded string
+	if len(data) == 0 || json.Unmarshal(data, &encoded) != nil {
+		return "", false
+	}
+	raw, err := hexutil.Decode(encoded)
+	if err != nil {
+		return "", false
+	}
+	reason, err := abi.UnpackRevert(raw)
+	if err != nil {
+		return "", false
+	}
+	return reason, true
+}
@@ -0,0 +1,112 @@
+package alchemy_test
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// revertData is the ABI encoding of Error(reason), as nodes put it in a revert's data
+func revertData(reason string) string {
+	data := []byte{0x08, 0xc3, 0x79, 0xa0}
+	data = append(data, common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(big.NewInt(int64(len(reason))).Bytes(), 32)...)
+	data = append(data, common.RightPadBytes([]byte(reason), (len(reason)+31)/32*32)...)
+	return hexutil.Encode(data)
+}
+
+func TestGetGasPrice(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_gasPrice", "0x3b9aca00")
+
+	price, err := client.GetGasPrice().Result()
+	if err != nil || price.String() != "1000000000" {
+		t.Fatalf("price %v, %v", price, err)
+	}
+
+	srv.SetResult("eth_gasPrice", "1000000000")
+	if err := client.GetGasPrice().Err(); err == nil {
+		t.Fatal("price without 0x prefix accepted")
+	}
+}
+
+func TestEstimateFee(t *testing.T) {
+	srv, client, key := newTestServer(t)
+	srv.SetResult("estimate_fee", map[string]interface{}{"gas": "0xc350", "gasPrice": "2000000000"})
+
+	estimate, err := client.EstimateFee(alchemy.MintOperation(testToken, testRecipient, "100")).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.Gas != 50000 || estimate.GasPrice.String() != "2000000000" ||
+		estimate.TotalWei.String() != "100000000000000" || estimate.TotalEth != "0.0001" {
+		t.Fatalf("got %+v", estimate)
+	}
+	req := srv.RequestsFor("estimate_fee")[0]
+	if req.ParamMap["from"] != crypto.PubkeyToAddress(key.PublicKey).Hex() ||
+		req.ParamMap["method"] != "mint" || req.ParamMap["token"] != testToken {
+		t.Fatalf("params %v", req.ParamMap)
+	}
+	if n := len(srv.RequestsFor("eth_gasPrice")); n != 0 {
+		t.Fatalf("%d gas price lookups with a quoted price", n)
+	}
+
+	// Without a quoted price the node's price is used
+	srv.SetResult("estimate_fee", map[string]interface{}{"gas": 21000})
+	srv.SetResult("eth_gasPrice", "0x3b9aca00")
+	estimate, err = client.EstimateFee(alchemy.BurnOperation(testToken, "1")).Result()
+	if err != nil || estimate.Gas != 21000 || estimate.TotalWei.String() != "21000000000000" {
+		t.Fatalf("got %+v, %v", estimate, err)
+	}
+}
+
+func TestEstimateFeeRevert(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    alchemytest.RPCError
+		reason string
+	}{
+		{"revert data", alchemytest.RPCError{Code: 3, Message: "execution reverted", Data: revertData("Pausable: paused")}, "Pausable: paused"},
+		{"reason in message", alchemytest.RPCError{Code: -32000, Message: "execution reverted: caller is not a minter"}, "caller is not a minter"},
+		{"no reason", alchemytest.RPCError{Code: 3, Message: "execution reverted"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetResponse("estimate_fee", alchemytest.Response{Error: &tt.err})
+
+			err := client.EstimateFee(alchemy.MintOperation(testToken, testRecipient, "100")).Err()
+			var revert *alchemy.RevertError
+			if !errors.As(err, &revert) || !errors.Is(err, alchemy.ErrWouldRevert) {
+				t.Fatalf("err = %v, want a *RevertError", err)
+			}
+			if revert.Reason != tt.reason {
+				t.Fatalf("reason %q, want %q", revert.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestEstimateFeeOtherErrors(t *testing.T) {
+	// Only JSON-RPC reverts are reverts: a gateway page mentioning one is not
+	srv, client, _ := newTestServer(t)
+	srv.SetResponse("estimate_fee", alchemytest.Response{Status: http.StatusBadGateway, Body: "upstream execution reverted"})
+	err := client.EstimateFee(alchemy.MintOperation(testToken, testRecipient, "100")).Err()
+	if err == nil || errors.Is(err, alchemy.ErrWouldRevert) {
+		t.Fatalf("err = %v, want the gateway error", err)
+	}
+
+	srv.SetError("estimate_fee", -32000, "insufficient balance")
+	err = client.EstimateFee(alchemy.MintOperation(testToken, testRecipient, "100")).Err()
+	if !errors.Is(err, alchemy.ErrInsufficientBalance) || errors.Is(err, alchemy.ErrWouldRevert) {
+		t.Fatalf("err = %v, want ErrInsufficientBalance", err)
+	}
+}
@@ -0,0 +1,86 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// Map transforms the result of r with f, keeping the fluent chain:
+//
+//	alchemy.Map(alchemy.GetTokenMetadata(token), parseSupply).
+//		Success(func(supply *big.Int) { ... })
+//
+// An error in r is passed through untouched and f is not called; an error returned by
+// f becomes the error of the new handler. Raw carries over.
+func Map[T, U any](r *ResponseHandler[T], f func(T) (U, error)) *ResponseHandler[U] {
+	if r.err != nil {
+		return &ResponseHandler[U]{err: r.err, raw: r.raw}
+	}
+
+	data, err := f(r.data)
+	if err != nil {
+		return &ResponseHandler[U]{err: err, raw: r.raw}
+	}
+	return &ResponseHandler[U]{data: data, raw: r.raw}
+}
+
+// ErrCallbackPanic matches any *CallbackPanicError via errors.Is
+var ErrCallbackPanic = errors.New("callback panicked")
+
+// CallbackPanicError records a panic recovered from a ResponseHandler callback
+type CallbackPanicError struct {
+	Value interface{} // value passed to panic
+	Stack []byte      // stack of the panicking goroutine
+}
+
+func (e *CallbackPanicError) Error() string {
+	return fmt.Sprintf("callback panicked: %v", e.Value)
+}
+
+// Is makes errors.Is(err, ErrCallbackPanic) match
+func (e *CallbackPanicError) Is(target error) bool {
+	return target == ErrCallbackPanic
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *CallbackPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Finally calls callback regardless of the outcome, after the callbacks chained before it.
+// A panic in callback is recovered and becomes the handler's error.
+func (r *ResponseHandler[T]) Finally(callback func()) *ResponseHandler[T] {
+	r.run(callback)
+	return r
+}
+
+// Result returns the result and the error, including a panic recovered from a callback
+func (r *ResponseHandler[T]) Result() (T, error) {
+	return r.data, r.err
+}
+
+// Err returns the error, including a panic recovered from a callback
+func (r *ResponseHandler[T]) Err() error {
+	return r.err
+}
+
+// Raw returns the JSON-RPC result exactly as the server sent it, e.g. for audit logs.
+// Set by token calls and CreateToken, including when decoding failed or the result was
+// post-processed (e.g. addresses checksummed); nil for results served from a cache and for
+// calls without a server response. The bytes are a private copy, safe to retain.
+func (r *ResponseHandler[T]) Raw() json.RawMessage {
+	return r.raw
+}
+
+// Internal method: run a user callback, turning a panic into the handler's error
+func (r *ResponseHandler[T]) run(callback func()) {
+	defer func() {
+		if value := recover(); value != nil {
+			r.err = &CallbackPanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+	callback()
+}
@@ -0,0 +1,135 @@
+package alchemy_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// parseSupply is a typical Map step
+func parseSupply(meta *alchemy.TokenMetadata) (*big.Int, error) {
+	supply, ok := new(big.Int).SetString(meta.Supply, 10)
+	if !ok {
+		return nil, errors.New("bad supply")
+	}
+	return supply, nil
+}
+
+func TestMapSuccess(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Dollar", "symbol": "USDX", "decimals": 6, "supply": "123456789012345678901234567890"})
+
+	var got *big.Int
+	handler := alchemy.Map(client.GetTokenMetadata(testToken), parseSupply).
+		Success(func(supply *big.Int) { got = supply }).
+		Error(func(err error) { t.Fatalf("Error callback with %v", err) })
+	if got == nil || got.String() != "123456789012345678901234567890" {
+		t.Fatalf("supply %v", got)
+	}
+	if handler.Raw() == nil {
+		t.Fatal("Raw not carried over")
+	}
+}
+
+func TestMapPassesErrorsThrough(t *testing.T) {
+	errUpstream := errors.New("upstream")
+	called := false
+	mapped := alchemy.Map(errorHandler[*alchemy.TokenMetadata](errUpstream), func(*alchemy.TokenMetadata) (*big.Int, error) {
+		called = true
+		return nil, nil
+	})
+	if called {
+		t.Fatal("f called despite the error")
+	}
+	if err := mapped.Err(); err != errUpstream {
+		t.Fatalf("err = %v, want the original error untouched", err)
+	}
+
+	var seen error
+	mapped.Success(func(*big.Int) { t.Fatal("Success called") }).Error(func(err error) { seen = err })
+	if seen != errUpstream {
+		t.Fatalf("Error callback got %v", seen)
+	}
+}
+
+func TestMapCapturesErrorFromF(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Dollar", "symbol": "USDX", "decimals": 6, "supply": "not a number"})
+
+	supply, err := alchemy.Map(client.GetTokenMetadata(testToken), parseSupply).Result()
+	if supply != nil || err == nil || err.Error() != "bad supply" {
+		t.Fatalf("supply %v, err %v", supply, err)
+	}
+
+	// Chained maps stop at the first failure
+	calls := 0
+	_, err = alchemy.Map(alchemy.Map(client.GetTokenMetadata(testToken), parseSupply), func(s *big.Int) (string, error) {
+		calls++
+		return s.String(), nil
+	}).Result()
+	if calls != 0 || err == nil {
+		t.Fatalf("second step called %d times, err %v", calls, err)
+	}
+}
+
+func TestCallbackOrdering(t *testing.T) {
+	var order []string
+	ok := &alchemy.ResponseHandler[int]{}
+	ok.Success(func(int) { order = append(order, "success") }).
+		Error(func(error) { order = append(order, "error") }).
+		Finally(func() { order = append(order, "finally") })
+
+	failed := errorHandler[int](errors.New("x"))
+	failed.Finally(func() { order = append(order, "finally first") }).
+		Success(func(int) { order = append(order, "success") }).
+		Error(func(error) { order = append(order, "error") })
+
+	want := []string{"success", "finally", "finally first", "error"}
+	if len(order) != len(want) {
+		t.Fatalf("order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestPanicInSuccessBecomesError(t *testing.T) {
+	ok := &alchemy.ResponseHandler[int]{}
+
+	var seen error
+	finally := false
+	ok.Success(func(int) { panic("kaboom") }).
+		Error(func(err error) { seen = err }).
+		Finally(func() { finally = true })
+
+	var panicErr *alchemy.CallbackPanicError
+	if !errors.As(seen, &panicErr) || panicErr.Value != "kaboom" || len(panicErr.Stack) == 0 {
+		t.Fatalf("Error callback got %v, want a *CallbackPanicError with a stack", seen)
+	}
+	if !finally {
+		t.Fatal("Finally not called after a panic")
+	}
+	if _, err := ok.Result(); !errors.Is(err, alchemy.ErrCallbackPanic) {
+		t.Fatalf("Result err = %v", err)
+	}
+}
+
+func TestPanicInErrorAndFinally(t *testing.T) {
+	errCause := errors.New("cause")
+	failed := errorHandler[int](errors.New("original"))
+	failed.Error(func(error) { panic(errCause) })
+	if err := failed.Err(); !errors.Is(err, alchemy.ErrCallbackPanic) || !errors.Is(err, errCause) {
+		t.Fatalf("err = %v, want the panic wrapping its error value", err)
+	}
+
+	ok := &alchemy.ResponseHandler[int]{}
+	ok.Finally(func() { panic("in finally") })
+	var panicErr *alchemy.CallbackPanicError
+	if !errors.As(ok.Err(), &panicErr) || panicErr.Value != "in finally" {
+		t.Fatalf("err = %v", ok.Err())
+	}
+}
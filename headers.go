@@ -0,0 +1,53 @@
+package alchemy
+
+import (
+	"net/http"
+)
+
+// Version is the SDK version reported in the default User-Agent
+const Version = "0.1.0"
+
+const defaultUserAgent = "alchemy-chain-go-sdk/" + Version
+
+// ConfigHeader sends header name with value on every HTTP and WebSocket request, e.g. a
+// tenant routing header. An empty value removes the header. Authentication headers
+// configured with ConfigBearerToken/ConfigAPIKeyHeader take precedence.
+func ConfigHeader(name, value string) {
+	defaultClient.setHeader(name, value)
+}
+
+// ConfigUserAgent overrides the default "alchemy-chain-go-sdk/<Version>" User-Agent.
+// An empty value restores the default.
+func ConfigUserAgent(ua string) {
+	c := defaultClient
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+	if ua == "" {
+		ua = defaultUserAgent
+	}
+	c.userAgent = ua
+}
+
+// Internal method: set or remove a static header
+func (c *Client) setHeader(name, value string) {
+	c.headersMu.Lock()
+	defer c.headersMu.Unlock()
+	name = http.CanonicalHeaderKey(name)
+	if value == "" {
+		delete(c.staticHeaders, name)
+		return
+	}
+	c.staticHeaders[name] = value
+}
+
+// Internal method: add User-Agent, static headers and credentials to header
+func (c *Client) applyHeaders(header http.Header) error {
+	c.headersMu.RLock()
+	header.Set("User-Agent", c.userAgent)
+	for name, value := range c.staticHeaders {
+		header.Set(name, value)
+	}
+	c.headersMu.RUnlock()
+
+	return c.applyAuth(header)
+}
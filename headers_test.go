@@ -0,0 +1,59 @@
+package alchemy_test
+
+import (
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestHeadersAndUserAgentOnBothEndpoints(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithHeaders(map[string]string{"X-Tenant": "acme"}))
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := map[string]bool{}
+	for _, req := range srv.Requests() {
+		paths[req.Path] = true
+		if got := req.Header.Get("User-Agent"); got != "alchemy-chain-go-sdk/"+alchemy.Version {
+			t.Fatalf("%s %s: User-Agent = %q", req.Path, req.Method, got)
+		}
+		if got := req.Header.Get("X-Tenant"); got != "acme" {
+			t.Fatalf("%s %s: X-Tenant = %q", req.Path, req.Method, got)
+		}
+	}
+	if !paths["/"] || !paths["/rpc"] {
+		t.Fatalf("requests only reached %v", paths)
+	}
+}
+
+func TestUserAgentOverride(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithUserAgent("payouts/2.1"))
+
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := srv.RequestsFor("eth_getBalance")[0].Header.Get("User-Agent"); got != "payouts/2.1" {
+		t.Fatalf("User-Agent = %q", got)
+	}
+}
+
+func TestHeadersDoNotLeakAcrossClients(t *testing.T) {
+	srv, tenantA, _ := newTestServer(t, alchemy.WithHeaders(map[string]string{"X-Tenant": "a"}))
+	tenantB, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tenantA.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tenantB.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("eth_getBalance")
+	if reqs[0].Header.Get("X-Tenant") != "a" || reqs[1].Header.Get("X-Tenant") != "" {
+		t.Fatalf("X-Tenant %q then %q", reqs[0].Header.Get("X-Tenant"), reqs[1].Header.Get("X-Tenant"))
+	}
+}
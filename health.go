@@ -0,0 +1,96 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultPingTimeout bounds each Ping/HealthCheck leg when ctx has no earlier deadline
+const DefaultPingTimeout = 5 * time.Second
+
+// Health check failures, one per endpoint
+var (
+	ErrNodeUnavailable    = errors.New("node unavailable")
+	ErrServiceUnavailable = errors.New("rpc service unavailable")
+)
+
+// HealthReport is the result of HealthCheck. A nil error means the leg is healthy.
+type HealthReport struct {
+	NodeErr        error
+	NodeLatency    time.Duration
+	BlockNumber    int64 // latest block reported by the node
+	ServiceErr     error
+	ServiceLatency time.Duration
+}
+
+// Healthy reports whether both the node and the /rpc service are reachable
+func (h *HealthReport) Healthy() bool {
+	return h.NodeErr == nil && h.ServiceErr == nil
+}
+
+// Err joins the failed legs, nil when healthy
+func (h *HealthReport) Err() error {
+	return errors.Join(h.NodeErr, h.ServiceErr)
+}
+
+// HealthCheck checks the node (eth_blockNumber) and the /rpc service (get_server_info) in
+// parallel, each bounded by DefaultPingTimeout. The service counts as healthy when it answers
+// with a well-formed JSON-RPC response, even an error one (e.g. method not found on older
+// servers). No private key is required.
+func (c *Client) HealthCheck(ctx context.Context) *HealthReport {
+	report := &HealthReport{}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		start := time.Now()
+		result, err := c.pingEndpoint(ctx, c.nodeEndpoint(), "eth_blockNumber", []interface{}{}, true)
+		report.NodeLatency = time.Since(start)
+		if err == nil {
+			var hex string
+			var number uint64
+			if err = json.Unmarshal(result, &hex); err == nil {
+				number, err = parseHexQuantity(hex)
+			}
+			report.BlockNumber = int64(number)
+		}
+		if err != nil {
+			report.NodeErr = fmt.Errorf("%w: %w", ErrNodeUnavailable, err)
+		}
+	}()
+
+	start := time.Now()
+	_, err := c.pingEndpoint(ctx, c.serviceEndpoint(), "get_server_info", map[string]interface{}{}, false)
+	report.ServiceLatency = time.Since(start)
+	if err != nil {
+		report.ServiceErr = fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+	}
+
+	<-done
+	return report
+}
+
+// Ping returns nil when both the node and the /rpc service are reachable, otherwise an
+// error wrapping ErrNodeUnavailable and/or ErrServiceUnavailable
+func (c *Client) Ping(ctx context.Context) (err error) {
+	defer withOperationErr(&err, "Ping", "")
+	return c.HealthCheck(ctx).Err()
+}
+
+// Internal method: issue a single JSON-RPC request with a short timeout, without retries.
+// With requireResult a JSON-RPC error response is a failure; otherwise any well-formed
+// response is accepted.
+func (c *Client) pingEndpoint(ctx context.Context, url, method string, params interface{}, requireResult bool) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultPingTimeout)
+	defer cancel()
+
+	result, err := c.transport.Call(ctx, url, method, params)
+	var rpcErr *RPCError
+	if err != nil && !requireResult && errors.As(err, &rpcErr) {
+		return nil, nil
+	}
+	return result, err
+}
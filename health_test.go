@@ -0,0 +1,100 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// deadURL returns the address of a server that has been shut down
+func deadURL(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	return srv.URL
+}
+
+func TestHealthCheckBothUp(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetBlockNumber(42)
+	client, err := srv.NewClient() // no key configured
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.HealthCheck(context.Background())
+	if !report.Healthy() || report.Err() != nil || report.BlockNumber != 42 {
+		t.Fatalf("report %+v", report)
+	}
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHealthCheckServiceDown(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(deadURL(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.HealthCheck(context.Background())
+	if report.NodeErr != nil || !errors.Is(report.ServiceErr, alchemy.ErrServiceUnavailable) {
+		t.Fatalf("node %v, service %v", report.NodeErr, report.ServiceErr)
+	}
+	err = client.Ping(context.Background())
+	if !errors.Is(err, alchemy.ErrServiceUnavailable) || errors.Is(err, alchemy.ErrNodeUnavailable) {
+		t.Fatalf("Ping = %v", err)
+	}
+}
+
+func TestHealthCheckNodeDown(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := alchemy.NewClient(deadURL(t), alchemy.WithServiceURL(srv.URL+"/rpc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.HealthCheck(context.Background())
+	if report.ServiceErr != nil || !errors.Is(report.NodeErr, alchemy.ErrNodeUnavailable) {
+		t.Fatalf("node %v, service %v", report.NodeErr, report.ServiceErr)
+	}
+	if err := client.Ping(context.Background()); errors.Is(err, alchemy.ErrServiceUnavailable) {
+		t.Fatalf("Ping = %v blames the service", err)
+	}
+}
+
+func TestHealthCheckServiceAnswers(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Older servers without get_server_info still answer
+	srv.SetError("get_server_info", alchemytest.CodeMethodNotFound, "method not found")
+	if report := client.HealthCheck(context.Background()); report.ServiceErr != nil {
+		t.Fatalf("method not found counted as down: %v", report.ServiceErr)
+	}
+
+	// A gateway error page is not an answer from the service
+	srv.SetResponse("get_server_info", alchemytest.Response{Status: http.StatusBadGateway})
+	if report := client.HealthCheck(context.Background()); !errors.Is(report.ServiceErr, alchemy.ErrServiceUnavailable) {
+		t.Fatalf("service error %v, want ErrServiceUnavailable", report.ServiceErr)
+	}
+
+	// A node error response is a failure
+	srv.SetError("eth_blockNumber", -32000, "syncing")
+	if report := client.HealthCheck(context.Background()); !errors.Is(report.NodeErr, alchemy.ErrNodeUnavailable) {
+		t.Fatalf("node error %v, want ErrNodeUnavailable", report.NodeErr)
+	}
+}
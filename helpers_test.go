@@ -0,0 +1,76 @@
+package alchemy_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strconv"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	testToken     = "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	testRecipient = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+)
+
+// newTestServer starts a fake server that only accepts requests signed by a fresh key, and
+// a client signing with that key
+func newTestServer(t *testing.T, opts ...alchemy.Option) (*alchemytest.Server, *alchemy.Client, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.RegisterKey(&key.PublicKey)
+
+	client, err := srv.NewClient(append([]alchemy.Option{alchemy.WithKey(key)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client, key
+}
+
+// useDefaultClient makes c the default client for the duration of the test
+func useDefaultClient(t *testing.T, c *alchemy.Client) {
+	t.Helper()
+	previous := alchemy.DefaultClient()
+	alchemy.SetDefaultClient(c)
+	t.Cleanup(func() { alchemy.SetDefaultClient(previous) })
+}
+
+// errorHandler is a completed call that failed with err
+func errorHandler[T any](err error) *alchemy.ResponseHandler[T] {
+	return alchemy.Map(&alchemy.ResponseHandler[struct{}]{}, func(struct{}) (T, error) {
+		var zero T
+		return zero, err
+	})
+}
+
+// recoverPayload recovers the checksummed signer address of a signed payload
+func recoverPayload(t *testing.T, payload *alchemy.SignedPayload) string {
+	t.Helper()
+	r, okR := new(big.Int).SetString(payload.Signature.R, 10)
+	s, okS := new(big.Int).SetString(payload.Signature.S, 10)
+	v, err := strconv.ParseUint(payload.Signature.V, 10, 8)
+	if !okR || !okS || err != nil {
+		t.Fatalf("malformed signature %+v", payload.Signature)
+	}
+	if v >= 27 {
+		v -= 27
+	}
+	compact := make([]byte, 65)
+	r.FillBytes(compact[:32])
+	s.FillBytes(compact[32:64])
+	compact[64] = byte(v)
+
+	pub, err := crypto.SigToPub(crypto.Keccak256([]byte(payload.Message)), compact)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex()
+}
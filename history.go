@@ -0,0 +1,121 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Token operation names as reported in transaction history
+const (
+	OpCreateToken     = "createToken"
+	OpMint            = "mint"
+	OpBurn            = "burn"
+	OpAdminBurn       = "adminBurn"
+	OpSeize           = "seize"
+	OpTransfer        = "transfer"
+	OpGrantAuthority  = "grantAuthority"
+	OpRevokeAuthority = "revokeAuthority"
+	OpUpdateMetadata  = "updateMetadata"
+	OpPause           = "pause"
+	OpUnpause         = "unpause"
+	OpAddToBlacklist  = "addToBlacklist"
+)
+
+// TxFilter selects transaction history entries, zero fields don't filter
+type TxFilter struct {
+	Token     string    // token contract address
+	Account   string    // account involved as sender or counterparty
+	Operation string    // e.g. OpMint
+	FromBlock int64     // inclusive
+	ToBlock   int64     // inclusive
+	FromTime  time.Time // inclusive
+	ToTime    time.Time // inclusive
+	Cursor    string    // empty for the first page, TxPage.NextCursor afterwards
+	Limit     int       // 0 uses the server default
+}
+
+// TxRecord is one token operation from the transaction history
+type TxRecord struct {
+	Hash        string `json:"hash"`
+	BlockNumber int64  `json:"blockNumber"`
+	Timestamp   int64  `json:"timestamp"` // unix seconds
+	Token       string `json:"token"`
+	Operation   string `json:"operation"`
+	Signer      string `json:"signer"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+	Amount      string `json:"amount,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+// Time returns the record timestamp as time.Time
+func (r TxRecord) Time() time.Time {
+	return time.Unix(r.Timestamp, 0).UTC()
+}
+
+// TxPage is one page of transaction history
+type TxPage struct {
+	Transactions []TxRecord `json:"transactions"`
+	NextCursor   string     `json:"nextCursor"` // empty on the last page
+}
+
+// HasMore reports whether another page can be fetched with NextCursor
+func (p *TxPage) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// GetTransactions queries the token operation history, e.g. all mints to an address
+func (c *Client) GetTransactions(filter TxFilter) (r *ResponseHandler[*TxPage]) {
+	defer withOperation(&r, "GetTransactions", "")
+	if filter.Limit < 0 {
+		return &ResponseHandler[*TxPage]{err: fmt.Errorf("invalid limit %d", filter.Limit)}
+	}
+	if filter.ToBlock != 0 && filter.ToBlock < filter.FromBlock {
+		return &ResponseHandler[*TxPage]{err: fmt.Errorf("invalid block range %d-%d", filter.FromBlock, filter.ToBlock)}
+	}
+
+	params := map[string]interface{}{}
+	if filter.Token != "" {
+		params["token"] = filter.Token
+	}
+	if filter.Account != "" {
+		params["account"] = filter.Account
+	}
+	if filter.Operation != "" {
+		params["operation"] = filter.Operation
+	}
+	if filter.FromBlock != 0 {
+		params["fromBlock"] = filter.FromBlock
+	}
+	if filter.ToBlock != 0 {
+		params["toBlock"] = filter.ToBlock
+	}
+	if !filter.FromTime.IsZero() {
+		params["fromTime"] = filter.FromTime.Unix()
+	}
+	if !filter.ToTime.IsZero() {
+		params["toTime"] = filter.ToTime.Unix()
+	}
+	if filter.Cursor != "" {
+		params["cursor"] = filter.Cursor
+	}
+	if filter.Limit > 0 {
+		params["limit"] = filter.Limit
+	}
+
+	result, err := c.rpcCall("get_transactions", params)
+	if err != nil {
+		return &ResponseHandler[*TxPage]{err: err}
+	}
+
+	var page TxPage
+	if err := json.Unmarshal(result, &page); err != nil {
+		return &ResponseHandler[*TxPage]{err: err}
+	}
+	if page.Transactions == nil {
+		page.Transactions = []TxRecord{}
+	}
+
+	return &ResponseHandler[*TxPage]{data: &page}
+}
@@ -0,0 +1,100 @@
+package alchemy_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestGetTransactionsFilter(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_transactions", map[string]interface{}{
+		"transactions": []map[string]interface{}{{
+			"hash": "0x01", "blockNumber": 12, "timestamp": 1700000000, "token": testToken,
+			"operation": "mint", "signer": testRecipient, "to": testRecipient, "amount": "500",
+		}},
+		"nextCursor": "next",
+	})
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	page, err := client.GetTransactions(alchemy.TxFilter{
+		Token: testToken, Account: testRecipient, Operation: alchemy.OpMint,
+		FromBlock: 10, ToBlock: 20, FromTime: from, Limit: 50,
+	}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page.HasMore() || len(page.Transactions) != 1 {
+		t.Fatalf("got %+v", page)
+	}
+	record := page.Transactions[0]
+	if record.Operation != alchemy.OpMint || record.To != testRecipient || record.Amount != "500" || record.BlockNumber != 12 {
+		t.Fatalf("record %+v", record)
+	}
+	if !record.Time().Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("time %v", record.Time())
+	}
+
+	params := srv.RequestsFor("get_transactions")[0].ParamMap
+	want := map[string]string{
+		"token": testToken, "account": testRecipient, "operation": "mint",
+		"fromBlock": "10", "toBlock": "20", "fromTime": fmt.Sprint(from.Unix()), "limit": "50",
+	}
+	if len(params) != len(want) {
+		t.Fatalf("params = %v", params)
+	}
+	for name, value := range want {
+		if fmt.Sprint(params[name]) != value {
+			t.Fatalf("%s = %v, want %s", name, params[name], value)
+		}
+	}
+}
+
+func TestGetTransactionsPages(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("get_transactions",
+		alchemytest.Response{Result: map[string]interface{}{"transactions": []map[string]interface{}{{"hash": "0x01"}}, "nextCursor": "p2"}},
+		alchemytest.Response{Result: map[string]interface{}{"transactions": nil, "nextCursor": ""}},
+	)
+
+	filter := alchemy.TxFilter{Account: testRecipient}
+	var hashes []string
+	for {
+		page, err := client.GetTransactions(filter).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range page.Transactions {
+			hashes = append(hashes, record.Hash)
+		}
+		if !page.HasMore() {
+			if page.Transactions == nil {
+				t.Fatal("nil transactions on the last page")
+			}
+			break
+		}
+		filter.Cursor = page.NextCursor
+	}
+	if fmt.Sprint(hashes) != "[0x01]" {
+		t.Fatalf("hashes %v", hashes)
+	}
+	if cursor := srv.RequestsFor("get_transactions")[1].ParamMap["cursor"]; cursor != "p2" {
+		t.Fatalf("second page cursor = %v", cursor)
+	}
+}
+
+func TestGetTransactionsValidation(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	for _, filter := range []alchemy.TxFilter{{Limit: -1}, {FromBlock: 20, ToBlock: 10}} {
+		if err := client.GetTransactions(filter).Err(); err == nil {
+			t.Errorf("filter %+v accepted", filter)
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}
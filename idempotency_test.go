@@ -0,0 +1,98 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+const testIdempotencyKey = "3f1c2a9e-8b7d-4c6e-9f0a-1b2c3d4e5f60"
+
+// TestIdempotencyKeyMessageFormat pins where the key lands in the sorted-key message
+func TestIdempotencyKeyMessageFormat(t *testing.T) {
+	got, err := alchemy.SignedMessage(map[string]interface{}{
+		"idempotencyKey":   testIdempotencyKey,
+		"methodArgs":       []interface{}{testRecipient, "1000"},
+		"nonce":            int64(7),
+		"recentCheckpoint": int64(12345),
+		"token":            testToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := testIdempotencyKey + "," + testRecipient + ",1000,7,12345," + testToken; got != want {
+		t.Fatalf("message %q, want %q", got, want)
+	}
+}
+
+func TestIdempotencyKeySigned(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	tx, err := client.Mint(testToken, testRecipient, "1000", 7, alchemy.WithIdempotencyKey(testIdempotencyKey)).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.IdempotencyKey != testIdempotencyKey {
+		t.Fatalf("result key %q", tx.IdempotencyKey)
+	}
+	req := srv.RequestsFor("mint")[0]
+	if req.ParamMap["idempotencyKey"] != testIdempotencyKey || req.SignatureErr != nil {
+		t.Fatalf("key %v, signature error %v", req.ParamMap["idempotencyKey"], req.SignatureErr)
+	}
+
+	// A request whose key was swapped in transit no longer verifies
+	tampered := req.ParamMap
+	tampered["idempotencyKey"] = alchemy.NewIdempotencyKey()
+	srv.Reset()
+	body, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": "mint", "params": tampered})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(srv.URL+"/rpc", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var reply struct {
+		Error *alchemytest.RPCError `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Error == nil || reply.Error.Code != alchemytest.CodeInvalidSignature {
+		t.Fatalf("tampered key accepted: %+v", reply.Error)
+	}
+}
+
+func TestGeneratedIdempotencyKey(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	uuidV4 := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	first, err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithGeneratedIdempotencyKey()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.Mint(testToken, testRecipient, "1", 1, alchemy.WithGeneratedIdempotencyKey()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !uuidV4.MatchString(first.IdempotencyKey) || first.IdempotencyKey == second.IdempotencyKey {
+		t.Fatalf("keys %q and %q", first.IdempotencyKey, second.IdempotencyKey)
+	}
+	if sent := srv.RequestsFor("mint")[0].ParamMap["idempotencyKey"]; sent != first.IdempotencyKey {
+		t.Fatalf("sent %v, result has %q", sent, first.IdempotencyKey)
+	}
+
+	// Without a key nothing is sent
+	if _, err := client.Mint(testToken, testRecipient, "1", 2).Result(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.RequestsFor("mint")[2].ParamMap["idempotencyKey"]; ok {
+		t.Fatal("key sent without the option")
+	}
+}
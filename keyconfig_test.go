@@ -0,0 +1,107 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestKeyConfigurationPathsSignIdentically(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hexKey := hex.EncodeToString(crypto.FromECDSA(key))
+
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.RegisterKey(&key.PublicKey)
+
+	// Each path sends the same mint; the server records what it received
+	paths := []struct {
+		name string
+		mint func() error
+	}{
+		{"WithPrivateKey", func() error { return mintWith(t, srv, alchemy.WithPrivateKey(hexKey)) }},
+		{"WithPrivateKey 0x", func() error { return mintWith(t, srv, alchemy.WithPrivateKey("0x"+hexKey)) }},
+		{"WithKey", func() error { return mintWith(t, srv, alchemy.WithKey(key)) }},
+		{"Config", func() error {
+			useFreshDefaultClient(t, srv)
+			alchemy.Config(srv.URL, hexKey)
+			return alchemy.Mint(testToken, testRecipient, "1", 9).Err()
+		}},
+		{"ConfigWithKey", func() error {
+			useFreshDefaultClient(t, srv)
+			alchemy.ConfigWithKey(srv.URL, key)
+			return alchemy.Mint(testToken, testRecipient, "1", 9).Err()
+		}},
+	}
+	for _, path := range paths {
+		if err := path.mint(); err != nil {
+			t.Fatalf("%s: %v", path.name, err)
+		}
+	}
+
+	mints := srv.RequestsFor("mint")
+	if len(mints) != len(paths) {
+		t.Fatalf("%d mints, want %d", len(mints), len(paths))
+	}
+	for i, req := range mints {
+		if req.SignatureErr != nil {
+			t.Fatalf("%s: %v", paths[i].name, req.SignatureErr)
+		}
+		if req.Signer != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+			t.Fatalf("%s signed by %s", paths[i].name, req.Signer)
+		}
+		if !bytes.Equal(req.Params, mints[0].Params) {
+			t.Fatalf("%s payload differs:\n%s\n%s", paths[i].name, req.Params, mints[0].Params)
+		}
+	}
+}
+
+// mintWith sends a fixed mint through a new client configured with opt
+func mintWith(t *testing.T, srv *alchemytest.Server, opt alchemy.Option) error {
+	t.Helper()
+	client, err := srv.NewClient(opt)
+	if err != nil {
+		return err
+	}
+	return client.Mint(testToken, testRecipient, "1", 9).Err()
+}
+
+func TestParsedKeyReplacesHexKey(t *testing.T) {
+	first, _ := crypto.GenerateKey()
+	second, _ := crypto.GenerateKey()
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	useFreshDefaultClient(t, srv)
+	alchemy.Config(srv.URL, hex.EncodeToString(crypto.FromECDSA(first)))
+	alchemy.ConfigWithKey(srv.URL, second)
+	if err := alchemy.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	alchemy.Config(srv.URL, hex.EncodeToString(crypto.FromECDSA(first)))
+	if err := alchemy.Mint(testToken, testRecipient, "1", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	mints := srv.RequestsFor("mint")
+	if mints[0].Signer != crypto.PubkeyToAddress(second.PublicKey).Hex() || mints[1].Signer != crypto.PubkeyToAddress(first.PublicKey).Hex() {
+		t.Fatalf("signers %s, %s", mints[0].Signer, mints[1].Signer)
+	}
+}
+
+// useFreshDefaultClient makes an unconfigured client for srv the default client
+func useFreshDefaultClient(t *testing.T, srv *alchemytest.Server) {
+	t.Helper()
+	client, err := srv.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	useDefaultClient(t, client)
+}
@@ -0,0 +1,154 @@
+package ledgersigner
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"sync"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// FakeDevice is an in-memory Ledger running the Ethereum app, for tests: it answers the
+// address and personal message instructions with key, whatever the path, and the user
+// confirms every signature unless RejectNext, Lock, CloseApp or FailNext script otherwise.
+// It records the APDUs it received.
+type FakeDevice struct {
+	mu      sync.Mutex
+	key     *ecdsa.PrivateKey
+	locked  bool
+	closed  bool
+	reject  int
+	fail    []uint16
+	apdus   [][]byte
+	message []byte // personal message being received
+	length  int    // its announced length
+	signed  int
+}
+
+// NewFakeDevice returns an unlocked device with the Ethereum app open, holding key
+func NewFakeDevice(key *ecdsa.PrivateKey) *FakeDevice {
+	return &FakeDevice{key: key}
+}
+
+// Lock locks or unlocks the device; a locked device answers every command with the
+// locked status
+func (d *FakeDevice) Lock(locked bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.locked = locked
+}
+
+// CloseApp leaves the Ethereum app for the dashboard (closed true) or opens it again
+func (d *FakeDevice) CloseApp(closed bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.closed = closed
+}
+
+// RejectNext makes the user reject the next signature on the device
+func (d *FakeDevice) RejectNext() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reject++
+}
+
+// FailNext answers the next exchange with status instead of running it
+func (d *FakeDevice) FailNext(status uint16) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fail = append(d.fail, status)
+}
+
+// Confirmations returns the number of signatures the user confirmed
+func (d *FakeDevice) Confirmations() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.signed
+}
+
+// APDUs returns copies of the command APDUs received, oldest first
+func (d *FakeDevice) APDUs() [][]byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	apdus := make([][]byte, len(d.apdus))
+	for i, apdu := range d.apdus {
+		apdus[i] = bytes.Clone(apdu)
+	}
+	return apdus
+}
+
+// Exchange implements Device
+func (d *FakeDevice) Exchange(apdu []byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.apdus = append(d.apdus, bytes.Clone(apdu))
+
+	switch {
+	case len(d.fail) > 0:
+		status := d.fail[0]
+		d.fail = d.fail[1:]
+		return withStatus(nil, status), nil
+	case d.locked:
+		return withStatus(nil, statusLocked), nil
+	case d.closed:
+		return withStatus(nil, statusNoCLA), nil
+	case len(apdu) < 5 || int(apdu[4]) != len(apdu)-5:
+		return withStatus(nil, 0x6700), nil // wrong length
+	case apdu[0] != claEthereum:
+		return withStatus(nil, statusNoCLA), nil
+	}
+	p1, data := apdu[2], apdu[5:]
+
+	switch apdu[1] {
+	case insGetAddress:
+		if _, ok := skipPath(data); !ok {
+			return withStatus(nil, 0x6A80), nil
+		}
+		pubkey := crypto.FromECDSAPub(&d.key.PublicKey)
+		address := crypto.PubkeyToAddress(d.key.PublicKey).Hex()[2:]
+		reply := append([]byte{byte(len(pubkey))}, pubkey...)
+		reply = append(reply, byte(len(address)))
+		return withStatus(append(reply, address...), statusOK), nil
+
+	case insSignPersonal:
+		if p1 == p1FirstChunk {
+			rest, ok := skipPath(data)
+			if !ok || len(rest) < 4 {
+				return withStatus(nil, 0x6A80), nil
+			}
+			d.length, d.message = int(binary.BigEndian.Uint32(rest)), bytes.Clone(rest[4:])
+		} else {
+			d.message = append(d.message, data...)
+		}
+		if len(d.message) < d.length {
+			return withStatus(nil, statusOK), nil
+		}
+		if d.reject > 0 {
+			d.reject--
+			return withStatus(nil, statusRejected), nil
+		}
+		signature, err := crypto.Sign(alchemy.PersonalDigest(d.message), d.key)
+		if err != nil {
+			return nil, err
+		}
+		d.signed++
+		reply := append([]byte{signature[64] + 27}, signature[:64]...)
+		return withStatus(reply, statusOK), nil
+	}
+	return withStatus(nil, statusNoINS), nil
+}
+
+// Internal method: data after a leading derivation path, false if malformed
+func skipPath(data []byte) ([]byte, bool) {
+	if len(data) < 1 || len(data) < 1+4*int(data[0]) {
+		return nil, false
+	}
+	return data[1+4*int(data[0]):], true
+}
+
+// Internal method: reply followed by the status word
+func withStatus(reply []byte, status uint16) []byte {
+	return binary.BigEndian.AppendUint16(reply, status)
+}
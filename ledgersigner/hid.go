@@ -0,0 +1,139 @@
+package ledgersigner
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Ledger HID framing
+const (
+	hidPacketSize = 64
+	hidChannel    = 0x0101
+	hidTagAPDU    = 0x05
+	ledgerVendor  = "00002C97"
+)
+
+// HIDDevice is a Device over the USB HID interface of a Ledger: APDUs are split into
+// 64-byte reports with the device's channel, tag and sequence framing
+type HIDDevice struct {
+	mu       sync.Mutex
+	rw       io.ReadWriteCloser
+	reportID bool // prefix written reports with report ID 0, as hidraw expects
+}
+
+// NewHIDDevice returns a Device speaking the Ledger HID framing over rw, a handle of the
+// device's APDU interface from any HID library whose writes and reads are single
+// 64-byte reports without report ID
+func NewHIDDevice(rw io.ReadWriteCloser) *HIDDevice {
+	return &HIDDevice{rw: rw}
+}
+
+// OpenHIDRaw opens a Ledger through the Linux hidraw device at path, e.g. "/dev/hidraw3",
+// or the first connected Ledger if path is "". The user needs read and write access to
+// the device file (usually a udev rule for vendor 2c97).
+func OpenHIDRaw(path string) (*HIDDevice, error) {
+	if path == "" {
+		found, err := findHIDRaw("/sys/class/hidraw")
+		if err != nil {
+			return nil, err
+		}
+		path = found
+	}
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open ledger: %w", err)
+	}
+	return &HIDDevice{rw: file, reportID: true}, nil
+}
+
+// Exchange implements Device
+func (d *HIDDevice) Exchange(apdu []byte) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(apdu) > 0xFFFF {
+		return nil, fmt.Errorf("apdu of %d bytes", len(apdu))
+	}
+
+	payload := binary.BigEndian.AppendUint16(nil, uint16(len(apdu)))
+	payload = append(payload, apdu...)
+	for seq := uint16(0); len(payload) > 0; seq++ {
+		packet := make([]byte, 0, hidPacketSize+1)
+		if d.reportID {
+			packet = append(packet, 0x00)
+		}
+		size := len(packet) + hidPacketSize
+		packet = binary.BigEndian.AppendUint16(packet, hidChannel)
+		packet = append(packet, hidTagAPDU)
+		packet = binary.BigEndian.AppendUint16(packet, seq)
+		n := min(len(payload), size-len(packet))
+		packet = append(packet, payload[:n]...)
+		payload = payload[n:]
+		packet = packet[:size] // zero padded
+		if _, err := d.rw.Write(packet); err != nil {
+			return nil, fmt.Errorf("write to ledger: %w", err)
+		}
+	}
+
+	var reply []byte
+	length := -1
+	for seq := uint16(0); length < 0 || len(reply) < length; seq++ {
+		packet := make([]byte, hidPacketSize)
+		n, err := d.rw.Read(packet)
+		if err != nil {
+			return nil, fmt.Errorf("read from ledger: %w", err)
+		}
+		packet = packet[:n]
+		if len(packet) < 5 || binary.BigEndian.Uint16(packet) != hidChannel || packet[2] != hidTagAPDU ||
+			binary.BigEndian.Uint16(packet[3:]) != seq {
+			return nil, errors.New("read from ledger: malformed report")
+		}
+		data := packet[5:]
+		if seq == 0 {
+			if len(data) < 2 {
+				return nil, errors.New("read from ledger: malformed report")
+			}
+			length, data = int(binary.BigEndian.Uint16(data)), data[2:]
+		}
+		reply = append(reply, data[:min(len(data), length-len(reply))]...)
+	}
+	return reply, nil
+}
+
+// Close closes the device handle
+func (d *HIDDevice) Close() error {
+	return d.rw.Close()
+}
+
+// Internal method: the hidraw device of the APDU interface (interface 0) of the first
+// Ledger listed under root
+func findHIDRaw(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", fmt.Errorf("find ledger: %w", err)
+	}
+	for _, entry := range entries {
+		uevent, err := os.ReadFile(filepath.Join(root, entry.Name(), "device", "uevent"))
+		if err != nil {
+			continue
+		}
+		var vendor, iface0 bool
+		for _, line := range strings.Split(string(uevent), "\n") {
+			if id, ok := strings.CutPrefix(line, "HID_ID="); ok {
+				vendor = strings.Contains(strings.ToUpper(id), ":"+ledgerVendor+":")
+			}
+			if phys, ok := strings.CutPrefix(line, "HID_PHYS="); ok {
+				iface0 = strings.HasSuffix(phys, "/input0")
+			}
+		}
+		if vendor && iface0 {
+			return "/dev/" + entry.Name(), nil
+		}
+	}
+	return "", errors.New("find ledger: no Ledger connected")
+}
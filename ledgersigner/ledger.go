@@ -0,0 +1,252 @@
+// Package ledgersigner signs SDK requests with a key held on a Ledger hardware wallet,
+// through its Ethereum app. The key never leaves the device: every request is shown on the
+// device and signed only once the user confirms it.
+//
+//	device, err := ledgersigner.OpenHIDRaw("") // first Ledger found
+//	if err != nil { ... }
+//	defer device.Close()
+//	signer, err := ledgersigner.New(device, ledgersigner.DefaultPath)
+//	if err != nil { ... } // e.g. ledgersigner.ErrDeviceLocked
+//	client, err := alchemy.NewClient(endpoint, alchemy.WithSigner(signer))
+//
+// The Ethereum app only signs EIP-191 personal messages, so the request hash is signed as
+// one (see alchemy.PersonalSigner) and sent with "scheme": "personal".
+package ledgersigner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultPath is the derivation path of the first account of Ledger Live
+const DefaultPath = "m/44'/60'/0'/0/0"
+
+// Ethereum app instructions
+const (
+	claEthereum         = 0xE0
+	insGetAddress       = 0x02
+	insSignPersonal     = 0x08
+	p1FirstChunk        = 0x00
+	p1NextChunk         = 0x80
+	maxChunk            = 255
+	statusOK            = 0x9000
+	statusRejected      = 0x6985
+	statusLocked        = 0x5515
+	statusSecurity      = 0x6982
+	statusNoINS         = 0x6D00
+	statusNoCLA         = 0x6E00
+	statusNoApp         = 0x6E01
+	statusDashboard     = 0x6511
+	statusWrongAppState = 0x6807
+)
+
+var (
+	// ErrDeviceLocked is returned while the device waits for its PIN
+	ErrDeviceLocked = errors.New("ledger is locked: unlock it with the PIN")
+	// ErrAppNotOpen is returned when the device is on its dashboard or in another app
+	ErrAppNotOpen = errors.New("ledger Ethereum app is not open")
+	// ErrRejected is returned when the user rejected the request on the device
+	ErrRejected = errors.New("request rejected on the ledger")
+)
+
+// Device exchanges APDUs with a Ledger. Exchange sends one command APDU and returns the
+// response, status word included. OpenHIDRaw returns the USB HID implementation; tests can
+// use FakeDevice or their own script.
+type Device interface {
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// StatusError is a status word other than success returned by the device. Errors matches
+// the known causes: ErrDeviceLocked, ErrAppNotOpen and ErrRejected.
+type StatusError struct {
+	Instruction byte   // INS of the failed command
+	Status      uint16 // e.g. 0x6985
+}
+
+// Error implements error
+func (e *StatusError) Error() string {
+	if cause := e.Unwrap(); cause != nil {
+		return fmt.Sprintf("%v (status 0x%04x)", cause, e.Status)
+	}
+	return fmt.Sprintf("ledger instruction 0x%02x failed with status 0x%04x", e.Instruction, e.Status)
+}
+
+// Unwrap returns the known cause of the status word, nil for other statuses
+func (e *StatusError) Unwrap() error {
+	switch e.Status {
+	case statusLocked, statusSecurity:
+		return ErrDeviceLocked
+	case statusNoINS, statusNoCLA, statusNoApp, statusDashboard, statusWrongAppState:
+		return ErrAppNotOpen
+	case statusRejected:
+		return ErrRejected
+	}
+	return nil
+}
+
+// Signer signs with the key of one derivation path on a Ledger. It implements
+// alchemy.PersonalSigner. Requests are signed one at a time, as the device shows one at a
+// time.
+type Signer struct {
+	mu      sync.Mutex
+	device  Device
+	path    []uint32
+	address common.Address
+}
+
+// New returns a Signer for the key at path (e.g. DefaultPath) on device, reading its
+// address from the device without a confirmation.
+func New(device Device, path string) (*Signer, error) {
+	if device == nil {
+		return nil, errors.New("nil ledger device")
+	}
+	components, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Signer{device: device, path: components}
+
+	reply, err := s.exchange(insGetAddress, p1FirstChunk, encodePath(components))
+	if err != nil {
+		return nil, fmt.Errorf("read address: %w", err)
+	}
+	// [pubkey length][pubkey][address length][address as ASCII hex]
+	if len(reply) < 1 || len(reply) < 1+int(reply[0])+1 {
+		return nil, fmt.Errorf("read address: short reply of %d bytes", len(reply))
+	}
+	pubkey, rest := reply[1:1+int(reply[0])], reply[1+int(reply[0]):]
+	pub, err := crypto.UnmarshalPubkey(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("read address: %w", err)
+	}
+	s.address = crypto.PubkeyToAddress(*pub)
+	if len(rest) >= 1+int(rest[0]) {
+		reported := "0x" + strings.TrimPrefix(string(rest[1:1+int(rest[0])]), "0x")
+		if !strings.EqualFold(reported, s.address.Hex()) {
+			return nil, fmt.Errorf("read address: device reports %s for public key of %s", reported, s.address.Hex())
+		}
+	}
+	return s, nil
+}
+
+// Address implements alchemy.Signer
+func (s *Signer) Address() string {
+	return s.address.Hex()
+}
+
+// SignsPersonalMessages implements alchemy.PersonalSigner: the Ethereum app signs hash as
+// an EIP-191 personal message
+func (s *Signer) SignsPersonalMessages() bool {
+	return true
+}
+
+// Sign implements alchemy.Signer. It blocks until the user confirms or rejects the message
+// on the device; a rejection fails with ErrRejected.
+func (s *Signer) Sign(hash []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The first chunk carries the path and the message length
+	data := encodePath(s.path)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(hash)))
+	message := hash
+	var reply []byte
+	for p1 := byte(p1FirstChunk); ; p1 = p1NextChunk {
+		n := min(len(message), maxChunk-len(data))
+		data = append(data, message[:n]...)
+		message = message[n:]
+		var err error
+		if reply, err = s.exchange(insSignPersonal, p1, data); err != nil {
+			return nil, err
+		}
+		if len(message) == 0 {
+			break
+		}
+		data = nil
+	}
+
+	// [v][r][s] with v 27/28, into [r][s][v] with v 0/1
+	if len(reply) != 65 {
+		return nil, fmt.Errorf("sign: reply of %d bytes, want 65", len(reply))
+	}
+	v := reply[0]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, fmt.Errorf("sign: invalid v %d", reply[0])
+	}
+	signature := make([]byte, 0, 65)
+	signature = append(signature, reply[1:65]...)
+	signature = append(signature, v)
+
+	// A different key signing means a different device or app than New talked to
+	pub, err := crypto.SigToPub(alchemy.PersonalDigest(hash), signature)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*pub); signer != s.address {
+		return nil, fmt.Errorf("sign: device signed as %s, not %s", signer.Hex(), s.address.Hex())
+	}
+	return signature, nil
+}
+
+// ParsePath parses a BIP-32 derivation path such as "m/44'/60'/0'/0/0"; hardened
+// components are marked with ' or h
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/"), "/")
+	if path == "" || len(parts) > 10 {
+		return nil, fmt.Errorf("invalid derivation path %q", path)
+	}
+	components := make([]uint32, len(parts))
+	for i, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		n, err := strconv.ParseUint(strings.TrimRight(part, "'h"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: %w", path, err)
+		}
+		components[i] = uint32(n)
+		if hardened {
+			components[i] |= 0x80000000
+		}
+	}
+	return components, nil
+}
+
+// Internal method: send one command and return its reply without the status word
+func (s *Signer) exchange(ins, p1 byte, data []byte) ([]byte, error) {
+	if len(data) > maxChunk {
+		return nil, fmt.Errorf("apdu data of %d bytes", len(data))
+	}
+	apdu := append([]byte{claEthereum, ins, p1, 0x00, byte(len(data))}, data...)
+	reply, err := s.device.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, fmt.Errorf("ledger reply of %d bytes has no status word", len(reply))
+	}
+	status := binary.BigEndian.Uint16(reply[len(reply)-2:])
+	if status != statusOK {
+		return nil, &StatusError{Instruction: ins, Status: status}
+	}
+	return bytes.Clone(reply[:len(reply)-2]), nil
+}
+
+// Internal method: the path as the Ethereum app expects it, count then big-endian components
+func encodePath(path []uint32) []byte {
+	encoded := []byte{byte(len(path))}
+	for _, component := range path {
+		encoded = binary.BigEndian.AppendUint32(encoded, component)
+	}
+	return encoded
+}
@@ -0,0 +1,253 @@
+package ledgersigner_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/ledgersigner"
+)
+
+const (
+	token     = "0x1111111111111111111111111111111111111111"
+	newMaster = "0x2222222222222222222222222222222222222222"
+)
+
+// ledger returns a fake device with a fresh key, and the key
+func ledger(t *testing.T) (*ledgersigner.FakeDevice, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ledgersigner.NewFakeDevice(key), key
+}
+
+// ledgerClient returns a fake server accepting only the ledger's key and a client signing
+// with it
+func ledgerClient(t *testing.T, device ledgersigner.Device) (*alchemytest.Server, *alchemy.Client, *ledgersigner.Signer) {
+	t.Helper()
+	signer, err := ledgersigner.New(device, ledgersigner.DefaultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.RegisterAddress(signer.Address())
+	client, err := srv.NewClient(alchemy.WithSigner(signer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return srv, client, signer
+}
+
+func TestTransferMasterAuthority(t *testing.T) {
+	device, key := ledger(t)
+	srv, client, signer := ledgerClient(t, device)
+	if signer.Address() != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+		t.Fatalf("address %s", signer.Address())
+	}
+
+	result, err := client.TransferMasterAuthorityIrreversibly(token, newMaster, 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hash == "" || device.Confirmations() != 1 {
+		t.Fatalf("result %+v after %d confirmations", result, device.Confirmations())
+	}
+	reqs := srv.RequestsFor("transferMasterAuthority")
+	if len(reqs) != 1 || reqs[0].SignatureErr != nil || reqs[0].Signer != signer.Address() ||
+		reqs[0].Signature.Scheme != alchemy.SignatureSchemePersonal {
+		t.Fatalf("requests %+v, want one personal signature of %s", reqs, signer.Address())
+	}
+
+	// The key check of Verify recovers the personal signature too
+	if check := client.Verify(context.Background()).Check(alchemy.CheckKey); check.Status != alchemy.CheckPassed {
+		t.Fatalf("key check %+v", check)
+	}
+}
+
+func TestSignerErrors(t *testing.T) {
+	device, _ := ledger(t)
+
+	device.Lock(true)
+	if _, err := ledgersigner.New(device, ledgersigner.DefaultPath); !errors.Is(err, ledgersigner.ErrDeviceLocked) {
+		t.Fatalf("locked: %v", err)
+	}
+	device.Lock(false)
+	device.CloseApp(true)
+	if _, err := ledgersigner.New(device, ledgersigner.DefaultPath); !errors.Is(err, ledgersigner.ErrAppNotOpen) {
+		t.Fatalf("dashboard: %v", err)
+	}
+	device.CloseApp(false)
+
+	srv, client, _ := ledgerClient(t, device)
+	device.RejectNext()
+	err := client.TransferMasterAuthorityIrreversibly(token, newMaster, 0).Err()
+	var status *ledgersigner.StatusError
+	if !errors.Is(err, ledgersigner.ErrRejected) || !errors.As(err, &status) || status.Status != 0x6985 {
+		t.Fatalf("rejected: %v", err)
+	}
+	var opErr *alchemy.OperationError
+	if !errors.As(err, &opErr) || opErr.Op != "TransferMasterAuthorityIrreversibly" {
+		t.Fatalf("rejected: %v, want an OperationError", err)
+	}
+	if n := len(srv.RequestsFor("transferMasterAuthority")); n != 0 {
+		t.Fatalf("%d rejected requests sent", n)
+	}
+
+	// Locked or closed after New
+	for _, tt := range []struct {
+		setup func()
+		want  error
+	}{
+		{func() { device.Lock(true) }, ledgersigner.ErrDeviceLocked},
+		{func() { device.CloseApp(true) }, ledgersigner.ErrAppNotOpen},
+		{func() { device.FailNext(0x6A80) }, nil},
+	} {
+		tt.setup()
+		_, err := client.Mint(token, newMaster, "1", 0).Result()
+		if tt.want != nil && !errors.Is(err, tt.want) || !errors.As(err, &status) {
+			t.Fatalf("err = %v, want %v", err, tt.want)
+		}
+		device.Lock(false)
+		device.CloseApp(false)
+	}
+	if err := client.Mint(token, newMaster, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignerChecksDevice(t *testing.T) {
+	device, _ := ledger(t)
+	signer, err := ledgersigner.New(device, "m/44'/60'/1'/0/3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := []byte{5, 0x80, 0, 0, 44, 0x80, 0, 0, 60, 0x80, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 3}
+	if apdu := device.APDUs()[0]; !bytes.Equal(apdu[:5], []byte{0xE0, 0x02, 0x00, 0x00, byte(len(path))}) || !bytes.Equal(apdu[5:], path) {
+		t.Fatalf("address apdu % x", apdu)
+	}
+
+	// Another device answering for the same signer is caught
+	other, _ := ledger(t)
+	swapped := &swapDevice{first: device, then: other}
+	signer, err = ledgersigner.New(swapped, ledgersigner.DefaultPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.Sign(crypto.Keccak256([]byte("x"))); err == nil {
+		t.Fatal("signature of another key accepted")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	got, err := ledgersigner.ParsePath("m/44'/60'/0h/0/7")
+	if want := []uint32{0x8000002C, 0x8000003C, 0x80000000, 0, 7}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("path %x, %v", got, err)
+	}
+	for _, path := range []string{"", "m", "m/44'/x", "m/44'/2147483648", "m//0"} {
+		if _, err := ledgersigner.ParsePath(path); err == nil {
+			t.Fatalf("%q accepted", path)
+		}
+	}
+}
+
+func TestHIDFraming(t *testing.T) {
+	device, key := ledger(t)
+	hid := ledgersigner.NewHIDDevice(&hidLoopback{t: t, device: device})
+	defer hid.Close()
+
+	// The address reply spans two reports
+	signer, err := ledgersigner.New(hid, ledgersigner.DefaultPath)
+	if err != nil || signer.Address() != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+		t.Fatalf("address %v, %v", signer, err)
+	}
+	if _, err := signer.Sign(crypto.Keccak256([]byte("x"))); err != nil {
+		t.Fatal(err)
+	}
+	device.RejectNext()
+	if _, err := signer.Sign(crypto.Keccak256([]byte("y"))); !errors.Is(err, ledgersigner.ErrRejected) {
+		t.Fatalf("rejected over HID: %v", err)
+	}
+}
+
+// swapDevice answers the first exchange with first and all others with then
+type swapDevice struct {
+	first, then ledgersigner.Device
+	used        bool
+}
+
+func (d *swapDevice) Exchange(apdu []byte) ([]byte, error) {
+	if !d.used {
+		d.used = true
+		return d.first.Exchange(apdu)
+	}
+	return d.then.Exchange(apdu)
+}
+
+// hidLoopback unframes the reports written to it into APDUs for device and frames the
+// replies into reports to read, as the Ledger firmware does
+type hidLoopback struct {
+	t       *testing.T
+	device  ledgersigner.Device
+	command []byte
+	length  int
+	seq     int
+	replies [][]byte
+}
+
+func (l *hidLoopback) Write(report []byte) (int, error) {
+	if l.command == nil {
+		l.seq = 0
+	}
+	if len(report) != 64 || !bytes.Equal(report[:3], []byte{0x01, 0x01, 0x05}) || int(binary.BigEndian.Uint16(report[3:])) != l.seq {
+		l.t.Errorf("malformed report % x", report)
+	}
+	l.seq++
+	data := report[5:]
+	if l.command == nil {
+		l.length, data = int(binary.BigEndian.Uint16(data)), data[2:]
+		l.command = []byte{}
+	}
+	l.command = append(l.command, data[:min(len(data), l.length-len(l.command))]...)
+	if len(l.command) < l.length {
+		return len(report), nil
+	}
+
+	reply, err := l.device.Exchange(l.command)
+	if err != nil {
+		return 0, err
+	}
+	l.command = nil
+	payload := binary.BigEndian.AppendUint16(nil, uint16(len(reply)))
+	payload = append(payload, reply...)
+	for seq := 0; len(payload) > 0; seq++ {
+		packet := []byte{0x01, 0x01, 0x05, 0, byte(seq)}
+		n := min(len(payload), 64-len(packet))
+		packet = append(packet, payload[:n]...)
+		payload = payload[n:]
+		l.replies = append(l.replies, append(packet, make([]byte, 64-len(packet))...))
+	}
+	return len(report), nil
+}
+
+func (l *hidLoopback) Read(report []byte) (int, error) {
+	if len(l.replies) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(report, l.replies[0])
+	l.replies = l.replies[1:]
+	return n, nil
+}
+
+func (l *hidLoopback) Close() error { return nil }
@@ -0,0 +1,58 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxResponseSize is the default cap on response bodies
+const DefaultMaxResponseSize int64 = 8 << 20
+
+// ErrResponseTooLarge matches any *ResponseTooLargeError via errors.Is
+var ErrResponseTooLarge = errors.New("response too large")
+
+// ResponseTooLargeError is returned when a response body exceeds the configured limit
+type ResponseTooLargeError struct {
+	Method string
+	Limit  int64
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response too large: %s exceeded %d bytes", e.Method, e.Limit)
+}
+
+// Is makes errors.Is(err, ErrResponseTooLarge) match
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// ConfigMaxResponseSize sets the maximum response body size in bytes (default
+// DefaultMaxResponseSize). Larger responses fail with a *ResponseTooLargeError.
+func ConfigMaxResponseSize(bytes int64) {
+	if bytes <= 0 {
+		bytes = DefaultMaxResponseSize
+	}
+	defaultClient.maxResponseSize = bytes
+}
+
+// WithMaxResponseSize raises (or lowers) the response size limit for a single call
+func WithMaxResponseSize(bytes int64) CallOption {
+	return func(c *callConfig) {
+		if bytes > 0 {
+			c.maxResponseSize = bytes
+		}
+	}
+}
+
+// Internal method: read a response body of at most limit bytes
+func readBody(body io.Reader, method string, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, &ResponseTooLargeError{Method: method, Limit: limit}
+	}
+	return data, nil
+}
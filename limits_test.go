@@ -0,0 +1,83 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// newFixedBodyServer answers every request with body
+func newFixedBodyServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMaxResponseSizeBoundary(t *testing.T) {
+	// Padding inside the JSON keeps the body valid at any length
+	body := `{"jsonrpc":"2.0","id":1,"result":"0x1","pad":"` + strings.Repeat("x", 1000) + `"}`
+	size := int64(len(body))
+	srv := newFixedBodyServer(t, body)
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useDefaultClient(t, client)
+
+	alchemy.ConfigMaxResponseSize(size)
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatalf("body of exactly the limit: %v", err)
+	}
+
+	alchemy.ConfigMaxResponseSize(size - 1)
+	err = alchemy.GetBalance(testRecipient).Err()
+	var tooLarge *alchemy.ResponseTooLargeError
+	if !errors.As(err, &tooLarge) || !errors.Is(err, alchemy.ErrResponseTooLarge) {
+		t.Fatalf("err = %v, want a *ResponseTooLargeError", err)
+	}
+	if tooLarge.Limit != size-1 || tooLarge.Method != "eth_getBalance" {
+		t.Fatalf("error reports %s over %d", tooLarge.Method, tooLarge.Limit)
+	}
+}
+
+func TestMaxResponseSizeRaisedPerCall(t *testing.T) {
+	events := make([]map[string]interface{}, 200)
+	for i := range events {
+		events[i] = map[string]interface{}{"type": "Mint", "blockNumber": 1, "logIndex": i}
+	}
+	result, err := json.Marshal(events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := newFixedBodyServer(t, `{"jsonrpc":"2.0","id":1,"result":`+string(result)+`}`)
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	useDefaultClient(t, client)
+	alchemy.ConfigMaxResponseSize(1024)
+
+	filter := alchemy.EventFilter{FromBlock: 1, ToBlock: 1}
+	if err := client.GetTokenEvents(testToken, filter).Err(); !errors.Is(err, alchemy.ErrResponseTooLarge) {
+		t.Fatalf("err = %v, want ErrResponseTooLarge under the client limit", err)
+	}
+
+	filter.MaxResponseSize = int64(len(result)) * 2
+	got, err := client.GetTokenEvents(testToken, filter).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("%d events, want %d", len(got), len(events))
+	}
+}
@@ -0,0 +1,67 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxMemoLength is the longest memo (in bytes) the *WithMemo writes accept
+const MaxMemoLength = 256
+
+// ErrInvalidMemo is returned when a memo fails validation before signing
+var ErrInvalidMemo = errors.New("invalid memo")
+
+// MintWithMemo is Mint with a memo, e.g. an internal payout ID. The memo is sent and signed
+// with the request, so it can't be altered in transit, and is returned on the Mint event
+// (TokenEvent.Memo).
+func (c *Client) MintWithMemo(tokenAddress, toAddress, amount, memo string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "MintWithMemo", tokenAddress)
+	if err := checkMemo(memo); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Mint(tokenAddress, toAddress, amount, nonce, withMemo(opts, memo)...)
+}
+
+// BurnWithMemo is Burn with a memo (see MintWithMemo)
+func (c *Client) BurnWithMemo(tokenAddress, amount, memo string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "BurnWithMemo", tokenAddress)
+	if err := checkMemo(memo); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.Burn(tokenAddress, amount, nonce, withMemo(opts, memo)...)
+}
+
+// AdminBurnWithMemo is AdminBurn with a memo (see MintWithMemo)
+func (c *Client) AdminBurnWithMemo(tokenAddress, fromAddress, amount, memo string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "AdminBurnWithMemo", tokenAddress)
+	if err := checkMemo(memo); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	return c.AdminBurn(tokenAddress, fromAddress, amount, nonce, withMemo(opts, memo)...)
+}
+
+// Internal method: opts plus the memo, without touching the caller's slice
+func withMemo(opts []CallOption, memo string) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(cfg *callConfig) { cfg.memo = memo })
+}
+
+// Internal method: reject empty, oversized, non-UTF-8 and control-character memos
+func checkMemo(memo string) error {
+	if memo == "" {
+		return fmt.Errorf("%w: empty memo", ErrInvalidMemo)
+	}
+	if len(memo) > MaxMemoLength {
+		return fmt.Errorf("%w: %d bytes, at most %d allowed", ErrInvalidMemo, len(memo), MaxMemoLength)
+	}
+	if !utf8.ValidString(memo) {
+		return fmt.Errorf("%w: not valid UTF-8", ErrInvalidMemo)
+	}
+	for _, r := range memo {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: contains control character %U", ErrInvalidMemo, r)
+		}
+	}
+	return nil
+}
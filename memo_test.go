@@ -0,0 +1,98 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestMintWithMemoSignsMemo(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(12345)
+
+	if err := client.MintWithMemo(testToken, testRecipient, "100", "payout-42", 7).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("mint")
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	req := reqs[0]
+	if req.SignatureErr != nil {
+		t.Fatal(req.SignatureErr)
+	}
+	if req.ParamMap["memo"] != "payout-42" {
+		t.Fatalf("memo = %v", req.ParamMap["memo"])
+	}
+	// memo sorts before methodArgs
+	message, err := alchemy.SignedMessage(req.ParamMap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "payout-42," + testRecipient + ",100,7,12345," + testToken; message != want {
+		t.Fatalf("message %q, want %q", message, want)
+	}
+
+	if err := client.BurnWithMemo(testToken, "5", "refund-1", 8).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AdminBurnWithMemo(testToken, testRecipient, "5", "case-9", 9).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for method, want := range map[string]string{"burn": "refund-1", "adminBurn": "case-9"} {
+		reqs := srv.RequestsFor(method)
+		if len(reqs) != 1 || reqs[0].ParamMap["memo"] != want || reqs[0].SignatureErr != nil {
+			t.Fatalf("%s requests %+v", method, reqs)
+		}
+	}
+
+	// Writes without a memo don't send the key
+	if err := client.Mint(testToken, testRecipient, "1", 10).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.RequestsFor("mint")[1].ParamMap["memo"]; ok {
+		t.Fatal("memo sent for a plain Mint")
+	}
+}
+
+func TestMemoValidation(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	for _, memo := range []string{"", strings.Repeat("x", alchemy.MaxMemoLength+1), "line\nbreak", "bad \xff utf-8"} {
+		if err := client.MintWithMemo(testToken, testRecipient, "1", memo, 1).Err(); !errors.Is(err, alchemy.ErrInvalidMemo) {
+			t.Fatalf("%q: err = %v, want ErrInvalidMemo", memo, err)
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent for invalid memos", n)
+	}
+
+	if err := client.MintWithMemo(testToken, testRecipient, "1", strings.Repeat("é", alchemy.MaxMemoLength/2), 1).Err(); err != nil {
+		t.Fatalf("memo of exactly %d bytes: %v", alchemy.MaxMemoLength, err)
+	}
+}
+
+func TestTokenEventMemo(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(10)
+	srv.SetResult("get_token_events", []map[string]interface{}{
+		{"type": alchemy.EventMint, "blockNumber": 3, "memo": "payout-42", "fields": map[string]interface{}{"to": testRecipient}},
+		{"type": alchemy.EventBurn, "blockNumber": 4, "fields": map[string]interface{}{"memo": "refund-1"}},
+		{"type": alchemy.EventMint, "blockNumber": 5, "fields": map[string]interface{}{}},
+	})
+
+	events, err := client.GetTokenEvents(testToken, alchemy.EventFilter{}).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var memos []string
+	for _, event := range events {
+		memos = append(memos, event.Memo)
+	}
+	if got := fmt.Sprintf("%q", memos); got != `["payout-42" "refund-1" ""]` {
+		t.Fatalf("memos %s", got)
+	}
+}
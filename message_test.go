@@ -0,0 +1,107 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// TestSignedMessageRegression pins the messages of the param sets CreateToken and dynamic
+// calls have always produced
+func TestSignedMessageRegression(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{"create_token", map[string]interface{}{
+			"decimals":         int32(18),
+			"masterAuthority":  testRecipient,
+			"name":             "My Token",
+			"nonce":            int64(0),
+			"recentCheckpoint": int64(12345),
+			"symbol":           "MTK",
+		}, "18," + testRecipient + ",My Token,0,12345,MTK"},
+		{"mint", map[string]interface{}{
+			"methodArgs":       []interface{}{testRecipient, "1000"},
+			"nonce":            int64(7),
+			"recentCheckpoint": int64(12345),
+			"token":            testToken,
+		}, testRecipient + ",1000,7,12345," + testToken},
+		{"no args", map[string]interface{}{
+			"methodArgs":       []interface{}{},
+			"nonce":            int64(1),
+			"recentCheckpoint": int64(2),
+			"token":            testToken,
+		}, "1,2," + testToken},
+		{"nil values skipped", map[string]interface{}{
+			"a": nil,
+			"b": []interface{}{nil, "x"},
+			"c": "y",
+		}, "x,y"},
+		{"signature ignored", map[string]interface{}{
+			"nonce":     int64(1),
+			"signature": map[string]interface{}{"r": "1", "s": "2", "v": "27"},
+		}, "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := alchemy.SignedMessage(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("message %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignedMessageCanonicalValues(t *testing.T) {
+	large, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"int", 42, "42"},
+		{"int8", int8(-8), "-8"},
+		{"uint64 max", uint64(18446744073709551615), "18446744073709551615"},
+		{"big.Int", large, "123456789012345678901234567890"},
+		{"json.Number", json.Number("1000000000000000000000"), "1000000000000000000000"},
+		{"json.Number leading zeros", json.Number("007"), "7"},
+		{"bool", true, "true"},
+		{"string type", alchemy.RoleMint, alchemy.RoleMint.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := alchemy.SignedMessage(map[string]interface{}{"v": tt.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignedMessageRejectsUnstableValues(t *testing.T) {
+	for name, value := range map[string]interface{}{
+		"float":               1.5,
+		"float integral":      float64(2),
+		"non-integer number":  json.Number("1e3"),
+		"map":                 map[string]interface{}{"a": 1},
+		"nil big.Int":         (*big.Int)(nil),
+		"nested slice in arg": []interface{}{[]interface{}{"a"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := alchemy.SignedMessage(map[string]interface{}{"v": value}); !errors.Is(err, alchemy.ErrUnsupportedValue) {
+				t.Fatalf("err = %v, want ErrUnsupportedValue", err)
+			}
+		})
+	}
+}
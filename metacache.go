@@ -0,0 +1,136 @@
+package alchemy
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMetadataCacheSize bounds the metadata cache when no size is given
+const DefaultMetadataCacheSize = 256
+
+// metadataCache is an LRU of GetTokenMetadata results, disabled while ttl is 0
+type metadataCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	size       int
+	order      *list.List               // most recently used first, values are *metadataEntry
+	entries    map[string]*list.Element // lower-cased token address -> element of order
+	generation uint64                   // bumped by every invalidation
+}
+
+type metadataEntry struct {
+	key      string
+	metadata TokenMetadata
+	expires  time.Time
+}
+
+// ConfigMetadataCache caches GetTokenMetadata results of the default client for ttl, keeping
+// at most size tokens (0 means DefaultMetadataCacheSize). ttl 0, the default, disables it.
+func ConfigMetadataCache(ttl time.Duration, size int) {
+	defaultClient.metadataCache.configure(ttl, size)
+}
+
+// WithMetadataCache caches GetTokenMetadata results for ttl, keeping the
+// DefaultMetadataCacheSize most recently used tokens (see WithMetadataCacheSize)
+func WithMetadataCache(ttl time.Duration) Option {
+	return func(o *clientOptions) { o.metadataTTL = ttl }
+}
+
+// WithMetadataCacheSize sets the maximum number of tokens in the metadata cache
+func WithMetadataCacheSize(n int) Option {
+	return func(o *clientOptions) { o.metadataSize = n }
+}
+
+// RefreshTokenMetadata reloads token metadata from the server, replacing the cached entry
+func (c *Client) RefreshTokenMetadata(tokenAddress string) (r *ResponseHandler[*TokenMetadata]) {
+	defer withOperation(&r, "RefreshTokenMetadata", tokenAddress)
+	c.metadataCache.invalidate(tokenAddress)
+	return c.GetTokenMetadata(tokenAddress)
+}
+
+// Internal method: set the TTL and size, dropping all entries
+func (m *metadataCache) configure(ttl time.Duration, size int) {
+	if size <= 0 {
+		size = DefaultMetadataCacheSize
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ttl = max(ttl, 0)
+	m.size = size
+	m.order = list.New()
+	m.entries = map[string]*list.Element{}
+	m.generation++
+}
+
+// Internal method: fresh cached metadata of a token (a copy), and the generation to pass
+// to put after a miss
+func (m *metadataCache) get(tokenAddress string) (*TokenMetadata, uint64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ttl == 0 {
+		return nil, m.generation, false
+	}
+
+	element, ok := m.entries[strings.ToLower(tokenAddress)]
+	if !ok {
+		return nil, m.generation, false
+	}
+	entry := element.Value.(*metadataEntry)
+	if !time.Now().Before(entry.expires) {
+		m.order.Remove(element)
+		delete(m.entries, entry.key)
+		return nil, m.generation, false
+	}
+	m.order.MoveToFront(element)
+	metadata := entry.metadata
+	return &metadata, m.generation, true
+}
+
+// Internal method: cache metadata read at generation, unless an invalidation happened
+// while the read was in flight
+func (m *metadataCache) put(tokenAddress string, metadata *TokenMetadata, generation uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ttl == 0 || generation != m.generation {
+		return
+	}
+
+	key := strings.ToLower(tokenAddress)
+	entry := &metadataEntry{key: key, metadata: *metadata, expires: time.Now().Add(m.ttl)}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*metadataEntry).key)
+	}
+}
+
+// Internal method: drop the entry of a token
+func (m *metadataCache) invalidate(tokenAddress string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generation++
+	key := strings.ToLower(tokenAddress)
+	if element, ok := m.entries[key]; ok {
+		m.order.Remove(element)
+		delete(m.entries, key)
+	}
+}
+
+// Internal method: drop every entry (endpoint changed)
+func (m *metadataCache) clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.generation++
+	if m.order != nil {
+		m.order.Init()
+	}
+	clear(m.entries)
+}
@@ -0,0 +1,155 @@
+package alchemy_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// newMetadataServer answers getTokenMetadata for any token
+func newMetadataServer(t *testing.T, opts ...alchemy.Option) (*alchemytest.Server, *alchemy.Client) {
+	t.Helper()
+	srv, client, _ := newTestServer(t, opts...)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": "100"})
+	return srv, client
+}
+
+// metadataReads counts the getTokenMetadata requests for token
+func metadataReads(srv *alchemytest.Server, token string) int {
+	n := 0
+	for _, req := range srv.RequestsFor("getTokenMetadata") {
+		if req.ParamMap["token"] == token {
+			n++
+		}
+	}
+	return n
+}
+
+func TestMetadataCacheTTL(t *testing.T) {
+	srv, client := newMetadataServer(t, alchemy.WithMetadataCache(50*time.Millisecond))
+
+	for i := 0; i < 3; i++ {
+		if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := metadataReads(srv, testToken); n != 1 {
+		t.Fatalf("%d reads within the TTL, want 1", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := metadataReads(srv, testToken); n != 2 {
+		t.Fatalf("%d reads after expiry, want 2", n)
+	}
+
+	// Callers get copies
+	first, _ := client.GetTokenMetadata(testToken).Result()
+	first.Name = "changed"
+	if second, _ := client.GetTokenMetadata(testToken).Result(); second.Name != "Euro" {
+		t.Fatalf("cached entry modified through a returned value: %q", second.Name)
+	}
+}
+
+func TestMetadataCacheInvalidatedByWrites(t *testing.T) {
+	srv, client := newMetadataServer(t, alchemy.WithMetadataCache(time.Minute))
+	writes := []struct {
+		name       string
+		write      func() error
+		invalidate bool
+	}{
+		{"UpdateMetadata", func() error { return client.UpdateMetadata(testToken, "Euro Coin", "EURC", 0).Err() }, true},
+		{"Pause", func() error { return client.Pause(testToken, 1).Err() }, true},
+		{"Unpause", func() error { return client.Unpause(testToken, 2).Err() }, true},
+		{"SetSupplyCap", func() error { return client.SetSupplyCap(testToken, "1000", 3).Err() }, true},
+		{"Mint", func() error { return client.Mint(testToken, testRecipient, "1", 4).Err() }, false},
+		{"RefreshTokenMetadata", func() error { return client.RefreshTokenMetadata(testToken).Err() }, true},
+	}
+
+	if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range writes {
+		before := metadataReads(srv, testToken)
+		if err := w.write(); err != nil {
+			t.Fatalf("%s: %v", w.name, err)
+		}
+		if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+			t.Fatal(err)
+		}
+		refetched := metadataReads(srv, testToken) > before
+		if refetched != w.invalidate {
+			t.Fatalf("%s: metadata refetched %v, want %v", w.name, refetched, w.invalidate)
+		}
+	}
+}
+
+func TestMetadataCacheLRU(t *testing.T) {
+	srv, client := newMetadataServer(t, alchemy.WithMetadataCache(time.Minute), alchemy.WithMetadataCacheSize(2))
+	a, b, c := sanctioned[0], sanctioned[1], sanctioned[2]
+
+	for _, token := range []string{a, b, a, c, a, b} {
+		if err := client.GetTokenMetadata(token).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// c evicted b, the least recently used; b then evicted c
+	if got := [3]int{metadataReads(srv, a), metadataReads(srv, b), metadataReads(srv, c)}; got != [3]int{1, 2, 1} {
+		t.Fatalf("reads per token %v, want [1 2 1]", got)
+	}
+}
+
+func TestMetadataCacheConcurrentReaders(t *testing.T) {
+	srv, client := newMetadataServer(t, alchemy.WithMetadataCache(time.Minute), alchemy.WithMetadataCacheSize(2))
+	tokens := []string{testToken, sanctioned[0], sanctioned[1]}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				metadata, err := client.GetTokenMetadata(tokens[(g+i)%len(tokens)]).Result()
+				if err != nil || metadata.Symbol != "EURX" {
+					t.Errorf("metadata %+v, %v", metadata, err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			client.RefreshTokenMetadata(testToken)
+		}
+	}()
+	wg.Wait()
+
+	if n := len(srv.RequestsFor("getTokenMetadata")); n >= 50*20 {
+		t.Fatalf("%d reads for %d calls, cache unused", n, 50*20)
+	}
+}
+
+func TestPinnedMetadataBypassesCache(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithMetadataCache(time.Minute))
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": "100"})
+
+	for i := 0; i < 2; i++ {
+		if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+			t.Fatal(err)
+		}
+		if err := client.GetTokenMetadata(testToken, alchemy.WithBlock(5)).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// One cached latest read, two pinned ones
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 3 {
+		t.Fatalf("%d metadata requests, want 3", n)
+	}
+}
@@ -0,0 +1,117 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestTokenMetadataOldFormat(t *testing.T) {
+	for _, strict := range []bool{false, true} {
+		var opts []alchemy.Option
+		if strict {
+			opts = append(opts, alchemy.WithStrictDecoding())
+		}
+		srv, client, _ := newTestServer(t, opts...)
+		// Exactly what servers sent before the optional fields existed
+		srv.SetResult("getTokenMetadata", json.RawMessage(`{"name":"Dollar","symbol":"USDX","decimals":6,"supply":"1500000","isPaused":false}`))
+
+		meta, err := client.GetTokenMetadata(testToken).Result()
+		if err != nil {
+			t.Fatalf("strict=%v: %v", strict, err)
+		}
+		if meta.Name != "Dollar" || meta.Symbol != "USDX" || meta.Decimals != 6 || meta.Supply != "1500000" {
+			t.Fatalf("strict=%v: got %+v", strict, meta)
+		}
+		if meta.MasterAuthority != "" || meta.Cap != "" || meta.CreatedAtBlock != 0 || meta.Creator != "" {
+			t.Fatalf("strict=%v: optional fields set: %+v", strict, meta)
+		}
+	}
+}
+
+func TestTokenMetadataExtendedFields(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithStrictDecoding())
+	srv.SetResult("getTokenMetadata", map[string]interface{}{
+		"name": "Dollar", "symbol": "USDX", "decimals": 6, "supply": "1500000", "isPaused": true,
+		"masterAuthority": testRecipient, "cap": "9000000", "createdAtBlock": 1234, "creator": testRecipient,
+	})
+
+	meta, err := client.GetTokenMetadata(testToken).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsPaused || meta.MasterAuthority != testRecipient || meta.Cap != "9000000" ||
+		meta.CreatedAtBlock != 1234 || meta.Creator != testRecipient {
+		t.Fatalf("got %+v", meta)
+	}
+}
+
+func TestSupplyDecimal(t *testing.T) {
+	tests := []struct {
+		supply   string
+		decimals uint8
+		want     string
+	}{
+		{"1500000", 6, "1.5"},
+		{"1500000", 0, "1500000"},
+		{"150000000", 8, "1.5"},
+		{"1000000000000000000", 18, "1"},
+		{"1234567890000000000", 18, "1.23456789"},
+		{"42", 0, "42"},
+		{"5", 8, "0.00000005"},
+		{"0", 18, "0"},
+		{"000120", 2, "1.2"},
+		// Beyond float64 precision and range
+		{"123456789012345678901234567890123456789", 18, "123456789012345678901.234567890123456789"},
+		{"9007199254740993000000", 6, "9007199254740993"},
+		{strings.Repeat("9", 400), 18, strings.Repeat("9", 382) + "." + strings.Repeat("9", 18)},
+		{"", 6, ""},
+		{"1.5", 6, ""},
+	}
+	for _, tt := range tests {
+		meta := alchemy.TokenMetadata{Supply: tt.supply, Decimals: tt.decimals}
+		if got := meta.SupplyDecimal(); got != tt.want {
+			t.Errorf("SupplyDecimal(%q, %d) = %q, want %q", tt.supply, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestGetTokenBalanceDecimal(t *testing.T) {
+	tests := []struct {
+		balance  string
+		decimals uint8
+		want     string
+	}{
+		{"42", 0, "42"},
+		{"2500000", 6, "2.5"},
+		{"100000000", 8, "1"},
+		{"123456789012345678901234567890", 18, "123456789012.34567890123456789"},
+	}
+	for _, tt := range tests {
+		srv, client, _ := newTestServer(t)
+		srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "T", "symbol": "T", "decimals": tt.decimals, "supply": "0"})
+		srv.SetResult("balanceOf", tt.balance)
+
+		for i := 0; i < 2; i++ {
+			got, err := client.GetTokenBalanceDecimal(testToken, testRecipient).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("balance %s with %d decimals = %q, want %q", tt.balance, tt.decimals, got, tt.want)
+			}
+		}
+		if n := len(srv.RequestsFor("getTokenMetadata")); n != 1 {
+			t.Fatalf("decimals fetched %d times, want once", n)
+		}
+	}
+
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "T", "symbol": "T", "decimals": 6, "supply": "0"})
+	srv.SetResult("balanceOf", "-1")
+	if err := client.GetTokenBalanceDecimal(testToken, testRecipient).Err(); err == nil {
+		t.Fatal("invalid balance accepted")
+	}
+}
@@ -0,0 +1,124 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// metadataHistoryPageSize is the number of changes requested per get_metadata_history page
+const metadataHistoryPageSize = 100
+
+// MetadataChange is one UpdateMetadata applied to a token
+type MetadataChange struct {
+	Block     int64  `json:"block"`
+	TxHash    string `json:"txHash"`
+	LogIndex  int64  `json:"logIndex"`
+	OldName   string `json:"oldName"`
+	NewName   string `json:"newName"`
+	OldSymbol string `json:"oldSymbol"`
+	NewSymbol string `json:"newSymbol"`
+	Authority string `json:"authority"` // checksummed address that signed the update
+}
+
+// metadataHistoryPage is one page of get_metadata_history
+type metadataHistoryPage struct {
+	Changes    []MetadataChange `json:"changes"`
+	NextCursor string           `json:"nextCursor"` // empty on the last page
+}
+
+// GetMetadataHistory lists the name and symbol changes of a token, oldest first. Uses the
+// server's get_metadata_history when available, fetching every page, otherwise scans the
+// MetadataUpdated events of the whole chain in GetTokenEvents chunks. Old values missing from
+// an event are taken from the previous change.
+func (c *Client) GetMetadataHistory(tokenAddress string) (r *ResponseHandler[[]MetadataChange]) {
+	defer withOperation(&r, "GetMetadataHistory", tokenAddress)
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return &ResponseHandler[[]MetadataChange]{err: err}
+	}
+
+	changes, err := c.metadataHistoryFromServer(tokenAddress)
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return &ResponseHandler[[]MetadataChange]{err: err}
+		}
+		if changes, err = c.metadataHistoryFromEvents(tokenAddress); err != nil {
+			return &ResponseHandler[[]MetadataChange]{err: err}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Block != changes[j].Block {
+			return changes[i].Block < changes[j].Block
+		}
+		return changes[i].LogIndex < changes[j].LogIndex
+	})
+	for i := range changes {
+		if i > 0 {
+			if changes[i].OldName == "" {
+				changes[i].OldName = changes[i-1].NewName
+			}
+			if changes[i].OldSymbol == "" {
+				changes[i].OldSymbol = changes[i-1].NewSymbol
+			}
+		}
+		changes[i].Authority = checksummed(changes[i].Authority)
+	}
+	return &ResponseHandler[[]MetadataChange]{data: changes}
+}
+
+// Internal method: all pages of get_metadata_history
+func (c *Client) metadataHistoryFromServer(tokenAddress string) ([]MetadataChange, error) {
+	changes := []MetadataChange{}
+	cursor := ""
+	for {
+		result, err := c.rpcCall("get_metadata_history", map[string]interface{}{
+			"token":  tokenAddress,
+			"cursor": cursor,
+			"limit":  metadataHistoryPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var page metadataHistoryPage
+		if err := json.Unmarshal(result, &page); err != nil {
+			return nil, fmt.Errorf("decode metadata history: %w", err)
+		}
+		changes = append(changes, page.Changes...)
+
+		if page.NextCursor == "" {
+			return changes, nil
+		}
+		if page.NextCursor == cursor {
+			return nil, fmt.Errorf("decode metadata history: cursor %q repeated", cursor)
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// Internal method: rebuild the history from MetadataUpdated events
+func (c *Client) metadataHistoryFromEvents(tokenAddress string) ([]MetadataChange, error) {
+	events := c.GetTokenEvents(tokenAddress, EventFilter{EventTypes: []string{EventMetadataUpdated}})
+	if events.err != nil {
+		return nil, events.err
+	}
+
+	changes := make([]MetadataChange, 0, len(events.data))
+	for _, event := range events.data {
+		if event.Type != EventMetadataUpdated {
+			continue
+		}
+		changes = append(changes, MetadataChange{
+			Block:     event.BlockNumber,
+			TxHash:    event.TxHash,
+			LogIndex:  event.LogIndex,
+			OldName:   event.Field("oldName"),
+			NewName:   event.Field("newName"),
+			OldSymbol: event.Field("oldSymbol"),
+			NewSymbol: event.Field("newSymbol"),
+			Authority: event.Field("authority"),
+		})
+	}
+	return changes, nil
+}
@@ -0,0 +1,123 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// loadMetadataChanges reads the fixture of three changes (MTK -> UPD, then two renames in
+// one block) as the server sends them: lower-case authorities
+func loadMetadataChanges(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile("testdata/metadata_history.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changes []map[string]interface{}
+	if err := json.Unmarshal(data, &changes); err != nil {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+// wantMetadataChanges is the decoded fixture
+var wantMetadataChanges = []alchemy.MetadataChange{
+	{Block: 120, TxHash: "0x5b1c9a0e7f2d3c4b5a69788796a5b4c3d2e1f00112233445566778899aabbcc0", LogIndex: 0,
+		OldName: "My Token", NewName: "My Token", OldSymbol: "MTK", NewSymbol: "UPD",
+		Authority: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+	{Block: 345, TxHash: "0x8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b4a39281706f5e4d3c2b1a0f1", LogIndex: 2,
+		OldName: "My Token", NewName: "Updated Token", OldSymbol: "UPD", NewSymbol: "UPD",
+		Authority: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"},
+	{Block: 345, TxHash: "0x0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f2", LogIndex: 5,
+		OldName: "Updated Token", NewName: "Updated Token v2", OldSymbol: "UPD", NewSymbol: "UPD2",
+		Authority: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+}
+
+func TestGetMetadataHistoryFromServer(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	fixture := loadMetadataChanges(t)
+	srv.QueueResponse("get_metadata_history",
+		alchemytest.Response{Result: map[string]interface{}{"changes": fixture[:2], "nextCursor": "c2"}},
+		alchemytest.Response{Result: map[string]interface{}{"changes": fixture[2:], "nextCursor": ""}},
+	)
+
+	changes, err := client.GetMetadataHistory(testToken).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, wantMetadataChanges) {
+		t.Fatalf("got %+v\nwant %+v", changes, wantMetadataChanges)
+	}
+
+	requests := srv.RequestsFor("get_metadata_history")
+	if len(requests) != 2 {
+		t.Fatalf("%d pages requested, want 2", len(requests))
+	}
+	for i, want := range []string{"", "c2"} {
+		if got := fmt.Sprint(requests[i].ParamMap["cursor"]); got != want {
+			t.Fatalf("page %d cursor %q, want %q", i, got, want)
+		}
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatalf("%d event queries", n)
+	}
+}
+
+func TestGetMetadataHistoryFromEvents(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetBlockNumber(400)
+
+	// Newest first, and the last event doesn't repeat the old values
+	var events []map[string]interface{}
+	for i, change := range loadMetadataChanges(t) {
+		fields := map[string]interface{}{"newName": change["newName"], "newSymbol": change["newSymbol"], "authority": change["authority"]}
+		if i < 2 {
+			fields["oldName"], fields["oldSymbol"] = change["oldName"], change["oldSymbol"]
+		}
+		events = append([]map[string]interface{}{{
+			"type": alchemy.EventMetadataUpdated, "blockNumber": change["block"], "txHash": change["txHash"],
+			"logIndex": change["logIndex"], "fields": fields,
+		}}, events...)
+	}
+	srv.SetResult("get_token_events", events)
+
+	changes, err := client.GetMetadataHistory(testToken).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes, wantMetadataChanges) {
+		t.Fatalf("got %+v\nwant %+v", changes, wantMetadataChanges)
+	}
+
+	query := srv.RequestsFor("get_token_events")
+	if len(query) != 1 || fmt.Sprint(query[0].ParamMap["eventTypes"]) != "["+alchemy.EventMetadataUpdated+"]" {
+		t.Fatalf("event queries %+v", query)
+	}
+}
+
+func TestGetMetadataHistoryErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("get_metadata_history", -32000, "token not found")
+	if err := client.GetMetadataHistory(testToken).Err(); err == nil {
+		t.Fatal("server error not returned")
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 0 {
+		t.Fatalf("fell back to events after a server error")
+	}
+
+	if err := client.GetMetadataHistory("0x12").Err(); err == nil {
+		t.Fatal("invalid token address accepted")
+	}
+
+	// A server repeating its cursor would loop forever
+	srv.SetResult("get_metadata_history", map[string]interface{}{"changes": []interface{}{}, "nextCursor": "same"})
+	if err := client.GetMetadataHistory(testToken).Err(); err == nil {
+		t.Fatal("repeated cursor accepted")
+	}
+}
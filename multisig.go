@@ -0,0 +1,232 @@
+package alchemy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrDuplicateSigner is returned when a multisig request already holds a signature of
+	// the same address
+	ErrDuplicateSigner = errors.New("address already signed the request")
+	// ErrMultisigHash is returned when the params of a multisig request no longer match the
+	// hash the parties sign, e.g. after they were edited in transit
+	ErrMultisigHash = errors.New("request params don't match the request hash")
+	// ErrNoSignatures is returned by SubmitMultisig for a request nobody signed
+	ErrNoSignatures = errors.New("request has no signatures")
+)
+
+// MultisigRequest is a write whose signatures are collected from several parties, e.g. a
+// MASTER_ROLE operation needing two of three approvals. BuildUnsignedRequest creates it,
+// each party adds a signature with AddSignature, possibly offline after a JSON round trip,
+// and SubmitMultisig sends it with every collected signature.
+type MultisigRequest struct {
+	Method     string                 `json:"method"`     // token method, e.g. "transferMasterAuthority"
+	Params     map[string]interface{} `json:"params"`     // the signed params, numbers as json.Number after decoding
+	Message    string                 `json:"message"`    // sorted message of Params
+	Hash       string                 `json:"hash"`       // 0x-prefixed Keccak256 of Message, what every party signs
+	Signatures []PartySignature       `json:"signatures"` // in the order they were added
+}
+
+// PartySignature is the signature of one party of a MultisigRequest
+type PartySignature struct {
+	Signer    string    `json:"signer"` // checksummed address of the party
+	Signature Signature `json:"signature"`
+}
+
+// UnmarshalJSON decodes numbers in Params as json.Number, so they keep the exact form
+// they were signed in
+func (r *MultisigRequest) UnmarshalJSON(data []byte) error {
+	type plain MultisigRequest
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode((*plain)(r))
+}
+
+// Token returns the token address the request acts on
+func (r *MultisigRequest) Token() string {
+	if r == nil {
+		return ""
+	}
+	token, _ := r.Params["token"].(string)
+	return token
+}
+
+// BuildUnsignedRequest prepares the write of method with args on the token for signature
+// collection: the params are built exactly as for CallWrite (nonce, recentCheckpoint,
+// chainId when chain ID signing is enabled, idempotency key and memo from the call
+// options) and hashed, but nobody signs. The recentCheckpoint ages while signatures are
+// collected, so the request must be submitted within the server's checkpoint window.
+func (c *Client) BuildUnsignedRequest(tokenAddress, method string, args []interface{}, nonce int64, opts ...CallOption) (r *ResponseHandler[*MultisigRequest]) {
+	defer withOperation(&r, "BuildUnsignedRequest", tokenAddress)
+	args, err := checkCallArgs(method, args)
+	if err != nil {
+		return &ResponseHandler[*MultisigRequest]{err: err}
+	}
+	cfg := c.newCallConfig(opts)
+	cfg.write = true
+	ctx := cfg.context()
+
+	params, err := c.buildDynamicParams(ctx, tokenAddress, args, nonce, cfg)
+	if err != nil {
+		return &ResponseHandler[*MultisigRequest]{err: err}
+	}
+	if err := c.addSigningChainID(ctx, params); err != nil {
+		return &ResponseHandler[*MultisigRequest]{err: err}
+	}
+	message, err := buildSortedMessage(params)
+	if err != nil {
+		return &ResponseHandler[*MultisigRequest]{err: err}
+	}
+	return &ResponseHandler[*MultisigRequest]{data: &MultisigRequest{
+		Method:     method,
+		Params:     params,
+		Message:    message,
+		Hash:       crypto.Keccak256Hash([]byte(message)).Hex(),
+		Signatures: []PartySignature{},
+	}}
+}
+
+// AddSignature signs req with signer and appends the signature. No client or network
+// access is needed, so parties can sign offline. The params are checked against the
+// request hash first (ErrMultisigHash), and an address that already signed is refused
+// with ErrDuplicateSigner.
+func AddSignature(req *MultisigRequest, signer Signer) error {
+	if req == nil || signer == nil {
+		return errors.New("sign error: nil request or signer")
+	}
+	if err := req.checkHash(); err != nil {
+		return err
+	}
+	address := signer.Address()
+	for _, party := range req.Signatures {
+		if strings.EqualFold(party.Signer, address) {
+			return fmt.Errorf("%w: %s", ErrDuplicateSigner, address)
+		}
+	}
+
+	payload, err := signParams(signer, req.Params, VFormatEthereum)
+	if err != nil {
+		return err
+	}
+	recovered, err := RecoverSigner(req.Params, &payload.Signature)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(recovered, address) {
+		return fmt.Errorf("%w: signer %s returned a signature of %s", ErrInvalidSignature, address, recovered)
+	}
+	req.Signatures = append(req.Signatures, PartySignature{Signer: recovered, Signature: payload.Signature})
+	return nil
+}
+
+// VerifySignatures checks req before submission: the params must match the hash, every
+// signature must recover to the address it was added for, and no address may sign twice.
+// It returns the signing addresses in order.
+func (r *MultisigRequest) VerifySignatures() ([]string, error) {
+	if err := r.checkHash(); err != nil {
+		return nil, err
+	}
+	if len(r.Signatures) == 0 {
+		return nil, ErrNoSignatures
+	}
+	signers := make([]string, 0, len(r.Signatures))
+	seen := map[string]bool{}
+	for i, party := range r.Signatures {
+		recovered, err := RecoverSigner(r.Params, &party.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		if !strings.EqualFold(recovered, party.Signer) {
+			return nil, fmt.Errorf("signature %d: %w: signed by %s, not %s", i, ErrInvalidSignature, recovered, party.Signer)
+		}
+		key := strings.ToLower(recovered)
+		if seen[key] {
+			return nil, fmt.Errorf("signature %d: %w: %s", i, ErrDuplicateSigner, recovered)
+		}
+		seen[key] = true
+		signers = append(signers, recovered)
+	}
+	return signers, nil
+}
+
+// SubmitMultisig sends req with the list of collected signatures as "signatures", after
+// verifying them all locally (see VerifySignatures): nothing is sent when one fails. The
+// signatures are encoded like single signatures (see ConfigVFormat and
+// ConfigSignatureEncoding). Call options such as WithConfirmations apply as for writes.
+func (c *Client) SubmitMultisig(req *MultisigRequest, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	token := req.Token()
+	defer withOperation(&r, "SubmitMultisig", token)
+	if req == nil {
+		return &ResponseHandler[*TransactionResult]{err: errors.New("nil request")}
+	}
+	if _, err := req.VerifySignatures(); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	cfg := c.newCallConfig(opts)
+	ctx := cfg.context()
+
+	reqParams := make(map[string]interface{}, len(req.Params)+1)
+	for key, value := range req.Params {
+		reqParams[key] = value
+	}
+	signatures := make([]map[string]string, len(req.Signatures))
+	for i, party := range req.Signatures {
+		signatures[i] = party.Signature.inFormat(c.vFormat).encode(c.sigEncoding)
+	}
+	reqParams["signatures"] = signatures
+
+	result, err := c.rpcWriteContext(ctx, req.Method, reqParams, cfg.maxResponseSize)
+	c.invalidateAfterWrite(token, req.Method)
+	if err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	raw := bytes.Clone(result)
+	var response *TransactionResult
+	if err := c.decodeResult(result, &response); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err, raw: raw}
+	}
+	setIdempotencyKey(response, cfg.idempotencyKey)
+	if nonce, err := strconv.ParseInt(fmt.Sprint(req.Params["nonce"]), 10, 64); err == nil {
+		setResultNonce(response, nonce)
+	}
+	if err := c.awaitConfirmations(response, cfg); err != nil {
+		return &ResponseHandler[*TransactionResult]{data: response, err: err, raw: raw}
+	}
+	return &ResponseHandler[*TransactionResult]{data: response, raw: raw}
+}
+
+// Internal method: fail with ErrMultisigHash unless Params hash to Hash
+func (r *MultisigRequest) checkHash() error {
+	message, err := buildSortedMessage(r.Params)
+	if err != nil {
+		return err
+	}
+	if hash := crypto.Keccak256Hash([]byte(message)).Hex(); !strings.EqualFold(hash, r.Hash) {
+		return fmt.Errorf("%w: params hash to %s, request to %s", ErrMultisigHash, hash, r.Hash)
+	}
+	return nil
+}
+
+// Internal method: s with V as 27/28 or as the raw recovery id
+func (s Signature) inFormat(format VFormat) Signature {
+	v, err := parseSignatureValue(s.V, 8)
+	if err != nil {
+		return s
+	}
+	switch {
+	case format == VFormatRaw && v.Uint64() >= 27:
+		v.Sub(v, big.NewInt(27))
+	case format == VFormatEthereum && v.Uint64() < 27:
+		v.Add(v, big.NewInt(27))
+	}
+	s.V = v.String()
+	return s
+}
@@ -0,0 +1,189 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// approvers returns three signers registered on srv
+func approvers(t *testing.T, srv *alchemytest.Server) []alchemy.Signer {
+	t.Helper()
+	var signers []alchemy.Signer
+	for range 3 {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		srv.RegisterKey(&key.PublicKey)
+		signers = append(signers, alchemy.NewKeySigner(key))
+	}
+	return signers
+}
+
+// roundTrip passes req through JSON, as between parties
+func roundTrip(t *testing.T, req *alchemy.MultisigRequest) *alchemy.MultisigRequest {
+	t.Helper()
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded alchemy.MultisigRequest
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return &decoded
+}
+
+func TestMultisigTwoOfThree(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts []alchemy.Option
+	}{
+		{"default", nil},
+		{"raw v, hex, chain id", []alchemy.Option{alchemy.WithVFormat(alchemy.VFormatRaw),
+			alchemy.WithSignatureEncoding(alchemy.SignatureEncodingHex), alchemy.WithChainIDSigning()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t, tt.opts...)
+			parties := approvers(t, srv)
+
+			req, err := client.BuildUnsignedRequest(testToken, "transferMasterAuthority", []interface{}{testRecipient}, 7).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(srv.RequestsFor("transferMasterAuthority")) != 0 {
+				t.Fatal("building the request sent it")
+			}
+			hash := req.Hash
+
+			// A and B sign, each on their own copy; C abstains
+			for _, party := range parties[:2] {
+				req = roundTrip(t, req)
+				if err := alchemy.AddSignature(req, party); err != nil {
+					t.Fatal(err)
+				}
+			}
+			req = roundTrip(t, req)
+			if req.Hash != hash || len(req.Signatures) != 2 {
+				t.Fatalf("hash %s, %d signatures after the round trips", req.Hash, len(req.Signatures))
+			}
+
+			result, err := client.SubmitMultisig(req).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Hash == "" || result.Nonce != 7 {
+				t.Fatalf("result %+v", result)
+			}
+			sent := srv.RequestsFor("transferMasterAuthority")
+			want := []string{parties[0].Address(), parties[1].Address()}
+			if len(sent) != 1 || sent[0].SignatureErr != nil || !reflect.DeepEqual(sent[0].Signers, want) {
+				t.Fatalf("sent %+v, want signed by %v", sent, want)
+			}
+			message, err := alchemy.SignedMessage(sent[0].ParamMap)
+			if err != nil || crypto.Keccak256Hash([]byte(message)).Hex() != hash {
+				t.Fatalf("server rebuilt %q, %v, not the signed hash", message, err)
+			}
+		})
+	}
+}
+
+func TestMultisigDuplicates(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	parties := approvers(t, srv)
+	req, err := client.BuildUnsignedRequest(testToken, "transferMasterAuthority", []interface{}{testRecipient}, 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := alchemy.AddSignature(req, parties[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := alchemy.AddSignature(roundTrip(t, req), parties[0]); !errors.Is(err, alchemy.ErrDuplicateSigner) {
+		t.Fatalf("second signature of the same party: %v", err)
+	}
+
+	// A copy of a signature smuggled in after the fact is caught before sending
+	req.Signatures = append(req.Signatures, req.Signatures[0])
+	if err := client.SubmitMultisig(req).Err(); !errors.Is(err, alchemy.ErrDuplicateSigner) {
+		t.Fatalf("submit with a duplicate: %v", err)
+	}
+	if len(srv.RequestsFor("transferMasterAuthority")) != 0 {
+		t.Fatal("request with a duplicate signature sent")
+	}
+}
+
+func TestMultisigRejectsBeforeSending(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	parties := approvers(t, srv)
+	build := func() *alchemy.MultisigRequest {
+		req, err := client.BuildUnsignedRequest(testToken, "transferMasterAuthority", []interface{}{testRecipient}, 0).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+
+	// Nobody signed
+	if err := client.SubmitMultisig(build()).Err(); !errors.Is(err, alchemy.ErrNoSignatures) {
+		t.Fatalf("unsigned: %v", err)
+	}
+
+	// Params edited after signing
+	req := build()
+	if err := alchemy.AddSignature(req, parties[0]); err != nil {
+		t.Fatal(err)
+	}
+	req.Params["methodArgs"] = []interface{}{thirdToken}
+	if err := client.SubmitMultisig(req).Err(); !errors.Is(err, alchemy.ErrMultisigHash) {
+		t.Fatalf("tampered params: %v", err)
+	}
+	if err := alchemy.AddSignature(req, parties[1]); !errors.Is(err, alchemy.ErrMultisigHash) {
+		t.Fatalf("signing tampered params: %v", err)
+	}
+
+	// A signature attributed to another party
+	req = build()
+	if err := alchemy.AddSignature(req, parties[0]); err != nil {
+		t.Fatal(err)
+	}
+	req.Signatures[0].Signer = parties[2].Address()
+	err := client.SubmitMultisig(req).Err()
+	if !errors.Is(err, alchemy.ErrInvalidSignature) || operationError(t, err).Op != "SubmitMultisig" {
+		t.Fatalf("misattributed signature: %v", err)
+	}
+
+	if n := len(srv.RequestsFor("transferMasterAuthority")); n != 0 {
+		t.Fatalf("%d invalid requests sent", n)
+	}
+}
+
+func TestMultisigDefaultClient(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	useDefaultClient(t, client)
+	parties := approvers(t, srv)
+
+	req, err := alchemy.BuildUnsignedRequest(testToken, "transferMasterAuthority", []interface{}{testRecipient}, 3,
+		alchemy.WithIdempotencyKey("rotate-master")).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, party := range parties {
+		if err := alchemy.AddSignature(req, party); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := alchemy.SubmitMultisig(req).Err(); err != nil {
+		t.Fatal(err)
+	}
+	sent := srv.RequestsFor("transferMasterAuthority")
+	if len(sent) != 1 || len(sent[0].Signers) != 3 || sent[0].ParamMap["idempotencyKey"] != "rotate-master" {
+		t.Fatalf("sent %+v", sent)
+	}
+}
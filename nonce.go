@@ -0,0 +1,152 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ConfigNonceRetry enables automatic nonce recovery for write requests: when the server
+// rejects a request with a nonce error (e.g. "nonce too low"), the current nonce is fetched
+// with GetNonce and the request is re-signed with it and a fresh recentCheckpoint, up to
+// attempts times. Errors indicating the transaction was already accepted are never retried.
+// Disabled (0) by default.
+func ConfigNonceRetry(attempts int) {
+	if attempts < 0 {
+		attempts = 0
+	}
+	defaultClient.nonceRetries = attempts
+}
+
+// WithNonceRetry overrides ConfigNonceRetry for a single request
+func WithNonceRetry(attempts int) CallOption {
+	return func(c *callConfig) {
+		if attempts >= 0 {
+			c.nonceRetries = attempts
+		}
+	}
+}
+
+// GetNonce gets the next nonce the server expects from the configured key for tokenAddress
+func (c *Client) GetNonce(tokenAddress string) (r *ResponseHandler[int64]) {
+	defer withOperation(&r, "GetNonce", tokenAddress)
+	nonce, err := c.getAccountNonce(context.Background(), tokenAddress)
+	if err != nil {
+		return &ResponseHandler[int64]{err: err}
+	}
+	return &ResponseHandler[int64]{data: nonce}
+}
+
+// Internal method: get_nonce for the signer address
+func (c *Client) getAccountNonce(ctx context.Context, tokenAddress string) (int64, error) {
+	address, err := c.signerAddress(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := c.rpcCallContext(ctx, "get_nonce", map[string]interface{}{
+		"address": address,
+		"token":   tokenAddress,
+	}, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce json.Number
+	if err := json.Unmarshal(result, &nonce); err != nil {
+		return 0, fmt.Errorf("decode nonce: %w", err)
+	}
+	n, err := nonce.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("decode nonce: %w", err)
+	}
+	return n, nil
+}
+
+// Messages meaning the nonce is taken, possibly by this very request when an earlier attempt
+// went through: a nonce conflict, but not safe to retry
+var usedNonceMarkers = []string{
+	"nonce already used",
+	"nonce has already been used",
+}
+
+// Messages meaning the transaction already reached the server; retrying them could double-apply
+var acceptedErrorMarkers = append([]string{
+	"already known",
+	"already imported",
+	"known transaction",
+	"already accepted",
+	"duplicate",
+}, usedNonceMarkers...)
+
+var nonceErrorMarkers = []string{
+	"nonce too low",
+	"nonce too high",
+	"invalid nonce",
+	"nonce mismatch",
+}
+
+// Internal method: whether err is a nonce rejection that is safe to resync and retry
+func isRetryableNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, marker := range acceptedErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return false
+		}
+	}
+	for _, marker := range nonceErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Internal method: whether a write that failed with err provably left its nonce unused:
+// it failed before any request, never reached the server, or was rejected for a reason
+// other than its nonce. A transport error or timeout may have been processed.
+func nonceUnused(err error) bool {
+	var opErr *OperationError
+	if errors.As(err, &opErr) && opErr.Method == "" {
+		return true
+	}
+	if isRetryableWriteError(err) {
+		return true
+	}
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	msg := strings.ToLower(rpcErr.Message)
+	for _, markers := range [][]string{acceptedErrorMarkers, nonceErrorMarkers} {
+		for _, marker := range markers {
+			if strings.Contains(msg, marker) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// nonced is implemented by results that report the nonce the request was sent with
+type nonced interface {
+	setNonce(nonce int64)
+}
+
+func (r *TransactionResult) setNonce(nonce int64) {
+	if r != nil {
+		r.Nonce = nonce
+	}
+}
+
+// Internal method: record the nonce on results that support it
+func setResultNonce(result interface{}, nonce int64) {
+	if r, ok := result.(nonced); ok {
+		r.setNonce(nonce)
+	}
+}
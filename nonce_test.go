@@ -0,0 +1,78 @@
+package alchemy_test
+
+import (
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func nonceTooLow() alchemytest.Response {
+	return alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "nonce too low"}}
+}
+
+func TestNonceRetryResyncsAndResigns(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("eth_blockNumber",
+		alchemytest.Response{Result: "0x64"}, alchemytest.Response{Result: "0x65"})
+	srv.QueueResponse("mint", nonceTooLow())
+	srv.SetResult("get_nonce", 9)
+
+	tx, err := client.Mint(testToken, testRecipient, "1", 3, alchemy.WithNonceRetry(1)).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce != 9 {
+		t.Fatalf("result nonce %d, want 9", tx.Nonce)
+	}
+
+	reqs := srv.RequestsFor("mint")
+	if len(reqs) != 2 {
+		t.Fatalf("%d attempts, want 2", len(reqs))
+	}
+	retry := reqs[1]
+	if fmt.Sprint(retry.ParamMap["nonce"]) != "9" || fmt.Sprint(retry.ParamMap["recentCheckpoint"]) != "101" {
+		t.Fatalf("retry nonce %v, checkpoint %v", retry.ParamMap["nonce"], retry.ParamMap["recentCheckpoint"])
+	}
+	if retry.SignatureErr != nil {
+		t.Fatalf("retry not re-signed: %v", retry.SignatureErr)
+	}
+}
+
+func TestNonceRetryBounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []alchemy.CallOption
+		err      alchemytest.Response
+		attempts int
+	}{
+		{"disabled by default", nil, nonceTooLow(), 1},
+		{"bounded", []alchemy.CallOption{alchemy.WithNonceRetry(2)}, nonceTooLow(), 3},
+		{"already accepted", []alchemy.CallOption{alchemy.WithNonceRetry(2)},
+			alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "already known: nonce too low"}}, 1},
+		// The nonce may have been used by an earlier attempt of this very request
+		{"nonce already used", []alchemy.CallOption{alchemy.WithNonceRetry(2)},
+			alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "nonce already used"}}, 1},
+		{"nonce has already been used", []alchemy.CallOption{alchemy.WithNonceRetry(2)},
+			alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "Nonce has already been used"}}, 1},
+		{"other error", []alchemy.CallOption{alchemy.WithNonceRetry(2)},
+			alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "insufficient balance"}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetResponse("mint", tt.err)
+
+			if err := client.Mint(testToken, testRecipient, "1", 3, tt.opts...).Err(); err == nil {
+				t.Fatal("error not returned")
+			}
+			if n := len(srv.RequestsFor("mint")); n != tt.attempts {
+				t.Fatalf("%d attempts, want %d", n, tt.attempts)
+			}
+			if n := len(srv.RequestsFor("get_nonce")); n != tt.attempts-1 {
+				t.Fatalf("%d resyncs, want %d", n, tt.attempts-1)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+)
+
+// OperationError is the error of every failed Client operation. It names the operation
+// (the SDK method called, e.g. "Mint"), the token it acted on and the JSON-RPC method that
+// failed, so one Error callback shared by several calls can tell them apart:
+//
+//	var opErr *alchemy.OperationError
+//	if errors.As(err, &opErr) {
+//		log.Printf("%s on %s failed in %s: %v", opErr.Op, opErr.Token, opErr.Method, opErr.Err)
+//	}
+//
+// errors.Is and errors.As see through it, so matching ErrInsufficientBalance, *RPCError
+// and the other errors underneath works as before.
+type OperationError struct {
+	Op     string // SDK method, e.g. "Mint" or "GetBalance"
+	Token  string // token address, empty for operations not on a token
+	Method string // JSON-RPC method that failed, empty if the error came before any request
+	Signer string // WithSignerKey name of the signer used, empty for the client's key
+	Err    error
+}
+
+func (e *OperationError) Error() string {
+	msg := e.Op
+	if e.Token != "" {
+		msg += " " + e.Token
+	}
+	if e.Signer != "" {
+		msg += " as " + e.Signer
+	}
+	if e.Method != "" {
+		msg += ": " + e.Method
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// methodError records the JSON-RPC method of a failed request for OperationError. It is
+// transparent: the message is the underlying one.
+type methodError struct {
+	method string
+	err    error
+}
+
+func (e *methodError) Error() string { return e.err.Error() }
+
+func (e *methodError) Unwrap() error { return e.err }
+
+// Internal method: wrap a request failure with its JSON-RPC method
+func withMethod(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &methodError{method: method, err: err}
+}
+
+// signerError records the WithSignerKey signer of a failed request or signature for
+// OperationError. It is transparent like methodError.
+type signerError struct {
+	name string
+	err  error
+}
+
+func (e *signerError) Error() string { return e.err.Error() }
+
+func (e *signerError) Unwrap() error { return e.err }
+
+// Internal method: wrap a failure of the operation ctx belongs to with its named signer
+func withSigner(ctx context.Context, err error) error {
+	name := signerName(ctx)
+	if err == nil || name == "" {
+		return err
+	}
+	return &signerError{name: name, err: err}
+}
+
+// Internal method: wrap *err as an OperationError of op on token, deferred by the public
+// methods. Operations built on other operations (MintBig on Mint on CallWrite) report the
+// outermost name, the one the caller used.
+func withOperationErr(err *error, op, token string) {
+	if *err == nil {
+		return
+	}
+	if opErr, ok := (*err).(*OperationError); ok {
+		outer := *opErr
+		outer.Op = op
+		if outer.Token == "" {
+			outer.Token = token
+		}
+		*err = &outer
+		return
+	}
+	wrapped := &OperationError{Op: op, Token: token, Err: *err}
+	var methodErr *methodError
+	if errors.As(*err, &methodErr) {
+		wrapped.Method = methodErr.method
+	}
+	var signerErr *signerError
+	if errors.As(*err, &signerErr) {
+		wrapped.Signer = signerErr.name
+	}
+	*err = wrapped
+}
+
+// Internal method: withOperationErr for the error of a handler
+func withOperation[T any](r **ResponseHandler[T], op, token string) {
+	if *r != nil {
+		withOperationErr(&(*r).err, op, token)
+	}
+}
@@ -0,0 +1,110 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// operationError returns the *OperationError of err, failing the test if there is none
+func operationError(t *testing.T, err error) *alchemy.OperationError {
+	t.Helper()
+	var opErr *alchemy.OperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("err = %v, want an *OperationError", err)
+	}
+	return opErr
+}
+
+func TestOperationErrorSharedCallback(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("mint", -32000, "ERC20: insufficient balance")
+	srv.SetError("eth_getBalance", alchemytest.CodeInvalidParams, "bad")
+
+	var failed []*alchemy.OperationError
+	onError := func(err error) { failed = append(failed, operationError(t, err)) }
+	client.Mint(testToken, testRecipient, "100", 0).Error(onError)
+	client.Burn(testToken, "-1", 0).Error(onError)
+	client.GetBalance(testRecipient).Error(onError)
+
+	if len(failed) != 3 {
+		t.Fatalf("%d errors, want 3", len(failed))
+	}
+	for i, want := range []alchemy.OperationError{
+		{Op: "Mint", Token: testToken, Method: "mint"},
+		{Op: "Burn", Token: testToken}, // refused before any request
+		{Op: "GetBalance", Method: "eth_getBalance"},
+	} {
+		got := failed[i]
+		if got.Op != want.Op || got.Token != want.Token || got.Method != want.Method {
+			t.Fatalf("error %d: %+v, want %+v", i, *got, want)
+		}
+	}
+
+	// The layer is transparent to errors.Is and errors.As
+	mintErr := failed[0]
+	var rpcErr *alchemy.RPCError
+	if !errors.Is(mintErr, alchemy.ErrInsufficientBalance) || !errors.As(mintErr, &rpcErr) || rpcErr.Code != -32000 {
+		t.Fatalf("mint err = %v, want an insufficient balance *RPCError", mintErr)
+	}
+	if want := "Mint " + testToken + ": mint: " + mintErr.Err.Error(); mintErr.Error() != want {
+		t.Fatalf("message %q, want %q", mintErr.Error(), want)
+	}
+	if !errors.Is(failed[1], alchemy.ErrInvalidAmount) {
+		t.Fatalf("burn err = %v, want ErrInvalidAmount", failed[1])
+	}
+}
+
+func TestOperationErrorNamesOutermostOperation(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("mint", -32000, "account is blacklisted")
+
+	err := client.MintBig(testToken, testRecipient, big.NewInt(5), 0).Err()
+	opErr := operationError(t, err)
+	if opErr.Op != "MintBig" || opErr.Token != testToken || opErr.Method != "mint" {
+		t.Fatalf("%+v, want MintBig of mint", *opErr)
+	}
+	// Exactly one layer, however many operations were passed through
+	var inner *alchemy.OperationError
+	if errors.As(opErr.Err, &inner) {
+		t.Fatalf("nested operation error %v", inner)
+	}
+	if !errors.Is(err, alchemy.ErrBlacklisted) {
+		t.Fatalf("err = %v, want ErrBlacklisted", err)
+	}
+
+	// The default client's functions report the same
+	useDefaultClient(t, client)
+	if opErr := operationError(t, alchemy.Mint(testToken, testRecipient, "1", 0).Err()); opErr.Op != "Mint" {
+		t.Fatalf("default client op %q", opErr.Op)
+	}
+}
+
+func TestOperationErrorWrapsRetryAndClose(t *testing.T) {
+	var attempts atomic.Int32
+	_, client, _ := newTestServer(t,
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		alchemy.WithHTTPClient(failingTransport("getSupplyCap", refused, &attempts)))
+
+	err := client.GetSupplyCap(testToken).Err()
+	opErr := operationError(t, err)
+	var retryErr *alchemy.RetryError
+	if opErr.Op != "GetSupplyCap" || opErr.Method != "getSupplyCap" || !errors.As(err, &retryErr) || !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("err = %v (%+v), want a wrapped *RetryError", err, *opErr)
+	}
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.SubscribeTokenEvents(context.Background(), testToken)
+	if opErr := operationError(t, err); opErr.Op != "SubscribeTokenEvents" || opErr.Token != testToken || !errors.Is(err, alchemy.ErrClientClosed) {
+		t.Fatalf("err = %v, want ErrClientClosed from SubscribeTokenEvents", err)
+	}
+}
@@ -0,0 +1,165 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pausedEntry is a cached IsPaused result
+type pausedEntry struct {
+	paused  bool
+	expires time.Time
+}
+
+// ConfigPauseCacheTTL caches IsPaused results for ttl (0, the default, disables caching).
+// Pause and Unpause sent through the client drop the token's entry when they return; a
+// pause sent by someone else is seen after at most ttl.
+func ConfigPauseCacheTTL(ttl time.Duration) {
+	c := defaultClient
+	c.pausedMu.Lock()
+	defer c.pausedMu.Unlock()
+	c.pausedTTL = max(ttl, 0)
+	c.pausedCache = map[string]pausedEntry{}
+}
+
+// WithPauseCacheTTL caches IsPaused results (see ConfigPauseCacheTTL)
+func WithPauseCacheTTL(ttl time.Duration) Option {
+	return func(o *clientOptions) { o.pausedTTL = ttl }
+}
+
+// IsPaused reports whether the token is paused with a single read, cheaper than
+// GetTokenMetadata. Cached when a pause cache TTL is configured; WithBlock bypasses the cache.
+func (c *Client) IsPaused(tokenAddress string, opts ...CallOption) (r *ResponseHandler[bool]) {
+	defer withOperation(&r, "IsPaused", tokenAddress)
+	key := strings.ToLower(tokenAddress)
+	cacheable := c.newCallConfig(opts).block == nil
+
+	c.pausedMu.Lock()
+	ttl, generation := c.pausedTTL, c.pausedGeneration
+	if entry, ok := c.pausedCache[key]; ok && cacheable && time.Now().Before(entry.expires) {
+		c.pausedMu.Unlock()
+		return &ResponseHandler[bool]{data: entry.paused}
+	}
+	c.pausedMu.Unlock()
+
+	result := ClientCallRead[bool](c, tokenAddress, "paused", []interface{}{}, opts...)
+	if result.err != nil || ttl == 0 || !cacheable {
+		return result
+	}
+
+	c.pausedMu.Lock()
+	defer c.pausedMu.Unlock()
+	// A pause or unpause that returned while this read was in flight makes it stale
+	if generation == c.pausedGeneration {
+		if c.pausedCache == nil {
+			c.pausedCache = map[string]pausedEntry{}
+		}
+		c.pausedCache[key] = pausedEntry{paused: result.data, expires: time.Now().Add(ttl)}
+	}
+	return result
+}
+
+// Internal method: drop cached state a write may have changed. Runs after every dynamic
+// call, successful or not, since a failed request may still have been applied.
+func (c *Client) invalidateAfterWrite(tokenAddress, method string) {
+	switch method {
+	case "pause", "unpause":
+		c.pausedMu.Lock()
+		delete(c.pausedCache, strings.ToLower(tokenAddress))
+		c.pausedGeneration++
+		c.pausedMu.Unlock()
+	}
+
+	switch method {
+	case "updateMetadata", "pause", "unpause", "setSupplyCap", "retireToken":
+		c.metadataCache.invalidate(tokenAddress)
+	}
+}
+
+// pausedScanWorkers bounds the concurrent IsPaused reads of the GetPausedTokens fallback
+const pausedScanWorkers = 4
+
+// GetPausedTokens lists the tokens currently paused, paginated like ListTokens. Uses the
+// server's list_paused_tokens when available, which also reports the block and transaction
+// of each pause. Otherwise it filters a ListTokens page with IsPaused reads, four at a
+// time: pages may then hold fewer than limit tokens, or none while
+// HasMore is still true, Total is 0 and the pause block and transaction are left empty.
+func (c *Client) GetPausedTokens(cursor string, limit int) (r *ResponseHandler[*TokenPage]) {
+	defer withOperation(&r, "GetPausedTokens", "")
+	if limit < 0 {
+		return &ResponseHandler[*TokenPage]{err: fmt.Errorf("invalid limit %d", limit)}
+	}
+
+	params := map[string]interface{}{}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	result, err := c.rpcCall("list_paused_tokens", params)
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return &ResponseHandler[*TokenPage]{err: err}
+		}
+		page, err := c.pausedTokensFromListing(cursor, limit)
+		if err != nil {
+			return &ResponseHandler[*TokenPage]{err: err}
+		}
+		return &ResponseHandler[*TokenPage]{data: page}
+	}
+
+	var page TokenPage
+	if err := json.Unmarshal(result, &page); err != nil {
+		return &ResponseHandler[*TokenPage]{err: fmt.Errorf("decode paused tokens: %w", err)}
+	}
+	if page.Tokens == nil {
+		page.Tokens = []TokenSummary{}
+	}
+	for i := range page.Tokens {
+		page.Tokens[i].Address = checksummed(page.Tokens[i].Address)
+	}
+	return &ResponseHandler[*TokenPage]{data: &page}
+}
+
+// Internal method: the paused tokens of one ListTokens page, in listing order
+func (c *Client) pausedTokensFromListing(cursor string, limit int) (*TokenPage, error) {
+	listing := c.ListTokens(cursor, limit)
+	if listing.err != nil {
+		return nil, listing.err
+	}
+	tokens := listing.data.Tokens
+	paused := make([]bool, len(tokens))
+	errs := make([]error, len(tokens))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, pausedScanWorkers)
+	for i, token := range tokens {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			paused[i], errs[i] = c.IsPaused(address).Result()
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("token %s: %w", address, errs[i])
+			}
+		}(i, token.Address)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	page := &TokenPage{Tokens: []TokenSummary{}, NextCursor: listing.data.NextCursor}
+	for i, token := range tokens {
+		if paused[i] {
+			page.Tokens = append(page.Tokens, token)
+		}
+	}
+	return page, nil
+}
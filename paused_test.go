@@ -0,0 +1,279 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// isPaused calls IsPaused and fails the test on error
+func isPaused(t *testing.T, client *alchemy.Client, opts ...alchemy.CallOption) bool {
+	t.Helper()
+	paused, err := client.IsPaused(testToken, opts...).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paused
+}
+
+func TestIsPausedCacheInvalidatedByPause(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithPauseCacheTTL(time.Minute))
+	srv.SetResult("paused", false)
+
+	if isPaused(t, client) || isPaused(t, client) {
+		t.Fatal("token reported paused")
+	}
+	if n := len(srv.RequestsFor("paused")); n != 1 {
+		t.Fatalf("%d reads, want 1 cached", n)
+	}
+
+	// Pause → IsPaused sees the new state, not the cached one
+	if err := client.Pause(testToken, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	srv.SetResult("paused", true)
+	if !isPaused(t, client) {
+		t.Fatal("stale IsPaused after Pause")
+	}
+
+	// A failed Unpause may still have been applied, so it invalidates too
+	srv.SetError("unpause", -32000, "timeout")
+	if err := client.Unpause(testToken, 1).Err(); err == nil {
+		t.Fatal("Unpause succeeded")
+	}
+	srv.SetResult("paused", false)
+	if isPaused(t, client) {
+		t.Fatal("stale IsPaused after failed Unpause")
+	}
+	if n := len(srv.RequestsFor("paused")); n != 3 {
+		t.Fatalf("%d reads, want 3", n)
+	}
+
+	// Writes to another token or of another kind keep the entry
+	if err := client.Pause("0x9fE46736679d2D9a65F0992F2272dE9f3c7fa6e0", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Mint(testToken, testRecipient, "1", 2).Err(); err != nil {
+		t.Fatal(err)
+	}
+	isPaused(t, client)
+	if n := len(srv.RequestsFor("paused")); n != 3 {
+		t.Fatalf("%d reads, want 3", n)
+	}
+}
+
+func TestIsPausedReadInFlightDuringPauseNotCached(t *testing.T) {
+	release := make(chan struct{})
+	gated, arrived := gatedMethod("paused", release)
+	srv, client, _ := newTestServer(t, alchemy.WithPauseCacheTTL(time.Minute), alchemy.WithHTTPClient(gated))
+	srv.SetResult("paused", false)
+
+	stale := make(chan bool)
+	go func() {
+		paused, _ := client.IsPaused(testToken).Result()
+		stale <- paused
+	}()
+	<-arrived
+	// The read was sent before the pause; the server answers it after
+	if err := client.Pause(testToken, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	close(release)
+	if <-stale {
+		t.Fatal("read sent before the pause reported paused")
+	}
+
+	srv.SetResult("paused", true)
+	if !isPaused(t, client) {
+		t.Fatal("result of a read overtaken by Pause was cached")
+	}
+}
+
+func TestIsPausedCacheTTL(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithPauseCacheTTL(50*time.Millisecond))
+	srv.SetResult("paused", false)
+
+	isPaused(t, client)
+	isPaused(t, client)
+	time.Sleep(60 * time.Millisecond)
+	isPaused(t, client)
+	if n := len(srv.RequestsFor("paused")); n != 2 {
+		t.Fatalf("%d reads, want 2", n)
+	}
+
+	// Pinned reads bypass the cache
+	isPaused(t, client, alchemy.WithBlock(3))
+	if n := len(srv.RequestsFor("paused")); n != 3 {
+		t.Fatalf("%d reads, want 3", n)
+	}
+
+	// Without a TTL every call reads
+	srv, uncached, _ := newTestServer(t)
+	srv.SetResult("paused", true)
+	for i := 0; i < 3; i++ {
+		if !isPaused(t, uncached) {
+			t.Fatal("token reported unpaused")
+		}
+	}
+	if n := len(srv.RequestsFor("paused")); n != 3 {
+		t.Fatalf("%d reads, want 3", n)
+	}
+}
+
+// summaries builds a list_tokens page entry per address
+func summaries(addresses ...string) []map[string]interface{} {
+	tokens := []map[string]interface{}{}
+	for _, address := range addresses {
+		tokens = append(tokens, map[string]interface{}{"address": address, "symbol": "T" + address[len(address)-2:]})
+	}
+	return tokens
+}
+
+func TestGetPausedTokensPages(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("list_paused_tokens",
+		alchemytest.Response{Result: map[string]interface{}{
+			"tokens":     []map[string]interface{}{{"address": strings.ToLower(testToken), "symbol": "USDX", "pausedAtBlock": 120, "pauseTx": "0xabc"}},
+			"nextCursor": "c1",
+			"total":      2,
+		}},
+		alchemytest.Response{Result: map[string]interface{}{
+			"tokens": []map[string]interface{}{{"address": testRecipient, "symbol": "EURX"}},
+			"total":  2,
+		}},
+	)
+
+	var got []alchemy.TokenSummary
+	cursor := ""
+	for {
+		page, err := client.GetPausedTokens(cursor, 1).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, page.Tokens...)
+		if !page.HasMore() {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if len(got) != 2 || got[0].Address != testToken || got[0].PausedAtBlock != 120 || got[0].PauseTx != "0xabc" {
+		t.Fatalf("tokens %+v", got)
+	}
+	if got[1].Symbol != "EURX" || got[1].PausedAtBlock != 0 || got[1].PauseTx != "" {
+		t.Fatalf("second token %+v, want no pause details", got[1])
+	}
+
+	reqs := srv.RequestsFor("list_paused_tokens")
+	if len(reqs) != 2 || reqs[1].ParamMap["cursor"] != "c1" || fmt.Sprint(reqs[1].ParamMap["limit"]) != "1" {
+		t.Fatalf("requests %+v", reqs)
+	}
+	if len(srv.RequestsFor("list_tokens")) != 0 || len(srv.RequestsFor("paused")) != 0 {
+		t.Fatal("fallback used although the server lists paused tokens")
+	}
+	if err := client.GetPausedTokens("", -1).Err(); err == nil {
+		t.Fatal("expected an error for a negative limit")
+	}
+}
+
+// pausedTransport answers the paused reads of the fake server itself, true for the tokens in
+// paused and an error for failing, holding each read so concurrent reads overlap. It records
+// the largest number of reads in flight in peak.
+func pausedTransport(paused, failing map[string]bool, peak *atomic.Int32) *http.Client {
+	var inFlight atomic.Int32
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var req struct {
+			ID     interface{} `json:"id"`
+			Method string      `json:"method"`
+			Params struct {
+				Token string `json:"token"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.Method != "paused" {
+			return http.DefaultTransport.RoundTrip(r)
+		}
+
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for old := peak.Load(); n > old && !peak.CompareAndSwap(old, n); old = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		token := strings.ToLower(req.Params.Token)
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": paused[token]}
+		if failing[token] {
+			resp = map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32000, "message": "node timeout"}}
+		}
+		encoded, _ := json.Marshal(resp)
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"application/json"}},
+			Body: io.NopCloser(bytes.NewReader(encoded)), Request: r}, nil
+	})}
+}
+
+func TestGetPausedTokensFallback(t *testing.T) {
+	var addresses []string
+	for i := 1; i <= 9; i++ {
+		addresses = append(addresses, fmt.Sprintf("0x%040d", i))
+	}
+	paused := map[string]bool{addresses[1]: true, addresses[2]: true, addresses[7]: true}
+	var peak atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(pausedTransport(paused, nil, &peak)))
+	srv.SetError("list_paused_tokens", alchemytest.CodeMethodNotFound, "method not found")
+	srv.QueueResponse("list_tokens",
+		alchemytest.Response{Result: map[string]interface{}{"tokens": summaries(addresses[:6]...), "nextCursor": "c1", "total": 9}},
+		alchemytest.Response{Result: map[string]interface{}{"tokens": summaries(addresses[6:]...), "total": 9}},
+	)
+
+	first, err := client.GetPausedTokens("", 6).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := client.GetPausedTokens(first.NextCursor, 6).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, token := range append(first.Tokens, second.Tokens...) {
+		got = append(got, token.Address)
+	}
+	if want := []string{addresses[1], addresses[2], addresses[7]}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("paused %v, want %v in listing order", got, want)
+	}
+	if !first.HasMore() || first.NextCursor != "c1" || second.HasMore() || first.Total != 0 {
+		t.Fatalf("pages %+v, %+v", first, second)
+	}
+	if p := peak.Load(); p < 2 || p > 4 {
+		t.Fatalf("%d reads in flight, want concurrent reads bounded by 4", p)
+	}
+	if reqs := srv.RequestsFor("list_tokens"); len(reqs) != 2 || reqs[1].ParamMap["cursor"] != "c1" {
+		t.Fatalf("listing requests %+v", reqs)
+	}
+}
+
+func TestGetPausedTokensFallbackReadFailure(t *testing.T) {
+	failing := "0x" + strings.Repeat("0", 39) + "2"
+	var peak atomic.Int32
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(pausedTransport(nil, map[string]bool{failing: true}, &peak)))
+	srv.SetError("list_paused_tokens", alchemytest.CodeMethodNotFound, "method not found")
+	srv.SetResult("list_tokens", map[string]interface{}{"tokens": summaries("0x"+strings.Repeat("0", 39)+"1", failing)})
+
+	err := client.GetPausedTokens("", 0).Err()
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) || !strings.Contains(err.Error(), "token "+failing) {
+		t.Fatalf("err = %v, want the failed read of %s", err, failing)
+	}
+}
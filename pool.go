@@ -0,0 +1,82 @@
+package alchemy
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Connection pool defaults of the SDK's HTTP transport. net/http keeps only 2 idle
+// connections per host, which makes bursts of calls open new connections.
+const (
+	DefaultMaxIdleConnsPerHost = 16
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultDialTimeout         = 30 * time.Second
+)
+
+// maxDrainBytes is the most of an unread response body drained so its connection can be
+// reused; larger remainders are cheaper to discard with the connection
+const maxDrainBytes = 64 << 10
+
+// TransportTuning adjusts connection handling of the SDK's HTTP transport. Zero fields
+// keep the defaults.
+type TransportTuning struct {
+	MaxIdleConnsPerHost int           // idle connections kept per host (default DefaultMaxIdleConnsPerHost)
+	IdleConnTimeout     time.Duration // how long an idle connection is kept (default DefaultIdleConnTimeout)
+	DialTimeout         time.Duration // TCP connect timeout (default DefaultDialTimeout)
+	// DisableHTTP2 turns off ForceAttemptHTTP2 and HTTP/2 negotiation, so every request
+	// uses HTTP/1.1. HTTP/2 is attempted by default.
+	DisableHTTP2 bool
+}
+
+// ConfigTransportTuning applies connection tuning to the transport used for all SDK
+// requests. A zero TransportTuning restores the defaults.
+func ConfigTransportTuning(tuning TransportTuning) {
+	c := defaultClient
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	c.tuning = tuning
+	c.rebuildTransport()
+}
+
+// WithTransportTuning tunes connection handling (see ConfigTransportTuning)
+func WithTransportTuning(tuning TransportTuning) Option {
+	return func(o *clientOptions) { o.tuning = &tuning }
+}
+
+// Internal method: apply the tuning to transport
+func (t TransportTuning) apply(transport *http.Transport) {
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if t.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+	}
+	if transport.MaxIdleConns != 0 && transport.MaxIdleConns < transport.MaxIdleConnsPerHost {
+		transport.MaxIdleConns = transport.MaxIdleConnsPerHost
+	}
+
+	transport.IdleConnTimeout = DefaultIdleConnTimeout
+	if t.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = t.IdleConnTimeout
+	}
+
+	dialTimeout := DefaultDialTimeout
+	if t.DialTimeout > 0 {
+		dialTimeout = t.DialTimeout
+	}
+	transport.DialContext = (&net.Dialer{Timeout: dialTimeout, KeepAlive: 30 * time.Second}).DialContext
+
+	transport.ForceAttemptHTTP2 = !t.DisableHTTP2
+	if t.DisableHTTP2 {
+		// A non-nil empty map disables HTTP/2 negotiation entirely
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+}
+
+// Internal method: read what is left of a response body (up to maxDrainBytes) and close
+// it, so the connection goes back to the pool
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+	body.Close()
+}
@@ -0,0 +1,125 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// newCountingNode is a node answering eth_getBalance that counts the TCP connections
+// opened to it. fail decides, per call number, which failure to answer with instead.
+func newCountingNode(t testing.TB, fail func(call int64) string) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var conns, calls atomic.Int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		switch fail(calls.Add(1)) {
+		case "gateway":
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("<html>" + strings.Repeat("upstream unavailable ", 500) + "</html>"))
+		case "rate limited":
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "rpc error":
+			json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32000, "message": "header not found"}})
+		case "too large":
+			json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x" + strings.Repeat("1", 4096)})
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0xde0b6b3a7640000"})
+		}
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, &conns
+}
+
+func TestConnectionReuse(t *testing.T) {
+	srv, conns := newCountingNode(t, func(int64) string { return "" })
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		if err := client.GetBalance(testRecipient).Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("1000 sequential calls opened %d connections, want 1", n)
+	}
+}
+
+func TestConnectionReuseAfterErrors(t *testing.T) {
+	failures := []string{"", "gateway", "rate limited", "rpc error", "too large"}
+	srv, conns := newCountingNode(t, func(call int64) string { return failures[call%int64(len(failures))] })
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useDefaultClient(t, client)
+	alchemy.ConfigMaxResponseSize(1024)
+
+	errs := 0
+	for i := 0; i < 1000; i++ {
+		err := client.GetBalance(testRecipient).Err()
+		if err != nil {
+			errs++
+		}
+		var tooLarge *alchemy.ResponseTooLargeError
+		if (i+1)%5 == 4 && !errors.As(err, &tooLarge) {
+			t.Fatalf("call %d: err = %v, want a *ResponseTooLargeError", i+1, err)
+		}
+	}
+	if errs != 800 {
+		t.Fatalf("%d calls failed, want 800", errs)
+	}
+	// Error bodies are drained, so every call goes over the same connection
+	if n := conns.Load(); n != 1 {
+		t.Fatalf("opened %d connections, want 1", n)
+	}
+}
+
+func TestTransportTuningValidation(t *testing.T) {
+	for _, tuning := range []alchemy.TransportTuning{{MaxIdleConnsPerHost: -1}, {IdleConnTimeout: -1}, {DialTimeout: -1}} {
+		if _, err := alchemy.NewClient("http://localhost", alchemy.WithTransportTuning(tuning)); err == nil {
+			t.Errorf("tuning %+v accepted", tuning)
+		}
+	}
+	if _, err := alchemy.NewClient("http://localhost", alchemy.WithTransportTuning(alchemy.TransportTuning{}), alchemy.WithHTTPClient(http.DefaultClient)); err == nil {
+		t.Error("tuning combined with a custom HTTP client")
+	}
+}
+
+func BenchmarkGetBalanceConnections(b *testing.B) {
+	srv, conns := newCountingNode(b, func(int64) string { return "" })
+	client, err := alchemy.NewClient(srv.URL)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := client.GetBalance(testRecipient).Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(conns.Load()), "conns")
+}
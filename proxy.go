@@ -0,0 +1,99 @@
+package alchemy
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ConfigProxy routes all HTTP and WebSocket requests through the proxy at rawURL
+// (http, https, socks5 or socks5h scheme, optionally with user:password credentials).
+// An empty rawURL restores the default of honoring HTTP_PROXY/HTTPS_PROXY/NO_PROXY.
+// Proxy credentials are redacted from returned errors.
+func ConfigProxy(rawURL string) error {
+	var parsed *url.URL
+	if rawURL != "" {
+		u, err := parseProxyURL(rawURL)
+		if err != nil {
+			return err
+		}
+		parsed = u
+	}
+
+	c := defaultClient
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	c.proxyURL = parsed
+	c.rebuildTransport()
+	return nil
+}
+
+// Internal method: parse and validate a proxy URL without leaking its credentials
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy url: %s", redactURLString(rawURL))
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q in %s", u.Scheme, u.Redacted())
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy url: %s", u.Redacted())
+	}
+	return u, nil
+}
+
+// Internal method: proxy selection for the transport, callers hold transportMu
+func (c *Client) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if c.proxyURL == nil {
+		return http.ProxyFromEnvironment
+	}
+	return http.ProxyURL(c.proxyURL)
+}
+
+// proxyError hides proxy credentials in the message while keeping the cause
+type proxyError struct {
+	msg string
+	err error
+}
+
+func (e *proxyError) Error() string { return e.msg }
+func (e *proxyError) Unwrap() error { return e.err }
+
+// Internal method: strip configured proxy credentials from err's message
+func (c *Client) redactProxyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	c.transportMu.Lock()
+	u := c.proxyURL
+	c.transportMu.Unlock()
+	if u == nil || u.User == nil {
+		return err
+	}
+
+	msg := err.Error()
+	redacted := strings.ReplaceAll(msg, u.String(), u.Redacted())
+	redacted = strings.ReplaceAll(redacted, u.User.String(), "xxxxx")
+	if password, ok := u.User.Password(); ok && password != "" {
+		redacted = strings.ReplaceAll(redacted, password, "xxxxx")
+	}
+	if redacted == msg {
+		return err
+	}
+	return &proxyError{msg: redacted, err: err}
+}
+
+// Internal method: redact credentials of an unparsable URL string
+func redactURLString(raw string) string {
+	if at := strings.LastIndex(raw, "@"); at >= 0 {
+		if scheme := strings.Index(raw, "://"); scheme >= 0 && scheme < at {
+			return raw[:scheme+3] + "xxxxx" + raw[at:]
+		}
+		return "xxxxx" + raw[at:]
+	}
+	return raw
+}
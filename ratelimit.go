@@ -0,0 +1,63 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRateLimited matches any *RateLimitError via errors.Is
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is returned when an endpoint answers HTTP 429, or 503 with a Retry-After
+// header. With a retry policy the request is retried first (see RetryPolicy); the error
+// surfaces once the attempts are used up or the requested wait exceeds MaxRetryAfter.
+type RateLimitError struct {
+	Method     string
+	StatusCode int
+	RetryAfter time.Duration // wait requested by the server, 0 if it didn't send Retry-After
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s rate limited: HTTP %d", e.Method, e.StatusCode)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", retry after %v", e.RetryAfter)
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrRateLimited) match
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// Internal method: *RateLimitError for a 429 or a 503 with Retry-After, nil otherwise
+func rateLimitError(resp *http.Response, method string, now time.Time) error {
+	header := resp.Header.Get("Retry-After")
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		(resp.StatusCode != http.StatusServiceUnavailable || header == "") {
+		return nil
+	}
+	return &RateLimitError{Method: method, StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(header, now)}
+}
+
+// Internal method: Retry-After as delay-seconds or HTTP-date, 0 when missing or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(min(seconds, int64(24*time.Hour/time.Second))) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
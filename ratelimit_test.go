@@ -0,0 +1,113 @@
+package alchemy_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// limited is a canned rate-limit response, without Retry-After when retryAfter is empty
+func limited(status int, retryAfter string) alchemytest.Response {
+	resp := alchemytest.Response{Status: status}
+	if retryAfter != "" {
+		resp.Header = http.Header{"Retry-After": {retryAfter}}
+	}
+	return resp
+}
+
+func TestRateLimitError(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     alchemytest.Response
+		min, max time.Duration // accepted RetryAfter range
+	}{
+		{"seconds", limited(http.StatusTooManyRequests, "3"), 3 * time.Second, 3 * time.Second},
+		{"http date", limited(http.StatusTooManyRequests, time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat)), 3 * time.Second, 5 * time.Second},
+		{"past date", limited(http.StatusTooManyRequests, "Mon, 02 Jan 2006 15:04:05 GMT"), 0, 0},
+		{"missing header", limited(http.StatusTooManyRequests, ""), 0, 0},
+		{"invalid header", limited(http.StatusTooManyRequests, "soon"), 0, 0},
+		{"503 with header", limited(http.StatusServiceUnavailable, "2"), 2 * time.Second, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetResponse("getSupplyCap", tt.resp)
+
+			err := client.GetSupplyCap(testToken).Err()
+			var rateErr *alchemy.RateLimitError
+			if !errors.As(err, &rateErr) || !errors.Is(err, alchemy.ErrRateLimited) {
+				t.Fatalf("err = %v, want a *RateLimitError", err)
+			}
+			if rateErr.StatusCode != tt.resp.Status || rateErr.Method != "getSupplyCap" {
+				t.Fatalf("error %+v", rateErr)
+			}
+			if rateErr.RetryAfter < tt.min || rateErr.RetryAfter > tt.max {
+				t.Fatalf("RetryAfter = %v, want %v-%v", rateErr.RetryAfter, tt.min, tt.max)
+			}
+		})
+	}
+
+	// A plain 503 is an outage, not a rate limit
+	srv, client, _ := newTestServer(t)
+	srv.SetResponse("getSupplyCap", limited(http.StatusServiceUnavailable, ""))
+	if err := client.GetSupplyCap(testToken).Err(); err == nil || errors.Is(err, alchemy.ErrRateLimited) {
+		t.Fatalf("err = %v, want a non rate-limit error", err)
+	}
+}
+
+func TestRateLimitSharesRetryBudget(t *testing.T) {
+	policy := alchemy.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	srv, client, _ := newTestServer(t, alchemy.WithRetryPolicy(policy))
+	srv.QueueResponse("getSupplyCap", limited(http.StatusTooManyRequests, ""), limited(http.StatusServiceUnavailable, "0"))
+	srv.SetResult("getSupplyCap", "1000")
+	if supplyCap, err := client.GetSupplyCap(testToken).Result(); err != nil || supplyCap != "1000" {
+		t.Fatalf("GetSupplyCap = %q, %v", supplyCap, err)
+	}
+	if n := len(srv.RequestsFor("getSupplyCap")); n != 3 {
+		t.Fatalf("%d attempts, want 3", n)
+	}
+
+	// Rate-limited attempts use up MaxAttempts like any other failure
+	srv.Reset()
+	srv.SetResponse("getSupplyCap", limited(http.StatusTooManyRequests, ""))
+	if err := client.GetSupplyCap(testToken).Err(); !errors.Is(err, alchemy.ErrRateLimited) {
+		t.Fatalf("err = %v, want ErrRateLimited", err)
+	}
+	if n := len(srv.RequestsFor("getSupplyCap")); n != 3 {
+		t.Fatalf("%d attempts, want 3", n)
+	}
+}
+
+func TestRateLimitRetryWaitsRetryAfter(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	srv.QueueResponse("getSupplyCap", limited(http.StatusTooManyRequests, "1"))
+	srv.SetResult("getSupplyCap", "1000")
+
+	start := time.Now()
+	if err := client.GetSupplyCap(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, before Retry-After", elapsed)
+	}
+}
+
+func TestRateLimitBeyondMaxRetryAfterReturned(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 5, MaxRetryAfter: time.Second}))
+	srv.SetResponse("getSupplyCap", limited(http.StatusTooManyRequests, "60"))
+
+	start := time.Now()
+	err := client.GetSupplyCap(testToken).Err()
+	var rateErr *alchemy.RateLimitError
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter != time.Minute {
+		t.Fatalf("err = %v, want a *RateLimitError asking for 1m", err)
+	}
+	if n := len(srv.RequestsFor("getSupplyCap")); n != 1 || time.Since(start) > time.Second {
+		t.Fatalf("%d attempts in %v, want 1 without waiting", n, time.Since(start))
+	}
+}
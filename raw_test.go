@@ -0,0 +1,87 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestRawMatchesServerResult(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	metadata := `{"name":"Euro","symbol":"EURX","decimals":2,"supply":"5","website":"https://example.com","audit":{"by":"x"}}`
+	issued := `{"hash":"0x1","token":"` + strings.ToLower(testToken) + `","masterAuthority":"` + strings.ToLower(testRecipient) + `"}`
+	srv.SetResult("getTokenMetadata", json.RawMessage(metadata))
+	srv.SetResult("mint", json.RawMessage(`"0xabc"`))
+	srv.SetResult("create_token", json.RawMessage(issued))
+
+	// Decoded into a struct without the extra fields
+	meta := client.GetTokenMetadata(testToken)
+	if meta.Err() != nil || string(meta.Raw()) != metadata {
+		t.Fatalf("metadata raw %s, err %v", meta.Raw(), meta.Err())
+	}
+
+	mint := client.Mint(testToken, testRecipient, "1", 0)
+	if mint.Err() != nil || string(mint.Raw()) != `"0xabc"` {
+		t.Fatalf("mint raw %s, err %v", mint.Raw(), mint.Err())
+	}
+
+	// Addresses are checksummed in the decoded result only
+	token := client.CreateToken("Euro", "EURX", 2, testRecipient)
+	if token.Err() != nil || string(token.Raw()) != issued {
+		t.Fatalf("create raw %s, err %v", token.Raw(), token.Err())
+	}
+	if result, _ := token.Result(); result.Token != testToken {
+		t.Fatalf("token %s", result.Token)
+	}
+}
+
+func TestRawSetWhenDecodingFails(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithStrictDecoding())
+	renamed := `{"txHash":"0x1"}`
+	srv.SetResult("mint", json.RawMessage(renamed))
+
+	result := client.Mint(testToken, testRecipient, "1", 0)
+	if !errors.Is(result.Err(), alchemy.ErrSchemaMismatch) {
+		t.Fatalf("err = %v", result.Err())
+	}
+	if string(result.Raw()) != renamed {
+		t.Fatalf("raw %s", result.Raw())
+	}
+}
+
+func TestRawIsACopy(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getSupplyCap", "1000")
+
+	first := client.GetSupplyCap(testToken).Raw()
+	retained := string(first)
+	first[1] = 'X' // callers may modify their copy
+
+	srv.SetResult("getSupplyCap", "2000")
+	second := client.GetSupplyCap(testToken).Raw()
+	if string(second) != `"2000"` {
+		t.Fatalf("second raw %s", second)
+	}
+	if retained != `"1000"` || string(first) != `"X000"` {
+		t.Fatalf("retained raw changed by a later call: %s", first)
+	}
+}
+
+func TestRawNilWithoutServerResponse(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithMetadataCache(time.Minute))
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2})
+
+	if raw := client.GetTokenMetadata(testToken).Raw(); raw == nil {
+		t.Fatal("no raw result for a server response")
+	}
+	if raw := client.GetTokenMetadata(testToken).Raw(); raw != nil {
+		t.Fatalf("cached result has raw %s", raw)
+	}
+	if raw := client.Mint(testToken, "0x12", "1", 0).Raw(); raw != nil {
+		t.Fatalf("rejected call has raw %s", raw)
+	}
+}
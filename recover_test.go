@@ -0,0 +1,168 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// randomParams builds a param map of the value types requests carry
+func randomParams(rng *rand.Rand) map[string]interface{} {
+	params := map[string]interface{}{}
+	for i, n := 0, 1+rng.Intn(8); i < n; i++ {
+		key := fmt.Sprintf("k%x", rng.Int63())
+		switch rng.Intn(7) {
+		case 0:
+			params[key] = fmt.Sprintf("value-%d-é,%x", rng.Int(), rng.Int63())
+		case 1:
+			params[key] = rng.Int63() - rng.Int63()
+		case 2:
+			params[key] = rng.Uint64()
+		case 3:
+			params[key] = rng.Intn(2) == 1
+		case 4:
+			params[key] = new(big.Int).Lsh(big.NewInt(rng.Int63()), uint(rng.Intn(190)))
+		case 5:
+			params[key] = []interface{}{testRecipient, fmt.Sprint(rng.Uint64())}
+		case 6:
+			params[key] = int32(rng.Intn(1 << 20))
+		}
+	}
+	return params
+}
+
+func TestRecoverSignerRoundTrip(t *testing.T) {
+	signer, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(628))
+
+	for i := 0; i < 300; i++ {
+		params := randomParams(rng)
+		format := alchemy.VFormat(rng.Intn(2))
+		payload, err := alchemy.SignPayload(signer, params, format)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		sig := payload.Signature
+		hex := alchemy.Signature{R: sig.HexR(), S: sig.HexS(), V: sig.HexV()}
+
+		// The server side sees the params after a JSON round trip, signature included
+		received := decodeJSONParams(t, params)
+		received["signature"] = map[string]interface{}{"r": sig.R, "s": sig.S, "v": sig.V}
+
+		for name, recoverWith := range map[string]func() (string, error){
+			"decimal": func() (string, error) { return alchemy.RecoverSigner(params, &sig) },
+			"hex":     func() (string, error) { return alchemy.RecoverSigner(params, &hex) },
+			"mixed": func() (string, error) {
+				return alchemy.RecoverSigner(params, &alchemy.Signature{R: hex.R, S: sig.S, V: hex.V})
+			},
+			"received": func() (string, error) { return alchemy.RecoverSigner(received, &sig) },
+		} {
+			got, err := recoverWith()
+			if err != nil {
+				t.Fatalf("case %d %s (%v): %v", i, name, params, err)
+			}
+			if got != signer.Address() {
+				t.Fatalf("case %d %s (%v): recovered %s, want %s", i, name, params, got, signer.Address())
+			}
+		}
+
+		// Any change to the params changes the recovered address
+		params["tampered"] = "1"
+		if got, err := alchemy.RecoverSigner(params, &sig); err == nil && got == signer.Address() {
+			t.Fatalf("case %d: tampered params still recover the signer", i)
+		}
+	}
+}
+
+func TestRecoverSignerRejects(t *testing.T) {
+	signer, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := map[string]interface{}{"nonce": int64(1), "token": testToken}
+	payload, err := alchemy.SignPayload(signer, params, alchemy.VFormatEthereum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := payload.Signature
+
+	s, _ := new(big.Int).SetString(sig.S, 10)
+	highS := new(big.Int).Sub(crypto.S256().Params().N, s)
+
+	for name, bad := range map[string]*alchemy.Signature{
+		"nil":       nil,
+		"malformed": {R: "0xzz", S: sig.S, V: sig.V},
+		"v 2":       {R: sig.R, S: sig.S, V: "2"},
+		"v 29":      {R: sig.R, S: sig.S, V: "29"},
+		"high s":    {R: sig.R, S: highS.String(), V: sig.V},
+		"zero r":    {R: "0", S: sig.S, V: sig.V},
+	} {
+		if _, err := alchemy.RecoverSigner(params, bad); !errors.Is(err, alchemy.ErrInvalidSignature) {
+			t.Fatalf("%s: err = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+}
+
+// decodeJSONParams returns params as a server decodes them, numbers as json.Number
+func decodeJSONParams(t *testing.T, params map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var decoded map[string]interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+// personalSigner signs EIP-191 personal messages of the hash, like a hardware wallet
+type personalSigner struct{ alchemy.Signer }
+
+func (s personalSigner) SignsPersonalMessages() bool { return true }
+
+func (s personalSigner) Sign(hash []byte) ([]byte, error) {
+	return s.Signer.Sign(alchemy.PersonalDigest(hash))
+}
+
+func TestRecoverSignerPersonalScheme(t *testing.T) {
+	key, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := map[string]interface{}{"methodArgs": []interface{}{testRecipient, "5"}, "nonce": 1, "token": testToken}
+	payload, err := alchemy.SignPayload(personalSigner{key}, params, alchemy.VFormatEthereum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.Signature.Scheme != alchemy.SignatureSchemePersonal {
+		t.Fatalf("scheme %q", payload.Signature.Scheme)
+	}
+	if signer, err := alchemy.RecoverSigner(params, &payload.Signature); err != nil || signer != key.Address() {
+		t.Fatalf("recovered %s, %v", signer, err)
+	}
+
+	// Without the scheme the digest differs, an unknown scheme is refused
+	sig := payload.Signature
+	sig.Scheme = ""
+	if signer, _ := alchemy.RecoverSigner(params, &sig); signer == key.Address() {
+		t.Fatal("personal signature recovered as a plain one")
+	}
+	sig.Scheme = "eip712"
+	if _, err := alchemy.RecoverSigner(params, &sig); !errors.Is(err, alchemy.ErrInvalidSignature) {
+		t.Fatalf("unknown scheme: %v", err)
+	}
+}
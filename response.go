@@ -0,0 +1,103 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxBodyExcerpt is the number of body bytes quoted in an UnexpectedResponseError
+const maxBodyExcerpt = 256
+
+// ErrUnexpectedResponse matches any *UnexpectedResponseError via errors.Is
+var ErrUnexpectedResponse = errors.New("unexpected response")
+
+// UnexpectedResponseError is returned when an endpoint answers with something other than
+// a JSON-RPC response, e.g. an HTML maintenance page or a plain-text 503 from a gateway
+type UnexpectedResponseError struct {
+	Method      string
+	StatusCode  int
+	ContentType string
+	Body        string // truncated excerpt of the response body
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	msg := fmt.Sprintf("unexpected response to %s: HTTP %d", e.Method, e.StatusCode)
+	if e.ContentType != "" {
+		msg += " (" + e.ContentType + ")"
+	}
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrUnexpectedResponse) match
+func (e *UnexpectedResponseError) Is(target error) bool {
+	return target == ErrUnexpectedResponse
+}
+
+// Internal method: decode a JSON-RPC response envelope, rejecting non-JSON bodies and
+// envelopes with neither result nor error instead of yielding zero values
+func decodeRPCResponse(resp *http.Response, body []byte, method string) (json.RawMessage, error) {
+	contentType := resp.Header.Get("Content-Type")
+	unexpected := func() error {
+		return &UnexpectedResponseError{
+			Method:      method,
+			StatusCode:  resp.StatusCode,
+			ContentType: contentType,
+			Body:        bodyExcerpt(body),
+		}
+	}
+
+	// HTML is never a JSON-RPC reply; other types (servers often send JSON as text/plain)
+	// are judged by whether the body decodes
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil && mediaType == "text/html" {
+		return nil, unexpected()
+	}
+
+	var rpcResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int             `json:"code"`
+			Message string          `json:"message"`
+			Data    json.RawMessage `json:"data"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return nil, unexpected()
+	}
+
+	if rpcResp.Error != nil {
+		return nil, &RPCError{Code: rpcResp.Error.Code, Message: rpcResp.Error.Message, Data: rpcResp.Error.Data}
+	}
+	if rpcResp.Result == nil {
+		return nil, unexpected()
+	}
+
+	return rpcResp.Result, nil
+}
+
+// Internal method: single-line, truncated body for error messages
+func bodyExcerpt(body []byte) string {
+	excerpt := body
+	truncated := false
+	if len(excerpt) > maxBodyExcerpt {
+		excerpt = excerpt[:maxBodyExcerpt]
+		truncated = true
+	}
+	// Don't cut a multi-byte character in half
+	for len(excerpt) > 0 && !utf8.Valid(excerpt) {
+		excerpt = excerpt[:len(excerpt)-1]
+	}
+
+	text := strings.Join(strings.Fields(string(excerpt)), " ")
+	if truncated {
+		text += "..."
+	}
+	return text
+}
@@ -0,0 +1,101 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// gatewayPage is what a load balancer or captive portal sends instead of a JSON-RPC reply
+type gatewayPage struct {
+	name        string
+	status      int
+	contentType string
+	body        string
+}
+
+var gatewayPages = []gatewayPage{
+	{"html 503", http.StatusServiceUnavailable, "text/html; charset=utf-8", "<html><body><h1>Down for maintenance</h1></body></html>"},
+	{"plain 502", http.StatusBadGateway, "text/plain", "Bad Gateway"},
+	{"html 200", http.StatusOK, "text/html", "<html><body>Sign in to the Wi-Fi</body></html>"},
+	{"plain 200", http.StatusOK, "text/plain", "OK"},
+	{"json without result", http.StatusOK, "application/json", `{"jsonrpc":"2.0","id":1}`},
+	{"long html 500", http.StatusInternalServerError, "text/html", "<html>" + strings.Repeat("error ", 500) + "</html>"},
+}
+
+func newGateway(t *testing.T, page gatewayPage) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", page.contentType)
+		w.WriteHeader(page.status)
+		fmt.Fprint(w, page.body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// checkUnexpected asserts err is an *UnexpectedResponseError describing page
+func checkUnexpected(t *testing.T, err error, page gatewayPage) {
+	t.Helper()
+	var unexpected *alchemy.UnexpectedResponseError
+	if !errors.As(err, &unexpected) || !errors.Is(err, alchemy.ErrUnexpectedResponse) {
+		t.Fatalf("err = %v, want an *UnexpectedResponseError", err)
+	}
+	if unexpected.StatusCode != page.status || unexpected.ContentType != page.contentType {
+		t.Fatalf("status %d (%s), want %d (%s)", unexpected.StatusCode, unexpected.ContentType, page.status, page.contentType)
+	}
+	excerpt := strings.TrimSuffix(unexpected.Body, "...")
+	if excerpt == "" || len(excerpt) > 256 || !strings.HasPrefix(page.body, excerpt) {
+		t.Fatalf("body excerpt %q", unexpected.Body)
+	}
+	if !strings.Contains(err.Error(), fmt.Sprint(page.status)) {
+		t.Fatalf("status missing from %q", err)
+	}
+}
+
+func TestGatewayPagesFromNode(t *testing.T) {
+	for _, page := range gatewayPages {
+		t.Run(page.name, func(t *testing.T) {
+			gateway := newGateway(t, page)
+			client, err := alchemy.NewClient(gateway.URL, alchemy.WithPrivateKey(strings.Repeat("1", 64)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			balance := client.GetBalance(testRecipient)
+			checkUnexpected(t, balance.Err(), page)
+			if data, _ := balance.Result(); data != nil {
+				t.Fatalf("zero-valued data returned: %+v", data)
+			}
+			// Writes start with the hidden block number lookup
+			checkUnexpected(t, client.Mint(testToken, testRecipient, "1", 0).Err(), page)
+		})
+	}
+}
+
+func TestGatewayPagesFromService(t *testing.T) {
+	for _, page := range gatewayPages {
+		t.Run(page.name, func(t *testing.T) {
+			node := alchemytest.NewServer()
+			t.Cleanup(node.Close)
+			gateway := newGateway(t, page)
+			client, err := alchemy.NewClient(node.URL, alchemy.WithServiceURL(gateway.URL),
+				alchemy.WithPrivateKey(strings.Repeat("1", 64)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			created := client.CreateToken("Dollar", "USDX", 6, testRecipient)
+			checkUnexpected(t, created.Err(), page)
+			if data, _ := created.Result(); data != nil {
+				t.Fatalf("zero-valued result returned: %+v", data)
+			}
+		})
+	}
+}
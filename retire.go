@@ -0,0 +1,58 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrRetireSupply is returned by RetireToken for a token with supply left, unless
+// ForceRetire is passed
+var ErrRetireSupply = errors.New("token still has supply (burn it or pass ForceRetire)")
+
+// ErrRetireConfirmation is returned by RetireToken when the confirmation symbol doesn't
+// match the token's symbol
+var ErrRetireConfirmation = errors.New("confirmation symbol doesn't match the token")
+
+// ForceRetire lets RetireToken retire a token whose supply isn't zero. The balances stay
+// on chain but can never be moved, minted against or burned again.
+func ForceRetire() CallOption {
+	return func(c *callConfig) {
+		c.forceRetire = true
+	}
+}
+
+// RetireToken permanently retires a token (the chain's retireToken), so nothing can be
+// minted against it again. Two interlocks run before signing, on freshly read metadata:
+// confirmSymbol must equal the token's symbol exactly, guarding against retiring the wrong
+// address, and the supply must be zero unless ForceRetire is passed. The metadata cache
+// entry of the token is dropped when the call returns.
+func (c *Client) RetireToken(tokenAddress, confirmSymbol string, nonce int64, opts ...CallOption) (r *ResponseHandler[*TransactionResult]) {
+	defer withOperation(&r, "RetireToken", tokenAddress)
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return &ResponseHandler[*TransactionResult]{err: err}
+	}
+	cfg := c.newCallConfig(opts)
+
+	metadata := c.RefreshTokenMetadata(tokenAddress)
+	if metadata.err != nil {
+		return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("read metadata: %w", metadata.err)}
+	}
+	if metadata.data == nil {
+		return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("read metadata: empty metadata for %s", tokenAddress)}
+	}
+	if confirmSymbol != metadata.data.Symbol {
+		return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("%w: got %q, token symbol is %q", ErrRetireConfirmation, confirmSymbol, metadata.data.Symbol)}
+	}
+	if !cfg.forceRetire {
+		supply, ok := new(big.Int).SetString(metadata.data.Supply, 10)
+		if !ok {
+			return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("read metadata: invalid supply %q", metadata.data.Supply)}
+		}
+		if supply.Sign() != 0 {
+			return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("%w: supply is %s", ErrRetireSupply, supply)}
+		}
+	}
+
+	return c.CallWrite(tokenAddress, "retireToken", []interface{}{}, nonce, opts...)
+}
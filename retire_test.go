@@ -0,0 +1,76 @@
+package alchemy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestRetireTokenInterlocks(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test", "symbol": "TST", "decimals": 6, "supply": "250"})
+
+	for name, tc := range map[string]struct {
+		symbol string
+		opts   []alchemy.CallOption
+		want   error
+	}{
+		"wrong symbol":          {"USDX", nil, alchemy.ErrRetireConfirmation},
+		"symbol case":           {"tst", nil, alchemy.ErrRetireConfirmation},
+		"empty symbol":          {"", []alchemy.CallOption{alchemy.ForceRetire()}, alchemy.ErrRetireConfirmation},
+		"supply left":           {"TST", nil, alchemy.ErrRetireSupply},
+		"invalid token address": {"TST", nil, alchemy.ErrInvalidAddress},
+	} {
+		token := testToken
+		if name == "invalid token address" {
+			token = "0x12"
+		}
+		if err := client.RetireToken(token, tc.symbol, 1, tc.opts...).Err(); !errors.Is(err, tc.want) {
+			t.Fatalf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+	if n := len(srv.RequestsFor("retireToken")); n != 0 {
+		t.Fatalf("%d retire requests sent past the interlocks", n)
+	}
+
+	// ForceRetire overrides the supply check only
+	result, err := client.RetireToken(testToken, "TST", 1, alchemy.ForceRetire()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Hash == "" {
+		t.Fatal("no transaction hash")
+	}
+	if n := len(srv.RequestsFor("retireToken")); n != 1 {
+		t.Fatalf("%d retire requests, want 1", n)
+	}
+}
+
+func TestRetireTokenInvalidatesMetadata(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithMetadataCache(time.Minute))
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test", "symbol": "TST", "decimals": 6, "supply": "0"})
+
+	if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+	// The interlocks read fresh metadata rather than the cached entry
+	if err := client.RetireToken(testToken, "TST", 7).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 2 {
+		t.Fatalf("%d metadata reads, want 2", n)
+	}
+	req := srv.RequestsFor("retireToken")[0]
+	if req.ParamMap["token"] != testToken || req.Signer == "" {
+		t.Fatalf("retire request %+v", req)
+	}
+
+	if err := client.GetTokenMetadata(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("getTokenMetadata")); n != 3 {
+		t.Fatalf("%d metadata reads, want the cache dropped after retiring", n)
+	}
+}
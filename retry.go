@@ -0,0 +1,159 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryPolicy retries failed requests. Reads are retried on any transient error (see
+// IsTransient). Writes are retried only when they can't have been processed: the request
+// was never sent (connection refused, DNS failure) or was rate limited (*RateLimitError).
+// A rate-limited attempt counts against MaxAttempts like any other and waits at least the
+// server's Retry-After. The zero value disables retries.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts including the first, <= 1 disables retries
+	InitialBackoff time.Duration // delay before the first retry, doubled per attempt (default 200ms)
+	MaxBackoff     time.Duration // cap on the delay (default 5s)
+	// MaxRetryAfter is the longest Retry-After waited for (default 30s). Longer ones return
+	// the *RateLimitError right away so the caller can schedule the retry.
+	MaxRetryAfter time.Duration
+	// MaxElapsedTime bounds a call's attempts and backoff sleeps together (0: no bound). A
+	// retry whose backoff would end past the budget, or past the context deadline if that
+	// comes first, isn't attempted. An attempt in flight isn't cut short; bound it with
+	// WithTimeout or a context deadline.
+	MaxElapsedTime time.Duration
+}
+
+// ErrRetryBudgetExhausted is reported by a *RetryError when retrying stopped because the
+// next attempt wouldn't start within RetryPolicy.MaxElapsedTime
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryError is returned when a retried call fails: after more than one attempt, or when
+// the retry budget or the context stopped the retries. errors.Is and errors.As see both
+// Err and Stopped.
+type RetryError struct {
+	Attempts int           // attempts made
+	Elapsed  time.Duration // time spent, attempts and backoff included
+	Err      error         // error of the last attempt
+	// Stopped is why retrying stopped before MaxAttempts: ErrRetryBudgetExhausted, or the
+	// context's error when it was canceled or its deadline left no room for a retry. Nil
+	// when the attempts ran out or the last error wasn't retryable.
+	Stopped error
+}
+
+func (e *RetryError) Error() string {
+	reason := ""
+	if e.Stopped != nil {
+		reason = " (" + e.Stopped.Error() + ")"
+	}
+	return fmt.Sprintf("gave up after %d attempts in %v%s: %v", e.Attempts, e.Elapsed.Round(time.Millisecond), reason, e.Err)
+}
+
+// Unwrap returns the last attempt's error and, if set, Stopped
+func (e *RetryError) Unwrap() []error {
+	if e.Stopped == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.Stopped}
+}
+
+// Internal method: validate the policy
+func (p RetryPolicy) validate() error {
+	if p.MaxAttempts < 0 || p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.MaxRetryAfter < 0 || p.MaxElapsedTime < 0 {
+		return fmt.Errorf("alchemy: invalid retry policy %+v", p)
+	}
+	return nil
+}
+
+// Internal method: delay before retry number attempt (1-based)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	if delay == 0 {
+		delay = 200 * time.Millisecond
+	}
+	max := p.MaxBackoff
+	if max == 0 {
+		max = 5 * time.Second
+	}
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+// Internal method: longest Retry-After to wait for
+func (p RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxRetryAfter == 0 {
+		return 30 * time.Second
+	}
+	return p.MaxRetryAfter
+}
+
+// Internal method: whether a request that failed with err may be sent again
+func isRetryableRequestError(ctx context.Context, err error) bool {
+	if isWriteRequest(ctx) {
+		return isRetryableWriteError(err)
+	}
+	return IsTransient(err)
+}
+
+// Internal method: run call under the client's retry policy
+func (c *Client) withRetry(ctx context.Context, method string, call func() (json.RawMessage, error)) (json.RawMessage, error) {
+	begin := time.Now()
+	// Retries must start before deadline: the budget's or the context's, whichever is first
+	deadline, budgetFirst := time.Time{}, false
+	if c.retry.MaxElapsedTime > 0 {
+		deadline, budgetFirst = begin.Add(c.retry.MaxElapsedTime), true
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline, budgetFirst = ctxDeadline, false
+	}
+	giveUp := func(attempts int, err, stopped error) error {
+		if attempts == 1 && stopped == nil {
+			return err
+		}
+		return &RetryError{Attempts: attempts, Elapsed: time.Since(begin), Err: err, Stopped: stopped}
+	}
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		result, err := call()
+		if c.logger != nil {
+			attrs := []any{"method", method, "attempt", attempt, "duration", time.Since(start), "error", err}
+			if tags := TagsFromContext(ctx); tags != nil {
+				attrs = append(attrs, "tags", tags)
+			}
+			c.logger.DebugContext(ctx, "alchemy request", attrs...)
+		}
+		if err == nil {
+			return result, nil
+		}
+		if attempt >= c.retry.MaxAttempts || !isRetryableRequestError(ctx, err) {
+			return result, giveUp(attempt, err, nil)
+		}
+
+		delay := c.retry.backoff(attempt)
+		var limited *RateLimitError
+		if errors.As(err, &limited) {
+			if limited.RetryAfter > c.retry.maxRetryAfter() {
+				return result, giveUp(attempt, err, nil)
+			}
+			delay = max(delay, limited.RetryAfter)
+		}
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			if budgetFirst {
+				return result, giveUp(attempt, err, ErrRetryBudgetExhausted)
+			}
+			return result, giveUp(attempt, err, context.DeadlineExceeded)
+		}
+		if ctxErr := sleepContext(ctx, delay); ctxErr != nil {
+			return nil, giveUp(attempt, err, ctxErr)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// retryError returns the *RetryError of err, failing the test if there is none
+func retryError(t *testing.T, err error) *alchemy.RetryError {
+	t.Helper()
+	var retryErr *alchemy.RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("err = %v, want a *RetryError", err)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("err = %v doesn't wrap the attempt error", err)
+	}
+	return retryErr
+}
+
+func TestRetryErrorReportsAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	_, client, _ := newTestServer(t,
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+		alchemy.WithHTTPClient(failingTransport("getSupplyCap", refused, &attempts)))
+
+	err := client.GetSupplyCap(testToken).Err()
+	retryErr := retryError(t, err)
+	if retryErr.Attempts != 3 || attempts.Load() != 3 || retryErr.Stopped != nil {
+		t.Fatalf("%+v after %d attempts", *retryErr, attempts.Load())
+	}
+	// Backoff of 1ms then 2ms
+	if retryErr.Elapsed < 3*time.Millisecond {
+		t.Fatalf("elapsed %v, less than the backoff", retryErr.Elapsed)
+	}
+	if !strings.Contains(err.Error(), "gave up after 3 attempts") {
+		t.Fatalf("message %q", err)
+	}
+}
+
+func TestRetryMaxElapsedTime(t *testing.T) {
+	var attempts atomic.Int32
+	// Attempts at 0 and ~20ms; the next would start at ~60ms, past the 50ms budget
+	_, client, _ := newTestServer(t,
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 10, InitialBackoff: 20 * time.Millisecond, MaxElapsedTime: 50 * time.Millisecond}),
+		alchemy.WithHTTPClient(failingTransport("getSupplyCap", refused, &attempts)))
+
+	err := client.GetSupplyCap(testToken).Err()
+	retryErr := retryError(t, err)
+	if !errors.Is(err, alchemy.ErrRetryBudgetExhausted) || retryErr.Stopped != alchemy.ErrRetryBudgetExhausted {
+		t.Fatalf("err = %v, want ErrRetryBudgetExhausted", err)
+	}
+	if retryErr.Attempts != 2 || attempts.Load() != 2 {
+		t.Fatalf("%d attempts reported, %d made, want 2", retryErr.Attempts, attempts.Load())
+	}
+	if retryErr.Elapsed < 20*time.Millisecond || retryErr.Elapsed >= 50*time.Millisecond {
+		t.Fatalf("elapsed %v, want the first backoff and no sleep past the budget", retryErr.Elapsed)
+	}
+}
+
+func TestRetryContextDeadlineWinsOverBudget(t *testing.T) {
+	var attempts atomic.Int32
+	_, client, _ := newTestServer(t,
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 10, InitialBackoff: 20 * time.Millisecond, MaxElapsedTime: 10 * time.Second}),
+		alchemy.WithHTTPClient(failingTransport("getSupplyCap", refused, &attempts)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := client.GetSupplyCap(testToken, alchemy.WithContext(ctx)).Err()
+	retryErr := retryError(t, err)
+	if !errors.Is(err, context.DeadlineExceeded) || errors.Is(err, alchemy.ErrRetryBudgetExhausted) {
+		t.Fatalf("err = %v, want the context deadline", err)
+	}
+	if retryErr.Attempts != 2 || time.Since(start) >= 50*time.Millisecond {
+		t.Fatalf("%d attempts in %v, want 2 before the deadline", retryErr.Attempts, time.Since(start))
+	}
+}
+
+func TestRetryBackoffInterruptedByCancel(t *testing.T) {
+	var attempts atomic.Int32
+	_, client, _ := newTestServer(t,
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute}),
+		alchemy.WithHTTPClient(failingTransport("getSupplyCap", refused, &attempts)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start := time.Now()
+	err := client.GetSupplyCap(testToken, alchemy.WithContext(ctx)).Err()
+	retryErr := retryError(t, err)
+	if !errors.Is(err, context.Canceled) || retryErr.Attempts != 1 {
+		t.Fatalf("err = %v, want a canceled first backoff", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("cancel took %v to interrupt the backoff", elapsed)
+	}
+}
+
+func TestRetryPolicyRejectsNegativeBudget(t *testing.T) {
+	_, err := alchemy.NewClient("http://localhost:8545", alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxElapsedTime: -time.Second}))
+	if err == nil {
+		t.Fatal("expected an error for a negative MaxElapsedTime")
+	}
+}
@@ -0,0 +1,59 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Role is a token authority role
+type Role string
+
+// Roles supported by the chain
+const (
+	RoleMaster    Role = "MASTER_ROLE"
+	RoleMint      Role = "MINT_ROLE"
+	RoleBurn      Role = "BURN_ROLE"
+	RolePause     Role = "PAUSE_ROLE"
+	RoleBlacklist Role = "BLACKLIST_ROLE"
+)
+
+// knownRoles lists the roles the chain supports out of the box
+var knownRoles = []Role{RoleMaster, RoleMint, RoleBurn, RolePause, RoleBlacklist}
+
+// ErrInvalidRole is returned when a role fails validation before signing
+var ErrInvalidRole = errors.New("invalid role")
+
+// String returns the role name as sent to the server
+func (r Role) String() string {
+	return string(r)
+}
+
+// Validate rejects empty roles, roles containing whitespace and near-misses of
+// known roles (e.g. "MINTROLE" or "mint_role"). Other custom roles pass through.
+func (r Role) Validate() error {
+	name := string(r)
+	if name == "" {
+		return fmt.Errorf("%w: empty role", ErrInvalidRole)
+	}
+	if strings.TrimSpace(name) != name || strings.ContainsAny(name, " \t\r\n") {
+		return fmt.Errorf("%w: %q contains whitespace", ErrInvalidRole, name)
+	}
+
+	for _, known := range knownRoles {
+		if r == known {
+			return nil
+		}
+		if normalizeRoleName(name) == normalizeRoleName(string(known)) {
+			return fmt.Errorf("%w: %q, did you mean %s?", ErrInvalidRole, name, known)
+		}
+	}
+	return nil
+}
+
+// normalizeRoleName folds case and separators so near-miss spellings compare equal
+func normalizeRoleName(name string) string {
+	name = strings.ToUpper(name)
+	name = strings.NewReplacer("_", "", "-", "").Replace(name)
+	return strings.TrimSuffix(name, "ROLE")
+}
@@ -0,0 +1,88 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestRoleValidate(t *testing.T) {
+	tests := []struct {
+		role  alchemy.Role
+		valid bool
+	}{
+		{alchemy.RoleMaster, true},
+		{alchemy.RoleMint, true},
+		{alchemy.RoleBurn, true},
+		{alchemy.RolePause, true},
+		{alchemy.RoleBlacklist, true},
+		{"COMPLIANCE_ROLE", true},
+		{"custom.role-1", true},
+		{"", false},
+		{" MINT_ROLE", false},
+		{"MINT ROLE", false},
+		{"MINTROLE", false},
+		{"mint_role", false},
+		{"Mint-Role", false},
+		{"MINT", false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%q", tt.role), func(t *testing.T) {
+			err := tt.role.Validate()
+			if tt.valid && err != nil {
+				t.Fatalf("rejected: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, alchemy.ErrInvalidRole) {
+				t.Fatalf("err = %v, want ErrInvalidRole", err)
+			}
+		})
+	}
+}
+
+func TestGrantAuthorityRoles(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	if err := client.GrantAuthority(testToken, "", testRecipient, 0).Err(); !errors.Is(err, alchemy.ErrInvalidRole) {
+		t.Fatalf("empty role: err = %v, want ErrInvalidRole", err)
+	}
+	if err := client.RevokeCustomAuthority(testToken, "MINTROLE", testRecipient, 0).Err(); !errors.Is(err, alchemy.ErrInvalidRole) {
+		t.Fatalf("near-miss: err = %v, want ErrInvalidRole", err)
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent for invalid roles", n)
+	}
+
+	if err := client.GrantCustomAuthority(testToken, "COMPLIANCE_ROLE", testRecipient, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RevokeAuthority(testToken, alchemy.RoleMint, testRecipient, 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+	for method, want := range map[string]string{
+		"grantAuthority":  "[COMPLIANCE_ROLE " + testRecipient + "]",
+		"revokeAuthority": "[MINT_ROLE " + testRecipient + "]",
+	} {
+		if got := fmt.Sprint(srv.RequestsFor(method)[0].ParamMap["methodArgs"]); got != want {
+			t.Fatalf("%s methodArgs = %s, want %s", method, got, want)
+		}
+	}
+}
+
+func TestHasAuthority(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getAuthorities", []string{"0x70997970c51812dc3a010c7d01b50e0d17dc79c8"})
+
+	holders, err := client.GetAuthorities(testToken, alchemy.RoleMint).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holders) != 1 || holders[0] != testRecipient {
+		t.Fatalf("holders %v, want the checksummed %s", holders, testRecipient)
+	}
+	has, err := client.HasAuthority(testToken, alchemy.RoleMint, testRecipient).Result()
+	if err != nil || !has {
+		t.Fatalf("HasAuthority = %v, %v", has, err)
+	}
+}
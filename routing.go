@@ -0,0 +1,101 @@
+package alchemy
+
+import "context"
+
+// EndpointRole selects the endpoint a request is sent to when a read endpoint is
+// configured (see WithReadURL)
+type EndpointRole int
+
+const (
+	EndpointRead  EndpointRole = iota + 1 // the read replica
+	EndpointWrite                         // the primary, the client's node and service URLs
+)
+
+func (r EndpointRole) String() string {
+	switch r {
+	case EndpointRead:
+		return "read"
+	case EndpointWrite:
+		return "write"
+	default:
+		return "auto"
+	}
+}
+
+// primaryMethods always go to the write endpoint: the checkpoint block a write is signed
+// with, and the state a write is built on, must not lag behind the primary
+var primaryMethods = map[string]bool{
+	"eth_blockNumber": true,
+	"eth_syncing":     true,
+	"get_nonce":       true,
+}
+
+// endpointRoleKey carries a WithEndpoint override to the request path
+type endpointRoleKey struct{}
+
+// ConfigReadURL sends the reads of the default client to a replica (see WithReadURL); empty
+// sends them to the Config URL again
+func ConfigReadURL(url string) {
+	defaultClient.readNodeURL = url
+	defaultClient.readServiceURL = readServiceURL(url)
+}
+
+// WithReadURL sends reads to a replica at url: its Ethereum node at url and its token
+// service at url + "/rpc", as for NewClient. Reads are the unsigned node queries
+// (eth_getBalance, blocks, receipts) and the token service reads (GetTokenMetadata,
+// balances, holders, events, listings). Signed writes, eth_blockNumber (the recentCheckpoint
+// of every signed request), eth_syncing and nonce lookups go to the client's URLs, the
+// write endpoint, so they never see a replica lagging behind the primary. Reads made on
+// behalf of a write (preflight checks, metadata lookups) use the replica. HealthCheck,
+// Verify and WebSocket subscriptions use the write endpoint.
+func WithReadURL(url string) Option {
+	return func(o *clientOptions) {
+		o.readNodeURL = url
+		o.readServiceURL = readServiceURL(url)
+	}
+}
+
+// WithReadNodeURL sets the replica Ethereum node URL alone (see WithReadURL)
+func WithReadNodeURL(url string) Option {
+	return func(o *clientOptions) { o.readNodeURL = url }
+}
+
+// WithReadServiceURL sets the full replica token service URL alone (see WithReadURL)
+func WithReadServiceURL(url string) Option {
+	return func(o *clientOptions) { o.readServiceURL = url }
+}
+
+// WithEndpoint sends the reads of one call to the given endpoint, e.g. EndpointWrite to
+// compare a replica's answer with the primary's. Writes and the requests always sent to the
+// write endpoint aren't affected. Without a read endpoint both roles are the same.
+func WithEndpoint(role EndpointRole) CallOption {
+	return func(c *callConfig) { c.endpoint = role }
+}
+
+// Internal method: token service URL of the replica at url
+func readServiceURL(url string) string {
+	if url == "" {
+		return ""
+	}
+	return url + "/rpc"
+}
+
+// Internal method: the endpoint a request for the node or service endpoint goes to
+func (c *Client) routeEndpoint(ctx context.Context, endpoint, method string) string {
+	if c.readNodeURL == "" && c.readServiceURL == "" {
+		return endpoint
+	}
+	if isWriteRequest(ctx) || primaryMethods[method] {
+		return endpoint
+	}
+	if role, _ := ctx.Value(endpointRoleKey{}).(EndpointRole); role == EndpointWrite {
+		return endpoint
+	}
+	switch {
+	case endpoint == c.nodeEndpoint() && c.readNodeURL != "":
+		return c.readNodeURL
+	case endpoint == c.serviceEndpoint() && c.readServiceURL != "":
+		return c.readServiceURL
+	}
+	return endpoint
+}
@@ -0,0 +1,161 @@
+package alchemy_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// newReadSplit returns a client writing to primary and reading from replica
+func newReadSplit(t *testing.T, opts ...alchemy.Option) (primary, replica *alchemytest.Server, client *alchemy.Client) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	primary, replica = failoverServer(t, key), failoverServer(t, key)
+	client, err = primary.NewClient(append([]alchemy.Option{alchemy.WithKey(key), alchemy.WithReadURL(replica.URL)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return primary, replica, client
+}
+
+// routedTo fails the test unless method reached want and not other since the last reset
+func routedTo(t *testing.T, method string, want, other *alchemytest.Server) {
+	t.Helper()
+	if len(want.RequestsFor(method)) == 0 {
+		t.Fatalf("%s not sent to the expected endpoint", method)
+	}
+	if n := len(other.RequestsFor(method)); n != 0 {
+		t.Fatalf("%d %s requests sent to the other endpoint", n, method)
+	}
+}
+
+func TestReadWriteSplitRouting(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(*alchemy.Client) error
+		replica []string // methods expected on the replica only
+		primary []string // methods expected on the primary only
+	}{
+		{
+			name:    "GetTokenMetadata",
+			call:    func(c *alchemy.Client) error { return c.GetTokenMetadata(testToken).Err() },
+			replica: []string{"getTokenMetadata"},
+			primary: []string{"eth_blockNumber"},
+		},
+		{
+			name:    "GetTokenBalance",
+			call:    func(c *alchemy.Client) error { return c.GetTokenBalance(testToken, testRecipient).Err() },
+			replica: []string{"balanceOf"},
+			primary: []string{"eth_blockNumber"},
+		},
+		{
+			name:    "GetTokenHolders",
+			call:    func(c *alchemy.Client) error { return c.GetTokenHolders(testToken, "", 10).Err() },
+			replica: []string{"getTokenHolders"},
+		},
+		{
+			name: "GetTokenEvents",
+			call: func(c *alchemy.Client) error {
+				return c.GetTokenEvents(testToken, alchemy.EventFilter{}).Err()
+			},
+			replica: []string{"get_token_events"},
+			primary: []string{"eth_blockNumber"},
+		},
+		{
+			name:    "GetBalance",
+			call:    func(c *alchemy.Client) error { return c.GetBalance(testRecipient).Err() },
+			replica: []string{"eth_getBalance"},
+		},
+		{
+			name:    "CurrentCheckpoint",
+			call:    func(c *alchemy.Client) error { return c.CurrentCheckpoint().Err() },
+			primary: []string{"eth_blockNumber"},
+		},
+		{
+			name:    "GetNonce",
+			call:    func(c *alchemy.Client) error { return c.GetNonce(testToken).Err() },
+			primary: []string{"get_nonce"},
+		},
+		{
+			name:    "Mint",
+			call:    func(c *alchemy.Client) error { return c.Mint(testToken, testRecipient, "5", 0).Err() },
+			primary: []string{"mint", "eth_blockNumber"},
+		},
+		{
+			name: "Mint WithEndpoint(EndpointRead)",
+			call: func(c *alchemy.Client) error {
+				return c.Mint(testToken, testRecipient, "5", 0, alchemy.WithEndpoint(alchemy.EndpointRead)).Err()
+			},
+			primary: []string{"mint", "eth_blockNumber"},
+		},
+		{
+			name: "GetTokenBalance WithEndpoint(EndpointWrite)",
+			call: func(c *alchemy.Client) error {
+				return c.GetTokenBalance(testToken, testRecipient, alchemy.WithEndpoint(alchemy.EndpointWrite)).Err()
+			},
+			primary: []string{"balanceOf", "eth_blockNumber"},
+		},
+		{
+			name: "GetBalance WithEndpoint(EndpointWrite)",
+			call: func(c *alchemy.Client) error {
+				return c.GetBalance(testRecipient, alchemy.WithEndpoint(alchemy.EndpointWrite)).Err()
+			},
+			primary: []string{"eth_getBalance"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, replica, client := newReadSplit(t)
+			for _, srv := range []*alchemytest.Server{primary, replica} {
+				srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Test", "symbol": "TST", "decimals": 6})
+				srv.SetResult("balanceOf", "100")
+				srv.SetResult("getTokenHolders", map[string]interface{}{"holders": []interface{}{}})
+				srv.SetResult("get_token_events", []interface{}{})
+			}
+			if err := tt.call(client); err != nil {
+				t.Fatal(err)
+			}
+			for _, method := range tt.replica {
+				routedTo(t, method, replica, primary)
+			}
+			for _, method := range tt.primary {
+				routedTo(t, method, primary, replica)
+			}
+		})
+	}
+}
+
+func TestReadWriteSplitSingleEndpoint(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("balanceOf", "100")
+	if err := client.GetTokenBalance(testToken, testRecipient, alchemy.WithEndpoint(alchemy.EndpointRead)).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.RequestsFor("balanceOf")) != 1 {
+		t.Fatal("balanceOf not sent to the only endpoint")
+	}
+}
+
+func TestReadWriteSplitDefaultClient(t *testing.T) {
+	primary, replica, client := newReadSplit(t)
+	useDefaultClient(t, client)
+	alchemy.ConfigReadURL("")
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	routedTo(t, "eth_getBalance", primary, replica)
+
+	alchemy.ConfigReadURL(replica.URL)
+	if err := alchemy.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(replica.RequestsFor("eth_getBalance")) != 1 {
+		t.Fatal("eth_getBalance not sent to the replica")
+	}
+}
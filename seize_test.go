@@ -0,0 +1,54 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+const blacklistedAccount = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+
+func TestSeize(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	tx, err := client.Seize(testToken, blacklistedAccount, testRecipient, "250", 4).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash == "" {
+		t.Fatal("no transaction hash")
+	}
+	req := srv.RequestsFor("seize")[0]
+	if got := fmt.Sprint(req.ParamMap["methodArgs"]); got != "["+blacklistedAccount+" "+testRecipient+" 250]" {
+		t.Fatalf("methodArgs = %s", got)
+	}
+	if fmt.Sprint(req.ParamMap["nonce"]) != "4" || req.SignatureErr != nil {
+		t.Fatalf("nonce %v, signature error %v", req.ParamMap["nonce"], req.SignatureErr)
+	}
+}
+
+func TestSeizeValidation(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	tests := []struct {
+		name           string
+		from, to, amnt string
+		want           error
+	}{
+		{"bad from", "0x12", testRecipient, "1", alchemy.ErrInvalidAddress},
+		{"zero to", blacklistedAccount, "0x0000000000000000000000000000000000000000", "1", alchemy.ErrInvalidAddress},
+		{"negative", blacklistedAccount, testRecipient, "-1", alchemy.ErrInvalidAmount},
+		{"empty", blacklistedAccount, testRecipient, "", alchemy.ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.Seize(testToken, tt.from, tt.to, tt.amnt, 0).Err(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}
@@ -0,0 +1,122 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ServerInfo describes the token service deployment
+type ServerInfo struct {
+	Version string   `json:"version"`
+	ChainID uint64   `json:"chainId"`
+	Methods []string `json:"methods"`
+	// EventRetentionBlocks is the number of recent blocks whose events the server keeps,
+	// 0 when it doesn't say (assumed unlimited)
+	EventRetentionBlocks int64 `json:"eventRetentionBlocks,omitempty"`
+	// CapabilitiesKnown is false for older servers that don't implement get_server_info;
+	// Version and Methods are empty then
+	CapabilitiesKnown bool `json:"-"`
+}
+
+// Supports reports whether method is in the advertised method list (case-insensitive).
+// Always false when capabilities are unknown.
+func (s *ServerInfo) Supports(method string) bool {
+	if s == nil {
+		return false
+	}
+	for _, m := range s.Methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetServerInfo gets the server version, chain ID and supported methods (cached after the
+// first successful call). Servers without get_server_info yield a ServerInfo with
+// CapabilitiesKnown false instead of an error. Each call returns its own copy.
+func (c *Client) GetServerInfo() (r *ResponseHandler[*ServerInfo]) {
+	defer withOperation(&r, "GetServerInfo", "")
+	info, err := c.getServerInfo()
+	if err != nil {
+		return &ResponseHandler[*ServerInfo]{err: err}
+	}
+	return &ResponseHandler[*ServerInfo]{data: info}
+}
+
+// SupportsMethod reports whether the server advertises method, using the cached capability
+// list. Returns false when capabilities are unknown or can't be fetched.
+func (c *Client) SupportsMethod(name string) bool {
+	info, err := c.getServerInfo()
+	if err != nil {
+		return false
+	}
+	return info.Supports(name)
+}
+
+// Internal method: cached get_server_info, a copy the caller may modify. The lock isn't held
+// during the request, so a slow server doesn't block a cache reset; concurrent first
+// callers share one request.
+func (c *Client) getServerInfo() (*ServerInfo, error) {
+	c.serverInfoMu.Lock()
+	cached, generation := c.serverInfo, c.serverInfoGeneration
+	c.serverInfoMu.Unlock()
+	if cached != nil {
+		return cached.clone(), nil
+	}
+
+	info := &ServerInfo{}
+	result, err := c.serverInfoFlight.do(context.Background(), "get_server_info", func() (json.RawMessage, error) {
+		return c.rpcCall("get_server_info", map[string]interface{}{})
+	})
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return nil, err
+		}
+	} else {
+		if err := json.Unmarshal(result, info); err != nil {
+			return nil, fmt.Errorf("decode server info: %w", err)
+		}
+		info.CapabilitiesKnown = true
+	}
+
+	// A reset during the request means it may have gone to the previous endpoint
+	c.serverInfoMu.Lock()
+	if c.serverInfo == nil && c.serverInfoGeneration == generation {
+		c.serverInfo = info
+	}
+	c.serverInfoMu.Unlock()
+	return info.clone(), nil
+}
+
+// Internal method: copy of s that shares no slice with it
+func (s *ServerInfo) clone() *ServerInfo {
+	clone := *s
+	clone.Methods = slices.Clone(s.Methods)
+	return &clone
+}
+
+// Internal method: drop the cached server info (endpoint changed)
+func (c *Client) resetServerInfoCache() {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+	c.serverInfo = nil
+	c.serverInfoGeneration++
+}
+
+// Internal method: whether err is a JSON-RPC "method not found" rejection
+func isMethodNotFound(err error) bool {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) && rpcErr.Code == -32601 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") ||
+		strings.Contains(msg, "method not supported") ||
+		strings.Contains(msg, "unknown method") ||
+		strings.Contains(msg, "does not exist")
+}
@@ -0,0 +1,98 @@
+package alchemy_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestGetServerInfo(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("get_server_info", map[string]interface{}{
+		"version": "2.1.0", "chainId": 5, "methods": []string{"mint", "updateBlacklistBatch"}, "eventRetentionBlocks": 100,
+	})
+
+	info, err := client.GetServerInfo().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.CapabilitiesKnown || info.Version != "2.1.0" || info.ChainID != 5 || info.EventRetentionBlocks != 100 {
+		t.Fatalf("got %+v", info)
+	}
+	if !client.SupportsMethod("UpdateBlacklistBatch") || client.SupportsMethod("mintBatch") {
+		t.Fatalf("supports %v", info.Methods)
+	}
+
+	// Callers get their own copy of the cached info
+	info.Methods[0] = "mintBatch"
+	info.Version = "9"
+	again, err := client.GetServerInfo().Result()
+	if err != nil || again.Version != "2.1.0" || again.Methods[0] != "mint" || client.SupportsMethod("mintBatch") {
+		t.Fatalf("cache modified through a result: %+v, %v", again, err)
+	}
+	if n := len(srv.RequestsFor("get_server_info")); n != 1 {
+		t.Fatalf("%d requests, want 1 (cached)", n)
+	}
+}
+
+func TestGetServerInfoUnknownCapabilities(t *testing.T) {
+	// Older servers don't implement get_server_info
+	srv, client, _ := newTestServer(t)
+	srv.SetError("get_server_info", alchemytest.CodeMethodNotFound, "method not found")
+
+	info, err := client.GetServerInfo().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.CapabilitiesKnown || info.Version != "" || len(info.Methods) != 0 {
+		t.Fatalf("got %+v, want unknown capabilities", info)
+	}
+	if client.SupportsMethod("mint") {
+		t.Fatal("method supported with unknown capabilities")
+	}
+	if n := len(srv.RequestsFor("get_server_info")); n != 1 {
+		t.Fatalf("%d requests, want 1 (the fallback is cached)", n)
+	}
+}
+
+func TestGetServerInfoErrorNotCached(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("get_server_info", -32603, "internal error")
+	if err := client.GetServerInfo().Err(); err == nil {
+		t.Fatal("error swallowed")
+	}
+	if client.SupportsMethod("mint") {
+		t.Fatal("method supported after an error")
+	}
+
+	srv.SetResult("get_server_info", map[string]interface{}{"version": "2.1.0", "methods": []string{"mint"}})
+	if !client.SupportsMethod("mint") {
+		t.Fatal("server info not fetched again after an error")
+	}
+}
+
+func TestGetServerInfoConcurrent(t *testing.T) {
+	var upstream atomic.Int32
+	srv, client, _ := newTestServer(t,
+		alchemy.WithHTTPClient(slowTransport("get_server_info", 100*time.Millisecond, &upstream)))
+	srv.SetResult("get_server_info", map[string]interface{}{"version": "2.1.0", "methods": []string{"mint"}})
+
+	// Concurrent first callers share one request
+	for i, err := range concurrently(20, func() error {
+		info, err := client.GetServerInfo().Result()
+		if err == nil && !info.Supports("mint") {
+			t.Errorf("got %+v", info)
+		}
+		return err
+	}) {
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if n := upstream.Load(); n != 1 {
+		t.Fatalf("%d requests for 20 concurrent callers, want 1", n)
+	}
+}
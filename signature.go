@@ -0,0 +1,163 @@
+package alchemy
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SignatureEncoding selects how R, S and V are written into request payloads
+type SignatureEncoding int
+
+const (
+	// SignatureEncodingDecimal sends R, S and V as base-10 strings (default)
+	SignatureEncodingDecimal SignatureEncoding = iota
+	// SignatureEncodingHex sends R and S as 0x-prefixed 32-byte hex words and V as 0x hex
+	SignatureEncodingHex
+)
+
+// ConfigSignatureEncoding selects the R/S/V encoding of the default client's requests
+func ConfigSignatureEncoding(encoding SignatureEncoding) {
+	defaultClient.sigEncoding = encoding
+}
+
+// WithSignatureEncoding selects the R/S/V encoding expected by the server (see
+// ConfigSignatureEncoding). The signed message is the same either way.
+func WithSignatureEncoding(encoding SignatureEncoding) Option {
+	return func(o *clientOptions) { o.sigEncoding = encoding }
+}
+
+// HexR returns R as a 0x-prefixed 32-byte hex word, leading zeros kept, or "" if R is
+// malformed. R may be held in decimal or 0x hex.
+func (s Signature) HexR() string {
+	return hexWord(s.R)
+}
+
+// HexS returns S as a 0x-prefixed 32-byte hex word (see HexR)
+func (s Signature) HexS() string {
+	return hexWord(s.S)
+}
+
+// HexV returns V as 0x-prefixed hex ("0x1b" for 27), or "" if V is malformed
+func (s Signature) HexV() string {
+	v, err := parseSignatureValue(s.V, 8)
+	if err != nil {
+		return ""
+	}
+	return "0x" + v.Text(16)
+}
+
+// Compact returns the 65-byte [R || S || V] form, R and S left-padded to 32 bytes and V
+// as held (27/28 or 0/1), or nil if a value is malformed
+func (s Signature) Compact() []byte {
+	r, errR := parseSignatureValue(s.R, 256)
+	sv, errS := parseSignatureValue(s.S, 256)
+	v, errV := parseSignatureValue(s.V, 8)
+	if errR != nil || errS != nil || errV != nil {
+		return nil
+	}
+	compact := make([]byte, 65)
+	r.FillBytes(compact[:32])
+	sv.FillBytes(compact[32:64])
+	compact[64] = byte(v.Uint64())
+	return compact
+}
+
+// FromCompact sets s from a 65-byte [R || S || V] signature, V being 27/28 or 0/1, with
+// decimal strings as the SDK produces them
+func (s *Signature) FromCompact(compact []byte) error {
+	if len(compact) != 65 {
+		return fmt.Errorf("compact signature has %d bytes, want 65", len(compact))
+	}
+	if v := compact[64]; v > 1 && v != 27 && v != 28 {
+		return fmt.Errorf("compact signature has invalid v %d", v)
+	}
+	*s = Signature{
+		R: new(big.Int).SetBytes(compact[:32]).String(),
+		S: new(big.Int).SetBytes(compact[32:64]).String(),
+		V: fmt.Sprint(compact[64]),
+	}
+	return nil
+}
+
+// RecoverSigner returns the checksummed address that signed params with sig, the inverse
+// of request signing: the message is rebuilt with SignedMessage (a "signature" key in
+// params is ignored), so servers can verify a received request with the SDK's own rules.
+// R, S and V may be decimal or 0x hex; V may be 27/28 or 0/1. High-S signatures, which the
+// SDK never produces, are rejected. A sig with Scheme SignatureSchemePersonal is recovered
+// from the EIP-191 digest of the message hash. Errors wrap ErrInvalidSignature.
+func RecoverSigner(params map[string]interface{}, sig *Signature) (string, error) {
+	if sig == nil {
+		return "", fmt.Errorf("%w: nil signature", ErrInvalidSignature)
+	}
+	compact := sig.Compact()
+	if compact == nil {
+		return "", fmt.Errorf("%w: malformed r, s or v %+v", ErrInvalidSignature, *sig)
+	}
+	if compact[64] >= 27 {
+		compact[64] -= 27
+	}
+	if compact[64] > 1 {
+		return "", fmt.Errorf("%w: invalid v %s", ErrInvalidSignature, sig.V)
+	}
+	if new(big.Int).SetBytes(compact[32:64]).Cmp(secp256k1HalfN) > 0 {
+		return "", fmt.Errorf("%w: s is not in the lower half of the curve order", ErrInvalidSignature)
+	}
+
+	message, err := SignedMessage(params)
+	if err != nil {
+		return "", err
+	}
+	hash := crypto.Keccak256([]byte(message))
+	switch sig.Scheme {
+	case "":
+	case SignatureSchemePersonal:
+		hash = PersonalDigest(hash)
+	default:
+		return "", fmt.Errorf("%w: unknown scheme %q", ErrInvalidSignature, sig.Scheme)
+	}
+	pub, err := crypto.SigToPub(hash, compact)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+// Internal method: the signature in the request payload encoding
+func (s Signature) encode(encoding SignatureEncoding) map[string]string {
+	encoded := map[string]string{"r": s.R, "s": s.S, "v": s.V}
+	if encoding == SignatureEncodingHex {
+		encoded = map[string]string{"r": s.HexR(), "s": s.HexS(), "v": s.HexV()}
+	}
+	if s.Scheme != "" {
+		encoded["scheme"] = s.Scheme
+	}
+	return encoded
+}
+
+// Internal method: 0x-prefixed 32-byte hex word of a decimal or hex value
+func hexWord(value string) string {
+	n, err := parseSignatureValue(value, 256)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("0x%064x", n)
+}
+
+// Internal method: parse a decimal or 0x-hex signature value of at most bits bits
+func parseSignatureValue(value string, bits int) (*big.Int, error) {
+	digits, base := value, 10
+	if strings.HasPrefix(value, "0x") || strings.HasPrefix(value, "0X") {
+		digits, base = value[2:], 16
+	}
+	if digits == "" || strings.ContainsAny(digits, "+-") {
+		return nil, fmt.Errorf("malformed signature value %q", value)
+	}
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || n.BitLen() > bits {
+		return nil, fmt.Errorf("malformed signature value %q", value)
+	}
+	return n, nil
+}
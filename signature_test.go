@@ -0,0 +1,128 @@
+package alchemy_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSignatureHexAndCompact(t *testing.T) {
+	signer, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vector := range readVectors(t) {
+		t.Run(vector.Name, func(t *testing.T) {
+			sig := vector.Expected.Signature
+
+			for name, word := range map[string]struct{ hex, decimal string }{"r": {sig.HexR(), sig.R}, "s": {sig.HexS(), sig.S}} {
+				if len(word.hex) != 66 || !strings.HasPrefix(word.hex, "0x") {
+					t.Fatalf("hex %s %q is not a 32-byte word", name, word.hex)
+				}
+				n, _ := new(big.Int).SetString(word.hex[2:], 16)
+				if n.String() != word.decimal {
+					t.Fatalf("hex %s %s is %s, want %s", name, word.hex, n, word.decimal)
+				}
+			}
+			if want := map[string]string{"27": "0x1b", "28": "0x1c", "0": "0x0", "1": "0x1"}[sig.V]; sig.HexV() != want {
+				t.Fatalf("hex v %q, want %q", sig.HexV(), want)
+			}
+
+			compact := sig.Compact()
+			if len(compact) != 65 {
+				t.Fatalf("compact has %d bytes", len(compact))
+			}
+			if strings.Contains(vector.Name, "leading_zero_r") && (compact[0] != 0 || !strings.HasPrefix(sig.HexR(), "0x00")) {
+				t.Fatalf("R %s lost its leading zero byte", sig.HexR())
+			}
+
+			var decoded alchemy.Signature
+			if err := decoded.FromCompact(compact); err != nil {
+				t.Fatal(err)
+			}
+			if decoded != sig {
+				t.Fatalf("FromCompact(Compact()) = %+v, want %+v", decoded, sig)
+			}
+
+			// The compact form recovers the signer once V is a recovery id
+			recoverable := append([]byte(nil), compact...)
+			if recoverable[64] >= 27 {
+				recoverable[64] -= 27
+			}
+			pub, err := crypto.SigToPub(crypto.Keccak256([]byte(vector.Expected.Message)), recoverable)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := crypto.PubkeyToAddress(*pub).Hex(); got != signer.Address() {
+				t.Fatalf("recovered %s, want %s", got, signer.Address())
+			}
+		})
+	}
+}
+
+func TestSignatureHexInputs(t *testing.T) {
+	decimal := alchemy.Signature{R: "255", S: "1", V: "28"}
+	hex := alchemy.Signature{R: "0xff", S: "0x01", V: "0x1c"}
+	if string(decimal.Compact()) != string(hex.Compact()) {
+		t.Fatalf("decimal %x and hex %x differ", decimal.Compact(), hex.Compact())
+	}
+	if got := hex.HexR(); got != "0x00000000000000000000000000000000000000000000000000000000000000ff" {
+		t.Fatalf("HexR %s", got)
+	}
+}
+
+func TestSignatureMalformed(t *testing.T) {
+	tooBig := "0x1" + strings.Repeat("0", 64) // 257 bits
+	for _, sig := range []alchemy.Signature{
+		{R: "", S: "1", V: "27"},
+		{R: "-1", S: "1", V: "27"},
+		{R: "0x", S: "1", V: "27"},
+		{R: "12ab", S: "1", V: "27"},
+		{R: tooBig, S: "1", V: "27"},
+		{R: "1", S: "1", V: "256"},
+	} {
+		if sig.Compact() != nil {
+			t.Fatalf("%+v: Compact() = %x, want nil", sig, sig.Compact())
+		}
+	}
+	if (alchemy.Signature{R: tooBig}).HexR() != "" || (alchemy.Signature{V: "x"}).HexV() != "" {
+		t.Fatal("malformed values should have no hex form")
+	}
+
+	var sig alchemy.Signature
+	if err := sig.FromCompact(make([]byte, 64)); err == nil {
+		t.Fatal("expected an error for 64 bytes")
+	}
+	bad := make([]byte, 65)
+	bad[64] = 5
+	if err := sig.FromCompact(bad); err == nil {
+		t.Fatal("expected an error for v 5")
+	}
+}
+
+func TestSignatureEncodingHex(t *testing.T) {
+	srv, client, key := newTestServer(t, alchemy.WithSignatureEncoding(alchemy.SignatureEncodingHex))
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("mint")
+	if len(reqs) != 1 {
+		t.Fatalf("%d mint requests", len(reqs))
+	}
+	sig := reqs[0].ParamMap["signature"].(map[string]interface{})
+	for _, name := range []string{"r", "s"} {
+		if word, _ := sig[name].(string); len(word) != 66 || !strings.HasPrefix(word, "0x") {
+			t.Fatalf("%s = %v, want a 0x 32-byte word", name, sig[name])
+		}
+	}
+	if v := sig["v"]; v != "0x1b" && v != "0x1c" {
+		t.Fatalf("v = %v", v)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey).Hex(); reqs[0].Signer != want {
+		t.Fatalf("server recovered %s, want %s", reqs[0].Signer, want)
+	}
+}
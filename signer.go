@@ -0,0 +1,94 @@
+package alchemy
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs request digests. Implementations may keep the key outside the process
+// (hardware wallet, KMS); the SDK only ever passes 32-byte Keccak256 hashes.
+type Signer interface {
+	// Address returns the checksummed address of the signing key
+	Address() string
+	// Sign returns a 65-byte [R || S || V] secp256k1 signature of hash, V being the
+	// recovery id 0 or 1. High-S signatures are accepted and normalized by the SDK.
+	Sign(hash []byte) ([]byte, error)
+}
+
+// SignatureSchemePersonal marks signatures of the EIP-191 personal message digest of the
+// request hash (see PersonalSigner)
+const SignatureSchemePersonal = "personal"
+
+// PersonalSigner is implemented by Signers that can only sign EIP-191 personal messages,
+// such as the Ethereum app of a Ledger: when SignsPersonalMessages reports true, Sign
+// returns a signature of PersonalDigest(hash) instead of hash. The SDK sends their request
+// signatures with "scheme": "personal", so the server rebuilds the same digest;
+// RecoverSigner does.
+type PersonalSigner interface {
+	Signer
+	SignsPersonalMessages() bool
+}
+
+// PersonalDigest returns the EIP-191 digest a personal message signature of hash covers:
+// Keccak256("\x19Ethereum Signed Message:\n32" || hash)
+func PersonalDigest(hash []byte) []byte {
+	return crypto.Keccak256([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(hash))), hash)
+}
+
+// Internal method: the Scheme of signatures made by signer
+func signatureScheme(signer Signer) string {
+	if personal, ok := signer.(PersonalSigner); ok && personal.SignsPersonalMessages() {
+		return SignatureSchemePersonal
+	}
+	return ""
+}
+
+// PrivateKeySigner signs with an in-memory secp256k1 private key
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner parses a hex private key (with or without 0x prefix)
+func NewPrivateKeySigner(hexKey string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return &PrivateKeySigner{key: key}, nil
+}
+
+// NewKeySigner signs with an already parsed private key
+func NewKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// Address implements Signer
+func (s *PrivateKeySigner) Address() string {
+	return crypto.PubkeyToAddress(s.key.PublicKey).Hex()
+}
+
+// Sign implements Signer
+func (s *PrivateKeySigner) Sign(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// SignedPayload is the intermediate and final form of a request signature: the sorted
+// message, its Keccak256 hash and the low-S signature
+type SignedPayload struct {
+	Message   string    `json:"message"`
+	Hash      string    `json:"hash"` // 0x-prefixed hex
+	Signature Signature `json:"signature"`
+}
+
+// SignPayload signs params exactly as requests are signed, without a client or network
+// access. chainId is only included if present in params. Useful for pinning the signature
+// scheme with test vectors and for validating other SDK implementations.
+func SignPayload(signer Signer, params map[string]interface{}, format VFormat) (*SignedPayload, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("sign error: nil signer")
+	}
+	return signParams(signer, params, format)
+}
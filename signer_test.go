@@ -0,0 +1,111 @@
+package alchemy_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	curveN     = crypto.S256().Params().N
+	curveHalfN = new(big.Int).Rsh(curveN, 1)
+)
+
+// highSSigner returns the high-S twin of every signature, as some HSMs do
+type highSSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s highSSigner) Address() string { return crypto.PubkeyToAddress(s.key.PublicKey).Hex() }
+
+func (s highSSigner) Sign(hash []byte) ([]byte, error) {
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	sv := new(big.Int).SetBytes(sig[32:64])
+	if sv.Cmp(curveHalfN) <= 0 {
+		sv.Sub(curveN, sv)
+		sv.FillBytes(sig[32:64])
+		sig[64] ^= 1
+	}
+	return sig, nil
+}
+
+func TestSignaturesAreLowS(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	for i := 0; i < 32; i++ {
+		params := map[string]interface{}{"nonce": i, "token": testToken}
+		low, err := alchemy.SignPayload(alchemy.NewKeySigner(key), params, alchemy.VFormatEthereum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		high, err := alchemy.SignPayload(highSSigner{key}, params, alchemy.VFormatEthereum)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s, _ := new(big.Int).SetString(high.Signature.S, 10); s.Cmp(curveHalfN) > 0 {
+			t.Fatalf("high S %s not normalized", high.Signature.S)
+		}
+		// A signature that was already low-S is unchanged
+		if *low != *high {
+			t.Fatalf("normalized %+v differs from the low-S signature %+v", high.Signature, low.Signature)
+		}
+		if got := recoverPayload(t, high); got != want {
+			t.Fatalf("recovered %s, want %s", got, want)
+		}
+	}
+}
+
+func TestVFormats(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	params := map[string]interface{}{"nonce": 1}
+
+	ethereum, err := alchemy.SignPayload(alchemy.NewKeySigner(key), params, alchemy.VFormatEthereum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := alchemy.SignPayload(alchemy.NewKeySigner(key), params, alchemy.VFormatRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethereum.Signature.V != "27" && ethereum.Signature.V != "28" {
+		t.Fatalf("ethereum V = %s", ethereum.Signature.V)
+	}
+	if raw.Signature.V != "0" && raw.Signature.V != "1" {
+		t.Fatalf("raw V = %s", raw.Signature.V)
+	}
+	if raw.Signature.R != ethereum.Signature.R || raw.Signature.S != ethereum.Signature.S {
+		t.Fatal("r or s depend on the V format")
+	}
+	for _, payload := range []*alchemy.SignedPayload{ethereum, raw} {
+		if got := recoverPayload(t, payload); got != want {
+			t.Fatalf("recovered %s, want %s", got, want)
+		}
+	}
+}
+
+func TestMalformedSignerOutputRejected(t *testing.T) {
+	if _, err := alchemy.SignPayload(badSigner{}, map[string]interface{}{"nonce": 1}, alchemy.VFormatEthereum); err == nil {
+		t.Fatal("malformed signature accepted")
+	}
+}
+
+type badSigner struct{}
+
+func (badSigner) Address() string { return testRecipient }
+
+func (badSigner) Sign([]byte) ([]byte, error) { return make([]byte, 64), nil }
@@ -0,0 +1,93 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownSigner is returned for a WithSignerKey name no signer is registered under
+var ErrUnknownSigner = errors.New("unknown signer")
+
+// signerNameKey carries the WithSignerKey name of an operation to its requests
+type signerNameKey struct{}
+
+// ConfigNamedSigner registers signer under name on the default client (see
+// WithNamedSigner); a nil signer removes the name
+func ConfigNamedSigner(name string, signer Signer) {
+	defaultClient.setNamedSigner(name, signer)
+}
+
+// WithNamedSigner registers signer under name, e.g. the operator key of one token, for calls
+// made WithSignerKey(name). The client's own key (WithKey, WithSigner) still signs every
+// other call. One client can so operate several tokens with their own keys.
+func WithNamedSigner(name string, signer Signer) Option {
+	return func(o *clientOptions) {
+		if o.signers == nil {
+			o.signers = map[string]Signer{}
+		}
+		o.signers[name] = signer
+	}
+}
+
+// WithSignerKey signs the call with the signer registered under name instead of the
+// client's key. Everything the call does on the signer's behalf uses it too: nonce lookups
+// and resyncs, the authority preflight, RenounceAuthority's account. A TxQueue counts nonces
+// per signer, so the sequences of two signers don't interfere. Failed calls name the signer
+// in their OperationError; an unregistered name fails with ErrUnknownSigner.
+func WithSignerKey(name string) CallOption {
+	return func(c *callConfig) { c.signerName = name }
+}
+
+// SignerNames returns the names of the registered signers, sorted
+func (c *Client) SignerNames() []string {
+	c.signersMu.RLock()
+	defer c.signersMu.RUnlock()
+	names := make([]string, 0, len(c.signers))
+	for name := range c.signers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Internal method: register or remove a named signer
+func (c *Client) setNamedSigner(name string, signer Signer) {
+	c.signersMu.Lock()
+	defer c.signersMu.Unlock()
+	if signer == nil {
+		delete(c.signers, name)
+		return
+	}
+	if c.signers == nil {
+		c.signers = map[string]Signer{}
+	}
+	c.signers[name] = signer
+}
+
+// Internal method: the WithSignerKey name the operation ctx belongs to uses, "" for the
+// client's key
+func signerName(ctx context.Context) string {
+	name, _ := ctx.Value(signerNameKey{}).(string)
+	return name
+}
+
+// Internal method: ctx for requests signed by the named signer
+func withSignerName(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, signerNameKey{}, name)
+}
+
+// Internal method: the signer registered under name
+func (c *Client) namedSigner(name string) (Signer, error) {
+	c.signersMu.RLock()
+	defer c.signersMu.RUnlock()
+	signer, ok := c.signers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSigner, name)
+	}
+	return signer, nil
+}
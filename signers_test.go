@@ -0,0 +1,194 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// namedSigners returns a client with signers "usdx" and "eurx" registered besides its own
+// key, and their addresses
+func namedSigners(t *testing.T) (*alchemytest.Server, *alchemy.Client, map[string]string) {
+	t.Helper()
+	addresses := map[string]string{}
+	var opts []alchemy.Option
+	for _, name := range []string{"usdx", "eurx"} {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer := alchemy.NewKeySigner(key)
+		addresses[name] = signer.Address()
+		opts = append(opts, alchemy.WithNamedSigner(name, signer))
+	}
+	srv, client, _ := newTestServer(t, opts...)
+	for _, address := range addresses {
+		srv.RegisterAddress(address)
+	}
+	return srv, client, addresses
+}
+
+// nonces returns the nonces of the recorded requests for method by recovered signer
+func nonces(t *testing.T, srv *alchemytest.Server, method string) map[string][]int64 {
+	t.Helper()
+	bySigner := map[string][]int64{}
+	for _, req := range srv.RequestsFor(method) {
+		n, err := req.ParamMap["nonce"].(interface{ Int64() (int64, error) }).Int64()
+		if err != nil {
+			t.Fatal(err)
+		}
+		bySigner[req.Signer] = append(bySigner[req.Signer], n)
+	}
+	return bySigner
+}
+
+func TestNamedSignersConcurrentQueue(t *testing.T) {
+	srv, client, addresses := namedSigners(t)
+	queue := client.NewTxQueue()
+	defer queue.Shutdown(context.Background())
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var futures []*alchemy.Future[*alchemy.TransactionResult]
+	for name := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 5 {
+				future := queue.Enqueue(alchemy.Operation{Token: testToken, Method: "mint", Args: []interface{}{testRecipient, "1"}}, alchemy.WithSignerKey(name))
+				mu.Lock()
+				futures = append(futures, future)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	for _, future := range futures {
+		if _, err := future.Result(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := nonces(t, srv, "mint")
+	want := []int64{0, 1, 2, 3, 4}
+	for name, address := range addresses {
+		if !reflect.DeepEqual(got[address], want) {
+			t.Fatalf("%s nonces %v, want %v (all: %v)", name, got[address], want, got)
+		}
+	}
+	if len(got) != 2 {
+		t.Fatalf("mints signed by %d signers, want 2: %v", len(got), got)
+	}
+
+	// Each sequence was fetched for its own address
+	fetched := map[string]bool{}
+	for _, req := range srv.RequestsFor("get_nonce") {
+		fetched[req.ParamMap["address"].(string)] = true
+	}
+	for name, address := range addresses {
+		if !fetched[address] {
+			t.Fatalf("nonce of %s never fetched: %v", name, fetched)
+		}
+	}
+}
+
+func TestNamedSignersDirectCalls(t *testing.T) {
+	srv, client, addresses := namedSigners(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for name := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for nonce := range int64(10) {
+				errs <- client.Mint(testToken, testRecipient, "1", nonce, alchemy.WithSignerKey(name)).Err()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, address := range addresses {
+		if n := len(nonces(t, srv, "mint")[address]); n != 10 {
+			t.Fatalf("%d mints recovered to %s, want 10", n, name)
+		}
+	}
+
+	// Calls without WithSignerKey use the client's key
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(nonces(t, srv, "mint")) != 3 {
+		t.Fatal("the client's key didn't sign the call without WithSignerKey")
+	}
+}
+
+func TestNamedSignersErrors(t *testing.T) {
+	srv, client, _ := namedSigners(t)
+	srv.SetError("mint", -32000, "account is blacklisted")
+
+	err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithSignerKey("eurx")).Err()
+	opErr := operationError(t, err)
+	if opErr.Signer != "eurx" || opErr.Method != "mint" || !errors.Is(err, alchemy.ErrBlacklisted) {
+		t.Fatalf("err = %v (%+v), want a mint failure signed by eurx", err, *opErr)
+	}
+	if !strings.Contains(err.Error(), "Mint "+testToken+" as eurx: mint: ") {
+		t.Fatalf("message %q doesn't name the signer", err)
+	}
+
+	err = client.Burn(testToken, "1", 0, alchemy.WithSignerKey("gbpx")).Err()
+	if opErr := operationError(t, err); opErr.Signer != "gbpx" || !errors.Is(err, alchemy.ErrUnknownSigner) {
+		t.Fatalf("err = %v, want ErrUnknownSigner for gbpx", err)
+	}
+	if n := len(srv.RequestsFor("burn")); n != 0 {
+		t.Fatalf("%d burns sent without a signer", n)
+	}
+
+	// Errors of the client's key name no signer
+	if opErr := operationError(t, client.Mint(testToken, testRecipient, "1", 0).Err()); opErr.Signer != "" {
+		t.Fatalf("signer %q without WithSignerKey", opErr.Signer)
+	}
+}
+
+func TestNamedSignersRenounceAndDefaultClient(t *testing.T) {
+	srv, client, _ := namedSigners(t)
+	useDefaultClient(t, client)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ops := alchemy.NewKeySigner(key)
+	srv.RegisterAddress(ops.Address())
+	alchemy.ConfigNamedSigner("ops", ops)
+	if names := client.SignerNames(); !reflect.DeepEqual(names, []string{"eurx", "ops", "usdx"}) {
+		t.Fatalf("signers %v", names)
+	}
+
+	// The account renouncing is the named signer's
+	if err := alchemy.RenounceAuthority(testToken, alchemy.RoleMint, 0, alchemy.WithSignerKey("ops")).Err(); err != nil {
+		t.Fatal(err)
+	}
+	reqs := srv.RequestsFor("renounceAuthority")
+	if len(reqs) != 1 || reqs[0].Signer != ops.Address() || !strings.Contains(string(reqs[0].Params), ops.Address()) {
+		t.Fatalf("renounce %+v, want signed by and for %s", reqs, ops.Address())
+	}
+
+	alchemy.ConfigNamedSigner("ops", nil)
+	if err := alchemy.Mint(testToken, testRecipient, "1", 0, alchemy.WithSignerKey("ops")).Err(); !errors.Is(err, alchemy.ErrUnknownSigner) {
+		t.Fatalf("err = %v after removing ops", err)
+	}
+}
@@ -0,0 +1,223 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+var updateVectors = flag.Bool("update", false, "regenerate "+vectorsFile+" (only for intentional scheme changes)")
+
+const vectorsFile = "testdata/signature_vectors.json"
+
+// Fixed test key, never use it for real funds
+const vectorKey = "1234567890123456789012345678901234567890123456789012345678901234"
+
+// signatureVector is a golden vector. Params are decoded from JSON with json.Number, as a
+// server receives them.
+type signatureVector struct {
+	Name     string                 `json:"name"`
+	Key      string                 `json:"key"` // hex private key
+	VFormat  alchemy.VFormat        `json:"vFormat"`
+	Params   map[string]interface{} `json:"params"`
+	Expected alchemy.SignedPayload  `json:"expected"`
+}
+
+var vectorInputs = []struct {
+	name    string
+	vFormat alchemy.VFormat
+	params  map[string]interface{}
+}{
+	{"create_token", alchemy.VFormatEthereum, map[string]interface{}{
+		"decimals":         int32(8),
+		"masterAuthority":  testRecipient,
+		"name":             "My Token",
+		"nonce":            int64(0),
+		"recentCheckpoint": int64(12345),
+		"symbol":           "MTK",
+	}},
+	{"create_token_idempotent_chain_id", alchemy.VFormatEthereum, map[string]interface{}{
+		"chainId":          uint64(1),
+		"decimals":         int32(18),
+		"idempotencyKey":   "6f1c2f0e-2b8e-4a53-9d0c-3f1d5b7a9e21",
+		"masterAuthority":  testRecipient,
+		"name":             "Tökén, Ltd",
+		"nonce":            int64(0),
+		"recentCheckpoint": int64(19000000),
+		"symbol":           "TKN",
+	}},
+	{"mint", alchemy.VFormatEthereum, map[string]interface{}{
+		"methodArgs":       []interface{}{testRecipient, "1000000000000000000"},
+		"nonce":            int64(7),
+		"recentCheckpoint": int64(12345),
+		"token":            testToken,
+	}},
+	{"mint_raw_v", alchemy.VFormatRaw, map[string]interface{}{
+		"methodArgs":       []interface{}{testRecipient, "1"},
+		"nonce":            int64(8),
+		"recentCheckpoint": int64(12346),
+		"token":            testToken,
+	}},
+	{"grant_authority", alchemy.VFormatEthereum, map[string]interface{}{
+		"methodArgs":       []interface{}{alchemy.RoleMint.String(), testRecipient},
+		"nonce":            int64(9),
+		"recentCheckpoint": int64(12347),
+		"token":            testToken,
+	}},
+	{"mint_memo", alchemy.VFormatEthereum, map[string]interface{}{
+		"memo":             "payout-2024-0042",
+		"methodArgs":       []interface{}{testRecipient, "1000000000000000000"},
+		"nonce":            int64(11),
+		"recentCheckpoint": int64(12349),
+		"token":            testToken,
+	}},
+	{"pause_no_args", alchemy.VFormatEthereum, map[string]interface{}{
+		"methodArgs":       []interface{}{},
+		"nonce":            int64(10),
+		"recentCheckpoint": int64(12348),
+		"token":            testToken,
+	}},
+	// R below 2^248: its 32-byte word starts with a zero byte
+	{"mint_leading_zero_r", alchemy.VFormatEthereum, map[string]interface{}{
+		"methodArgs":       []interface{}{testRecipient, "1"},
+		"nonce":            int64(44),
+		"recentCheckpoint": int64(12345),
+		"token":            testToken,
+	}},
+	{"mint_leading_zero_r_v28", alchemy.VFormatEthereum, map[string]interface{}{
+		"methodArgs":       []interface{}{testRecipient, "1"},
+		"nonce":            int64(937),
+		"recentCheckpoint": int64(12345),
+		"token":            testToken,
+	}},
+}
+
+// TestSignatureVectors pins the signature scheme: params, sorted message, Keccak256 hash
+// and r/s/v must match testdata/signature_vectors.json. Regenerate with
+// go test -run TestSignatureVectors -update.
+func TestSignatureVectors(t *testing.T) {
+	if *updateVectors {
+		writeVectors(t)
+	}
+
+	vectors := readVectors(t)
+	if len(vectors) != len(vectorInputs) {
+		t.Fatalf("%s has %d vectors, want %d", vectorsFile, len(vectors), len(vectorInputs))
+	}
+
+	for _, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			signer, err := alchemy.NewPrivateKeySigner(vector.Key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := alchemy.SignPayload(signer, vector.Params, vector.VFormat)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := vector.Expected
+			if got.Message != want.Message {
+				t.Fatalf("message %q, want %q", got.Message, want.Message)
+			}
+			if got.Hash != want.Hash {
+				t.Fatalf("hash %s, want %s", got.Hash, want.Hash)
+			}
+			if got.Signature != want.Signature {
+				t.Fatalf("signature %+v, want %+v", got.Signature, want.Signature)
+			}
+		})
+	}
+}
+
+// TestSignatureVectorsMatchTypedParams checks that signing the typed Go params gives the
+// same payload as signing their JSON-decoded form
+func TestSignatureVectorsMatchTypedParams(t *testing.T) {
+	signer, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range vectorInputs {
+		t.Run(in.name, func(t *testing.T) {
+			typed, err := alchemy.SignPayload(signer, in.params, in.vFormat)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(in.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoder := json.NewDecoder(bytes.NewReader(encoded))
+			decoder.UseNumber()
+			var decoded map[string]interface{}
+			if err := decoder.Decode(&decoded); err != nil {
+				t.Fatal(err)
+			}
+			fromJSON, err := alchemy.SignPayload(signer, decoded, in.vFormat)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *typed != *fromJSON {
+				t.Fatalf("typed %+v, decoded %+v", typed, fromJSON)
+			}
+		})
+	}
+}
+
+func TestSignPayloadNilSigner(t *testing.T) {
+	if _, err := alchemy.SignPayload(nil, map[string]interface{}{"nonce": 1}, alchemy.VFormatEthereum); err == nil {
+		t.Fatal("expected an error for a nil signer")
+	}
+}
+
+// Internal method: read the vectors file
+func readVectors(t *testing.T) []signatureVector {
+	t.Helper()
+	data, err := os.ReadFile(vectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var vectors []signatureVector
+	if err := decoder.Decode(&vectors); err != nil {
+		t.Fatal(err)
+	}
+	return vectors
+}
+
+// Internal method: regenerate the vectors file from vectorInputs
+func writeVectors(t *testing.T) {
+	t.Helper()
+	signer, err := alchemy.NewPrivateKeySigner(vectorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := make([]signatureVector, 0, len(vectorInputs))
+	for _, in := range vectorInputs {
+		payload, err := alchemy.SignPayload(signer, in.params, in.vFormat)
+		if err != nil {
+			t.Fatalf("%s: %v", in.name, err)
+		}
+		vectors = append(vectors, signatureVector{
+			Name:     in.name,
+			Key:      vectorKey,
+			VFormat:  in.vFormat,
+			Params:   in.params,
+			Expected: *payload,
+		})
+	}
+
+	data, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(vectorsFile, append(data, '\n'), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,68 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SimulationResult is the predicted outcome of a write operation
+type SimulationResult struct {
+	WouldSucceed    bool   `json:"wouldSucceed"`
+	RevertReason    string `json:"revertReason,omitempty"`
+	ResultingSupply string `json:"resultingSupply,omitempty"`
+	GasUsed         uint64 `json:"gasUsed,omitempty"`
+}
+
+// TransferMasterAuthorityOperation describes TransferMasterAuthorityIrreversibly(token, newMasterAuthority)
+func TransferMasterAuthorityOperation(token, newMasterAuthority string) Operation {
+	return Operation{Token: token, Method: "transferMasterAuthority", Args: []interface{}{newMasterAuthority}}
+}
+
+// SeizeOperation describes Seize(token, from, to, amount)
+func SeizeOperation(token, from, to, amount string) Operation {
+	return Operation{Token: token, Method: "seize", Args: []interface{}{from, to, amount}}
+}
+
+// PauseOperation describes Pause(token)
+func PauseOperation(token string) Operation {
+	return Operation{Token: token, Method: "pause", Args: []interface{}{}}
+}
+
+// UnpauseOperation describes Unpause(token)
+func UnpauseOperation(token string) Operation {
+	return Operation{Token: token, Method: "unpause", Args: []interface{}{}}
+}
+
+// Simulate dry-runs a write operation without changing state. The request is built like
+// the real call with the same nonce plus a dryRun flag, which is part of the signed message:
+// the simulation's signature doesn't verify for the real call, so the request can't be
+// replayed as the write. The nonce remains unused. Call options such as WithIdempotencyKey
+// are signed in the same way as for the real call. Like a write, it is only retried when it
+// can't have been processed, in case a server executes it without honoring dryRun.
+func (c *Client) Simulate(op Operation, nonce int64, opts ...CallOption) (r *ResponseHandler[*SimulationResult]) {
+	defer withOperation(&r, "Simulate", op.Token)
+	args := op.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	cfg := c.newCallConfig(opts)
+	cfg.write, cfg.dryRun = true, true
+	ctx := cfg.context()
+	reqParams, err := c.buildDynamicRequest(ctx, op.Token, args, nonce, cfg)
+	if err != nil {
+		return &ResponseHandler[*SimulationResult]{err: err}
+	}
+
+	result, err := c.rpcWriteContext(ctx, op.Method, reqParams, cfg.maxResponseSize)
+	if err != nil {
+		return &ResponseHandler[*SimulationResult]{err: err}
+	}
+
+	var simulation SimulationResult
+	if err := json.Unmarshal(result, &simulation); err != nil {
+		return &ResponseHandler[*SimulationResult]{err: fmt.Errorf("decode simulation: %w", err)}
+	}
+
+	return &ResponseHandler[*SimulationResult]{data: &simulation}
+}
@@ -0,0 +1,135 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestSimulateSignsDryRun(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("mint", map[string]interface{}{"wouldSucceed": true})
+
+	sim, err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "100"), 7).Result()
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	if !sim.WouldSucceed {
+		t.Fatal("WouldSucceed = false")
+	}
+
+	reqs := srv.RequestsFor("mint")
+	if len(reqs) != 1 {
+		t.Fatalf("got %d mint requests, want 1", len(reqs))
+	}
+	if reqs[0].SignatureErr != nil {
+		t.Fatalf("simulation signature rejected: %v", reqs[0].SignatureErr)
+	}
+	if reqs[0].ParamMap["dryRun"] != true {
+		t.Fatalf("dryRun = %v, want true", reqs[0].ParamMap["dryRun"])
+	}
+}
+
+func TestSimulateCannotBeReplayedAsWrite(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("mint", map[string]interface{}{"wouldSucceed": true})
+
+	if err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "100"), 7).Err(); err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	captured := srv.RequestsFor("mint")[0].ParamMap
+
+	// Replay the captured simulation without the flag, as an attacker would
+	delete(captured, "dryRun")
+	srv.Reset()
+	body, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0", "id": 1, "method": "mint", "params": captured,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Post(srv.URL+"/rpc", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var reply struct {
+		Error *alchemytest.RPCError `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Error == nil || reply.Error.Code != alchemytest.CodeInvalidSignature {
+		t.Fatalf("replayed simulation accepted as a write: %+v", reply.Error)
+	}
+	if req := srv.RequestsFor("mint")[0]; req.SignatureErr == nil {
+		t.Fatal("replayed simulation verified")
+	}
+}
+
+func TestSimulateNotRetriedOnServerError(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 3}))
+	srv.FailNext("mint", http.StatusBadGateway, 1)
+	srv.SetResult("mint", map[string]interface{}{"wouldSucceed": true})
+
+	err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "100"), 7).Err()
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("err = %v, want the 502", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("got %d attempts, want 1", n)
+	}
+}
+
+func TestSimulateDoesNotConsumeNonce(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("mint", map[string]interface{}{"wouldSucceed": true})
+
+	if err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "100"), 7).Err(); err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	srv.SetResult("mint", map[string]string{"hash": "0x01"})
+	tx, err := client.Mint(testToken, testRecipient, "100", 7).Result()
+	if err != nil {
+		t.Fatalf("Mint: %v", err)
+	}
+	if tx.Nonce != 7 {
+		t.Fatalf("real call used nonce %d, want 7", tx.Nonce)
+	}
+
+	reqs := srv.RequestsFor("mint")
+	for i, req := range reqs {
+		if fmt.Sprint(req.ParamMap["nonce"]) != "7" {
+			t.Fatalf("request %d nonce = %v, want 7", i, req.ParamMap["nonce"])
+		}
+	}
+	if _, ok := reqs[1].ParamMap["dryRun"]; ok {
+		t.Fatal("real call sent dryRun")
+	}
+	if n := len(srv.RequestsFor("get_nonce")); n != 0 {
+		t.Fatalf("%d get_nonce requests, want none", n)
+	}
+}
+
+func TestSimulateDoesNotResyncNonce(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("mint", -32000, "nonce too low")
+
+	err := client.Simulate(alchemy.MintOperation(testToken, testRecipient, "100"), 7, alchemy.WithNonceRetry(3)).Err()
+	if err == nil {
+		t.Fatal("nonce error not reported")
+	}
+	if n := len(srv.RequestsFor("get_nonce")); n != 0 {
+		t.Fatalf("%d get_nonce requests, want none", n)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d attempts, want 1", n)
+	}
+}
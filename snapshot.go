@@ -0,0 +1,274 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sync"
+)
+
+// ErrSnapshotMismatch is returned when the summed holder balances differ from the token
+// supply at the snapshot block. The snapshot has still been written completely.
+var ErrSnapshotMismatch = errors.New("snapshot total does not match token supply")
+
+// SnapshotFormat selects the output encoding of SnapshotBalances
+type SnapshotFormat int
+
+const (
+	// SnapshotCSV writes "address,balance,balanceDecimal" rows after a header row
+	SnapshotCSV SnapshotFormat = iota
+	// SnapshotJSONLines writes one JSON object per holder
+	SnapshotJSONLines
+)
+
+// SnapshotError is returned when a page of the snapshot could not be completed. Everything
+// before Cursor has been written; pass the error to WithSnapshotResume to continue.
+type SnapshotError struct {
+	Cursor  string // holder cursor of the failed page
+	Written int    // holders written so far, across resumes
+	Total   string // raw balance sum of the holders written so far
+	Err     error
+}
+
+func (e *SnapshotError) Error() string {
+	return fmt.Sprintf("snapshot stopped at cursor %q after %d holders: %v", e.Cursor, e.Written, e.Err)
+}
+
+func (e *SnapshotError) Unwrap() error {
+	return e.Err
+}
+
+// SnapshotOption configures SnapshotBalances
+type SnapshotOption func(*snapshotConfig)
+
+type snapshotConfig struct {
+	format      SnapshotFormat
+	concurrency int
+	pageSize    int
+	resume      *SnapshotError
+}
+
+// WithSnapshotFormat selects CSV (default) or JSON lines output
+func WithSnapshotFormat(format SnapshotFormat) SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.format = format
+	}
+}
+
+// WithSnapshotConcurrency bounds the number of balance reads in flight (default 4)
+func WithSnapshotConcurrency(n int) SnapshotOption {
+	return func(c *snapshotConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithSnapshotPageSize sets the holders page size, 0 uses the server default
+func WithSnapshotPageSize(n int) SnapshotOption {
+	return func(c *snapshotConfig) {
+		if n >= 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// WithSnapshotResume continues a snapshot that failed with err, appending to the same
+// output: the header is not written again and the total carries over
+func WithSnapshotResume(err *SnapshotError) SnapshotOption {
+	return func(c *snapshotConfig) {
+		c.resume = err
+	}
+}
+
+type snapshotRow struct {
+	Address        string `json:"address"`
+	Balance        string `json:"balance"`
+	BalanceDecimal string `json:"balanceDecimal"`
+}
+
+type snapshotTrailer struct {
+	Total        string `json:"total"`
+	TotalDecimal string `json:"totalDecimal"`
+	Supply       string `json:"supply"`
+	Holders      int    `json:"holders"`
+}
+
+// SnapshotBalances writes the balance of every holder of a token at block atBlock to w,
+// one page of holders at a time, followed by a trailer with the total. Holders come from
+// GetTokenHolders; each balance is read at the block with balanceOfAt, up to the configured
+// concurrency. The total is checked against totalSupplyAt(atBlock) and ErrSnapshotMismatch
+// is returned if they differ. A failed page returns a *SnapshotError to resume from.
+func (c *Client) SnapshotBalances(ctx context.Context, tokenAddress string, atBlock int64, w io.Writer, opts ...SnapshotOption) (err error) {
+	defer withOperationErr(&err, "SnapshotBalances", tokenAddress)
+	cfg := snapshotConfig{concurrency: 4}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if atBlock < 0 {
+		return fmt.Errorf("invalid block %d", atBlock)
+	}
+
+	decimals, err := c.tokenDecimals(tokenAddress)
+	if err != nil {
+		return fmt.Errorf("get decimals: %w", err)
+	}
+
+	out := newSnapshotWriter(w, cfg.format)
+	total := new(big.Int)
+	cursor, written := "", 0
+	if cfg.resume != nil {
+		if _, ok := total.SetString(cfg.resume.Total, 10); !ok && cfg.resume.Total != "" {
+			return fmt.Errorf("invalid resume total %q", cfg.resume.Total)
+		}
+		cursor, written = cfg.resume.Cursor, cfg.resume.Written
+	} else if err := out.header(); err != nil {
+		return err
+	} else if err := out.flush(); err != nil {
+		return err
+	}
+
+	stop := func(err error) error {
+		return &SnapshotError{Cursor: cursor, Written: written, Total: total.String(), Err: err}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return stop(err)
+		}
+
+		page := c.GetTokenHolders(tokenAddress, cursor, cfg.pageSize, WithBlock(atBlock))
+		if page.err != nil {
+			return stop(page.err)
+		}
+
+		rows, err := c.snapshotPage(ctx, tokenAddress, atBlock, decimals, page.data.Holders, cfg.concurrency)
+		if err != nil {
+			return stop(err)
+		}
+		// Write the page only once all of it has been read, so a resume never duplicates rows
+		for _, row := range rows {
+			if err := out.row(row); err != nil {
+				return err
+			}
+			balance, _ := new(big.Int).SetString(row.Balance, 10)
+			total.Add(total, balance)
+		}
+		if err := out.flush(); err != nil {
+			return err
+		}
+		written += len(rows)
+
+		if !page.data.HasMore() {
+			break
+		}
+		cursor = page.data.NextCursor
+	}
+
+	supply := ClientCallRead[string](c, tokenAddress, "totalSupplyAt", []interface{}{atBlock})
+	if supply.err != nil {
+		return fmt.Errorf("get supply: %w", supply.err) // all rows written, nothing to resume
+	}
+
+	totalDecimal, _ := formatUnits(total.String(), decimals)
+	if err := out.trailer(snapshotTrailer{Total: total.String(), TotalDecimal: totalDecimal, Supply: supply.data, Holders: written}); err != nil {
+		return err
+	}
+	if err := out.flush(); err != nil {
+		return err
+	}
+	if supply.data != total.String() {
+		return fmt.Errorf("%w: holders sum to %s, supply at block %d is %s", ErrSnapshotMismatch, total, atBlock, supply.data)
+	}
+	return nil
+}
+
+// Internal method: read the balances of one page of holders at a block, in page order
+func (c *Client) snapshotPage(ctx context.Context, tokenAddress string, atBlock int64, decimals uint8, holders []Holder, concurrency int) ([]snapshotRow, error) {
+	rows := make([]snapshotRow, len(holders))
+	errs := make([]error, len(holders))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, holder := range holders {
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			balance := ClientCallRead[string](c, tokenAddress, "balanceOfAt", []interface{}{address, atBlock})
+			if balance.err != nil {
+				errs[i] = fmt.Errorf("holder %s: %w", address, balance.err)
+				return
+			}
+			scaled, err := formatUnits(balance.data, decimals)
+			if err != nil {
+				errs[i] = fmt.Errorf("holder %s: %w", address, err)
+				return
+			}
+			rows[i] = snapshotRow{Address: address, Balance: balance.data, BalanceDecimal: scaled}
+		}(i, holder.Address)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Internal type: encodes snapshot rows in the selected format
+type snapshotWriter struct {
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newSnapshotWriter(w io.Writer, format SnapshotFormat) *snapshotWriter {
+	if format == SnapshotJSONLines {
+		return &snapshotWriter{json: json.NewEncoder(w)}
+	}
+	return &snapshotWriter{csv: csv.NewWriter(w)}
+}
+
+func (s *snapshotWriter) header() error {
+	if s.csv == nil {
+		return nil
+	}
+	return s.writeCSV("address", "balance", "balanceDecimal")
+}
+
+func (s *snapshotWriter) row(row snapshotRow) error {
+	if s.csv == nil {
+		return s.json.Encode(row)
+	}
+	return s.writeCSV(row.Address, row.Balance, row.BalanceDecimal)
+}
+
+func (s *snapshotWriter) trailer(trailer snapshotTrailer) error {
+	if s.csv == nil {
+		return s.json.Encode(trailer)
+	}
+	return s.writeCSV("total", trailer.Total, trailer.TotalDecimal)
+}
+
+func (s *snapshotWriter) writeCSV(record ...string) error {
+	return s.csv.Write(record)
+}
+
+// Internal method: push buffered CSV rows to the underlying writer
+func (s *snapshotWriter) flush() error {
+	if s.csv == nil {
+		return nil
+	}
+	s.csv.Flush()
+	return s.csv.Error()
+}
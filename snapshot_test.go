@@ -0,0 +1,214 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// holderRegistry is a fake token service paging through a fixed holder set. Balance reads
+// of the addresses in failOnce fail the first time.
+type holderRegistry struct {
+	holders  []string
+	balances map[string]string
+	supply   string
+
+	mu       sync.Mutex
+	failOnce map[string]bool
+	cursors  []string
+	blocks   []string
+}
+
+func newHolderRegistry(t *testing.T, reg *holderRegistry) *alchemy.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var params struct { // signed token service params; node calls are positional
+			MethodArgs []interface{} `json:"methodArgs"`
+			Block      json.Number   `json:"block"`
+		}
+		json.Unmarshal(req.Params, &params)
+		args := params.MethodArgs
+
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = "0x100"
+		case "getTokenMetadata":
+			result = map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": reg.supply}
+		case "getTokenHolders":
+			cursor, limit := args[0].(string), int(args[1].(float64))
+			reg.cursors = append(reg.cursors, cursor)
+			reg.blocks = append(reg.blocks, req.Method+"@"+params.Block.String())
+			start := 0
+			for i, holder := range reg.holders {
+				if holder == cursor {
+					start = i + 1
+				}
+			}
+			end := min(start+limit, len(reg.holders))
+			page := []map[string]string{}
+			for _, holder := range reg.holders[start:end] {
+				page = append(page, map[string]string{"address": holder, "balance": reg.balances[holder]})
+			}
+			next := ""
+			if end < len(reg.holders) {
+				next = reg.holders[end-1]
+			}
+			result = map[string]interface{}{"holders": page, "nextCursor": next, "decimals": 2}
+		case "balanceOfAt":
+			holder := args[0].(string)
+			reg.blocks = append(reg.blocks, fmt.Sprintf("%s@%v", req.Method, args[1]))
+			if reg.failOnce[holder] {
+				delete(reg.failOnce, holder)
+				json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32000, "message": "node timeout"}})
+				return
+			}
+			result = reg.balances[holder]
+		case "totalSupplyAt":
+			reg.blocks = append(reg.blocks, fmt.Sprintf("%s@%v", req.Method, args[0]))
+			result = reg.supply
+		default:
+			http.Error(w, "unexpected method "+req.Method, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL), alchemy.WithKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+// sevenHolders is spread over three pages of 3
+func sevenHolders() *holderRegistry {
+	reg := &holderRegistry{balances: map[string]string{}, supply: "2800", failOnce: map[string]bool{}}
+	for i := 1; i <= 7; i++ {
+		holder := fmt.Sprintf("0x%040d", i)
+		reg.holders = append(reg.holders, holder)
+		reg.balances[holder] = fmt.Sprint(i * 100)
+	}
+	return reg
+}
+
+const sevenHoldersCSV = `address,balance,balanceDecimal
+0x0000000000000000000000000000000000000001,100,1
+0x0000000000000000000000000000000000000002,200,2
+0x0000000000000000000000000000000000000003,300,3
+0x0000000000000000000000000000000000000004,400,4
+0x0000000000000000000000000000000000000005,500,5
+0x0000000000000000000000000000000000000006,600,6
+0x0000000000000000000000000000000000000007,700,7
+total,2800,28
+`
+
+func TestSnapshotBalancesThreePages(t *testing.T) {
+	reg := sevenHolders()
+	client := newHolderRegistry(t, reg)
+
+	var out bytes.Buffer
+	err := client.SnapshotBalances(context.Background(), testToken, 42, &out, alchemy.WithSnapshotPageSize(3), alchemy.WithSnapshotConcurrency(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != sevenHoldersCSV {
+		t.Fatalf("snapshot:\n%s", out.String())
+	}
+	if got := fmt.Sprint(reg.cursors); got != "[ "+reg.holders[2]+" "+reg.holders[5]+"]" {
+		t.Fatalf("cursors %s", got)
+	}
+	for _, read := range reg.blocks {
+		if !strings.HasSuffix(read, "@42") {
+			t.Fatalf("read %s not at block 42", read)
+		}
+	}
+}
+
+func TestSnapshotBalancesJSONLines(t *testing.T) {
+	client := newHolderRegistry(t, sevenHolders())
+
+	var out bytes.Buffer
+	if err := client.SnapshotBalances(context.Background(), testToken, 42, &out, alchemy.WithSnapshotPageSize(3), alchemy.WithSnapshotFormat(alchemy.SnapshotJSONLines)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 8 {
+		t.Fatalf("%d lines, want 7 holders and a trailer", len(lines))
+	}
+	if lines[4] != `{"address":"0x0000000000000000000000000000000000000005","balance":"500","balanceDecimal":"5"}` {
+		t.Fatalf("holder line %s", lines[4])
+	}
+	if lines[7] != `{"total":"2800","totalDecimal":"28","supply":"2800","holders":7}` {
+		t.Fatalf("trailer %s", lines[7])
+	}
+}
+
+func TestSnapshotBalancesSupplyMismatch(t *testing.T) {
+	reg := sevenHolders()
+	reg.supply = "2801"
+	client := newHolderRegistry(t, reg)
+
+	var out bytes.Buffer
+	err := client.SnapshotBalances(context.Background(), testToken, 42, &out, alchemy.WithSnapshotPageSize(3))
+	if !errors.Is(err, alchemy.ErrSnapshotMismatch) {
+		t.Fatalf("err = %v, want ErrSnapshotMismatch", err)
+	}
+	if out.String() != sevenHoldersCSV {
+		t.Fatalf("snapshot not written completely:\n%s", out.String())
+	}
+}
+
+func TestSnapshotBalancesResume(t *testing.T) {
+	reg := sevenHolders()
+	reg.failOnce[reg.holders[4]] = true // second page
+	client := newHolderRegistry(t, reg)
+
+	var out bytes.Buffer
+	err := client.SnapshotBalances(context.Background(), testToken, 42, &out, alchemy.WithSnapshotPageSize(3))
+	var stopped *alchemy.SnapshotError
+	if !errors.As(err, &stopped) {
+		t.Fatalf("err = %v, want *SnapshotError", err)
+	}
+	if stopped.Cursor != reg.holders[2] || stopped.Written != 3 || stopped.Total != "600" {
+		t.Fatalf("stopped at %+v", stopped)
+	}
+	if !strings.Contains(err.Error(), "node timeout") {
+		t.Fatalf("err = %v", err)
+	}
+
+	err = client.SnapshotBalances(context.Background(), testToken, 42, &out, alchemy.WithSnapshotPageSize(3), alchemy.WithSnapshotResume(stopped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No partial page was written before the failure, so nothing is duplicated
+	if out.String() != sevenHoldersCSV {
+		t.Fatalf("resumed snapshot:\n%s", out.String())
+	}
+}
@@ -0,0 +1,140 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Sampling limits of GetSupplyHistory: ranges are requested (or sampled) this many points
+// at a time, pausing between chunks so long histories don't flood the server
+const (
+	supplyHistoryChunkPoints = 500
+	supplyHistoryChunkPause  = 200 * time.Millisecond
+)
+
+// SupplyPoint is the token supply at a block
+type SupplyPoint struct {
+	Block     int64
+	Timestamp time.Time
+	Supply    string // raw base units
+}
+
+type rpcSupplyPoint struct {
+	Block     int64  `json:"block"`
+	Timestamp int64  `json:"timestamp"` // unix seconds
+	Supply    string `json:"supply"`
+}
+
+// GetTokenMetadataAt gets token metadata as of block n (e.g. the supply at that height),
+// shorthand for GetTokenMetadata with WithBlock(n)
+func (c *Client) GetTokenMetadataAt(tokenAddress string, n int64) (r *ResponseHandler[*TokenMetadata]) {
+	defer withOperation(&r, "GetTokenMetadataAt", tokenAddress)
+	return c.GetTokenMetadata(tokenAddress, WithBlock(n))
+}
+
+// GetSupplyHistory gets the token supply every interval blocks from fromBlock to toBlock,
+// in ascending block order; toBlock is always the last point. Uses the server's
+// get_supply_history when available, otherwise samples GetTokenMetadataAt and the block
+// timestamps from the node. Long ranges are fetched in chunks of 500 points with a short
+// pause in between.
+func (c *Client) GetSupplyHistory(tokenAddress string, fromBlock, toBlock int64, interval int64) (r *ResponseHandler[[]SupplyPoint]) {
+	defer withOperation(&r, "GetSupplyHistory", tokenAddress)
+	if interval <= 0 {
+		return &ResponseHandler[[]SupplyPoint]{err: fmt.Errorf("invalid interval %d", interval)}
+	}
+	if fromBlock < 0 || toBlock < fromBlock {
+		return &ResponseHandler[[]SupplyPoint]{err: fmt.Errorf("invalid block range %d-%d", fromBlock, toBlock)}
+	}
+	if err := c.checkAddress("tokenAddress", tokenAddress); err != nil {
+		return &ResponseHandler[[]SupplyPoint]{err: err}
+	}
+
+	blocks := supplySampleBlocks(fromBlock, toBlock, interval)
+	points := make([]SupplyPoint, 0, len(blocks))
+	serverSide := true
+	for start := 0; start < len(blocks); start += supplyHistoryChunkPoints {
+		if start > 0 {
+			time.Sleep(supplyHistoryChunkPause)
+		}
+		chunk := blocks[start:min(start+supplyHistoryChunkPoints, len(blocks))]
+
+		if serverSide {
+			fetched, err := c.supplyHistoryFromServer(tokenAddress, chunk, interval)
+			if err == nil {
+				points = append(points, fetched...)
+				continue
+			}
+			if !isMethodNotFound(err) {
+				return &ResponseHandler[[]SupplyPoint]{err: err}
+			}
+			serverSide = false
+		}
+
+		sampled, err := c.sampleSupply(tokenAddress, chunk)
+		if err != nil {
+			return &ResponseHandler[[]SupplyPoint]{err: err}
+		}
+		points = append(points, sampled...)
+	}
+
+	return &ResponseHandler[[]SupplyPoint]{data: points}
+}
+
+// Internal method: fromBlock, fromBlock+interval, ... and toBlock
+func supplySampleBlocks(fromBlock, toBlock, interval int64) []int64 {
+	blocks := make([]int64, 0, (toBlock-fromBlock)/interval+2)
+	for n := fromBlock; n <= toBlock; n += interval {
+		blocks = append(blocks, n)
+	}
+	if blocks[len(blocks)-1] != toBlock {
+		blocks = append(blocks, toBlock)
+	}
+	return blocks
+}
+
+// Internal method: one get_supply_history call covering the blocks of a chunk
+func (c *Client) supplyHistoryFromServer(tokenAddress string, blocks []int64, interval int64) ([]SupplyPoint, error) {
+	result, err := c.rpcCall("get_supply_history", map[string]interface{}{
+		"token":     tokenAddress,
+		"fromBlock": blocks[0],
+		"toBlock":   blocks[len(blocks)-1],
+		"interval":  interval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []rpcSupplyPoint
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("decode supply history: %w", err)
+	}
+	points := make([]SupplyPoint, len(raw))
+	for i, point := range raw {
+		if i > 0 && point.Block <= raw[i-1].Block {
+			return nil, fmt.Errorf("decode supply history: block %d after %d", point.Block, raw[i-1].Block)
+		}
+		points[i] = SupplyPoint{Block: point.Block, Timestamp: time.Unix(point.Timestamp, 0).UTC(), Supply: point.Supply}
+	}
+	return points, nil
+}
+
+// Internal method: read the supply and block timestamp at each block
+func (c *Client) sampleSupply(tokenAddress string, blocks []int64) ([]SupplyPoint, error) {
+	points := make([]SupplyPoint, len(blocks))
+	for i, n := range blocks {
+		metadata := c.GetTokenMetadataAt(tokenAddress, n)
+		if metadata.err != nil {
+			return nil, fmt.Errorf("supply at block %d: %w", n, metadata.err)
+		}
+		if metadata.data == nil {
+			return nil, fmt.Errorf("supply at block %d: empty metadata", n)
+		}
+		header, err := c.getHeaderByNumber(n)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", n, err)
+		}
+		points[i] = SupplyPoint{Block: n, Timestamp: header.Timestamp, Supply: metadata.data.Supply}
+	}
+	return points, nil
+}
@@ -0,0 +1,168 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// supplyAt and timeAt describe the fake chain of newSupplyChain
+func supplyAt(n int64) string { return fmt.Sprint(n * 10) }
+func timeAt(n int64) int64    { return 1_700_000_000 + n*12 }
+
+// newSupplyChain serves the supply history through get_supply_history when serverSide is
+// set, otherwise only metadata at a block and block headers. It counts the history calls.
+func newSupplyChain(t *testing.T, serverSide bool) (*alchemy.Client, *atomic.Int32) {
+	t.Helper()
+	var historyCalls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		reply := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_blockNumber":
+			reply["result"] = "0x10000"
+		case "eth_getBlockByNumber":
+			var params []interface{}
+			json.Unmarshal(req.Params, &params)
+			var n int64
+			fmt.Sscanf(params[0].(string), "0x%x", &n)
+			reply["result"] = map[string]string{
+				"number":     fmt.Sprintf("0x%x", n),
+				"hash":       fmt.Sprintf("0x%064x", n),
+				"parentHash": fmt.Sprintf("0x%064x", n-1),
+				"timestamp":  fmt.Sprintf("0x%x", timeAt(n)),
+			}
+		case "getTokenMetadata":
+			var params struct{ Block int64 }
+			json.Unmarshal(req.Params, &params)
+			reply["result"] = map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": supplyAt(params.Block)}
+		case "get_supply_history":
+			if !serverSide {
+				reply["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+				break
+			}
+			historyCalls.Add(1)
+			var params struct{ FromBlock, ToBlock, Interval int64 }
+			json.Unmarshal(req.Params, &params)
+			points := []map[string]interface{}{}
+			for n := params.FromBlock; ; n += params.Interval {
+				if n > params.ToBlock {
+					n = params.ToBlock
+				}
+				points = append(points, map[string]interface{}{"block": n, "timestamp": timeAt(n), "supply": supplyAt(n)})
+				if n == params.ToBlock {
+					break
+				}
+			}
+			reply["result"] = points
+		default:
+			http.Error(w, "unexpected method "+req.Method, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(reply)
+	}))
+	t.Cleanup(srv.Close)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL), alchemy.WithKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &historyCalls
+}
+
+// checkSupplyPoints checks the count, the ordering and each point's values
+func checkSupplyPoints(t *testing.T, points []alchemy.SupplyPoint, want int, last int64) {
+	t.Helper()
+	if len(points) != want {
+		t.Fatalf("%d points, want %d", len(points), want)
+	}
+	for i, point := range points {
+		if i > 0 && point.Block <= points[i-1].Block {
+			t.Fatalf("point %d at block %d after %d", i, point.Block, points[i-1].Block)
+		}
+		if point.Supply != supplyAt(point.Block) || !point.Timestamp.Equal(time.Unix(timeAt(point.Block), 0)) {
+			t.Fatalf("point %+v", point)
+		}
+	}
+	if points[len(points)-1].Block != last {
+		t.Fatalf("last point at block %d, want %d", points[len(points)-1].Block, last)
+	}
+}
+
+func TestGetSupplyHistory(t *testing.T) {
+	tests := []struct {
+		name               string
+		from, to, interval int64
+		wantPoints         int
+	}{
+		{"aligned", 100, 200, 10, 11},
+		{"unaligned end", 100, 205, 10, 12},
+		{"single block", 7, 7, 100, 1},
+		{"interval past range", 0, 50, 1000, 2},
+	}
+	for _, serverSide := range []bool{true, false} {
+		client, _ := newSupplyChain(t, serverSide)
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/server=%v", tt.name, serverSide), func(t *testing.T) {
+				points, err := client.GetSupplyHistory(testToken, tt.from, tt.to, tt.interval).Result()
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkSupplyPoints(t, points, tt.wantPoints, tt.to)
+				if points[0].Block != tt.from {
+					t.Fatalf("first point at block %d", points[0].Block)
+				}
+			})
+		}
+	}
+}
+
+func TestGetSupplyHistoryChunked(t *testing.T) {
+	client, calls := newSupplyChain(t, true)
+
+	start := time.Now()
+	points, err := client.GetSupplyHistory(testToken, 0, 1199, 1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSupplyPoints(t, points, 1200, 1199)
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("%d history calls, want 3 chunks of at most 500 points", n)
+	}
+	if elapsed := time.Since(start); elapsed < 2*200*time.Millisecond {
+		t.Fatalf("chunks fetched in %v without pausing", elapsed)
+	}
+}
+
+func TestGetSupplyHistoryValidation(t *testing.T) {
+	client, _ := newSupplyChain(t, true)
+	for _, tt := range []struct{ from, to, interval int64 }{
+		{0, 10, 0},
+		{0, 10, -1},
+		{-1, 10, 1},
+		{10, 9, 1},
+	} {
+		if err := client.GetSupplyHistory(testToken, tt.from, tt.to, tt.interval).Err(); err == nil {
+			t.Errorf("range %d-%d interval %d accepted", tt.from, tt.to, tt.interval)
+		}
+	}
+}
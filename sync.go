@@ -0,0 +1,147 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNodeLagging is returned by writes under the lag guard (see WithNodeLagGuard) when the
+// node is syncing or its block number has stopped advancing
+var ErrNodeLagging = errors.New("node is lagging behind the chain")
+
+// SyncStatus is the node's eth_syncing report
+type SyncStatus struct {
+	Syncing       bool
+	StartingBlock int64 // block the sync started at, 0 when not syncing
+	CurrentBlock  int64 // block the node has reached, 0 when not syncing
+	HighestBlock  int64 // estimated chain head, 0 when not syncing
+}
+
+// Lag returns the number of blocks the node is behind the estimated chain head
+func (s *SyncStatus) Lag() int64 {
+	if !s.Syncing || s.HighestBlock < s.CurrentBlock {
+		return 0
+	}
+	return s.HighestBlock - s.CurrentBlock
+}
+
+// headWatch records when the node's block number last advanced
+type headWatch struct {
+	block   int64
+	changed time.Time
+}
+
+// ConfigNodeLagGuard switches the lag guard of the default client on with the given stall
+// window, or off with a negative one (see WithNodeLagGuard)
+func ConfigNodeLagGuard(stallWindow time.Duration) {
+	c := defaultClient
+	c.headMu.Lock()
+	defer c.headMu.Unlock()
+	c.lagGuard = stallWindow >= 0
+	c.stallWindow = max(stallWindow, 0)
+}
+
+// WithNodeLagGuard refuses to sign writes against a node that isn't at the chain head, as
+// their recentCheckpoint would be stale: the write fails with ErrNodeLagging when eth_syncing
+// reports a sync in progress, or when the block number the node reports hasn't advanced for
+// stallWindow (0 checks eth_syncing only). Costs one eth_syncing request per write; nodes
+// without eth_syncing are taken as not syncing. Reads aren't checked.
+func WithNodeLagGuard(stallWindow time.Duration) Option {
+	return func(o *clientOptions) {
+		o.lagGuard = true
+		o.stallWindow = stallWindow
+	}
+}
+
+// GetSyncStatus reports whether the node is syncing and how far it has got (eth_syncing)
+func (c *Client) GetSyncStatus() (r *ResponseHandler[*SyncStatus]) {
+	defer withOperation(&r, "GetSyncStatus", "")
+	status, err := c.getSyncStatus(context.Background())
+	if err != nil {
+		return &ResponseHandler[*SyncStatus]{err: err}
+	}
+	return &ResponseHandler[*SyncStatus]{data: status}
+}
+
+// Internal method: eth_syncing, which returns false or an object of hex quantities
+func (c *Client) getSyncStatus(ctx context.Context) (*SyncStatus, error) {
+	result, err := c.nodeCallContext(ctx, "eth_syncing", []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var syncing bool
+	if err := json.Unmarshal(result, &syncing); err == nil {
+		if syncing {
+			return nil, errors.New("decode sync status: true without progress")
+		}
+		return &SyncStatus{}, nil
+	}
+	var progress struct {
+		StartingBlock string `json:"startingBlock"`
+		CurrentBlock  string `json:"currentBlock"`
+		HighestBlock  string `json:"highestBlock"`
+	}
+	if err := json.Unmarshal(result, &progress); err != nil {
+		return nil, fmt.Errorf("decode sync status: %w", err)
+	}
+	status := &SyncStatus{Syncing: true}
+	for _, field := range []struct {
+		hex  string
+		dest *int64
+	}{
+		{progress.StartingBlock, &status.StartingBlock},
+		{progress.CurrentBlock, &status.CurrentBlock},
+		{progress.HighestBlock, &status.HighestBlock},
+	} {
+		if field.hex == "" {
+			continue
+		}
+		n, err := parseHexQuantity(field.hex)
+		if err != nil {
+			return nil, fmt.Errorf("decode sync status: %w", err)
+		}
+		*field.dest = int64(n)
+	}
+	return status, nil
+}
+
+// Internal method: record a block number the node reported, for the stall check
+func (c *Client) observeHead(block int64) {
+	c.headMu.Lock()
+	defer c.headMu.Unlock()
+	if c.head.changed.IsZero() || block > c.head.block {
+		c.head = headWatch{block: block, changed: time.Now()}
+	}
+}
+
+// Internal method: the lag guard, run before signing a write with the checkpoint block
+func (c *Client) checkNodeLag(ctx context.Context, block int64) error {
+	c.headMu.Lock()
+	enabled, window, head := c.lagGuard, c.stallWindow, c.head
+	c.headMu.Unlock()
+	if !enabled {
+		return nil
+	}
+
+	if window > 0 && block <= head.block {
+		if stalled := time.Since(head.changed); stalled >= window {
+			return fmt.Errorf("%w: block number stuck at %d for %v", ErrNodeLagging, block, stalled.Round(time.Millisecond))
+		}
+	}
+
+	status, err := c.getSyncStatus(ctx)
+	if err != nil {
+		if isMethodNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("check sync status: %w", err)
+	}
+	if status.Syncing {
+		return fmt.Errorf("%w: node is syncing, at block %d of %d (%d behind)", ErrNodeLagging, status.CurrentBlock, status.HighestBlock, status.Lag())
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package alchemy_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+// syncingProgress is an eth_syncing result 0x200 blocks behind
+var syncingProgress = map[string]interface{}{"startingBlock": "0x0", "currentBlock": "0x100", "highestBlock": "0x300"}
+
+func TestGetSyncStatus(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	srv.SetResult("eth_syncing", false)
+	status, err := client.GetSyncStatus().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Syncing || status.Lag() != 0 {
+		t.Fatalf("status %+v, want not syncing", status)
+	}
+
+	srv.SetResult("eth_syncing", syncingProgress)
+	status, err = client.GetSyncStatus().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *status != (alchemy.SyncStatus{Syncing: true, CurrentBlock: 0x100, HighestBlock: 0x300}) || status.Lag() != 0x200 {
+		t.Fatalf("status %+v", status)
+	}
+
+	srv.SetResult("eth_syncing", map[string]interface{}{"currentBlock": "12"})
+	if err := client.GetSyncStatus().Err(); err == nil {
+		t.Fatal("non-hex block accepted")
+	}
+}
+
+func TestNodeLagGuardSyncing(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithNodeLagGuard(0))
+	srv.SetResult("eth_syncing", syncingProgress)
+
+	err := client.Mint(testToken, testRecipient, "1", 0).Err()
+	if !errors.Is(err, alchemy.ErrNodeLagging) || !strings.Contains(err.Error(), "at block 256 of 768 (512 behind)") {
+		t.Fatalf("err = %v, want ErrNodeLagging with the sync progress", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 0 {
+		t.Fatalf("%d writes sent by a syncing node", n)
+	}
+	// Reads aren't guarded
+	srv.SetResult("getSupplyCap", "100")
+	if err := client.GetSupplyCap(testToken).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv.SetResult("eth_syncing", false)
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nodes without eth_syncing count as not syncing
+	srv.SetError("eth_syncing", alchemytest.CodeMethodNotFound, "method not found")
+	if err := client.Mint(testToken, testRecipient, "1", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNodeLagGuardStalledBlock(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithNodeLagGuard(50*time.Millisecond))
+	srv.SetResult("eth_syncing", false)
+	srv.SetBlockNumber(700)
+
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	err := client.Mint(testToken, testRecipient, "1", 1).Err()
+	if !errors.Is(err, alchemy.ErrNodeLagging) || !strings.Contains(err.Error(), "stuck at 700") {
+		t.Fatalf("err = %v, want ErrNodeLagging for the stalled block", err)
+	}
+	if err := client.CreateToken("Dollar", "USDX", 6, testRecipient).Err(); !errors.Is(err, alchemy.ErrNodeLagging) {
+		t.Fatalf("CreateToken err = %v, want ErrNodeLagging", err)
+	}
+
+	// The node advancing again clears the guard
+	srv.SetBlockNumber(701)
+	if err := client.Mint(testToken, testRecipient, "1", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 2 {
+		t.Fatalf("%d writes sent, want 2", n)
+	}
+
+	// Without the guard a stalled node isn't noticed
+	srv, client, _ = newTestServer(t)
+	srv.SetBlockNumber(700)
+	if err := client.Mint(testToken, testRecipient, "1", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if err := client.Mint(testToken, testRecipient, "1", 1).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("eth_syncing")); n != 0 {
+		t.Fatalf("%d eth_syncing requests without the guard", n)
+	}
+}
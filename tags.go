@@ -0,0 +1,66 @@
+package alchemy
+
+import (
+	"context"
+	"maps"
+)
+
+// tagsKey is the context key of the operation tags
+type tagsKey struct{}
+
+// WithTag labels an operation with key=value, e.g. a job ID or tenant, for logging and
+// metrics. Tags never reach the server: they are added to the logger's request records
+// and to the context of every request the operation sends, including the hidden ones
+// (eth_blockNumber, chain ID and nonce lookups, preflight checks, confirmation polling),
+// where a custom Transport or slog.Handler reads them with TagsFromContext. Tags belong to
+// the single call they are passed to.
+func WithTag(key, value string) CallOption {
+	return func(c *callConfig) {
+		if c.tags == nil {
+			c.tags = map[string]string{}
+		}
+		c.tags[key] = value
+	}
+}
+
+// TagsFromContext returns the WithTag tags of the operation a request belongs to, nil if
+// it has none. The map is a copy.
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return maps.Clone(tags)
+}
+
+// Internal method: CallOption passing the tags and context of cfg on to the calls an
+// operation makes
+func (cfg *callConfig) inherited() CallOption {
+	return func(c *callConfig) {
+		for key, value := range cfg.tags {
+			WithTag(key, value)(c)
+		}
+		if cfg.ctx != nil {
+			c.ctx = cfg.ctx
+		}
+		if cfg.endpoint != 0 {
+			c.endpoint = cfg.endpoint
+		}
+		if cfg.signerName != "" {
+			c.signerName = cfg.signerName
+		}
+	}
+}
+
+// Internal method: context for the requests of an operation, carrying its tags
+func (cfg *callConfig) context() context.Context {
+	ctx := cfg.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if cfg.endpoint != 0 {
+		ctx = context.WithValue(ctx, endpointRoleKey{}, cfg.endpoint)
+	}
+	ctx = withSignerName(ctx, cfg.signerName)
+	if len(cfg.tags) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, tagsKey{}, maps.Clone(cfg.tags))
+}
@@ -0,0 +1,131 @@
+package alchemy_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// taggedRecord is a logged request with the tags of its record and of its context
+type taggedRecord struct {
+	method     string
+	recordTags map[string]string
+	ctxTags    map[string]string
+}
+
+// tagRecorder is a slog.Handler keeping the request records
+type tagRecorder struct {
+	mu      sync.Mutex
+	records []taggedRecord
+}
+
+func (h *tagRecorder) Enabled(context.Context, slog.Level) bool { return true }
+func (h *tagRecorder) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *tagRecorder) WithGroup(string) slog.Handler            { return h }
+
+func (h *tagRecorder) Handle(ctx context.Context, r slog.Record) error {
+	rec := taggedRecord{ctxTags: alchemy.TagsFromContext(ctx)}
+	r.Attrs(func(a slog.Attr) bool {
+		switch a.Key {
+		case "method":
+			rec.method = a.Value.String()
+		case "tags":
+			rec.recordTags, _ = a.Value.Any().(map[string]string)
+		}
+		return true
+	})
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.records = append(h.records, rec)
+	return nil
+}
+
+func (h *tagRecorder) byMethod() map[string]taggedRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	methods := map[string]taggedRecord{}
+	for _, rec := range h.records {
+		methods[rec.method] = rec
+	}
+	return methods
+}
+
+func TestTagsReachEveryRequestOfAnOperation(t *testing.T) {
+	recorder := &tagRecorder{}
+	srv, client, key := newTestServer(t, alchemy.WithLogger(slog.New(recorder)),
+		alchemy.WithExpectedChainID(1), alchemy.WithTokenAddressVerification(), alchemy.WithAuthorityPreflight())
+	srv.SetChainID(1)
+	srv.SetCode(testToken, []byte{0x60, 0x80})
+	srv.SetResult("getAuthorities", []string{crypto.PubkeyToAddress(key.PublicKey).Hex()})
+
+	err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithTag("job", "payout-7"), alchemy.WithTag("tenant", "acme")).Err()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"job": "payout-7", "tenant": "acme"}
+	records := recorder.byMethod()
+	for _, method := range []string{"eth_chainId", "eth_getCode", "getAuthorities", "eth_blockNumber", "mint"} {
+		rec, ok := records[method]
+		if !ok {
+			t.Fatalf("no %s request logged among %v", method, records)
+		}
+		if fmt.Sprint(rec.recordTags) != fmt.Sprint(want) || fmt.Sprint(rec.ctxTags) != fmt.Sprint(want) {
+			t.Fatalf("%s: record tags %v, context tags %v, want %v", method, rec.recordTags, rec.ctxTags, want)
+		}
+	}
+
+	// Tags stay client-side
+	for _, req := range srv.Requests() {
+		if strings.Contains(string(req.Params), "payout-7") {
+			t.Fatalf("%s sent the tags: %s", req.Method, req.Params)
+		}
+	}
+
+	// ...and don't outlive the call
+	recorder.records = nil
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if rec := recorder.byMethod()["eth_getBalance"]; rec.recordTags != nil || rec.ctxTags != nil {
+		t.Fatalf("untagged call logged tags %v / %v", rec.recordTags, rec.ctxTags)
+	}
+}
+
+func TestTagsDontLeakBetweenConcurrentOperations(t *testing.T) {
+	recorder := &tagRecorder{}
+	_, client, _ := newTestServer(t, alchemy.WithLogger(slog.New(recorder)))
+
+	const jobs = 20
+	var wg sync.WaitGroup
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func(job string) {
+			defer wg.Done()
+			if err := client.Mint(testToken, testRecipient, "1", 0, alchemy.WithTag("job", job)).Err(); err != nil {
+				t.Error(err)
+			}
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+
+	// Each Mint sends eth_blockNumber and mint: two records per job, tagged consistently
+	perJob := map[string]int{}
+	for _, rec := range recorder.records {
+		if rec.recordTags["job"] != rec.ctxTags["job"] || len(rec.recordTags) != 1 {
+			t.Fatalf("%s: record tags %v, context tags %v", rec.method, rec.recordTags, rec.ctxTags)
+		}
+		perJob[rec.recordTags["job"]]++
+	}
+	for i := 0; i < jobs; i++ {
+		if n := perJob[fmt.Sprint(i)]; n != 2 {
+			t.Fatalf("job %d has %d records, want 2", i, n)
+		}
+	}
+}
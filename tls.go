@@ -0,0 +1,114 @@
+package alchemy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gorilla/websocket"
+)
+
+// TLSOptions configures TLS for all SDK connections
+type TLSOptions struct {
+	// CertFile and KeyFile are a PEM client certificate and key for mutual TLS
+	CertFile string
+	KeyFile  string
+	// CertProvider returns the client certificate and is called on every handshake, so
+	// rotated certificates are picked up without a restart. Takes precedence over CertFile.
+	CertProvider func() (*tls.Certificate, error)
+	// CAFile is a PEM bundle of root CAs trusted instead of the system roots
+	CAFile string
+	// RootCAs is a root CA pool trusted instead of the system roots (combined with CAFile)
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables server certificate verification. Never use in production.
+	InsecureSkipVerify bool
+}
+
+// ConfigTLS applies TLS options to the transport used for all SDK requests.
+// A zero TLSOptions restores the default configuration.
+func ConfigTLS(opts TLSOptions) error {
+	cfg, err := opts.build()
+	if err != nil {
+		return err
+	}
+
+	c := defaultClient
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+	c.tlsConfig = cfg
+	c.rebuildTransport()
+	return nil
+}
+
+// Internal method: build a tls.Config, nil when no option is set
+func (o TLSOptions) build() (*tls.Config, error) {
+	if o.CertFile == "" && o.KeyFile == "" && o.CertProvider == nil &&
+		o.CAFile == "" && o.RootCAs == nil && !o.InsecureSkipVerify {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	switch {
+	case o.CertProvider != nil:
+		provider := o.CertProvider
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return provider()
+		}
+	case o.CertFile != "" || o.KeyFile != "":
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.RootCAs != nil || o.CAFile != "" {
+		pool := o.RootCAs
+		if pool == nil {
+			pool = x509.NewCertPool()
+		} else {
+			pool = pool.Clone()
+		}
+		if o.CAFile != "" {
+			pem, err := os.ReadFile(o.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("read CA file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, errors.New("read CA file: no certificates found")
+			}
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// Internal method: replace the HTTP client with one using the current transport settings,
+// including one supplied with WithHTTPClient. Callers hold transportMu.
+func (c *Client) rebuildTransport() {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	c.tuning.apply(transport)
+	transport.TLSClientConfig = c.tlsConfig
+	transport.Proxy = c.proxyFunc()
+	c.httpClient = &http.Client{Timeout: c.timeout, Transport: transport}
+	c.customHTTP = false
+}
+
+// Internal method: WebSocket dialer using the current transport settings
+func (c *Client) wsDialer() *websocket.Dialer {
+	c.transportMu.Lock()
+	defer c.transportMu.Unlock()
+
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = c.tlsConfig
+	dialer.Proxy = c.proxyFunc()
+	return &dialer
+}
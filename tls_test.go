@@ -0,0 +1,194 @@
+package alchemy_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// testCA is a throwaway certificate authority
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue signs a leaf certificate for a server (with 127.0.0.1 as SAN) or a client
+func (ca *testCA) issue(t *testing.T, serial int64, server bool) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// newMTLSServer answers every JSON-RPC call with "0x1" to clients presenting a certificate
+// issued by ca
+func newMTLSServer(t *testing.T, ca *testCA) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{ca.issue(t, 2, true)},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    ca.pool,
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// writePEM writes a certificate and its key as PEM files, returning their paths
+func writePEM(t *testing.T, cert tls.Certificate) (certFile, keyFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	keyDER, err := x509.MarshalECPrivateKey(cert.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newMTLSServer(t, ca)
+	clientCert := ca.issue(t, 3, false)
+	certFile, keyFile := writePEM(t, clientCert)
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	provider := func() (*tls.Certificate, error) { return &clientCert, nil }
+
+	tests := []struct {
+		name string
+		opts alchemy.TLSOptions
+		ok   bool
+	}{
+		{"files", alchemy.TLSOptions{CertFile: certFile, KeyFile: keyFile, CAFile: caFile}, true},
+		{"provider and pool", alchemy.TLSOptions{CertProvider: provider, RootCAs: ca.pool}, true},
+		{"insecure skip verify", alchemy.TLSOptions{CertProvider: provider, InsecureSkipVerify: true}, true},
+		{"no client certificate", alchemy.TLSOptions{RootCAs: ca.pool}, false},
+		{"unknown server CA", alchemy.TLSOptions{CertProvider: provider}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := alchemy.NewClient(srv.URL, alchemy.WithTLS(tt.opts))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.GetBalance(testRecipient).Err()
+			if tt.ok && err != nil {
+				t.Fatalf("GetBalance: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("handshake succeeded")
+			}
+		})
+	}
+}
+
+func TestTLSCertProviderRotation(t *testing.T) {
+	ca := newTestCA(t)
+	srv := newMTLSServer(t, ca)
+	var serials []int64
+	handler := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serials = append(serials, r.TLS.PeerCertificates[0].SerialNumber.Int64())
+		w.Header().Set("Connection", "close") // every request handshakes again
+		handler.ServeHTTP(w, r)
+	})
+
+	certs := []tls.Certificate{ca.issue(t, 10, false), ca.issue(t, 11, false)}
+	var current atomic.Int64
+	provider := func() (*tls.Certificate, error) { return &certs[current.Load()], nil }
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithTLS(alchemy.TLSOptions{CertProvider: provider, RootCAs: ca.pool}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	current.Store(1)
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(serials) != 2 || serials[0] != 10 || serials[1] != 11 {
+		t.Fatalf("server saw client certificates %v, want [10 11]", serials)
+	}
+}
@@ -0,0 +1,91 @@
+package alchemy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidTokenParams is returned by CreateToken for a name, symbol or decimals outside
+// the configured TokenRules, before anything is signed or sent
+var ErrInvalidTokenParams = errors.New("invalid token parameters")
+
+// Default TokenRules bounds, matching the token service's own checks
+const (
+	DefaultMaxNameLength   = 64 // characters
+	DefaultMaxSymbolLength = 12 // characters
+	DefaultMaxDecimals     = 18
+)
+
+// defaultSymbolPattern is the default TokenRules.SymbolPattern: letters and digits
+var defaultSymbolPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// TokenRules bounds the CreateToken inputs. Zero fields take the defaults, so only the
+// rules a deployment changes need to be set.
+type TokenRules struct {
+	MaxNameLength   int            // longest name in characters (DefaultMaxNameLength)
+	MaxSymbolLength int            // longest symbol in characters (DefaultMaxSymbolLength)
+	SymbolPattern   *regexp.Regexp // allowed symbols (letters and digits)
+	MaxDecimals     int32          // highest decimals (DefaultMaxDecimals); 0 is always allowed
+}
+
+// ConfigTokenRules sets the CreateToken input rules of the default client (see WithTokenRules)
+func ConfigTokenRules(rules TokenRules) {
+	defaultClient.tokenRules = rules
+}
+
+// WithTokenRules replaces the CreateToken input rules, for chain deployments whose token
+// service accepts other names, symbols or decimals than the defaults
+func WithTokenRules(rules TokenRules) Option {
+	return func(o *clientOptions) { o.tokenRules = rules }
+}
+
+// Internal method: validate CreateToken inputs against rules
+func (r TokenRules) check(name, symbol string, decimals int32) error {
+	maxName := r.MaxNameLength
+	if maxName <= 0 {
+		maxName = DefaultMaxNameLength
+	}
+	maxSymbol := r.MaxSymbolLength
+	if maxSymbol <= 0 {
+		maxSymbol = DefaultMaxSymbolLength
+	}
+	pattern := r.SymbolPattern
+	if pattern == nil {
+		pattern = defaultSymbolPattern
+	}
+	maxDecimals := r.MaxDecimals
+	if maxDecimals <= 0 {
+		maxDecimals = DefaultMaxDecimals
+	}
+
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("%w: empty name", ErrInvalidTokenParams)
+	case strings.TrimSpace(name) != name:
+		return fmt.Errorf("%w: name %q has leading or trailing whitespace", ErrInvalidTokenParams, name)
+	case !utf8.ValidString(name):
+		return fmt.Errorf("%w: name is not valid UTF-8", ErrInvalidTokenParams)
+	case utf8.RuneCountInString(name) > maxName:
+		return fmt.Errorf("%w: name has %d characters, at most %d allowed", ErrInvalidTokenParams, utf8.RuneCountInString(name), maxName)
+	case strings.IndexFunc(name, unicode.IsControl) >= 0:
+		return fmt.Errorf("%w: name %q contains a control character", ErrInvalidTokenParams, name)
+	}
+
+	switch {
+	case symbol == "":
+		return fmt.Errorf("%w: empty symbol", ErrInvalidTokenParams)
+	case utf8.RuneCountInString(symbol) > maxSymbol:
+		return fmt.Errorf("%w: symbol %q has %d characters, at most %d allowed", ErrInvalidTokenParams, symbol, utf8.RuneCountInString(symbol), maxSymbol)
+	case !pattern.MatchString(symbol):
+		return fmt.Errorf("%w: symbol %q doesn't match %s", ErrInvalidTokenParams, symbol, pattern)
+	}
+
+	if decimals < 0 || decimals > maxDecimals {
+		return fmt.Errorf("%w: decimals %d outside 0..%d", ErrInvalidTokenParams, decimals, maxDecimals)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package alchemy_test
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestCreateTokenValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		symbol   string
+		decimals int32
+	}{
+		{"empty name", "", "MTK", 18},
+		{"blank name", "   ", "MTK", 18},
+		{"padded name", " My Token", "MTK", 18},
+		{"name too long", strings.Repeat("n", alchemy.DefaultMaxNameLength+1), "MTK", 18},
+		{"control character in name", "My\nToken", "MTK", 18},
+		{"invalid UTF-8 name", "My \xff Token", "MTK", 18},
+		{"empty symbol", "My Token", "", 18},
+		{"symbol too long", "My Token", "ABCDEFGHIJKLM", 18},
+		{"symbol with space", "My Token", "MT K", 18},
+		{"symbol with punctuation", "My Token", "MTK!", 18},
+		{"negative decimals", "My Token", "MTK", -1},
+		{"decimals above 18", "My Token", "MTK", 19},
+		{"huge decimals", "My Token", "MTK", 1 << 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			err := client.CreateToken(tt.token, tt.symbol, tt.decimals, testRecipient).Err()
+			if !errors.Is(err, alchemy.ErrInvalidTokenParams) {
+				t.Fatalf("err = %v, want ErrInvalidTokenParams", err)
+			}
+			if n := len(srv.Requests()); n != 0 {
+				t.Fatalf("%d requests sent", n)
+			}
+		})
+	}
+
+	t.Run("bad master authority", func(t *testing.T) {
+		srv, client, _ := newTestServer(t)
+		if err := client.CreateToken("My Token", "MTK", 18, "0x1234").Err(); !errors.Is(err, alchemy.ErrInvalidAddress) {
+			t.Fatalf("err = %v, want ErrInvalidAddress", err)
+		}
+		if n := len(srv.Requests()); n != 0 {
+			t.Fatalf("%d requests sent", n)
+		}
+	})
+
+	t.Run("bounds accepted", func(t *testing.T) {
+		_, client, _ := newTestServer(t)
+		for _, decimals := range []int32{0, 18} {
+			name := strings.Repeat("é", alchemy.DefaultMaxNameLength)
+			if err := client.CreateToken(name, "ABCDEFGHIJK1", decimals, testRecipient).Err(); err != nil {
+				t.Fatalf("decimals %d: %v", decimals, err)
+			}
+		}
+	})
+}
+
+func TestCreateTokenCustomRules(t *testing.T) {
+	srv, client, _ := newTestServer(t, alchemy.WithTokenRules(alchemy.TokenRules{
+		MaxSymbolLength: 16,
+		SymbolPattern:   regexp.MustCompile(`^[A-Z][A-Z0-9.]*$`),
+		MaxDecimals:     24,
+	}))
+
+	if err := client.CreateToken("Bridged Ether", "WETH.E2024BRIDGE", 24, testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("create_token")); n != 1 {
+		t.Fatalf("%d create_token requests", n)
+	}
+
+	for _, bad := range []struct {
+		symbol   string
+		decimals int32
+	}{{"weth", 18}, {"WETH.E2024BRIDGE1", 18}, {"WETH", 25}} {
+		if err := client.CreateToken("Bridged Ether", bad.symbol, bad.decimals, testRecipient).Err(); !errors.Is(err, alchemy.ErrInvalidTokenParams) {
+			t.Fatalf("%s/%d: err = %v", bad.symbol, bad.decimals, err)
+		}
+	}
+	// Unset fields keep their defaults
+	if err := client.CreateToken(strings.Repeat("n", alchemy.DefaultMaxNameLength+1), "WETH", 18, testRecipient).Err(); !errors.Is(err, alchemy.ErrInvalidTokenParams) {
+		t.Fatalf("long name: err = %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package alchemy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TokenSummary is a short description of a token returned by listings
+type TokenSummary struct {
+	Address  string `json:"address"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+
+	// Set by GetPausedTokens when the server knows when the token was paused
+	PausedAtBlock int64  `json:"pausedAtBlock,omitempty"`
+	PauseTx       string `json:"pauseTx,omitempty"`
+}
+
+// TokenPage is one page of a token listing
+type TokenPage struct {
+	Tokens     []TokenSummary `json:"tokens"`
+	NextCursor string         `json:"nextCursor"` // empty on the last page
+	Total      int64          `json:"total"`
+}
+
+// HasMore reports whether another page can be fetched with NextCursor
+func (p *TokenPage) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// TokenFilter narrows a token listing, empty fields don't filter
+type TokenFilter struct {
+	Creator         string
+	MasterAuthority string
+	Symbol          string // servers may match it case-insensitively
+}
+
+// symbolSearchPageSize is the number of tokens requested per page by FindTokensBySymbol
+const symbolSearchPageSize = 100
+
+// ErrSymbolExists matches the *SymbolExistsError of CreateToken with WithUniqueSymbolCheck
+var ErrSymbolExists = errors.New("token symbol already exists")
+
+// SymbolExistsError is returned by CreateToken with WithUniqueSymbolCheck when tokens with
+// the symbol already exist
+type SymbolExistsError struct {
+	Symbol string
+	Tokens []string // checksummed addresses of the existing tokens
+}
+
+func (e *SymbolExistsError) Error() string {
+	return fmt.Sprintf("%v: %s is used by %s", ErrSymbolExists, e.Symbol, strings.Join(e.Tokens, ", "))
+}
+
+// Is makes errors.Is(err, ErrSymbolExists) match
+func (e *SymbolExistsError) Is(target error) bool {
+	return target == ErrSymbolExists
+}
+
+// ConfigCaseSensitiveSymbols makes the default client compare symbols case-sensitively (see
+// WithCaseSensitiveSymbols)
+func ConfigCaseSensitiveSymbols(enabled bool) {
+	defaultClient.caseSensitiveSymbols = enabled
+}
+
+// WithCaseSensitiveSymbols makes FindTokensBySymbol and WithUniqueSymbolCheck treat symbols
+// differing only in case ("usdx" and "USDX") as different. By default they match.
+func WithCaseSensitiveSymbols() Option {
+	return func(o *clientOptions) { o.caseSensitive = true }
+}
+
+// WithUniqueSymbolCheck makes CreateToken look the symbol up with FindTokensBySymbol first
+// and fail with a *SymbolExistsError instead of creating a duplicate. Tokens created
+// concurrently by others can still slip through between the check and the creation.
+func WithUniqueSymbolCheck() CallOption {
+	return func(c *callConfig) {
+		c.uniqueSymbol = true
+	}
+}
+
+// ListTokens lists tokens created through the server. Pass an empty cursor for the first
+// page and TokenPage.NextCursor afterwards; limit 0 uses the server default page size.
+func (c *Client) ListTokens(cursor string, limit int) (r *ResponseHandler[*TokenPage]) {
+	defer withOperation(&r, "ListTokens", "")
+	return c.ListTokensFiltered(TokenFilter{}, cursor, limit)
+}
+
+// ListTokensFiltered lists tokens matching filter, paginated like ListTokens
+func (c *Client) ListTokensFiltered(filter TokenFilter, cursor string, limit int) (r *ResponseHandler[*TokenPage]) {
+	defer withOperation(&r, "ListTokensFiltered", "")
+	if limit < 0 {
+		return &ResponseHandler[*TokenPage]{err: fmt.Errorf("invalid limit %d", limit)}
+	}
+
+	params := map[string]interface{}{}
+	if cursor != "" {
+		params["cursor"] = cursor
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	if filter.Creator != "" {
+		params["creator"] = filter.Creator
+	}
+	if filter.MasterAuthority != "" {
+		params["masterAuthority"] = filter.MasterAuthority
+	}
+	if filter.Symbol != "" {
+		params["symbol"] = filter.Symbol
+	}
+
+	result, err := c.rpcCall("list_tokens", params)
+	if err != nil {
+		return &ResponseHandler[*TokenPage]{err: err}
+	}
+
+	var page TokenPage
+	if err := json.Unmarshal(result, &page); err != nil {
+		return &ResponseHandler[*TokenPage]{err: err}
+	}
+	if page.Tokens == nil {
+		page.Tokens = []TokenSummary{}
+	}
+	for i := range page.Tokens {
+		page.Tokens[i].Address = checksummed(page.Tokens[i].Address)
+	}
+
+	return &ResponseHandler[*TokenPage]{data: &page}
+}
+
+// FindTokensBySymbol lists the tokens with symbol, in listing order, walking every page of
+// a symbol-filtered listing. Symbols are compared case-insensitively unless
+// WithCaseSensitiveSymbols is set; tokens the server returns that don't match are dropped,
+// so servers ignoring the filter are handled too, at the cost of listing every token.
+func (c *Client) FindTokensBySymbol(symbol string) (r *ResponseHandler[[]TokenSummary]) {
+	defer withOperation(&r, "FindTokensBySymbol", "")
+	if symbol == "" {
+		return &ResponseHandler[[]TokenSummary]{err: errors.New("empty symbol")}
+	}
+
+	matches := []TokenSummary{}
+	cursor := ""
+	for {
+		page := c.ListTokensFiltered(TokenFilter{Symbol: symbol}, cursor, symbolSearchPageSize)
+		if page.err != nil {
+			return &ResponseHandler[[]TokenSummary]{err: page.err}
+		}
+		for _, token := range page.data.Tokens {
+			if token.Symbol == symbol || (!c.caseSensitiveSymbols && strings.EqualFold(token.Symbol, symbol)) {
+				matches = append(matches, token)
+			}
+		}
+
+		if !page.data.HasMore() {
+			return &ResponseHandler[[]TokenSummary]{data: matches}
+		}
+		if page.data.NextCursor == cursor {
+			return &ResponseHandler[[]TokenSummary]{err: fmt.Errorf("list tokens: cursor %q repeated", cursor)}
+		}
+		cursor = page.data.NextCursor
+	}
+}
+
+// Internal method: fail with a *SymbolExistsError when tokens with symbol exist
+func (c *Client) checkUniqueSymbol(symbol string) error {
+	existing := c.FindTokensBySymbol(symbol)
+	if existing.err != nil {
+		return fmt.Errorf("check symbol: %w", existing.err)
+	}
+	if len(existing.data) == 0 {
+		return nil
+	}
+	err := &SymbolExistsError{Symbol: symbol}
+	for _, token := range existing.data {
+		err.Tokens = append(err.Tokens, token.Address)
+	}
+	return err
+}
+
+// Holder is an address holding a token and its balance
+type Holder struct {
+	Address        string `json:"address"`
+	Balance        string `json:"balance"`        // raw base units
+	BalanceDecimal string `json:"balanceDecimal"` // scaled by the token's decimals
+}
+
+// HolderPage is one page of token holders
+type HolderPage struct {
+	Holders    []Holder `json:"holders"`
+	NextCursor string   `json:"nextCursor"` // empty on the last page
+	Decimals   *uint8   `json:"decimals,omitempty"`
+}
+
+// HasMore reports whether another page can be fetched with NextCursor
+func (p *HolderPage) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// GetTokenHolders lists the holders of a token with their balances. The server orders holders
+// by address and the cursor is the last address of the previous page, so walking all pages with
+// NextCursor visits every holder exactly once. Pass an empty cursor for the first page, and the
+// same WithBlock on every page to list the holders as of one block.
+func (c *Client) GetTokenHolders(tokenAddress string, cursor string, limit int, opts ...CallOption) (r *ResponseHandler[*HolderPage]) {
+	defer withOperation(&r, "GetTokenHolders", tokenAddress)
+	if limit < 0 {
+		return &ResponseHandler[*HolderPage]{err: fmt.Errorf("invalid limit %d", limit)}
+	}
+
+	result := ClientCallRead[*HolderPage](c, tokenAddress, "getTokenHolders", []interface{}{cursor, limit}, opts...)
+	if result.err != nil {
+		return result
+	}
+
+	page := result.data
+	if page == nil {
+		page = &HolderPage{}
+	}
+	if page.Holders == nil {
+		page.Holders = []Holder{}
+	}
+
+	// Older servers don't send decimals with the page
+	if page.Decimals == nil && len(page.Holders) > 0 {
+		metadata := c.GetTokenMetadata(tokenAddress)
+		if metadata.err != nil {
+			return &ResponseHandler[*HolderPage]{err: fmt.Errorf("get decimals: %w", metadata.err)}
+		}
+		if metadata.data == nil {
+			return &ResponseHandler[*HolderPage]{err: fmt.Errorf("get decimals: empty metadata for %s", tokenAddress)}
+		}
+		decimals := metadata.data.Decimals
+		page.Decimals = &decimals
+	}
+
+	for i := range page.Holders {
+		page.Holders[i].Address = checksummed(page.Holders[i].Address)
+		scaled, err := formatUnits(page.Holders[i].Balance, *page.Decimals)
+		if err != nil {
+			return &ResponseHandler[*HolderPage]{err: fmt.Errorf("holder %s: %w", page.Holders[i].Address, err)}
+		}
+		page.Holders[i].BalanceDecimal = scaled
+	}
+
+	return &ResponseHandler[*HolderPage]{data: page, raw: result.raw}
+}
@@ -0,0 +1,249 @@
+package alchemy_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestListTokensEmpty(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("list_tokens", map[string]interface{}{"tokens": nil, "nextCursor": "", "total": 0})
+
+	page, err := client.ListTokens("", 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Tokens == nil || len(page.Tokens) != 0 || page.HasMore() || page.Total != 0 {
+		t.Fatalf("got %+v, want an empty last page", page)
+	}
+	if params := srv.RequestsFor("list_tokens")[0].ParamMap; len(params) != 0 {
+		t.Fatalf("params = %v, want none for the first page with the default size", params)
+	}
+}
+
+func TestListTokensPages(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.QueueResponse("list_tokens",
+		alchemytest.Response{Result: map[string]interface{}{
+			"tokens":     []map[string]interface{}{{"address": strings.ToLower(testToken), "name": "Dollar", "symbol": "USDX", "decimals": 6}},
+			"nextCursor": "c1",
+			"total":      2,
+		}},
+		alchemytest.Response{Result: map[string]interface{}{
+			"tokens":     []map[string]interface{}{{"address": testRecipient, "name": "Euro", "symbol": "EURX", "decimals": 2}},
+			"nextCursor": "",
+			"total":      2,
+		}},
+	)
+
+	var symbols []string
+	cursor := ""
+	for {
+		page, err := client.ListTokensFiltered(alchemy.TokenFilter{Creator: testRecipient}, cursor, 1).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, token := range page.Tokens {
+			symbols = append(symbols, token.Symbol)
+		}
+		if !page.HasMore() {
+			if page.Tokens[0].Address != testRecipient {
+				t.Fatalf("address %s", page.Tokens[0].Address)
+			}
+			break
+		}
+		if page.Tokens[0].Address != testToken {
+			t.Fatalf("address %s not checksummed", page.Tokens[0].Address)
+		}
+		cursor = page.NextCursor
+	}
+	if fmt.Sprint(symbols) != "[USDX EURX]" {
+		t.Fatalf("symbols = %v", symbols)
+	}
+
+	reqs := srv.RequestsFor("list_tokens")
+	if _, ok := reqs[0].ParamMap["cursor"]; ok {
+		t.Fatalf("first page sent a cursor: %v", reqs[0].ParamMap)
+	}
+	second := reqs[1].ParamMap
+	if second["cursor"] != "c1" || fmt.Sprint(second["limit"]) != "1" || second["creator"] != testRecipient {
+		t.Fatalf("second page params = %v", second)
+	}
+}
+
+func TestListTokensInvalidCursor(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("list_tokens", alchemytest.CodeInvalidParams, "invalid cursor")
+
+	err := client.ListTokens("garbage", 10).Err()
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != alchemytest.CodeInvalidParams {
+		t.Fatalf("err = %v, want the server's invalid params error", err)
+	}
+
+	if err := client.ListTokens("", -1).Err(); err == nil {
+		t.Fatal("negative limit accepted")
+	}
+	if n := len(srv.RequestsFor("list_tokens")); n != 1 {
+		t.Fatalf("%d requests sent, want 1", n)
+	}
+}
+
+func TestGetTokenHoldersWalk(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	holders := []string{
+		"0x1000000000000000000000000000000000000001",
+		"0x2000000000000000000000000000000000000002",
+		"0x3000000000000000000000000000000000000003",
+		"0x4000000000000000000000000000000000000004",
+		"0x5000000000000000000000000000000000000005",
+	}
+	page := func(addrs []string, next string) alchemytest.Response {
+		list := make([]map[string]string, len(addrs))
+		for i, addr := range addrs {
+			list[i] = map[string]string{"address": addr, "balance": "1500000"}
+		}
+		return alchemytest.Response{Result: map[string]interface{}{"holders": list, "nextCursor": next, "decimals": 6}}
+	}
+	srv.QueueResponse("getTokenHolders",
+		page(holders[0:2], holders[1]),
+		page(holders[2:4], holders[3]),
+		page(holders[4:], ""),
+	)
+
+	seen := map[string]bool{}
+	var cursors []string
+	cursor := ""
+	for {
+		cursors = append(cursors, cursor)
+		result, err := client.GetTokenHolders(testToken, cursor, 2).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, holder := range result.Holders {
+			if seen[holder.Address] {
+				t.Fatalf("holder %s listed twice", holder.Address)
+			}
+			seen[holder.Address] = true
+			if holder.Balance != "1500000" || holder.BalanceDecimal != "1.5" {
+				t.Fatalf("holder %+v", holder)
+			}
+		}
+		if !result.HasMore() {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	if len(seen) != len(holders) {
+		t.Fatalf("saw %d holders, want %d", len(seen), len(holders))
+	}
+
+	reqs := srv.RequestsFor("getTokenHolders")
+	for i, req := range reqs {
+		if got, want := fmt.Sprint(req.ParamMap["methodArgs"]), fmt.Sprintf("[%s 2]", cursors[i]); got != want {
+			t.Fatalf("page %d methodArgs = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestGetTokenHoldersDecimalsFromMetadata(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getTokenHolders", map[string]interface{}{
+		"holders": []map[string]string{{"address": strings.ToLower(testRecipient), "balance": "250"}},
+	})
+	srv.SetResult("getTokenMetadata", map[string]interface{}{"name": "Euro", "symbol": "EURX", "decimals": 2, "supply": "250"})
+
+	result, err := client.GetTokenHolders(testToken, "", 0).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holder := result.Holders[0]; holder.Address != testRecipient || holder.BalanceDecimal != "2.5" {
+		t.Fatalf("holder %+v", holder)
+	}
+}
+
+// thirdToken is a token address besides testToken and testRecipient
+const thirdToken = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+
+func TestFindTokensBySymbol(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	// A server ignoring the filter: every token comes back, over two pages
+	pages := []alchemytest.Response{
+		{Result: map[string]interface{}{
+			"tokens":     []map[string]interface{}{{"address": testToken, "symbol": "USDX"}, {"address": testRecipient, "symbol": "EURX"}},
+			"nextCursor": "c1",
+		}},
+		{Result: map[string]interface{}{
+			"tokens": []map[string]interface{}{{"address": thirdToken, "symbol": "usdx"}},
+		}},
+	}
+	srv.QueueResponse("list_tokens", pages...)
+
+	tokens, err := client.FindTokensBySymbol("USDX").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 || tokens[0].Address != testToken || tokens[1].Address != thirdToken {
+		t.Fatalf("tokens %+v, want both spellings of USDX", tokens)
+	}
+	reqs := srv.RequestsFor("list_tokens")
+	if len(reqs) != 2 || reqs[0].ParamMap["symbol"] != "USDX" || reqs[1].ParamMap["cursor"] != "c1" {
+		t.Fatalf("requests %+v", reqs)
+	}
+
+	srv, client, _ = newTestServer(t, alchemy.WithCaseSensitiveSymbols())
+	srv.QueueResponse("list_tokens", pages...)
+	tokens, err = client.FindTokensBySymbol("USDX").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].Address != testToken {
+		t.Fatalf("case-sensitive tokens %+v", tokens)
+	}
+
+	if err := client.FindTokensBySymbol("").Err(); err == nil {
+		t.Fatal("empty symbol accepted")
+	}
+}
+
+func TestCreateTokenUniqueSymbolCheck(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("list_tokens", map[string]interface{}{
+		"tokens": []map[string]interface{}{{"address": strings.ToLower(testToken), "symbol": "usdx"}, {"address": thirdToken, "symbol": "USDX"}},
+	})
+
+	// Off by default
+	if err := client.CreateToken("Dollar", "USDX", 6, testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("list_tokens")); n != 0 {
+		t.Fatalf("%d listings without WithUniqueSymbolCheck", n)
+	}
+
+	// Conflict
+	err := client.CreateToken("Dollar", "USDX", 6, testRecipient, alchemy.WithUniqueSymbolCheck()).Err()
+	var existsErr *alchemy.SymbolExistsError
+	if !errors.Is(err, alchemy.ErrSymbolExists) || !errors.As(err, &existsErr) {
+		t.Fatalf("err = %v, want a *SymbolExistsError", err)
+	}
+	if fmt.Sprint(existsErr.Tokens) != fmt.Sprint([]string{testToken, thirdToken}) || !strings.Contains(err.Error(), thirdToken) {
+		t.Fatalf("conflicting tokens %v in %q", existsErr.Tokens, err)
+	}
+	if n := len(srv.RequestsFor("create_token")); n != 1 {
+		t.Fatalf("%d creations, want only the unchecked one", n)
+	}
+
+	// No conflict
+	srv.SetResult("list_tokens", map[string]interface{}{"tokens": []map[string]interface{}{{"address": testToken, "symbol": "USDXX"}}})
+	if err := client.CreateToken("Dollar", "USDX", 6, testRecipient, alchemy.WithUniqueSymbolCheck()).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.RequestsFor("create_token")); n != 2 {
+		t.Fatalf("%d creations, want 2", n)
+	}
+}
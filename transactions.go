@@ -0,0 +1,282 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrTransactionNotFound is returned when the node doesn't know the transaction hash.
+// It matches ErrNotFound.
+var ErrTransactionNotFound = fmt.Errorf("transaction %w", ErrNotFound)
+
+// Transaction is a transaction as reported by the node, with hex quantities decoded.
+// Pending transactions have Pending set and no block fields.
+type Transaction struct {
+	Hash             string
+	From             string
+	To               string // empty for contract creation
+	Nonce            uint64
+	Value            *big.Int
+	Gas              uint64
+	GasPrice         *big.Int
+	Input            []byte
+	BlockNumber      int64
+	BlockHash        string
+	TransactionIndex uint64
+	Pending          bool
+}
+
+// GetTransactionByHash gets a transaction by hash, e.g. from TransactionResult.Hash
+func (c *Client) GetTransactionByHash(hash string) (r *ResponseHandler[*Transaction]) {
+	defer withOperation(&r, "GetTransactionByHash", "")
+	tx, err := c.getTransaction(hash)
+	if err != nil {
+		return &ResponseHandler[*Transaction]{err: err}
+	}
+	return &ResponseHandler[*Transaction]{data: tx}
+}
+
+// Internal method: eth_getTransactionByHash, ErrTransactionNotFound on a null result
+func (c *Client) getTransaction(hash string) (*Transaction, error) {
+	if err := c.checkChain(); err != nil {
+		return nil, err
+	}
+
+	result, err := c.nodeCall("eth_getTransactionByHash", []interface{}{hash})
+	if err != nil {
+		return nil, err
+	}
+	if isNullResult(result) {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, hash)
+	}
+
+	var raw rpcTransaction
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("decode transaction: %w", err)
+	}
+	return raw.toTransaction()
+}
+
+// rpcTransaction is the hex-encoded transaction as returned by the node
+type rpcTransaction struct {
+	Hash             string  `json:"hash"`
+	From             string  `json:"from"`
+	To               *string `json:"to"`
+	Nonce            string  `json:"nonce"`
+	Value            string  `json:"value"`
+	Gas              string  `json:"gas"`
+	GasPrice         string  `json:"gasPrice"`
+	Input            string  `json:"input"`
+	BlockNumber      *string `json:"blockNumber"`
+	BlockHash        *string `json:"blockHash"`
+	TransactionIndex *string `json:"transactionIndex"`
+}
+
+func (t rpcTransaction) toTransaction() (*Transaction, error) {
+	tx := &Transaction{Hash: t.Hash, From: t.From}
+	if t.To != nil {
+		tx.To = *t.To
+	}
+
+	var err error
+	if tx.Nonce, err = parseHexQuantity(t.Nonce); err != nil {
+		return nil, fmt.Errorf("transaction nonce: %w", err)
+	}
+	if tx.Gas, err = parseHexQuantity(t.Gas); err != nil {
+		return nil, fmt.Errorf("transaction gas: %w", err)
+	}
+	if tx.Value, err = parseHexBig(t.Value); err != nil {
+		return nil, fmt.Errorf("transaction value: %w", err)
+	}
+	if t.GasPrice != "" {
+		if tx.GasPrice, err = parseHexBig(t.GasPrice); err != nil {
+			return nil, fmt.Errorf("transaction gasPrice: %w", err)
+		}
+	}
+	if tx.Input, err = hex.DecodeString(strings.TrimPrefix(t.Input, "0x")); err != nil {
+		return nil, fmt.Errorf("transaction input: %w", err)
+	}
+
+	if t.BlockNumber == nil {
+		tx.Pending = true
+	} else {
+		number, err := parseHexQuantity(*t.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("transaction blockNumber: %w", err)
+		}
+		tx.BlockNumber = int64(number)
+	}
+	if t.BlockHash != nil {
+		tx.BlockHash = *t.BlockHash
+	}
+	if t.TransactionIndex != nil {
+		if tx.TransactionIndex, err = parseHexQuantity(*t.TransactionIndex); err != nil {
+			return nil, fmt.Errorf("transaction index: %w", err)
+		}
+	}
+
+	return tx, nil
+}
+
+// parseHexBig parses a 0x-prefixed hex quantity of arbitrary size
+func parseHexBig(s string) (*big.Int, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, fmt.Errorf("missing 0x prefix in %q", s)
+	}
+	value, ok := new(big.Int).SetString(s[2:], 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex quantity %q", s)
+	}
+	return value, nil
+}
+
+// Receipt is a transaction receipt with hex quantities decoded
+type Receipt struct {
+	TransactionHash string
+	BlockNumber     int64
+	BlockHash       string
+	Status          uint64 // 1 success, 0 reverted
+	GasUsed         uint64
+	ContractAddress string
+	RevertReason    string // only when the node exposes it
+}
+
+// Succeeded reports whether the transaction executed successfully
+func (r *Receipt) Succeeded() bool {
+	return r.Status == 1
+}
+
+// GetTransactionReceipt gets the receipt of a mined transaction. Fails with
+// ErrTransactionNotFound while the transaction is pending or unknown.
+func (c *Client) GetTransactionReceipt(hash string) (r *ResponseHandler[*Receipt]) {
+	defer withOperation(&r, "GetTransactionReceipt", "")
+	receipt, err := c.getReceipt(hash)
+	if err != nil {
+		return &ResponseHandler[*Receipt]{err: err}
+	}
+	if receipt == nil {
+		return &ResponseHandler[*Receipt]{err: fmt.Errorf("%w: no receipt for %s", ErrTransactionNotFound, hash)}
+	}
+	return &ResponseHandler[*Receipt]{data: receipt}
+}
+
+// Internal method: eth_getTransactionReceipt, nil receipt when there is none yet
+func (c *Client) getReceipt(hash string) (*Receipt, error) {
+	return c.getReceiptContext(context.Background(), hash)
+}
+
+// Internal method: getReceipt as part of the operation ctx belongs to
+func (c *Client) getReceiptContext(ctx context.Context, hash string) (*Receipt, error) {
+	if err := c.checkChainContext(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := c.nodeCallContext(ctx, "eth_getTransactionReceipt", []interface{}{hash})
+	if err != nil {
+		return nil, err
+	}
+	if isNullResult(result) {
+		return nil, nil
+	}
+
+	var raw struct {
+		TransactionHash string  `json:"transactionHash"`
+		BlockNumber     string  `json:"blockNumber"`
+		BlockHash       string  `json:"blockHash"`
+		Status          string  `json:"status"`
+		GasUsed         string  `json:"gasUsed"`
+		ContractAddress *string `json:"contractAddress"`
+		RevertReason    string  `json:"revertReason"`
+	}
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("decode receipt: %w", err)
+	}
+
+	receipt := &Receipt{
+		TransactionHash: raw.TransactionHash,
+		BlockHash:       raw.BlockHash,
+		RevertReason:    raw.RevertReason,
+	}
+	if raw.ContractAddress != nil {
+		receipt.ContractAddress = *raw.ContractAddress
+	}
+	number, err := parseHexQuantity(raw.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("receipt blockNumber: %w", err)
+	}
+	receipt.BlockNumber = int64(number)
+	if receipt.Status, err = parseHexQuantity(raw.Status); err != nil {
+		return nil, fmt.Errorf("receipt status: %w", err)
+	}
+	if raw.GasUsed != "" {
+		if receipt.GasUsed, err = parseHexQuantity(raw.GasUsed); err != nil {
+			return nil, fmt.Errorf("receipt gasUsed: %w", err)
+		}
+	}
+
+	return receipt, nil
+}
+
+// TxState is the coarse state of a transaction
+type TxState string
+
+const (
+	TxPending   TxState = "pending"
+	TxConfirmed TxState = "confirmed"
+	TxFailed    TxState = "failed"
+	TxNotFound  TxState = "not_found"
+)
+
+// TxStatus is the status of a transaction as shown on a dashboard
+type TxStatus struct {
+	State         TxState
+	BlockNumber   int64  // confirmed or failed only
+	Confirmations int64  // confirmed or failed only, 1 when in the latest block
+	RevertReason  string // failed only, when the node exposes it
+}
+
+// GetTransactionStatus gets the status of a transaction. A hash the node has never seen
+// yields TxNotFound rather than an error. Uses two node calls: the receipt plus either the
+// block number (mined) or the transaction itself (not mined).
+func (c *Client) GetTransactionStatus(hash string) (r *ResponseHandler[TxStatus]) {
+	defer withOperation(&r, "GetTransactionStatus", "")
+	receipt, err := c.getReceipt(hash)
+	if err != nil {
+		return &ResponseHandler[TxStatus]{err: err}
+	}
+
+	if receipt == nil {
+		_, err := c.getTransaction(hash)
+		if errors.Is(err, ErrTransactionNotFound) {
+			return &ResponseHandler[TxStatus]{data: TxStatus{State: TxNotFound}}
+		}
+		if err != nil {
+			return &ResponseHandler[TxStatus]{err: err}
+		}
+		return &ResponseHandler[TxStatus]{data: TxStatus{State: TxPending}}
+	}
+
+	latest, err := c.getBlockNumber()
+	if err != nil {
+		return &ResponseHandler[TxStatus]{err: err}
+	}
+
+	status := TxStatus{
+		State:       TxConfirmed,
+		BlockNumber: receipt.BlockNumber,
+	}
+	if latest >= receipt.BlockNumber {
+		status.Confirmations = latest - receipt.BlockNumber + 1
+	}
+	if !receipt.Succeeded() {
+		status.State = TxFailed
+		status.RevertReason = receipt.RevertReason
+	}
+
+	return &ResponseHandler[TxStatus]{data: status}
+}
@@ -0,0 +1,261 @@
+package alchemy_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func nodeTransaction(blockNumber interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"hash":             "0xabc",
+		"from":             testRecipient,
+		"to":               testToken,
+		"nonce":            "0x7",
+		"value":            "0xde0b6b3a7640000",
+		"gas":              "0x5208",
+		"gasPrice":         "0x3b9aca00",
+		"input":            "0xa9059cbb",
+		"blockNumber":      blockNumber,
+		"blockHash":        nil,
+		"transactionIndex": nil,
+	}
+}
+
+func TestGetTransactionByHashMined(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	mined := nodeTransaction("0x10")
+	mined["blockHash"] = "0xbeef"
+	mined["transactionIndex"] = "0x2"
+	srv.SetResult("eth_getTransactionByHash", mined)
+
+	tx, err := client.GetTransactionByHash("0xabc").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Pending || tx.BlockNumber != 16 || tx.BlockHash != "0xbeef" || tx.TransactionIndex != 2 {
+		t.Fatalf("block fields %+v", tx)
+	}
+	if tx.From != testRecipient || tx.To != testToken || tx.Nonce != 7 || tx.Gas != 21000 {
+		t.Fatalf("got %+v", tx)
+	}
+	if tx.Value.String() != "1000000000000000000" || tx.GasPrice.String() != "1000000000" || len(tx.Input) != 4 {
+		t.Fatalf("value %s, gas price %s, input %x", tx.Value, tx.GasPrice, tx.Input)
+	}
+}
+
+func TestGetTransactionByHashPending(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_getTransactionByHash", nodeTransaction(nil))
+
+	tx, err := client.GetTransactionByHash("0xabc").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tx.Pending || tx.BlockNumber != 0 || tx.BlockHash != "" {
+		t.Fatalf("got %+v, want pending", tx)
+	}
+}
+
+func TestGetTransactionByHashNotFound(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("eth_getTransactionByHash", nil)
+
+	err := client.GetTransactionByHash("0xabc").Err()
+	if !errors.Is(err, alchemy.ErrTransactionNotFound) || !errors.Is(err, alchemy.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrTransactionNotFound", err)
+	}
+}
+
+func TestTransactionResultShapes(t *testing.T) {
+	const hash = "0x8a9f0c1e2d3b4a5968778695a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5"
+	tests := []struct {
+		name   string
+		result interface{}
+		want   alchemy.TransactionResult
+	}{
+		{"bare hash", hash, alchemy.TransactionResult{Hash: hash, Nonce: 5}},
+		{"hash only", map[string]interface{}{"hash": hash}, alchemy.TransactionResult{Hash: hash, Nonce: 5}},
+		{"full object",
+			map[string]interface{}{"hash": hash, "blockNumber": 1234, "status": "success", "timestamp": 1700000000},
+			alchemy.TransactionResult{Hash: hash, Nonce: 5, BlockNumber: 1234, Status: "success", Timestamp: 1700000000}},
+		{"unknown fields", map[string]interface{}{"hash": hash, "gasUsed": "0x5208"}, alchemy.TransactionResult{Hash: hash, Nonce: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetResult("mint", tt.result)
+
+			tx, err := client.Mint(testToken, testRecipient, "1", 5).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *tx != tt.want {
+				t.Fatalf("result %+v, want %+v", *tx, tt.want)
+			}
+		})
+	}
+}
+
+// TestWriteResultServerFlavors covers both server flavors: one returns a bare hash string
+// for writes, the other a {"hash": ...} object
+func TestWriteResultServerFlavors(t *testing.T) {
+	const hash = "0x8a9f0c1e2d3b4a5968778695a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5"
+	flavors := []struct {
+		name        string
+		createToken interface{}
+		mint        interface{}
+		wantToken   string
+	}{
+		{"bare string", hash, hash, ""},
+		{"object", map[string]interface{}{"hash": hash, "token": strings.ToLower(testToken)},
+			map[string]interface{}{"hash": hash}, testToken},
+	}
+	for _, flavor := range flavors {
+		for _, strict := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s strict=%v", flavor.name, strict), func(t *testing.T) {
+				var opts []alchemy.Option
+				if strict {
+					opts = append(opts, alchemy.WithStrictDecoding())
+				}
+				srv, client, _ := newTestServer(t, opts...)
+				srv.SetResult("create_token", flavor.createToken)
+				srv.SetResult("mint", flavor.mint)
+
+				issued, err := client.CreateToken("My Token", "MTK", 18, testRecipient).Result()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if issued.Hash != hash || issued.Token != flavor.wantToken {
+					t.Fatalf("create_token result %+v", *issued)
+				}
+				tx, err := client.Mint(testToken, testRecipient, "1", 5).Result()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tx.Hash != hash || tx.Nonce != 5 {
+					t.Fatalf("mint result %+v", *tx)
+				}
+			})
+		}
+	}
+
+	var issued alchemy.TokenIssueResult
+	if err := json.Unmarshal([]byte(`42`), &issued); err == nil {
+		t.Fatal("expected an error for a number result")
+	}
+}
+
+func TestTransactionResultTime(t *testing.T) {
+	var tx alchemy.TransactionResult
+	if !tx.Time().IsZero() {
+		t.Fatal("Time of a result without timestamp should be zero")
+	}
+	if err := json.Unmarshal([]byte(`{"hash":"0x1","timestamp":1700000000}`), &tx); err != nil {
+		t.Fatal(err)
+	}
+	if got := tx.Time(); !got.Equal(time.Unix(1700000000, 0)) || got.Location() != time.UTC {
+		t.Fatalf("Time() = %v", got)
+	}
+	if err := json.Unmarshal([]byte(`42`), &tx); err == nil {
+		t.Fatal("number decoded as a transaction result")
+	}
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	created := nodeReceipt("0x1f", "0x1")
+	created["contractAddress"] = testToken
+	created["gasUsed"] = "0x2dc6c0"
+	srv.SetResult("eth_getTransactionReceipt", created)
+
+	receipt, err := client.GetTransactionReceipt(confirmHash).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := alchemy.Receipt{TransactionHash: confirmHash, BlockNumber: 31, BlockHash: "0xbeef", Status: 1,
+		GasUsed: 3000000, ContractAddress: testToken}
+	if *receipt != want || !receipt.Succeeded() {
+		t.Fatalf("got %+v, want %+v", *receipt, want)
+	}
+	if got := srv.RequestsFor("eth_getTransactionReceipt")[0].Params; string(got) != `["`+confirmHash+`"]` {
+		t.Fatalf("params %s", got)
+	}
+
+	reverted := nodeReceipt("0x1f", "0x0")
+	reverted["revertReason"] = "Pausable: paused"
+	srv.SetResult("eth_getTransactionReceipt", reverted)
+	receipt, err = client.GetTransactionReceipt(confirmHash).Result()
+	if err != nil || receipt.Succeeded() || receipt.RevertReason != "Pausable: paused" {
+		t.Fatalf("got %+v, %v", receipt, err)
+	}
+
+	// Pending and unknown transactions have no receipt
+	srv.SetResult("eth_getTransactionReceipt", nil)
+	err = client.GetTransactionReceipt(confirmHash).Err()
+	if !errors.Is(err, alchemy.ErrTransactionNotFound) || !errors.Is(err, alchemy.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrTransactionNotFound", err)
+	}
+
+	srv.SetResult("eth_getTransactionReceipt", map[string]interface{}{"blockNumber": "10", "status": "0x1"})
+	if err := client.GetTransactionReceipt(confirmHash).Err(); err == nil || !strings.Contains(err.Error(), "blockNumber") {
+		t.Fatalf("err = %v, want a blockNumber decode error", err)
+	}
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	failed := nodeReceipt("0xa", "0x0")
+	failed["revertReason"] = "insufficient balance"
+	tests := []struct {
+		name        string
+		receipt     interface{}
+		transaction interface{}
+		want        alchemy.TxStatus
+	}{
+		{"pending", nil, nodeTransaction(nil), alchemy.TxStatus{State: alchemy.TxPending}},
+		{"confirmed", nodeReceipt("0xa", "0x1"), nil,
+			alchemy.TxStatus{State: alchemy.TxConfirmed, BlockNumber: 10, Confirmations: 3}},
+		{"failed", failed, nil,
+			alchemy.TxStatus{State: alchemy.TxFailed, BlockNumber: 10, Confirmations: 3, RevertReason: "insufficient balance"}},
+		{"never broadcast", nil, nil, alchemy.TxStatus{State: alchemy.TxNotFound}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+			srv.SetBlockNumber(12)
+			srv.SetResult("eth_getTransactionReceipt", tt.receipt)
+			srv.SetResult("eth_getTransactionByHash", tt.transaction)
+
+			status, err := client.GetTransactionStatus(confirmHash).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tt.want {
+				t.Fatalf("got %+v, want %+v", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTransactionStatusErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("eth_getTransactionReceipt", -32000, "header not found")
+	if err := client.GetTransactionStatus(confirmHash).Err(); err == nil {
+		t.Fatal("receipt error swallowed")
+	}
+
+	srv.SetResult("eth_getTransactionReceipt", nil)
+	srv.SetError("eth_getTransactionByHash", -32603, "internal error")
+	err := client.GetTransactionStatus(confirmHash).Err()
+	if err == nil || errors.Is(err, alchemy.ErrNotFound) {
+		t.Fatalf("err = %v, want the node error", err)
+	}
+	if op := operationError(t, err); op.Op != "GetTransactionStatus" {
+		t.Fatalf("op %q", op.Op)
+	}
+}
@@ -0,0 +1,76 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// IsTransient reports whether err is a failure that may go away on its own: timeouts,
+// refused or reset connections, temporary DNS failures, rate limiting and gateway
+// responses (HTTP 502, 503, 504). Wrapped errors are unwrapped. JSON-RPC errors and other
+// answers from the application are never transient.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+
+	var unexpected *UnexpectedResponseError
+	if errors.As(err, &unexpected) {
+		switch unexpected.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// Internal method: whether a failed write is safe to send again, i.e. it failed before the
+// request was sent (connection refused, DNS failure) or was rejected unprocessed (rate limited)
+func isRetryableWriteError(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// writeRequestKey marks the context of a state-changing request
+type writeRequestKey struct{}
+
+// Internal method: ctx marked as carrying a write
+func withWriteRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, writeRequestKey{}, true)
+}
+
+// Internal method: whether ctx carries a write
+func isWriteRequest(ctx context.Context) bool {
+	write, _ := ctx.Value(writeRequestKey{}).(bool)
+	return write
+}
@@ -0,0 +1,134 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+// timeoutError is a net.Error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var (
+	refused = &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	reset   = &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+)
+
+// inURLError wraps err the way net/http reports transport failures
+func inURLError(err error) error {
+	return &url.Error{Op: "Post", URL: "https://node.example.com", Err: err}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain", errors.New("boom"), false},
+		{"timeout", timeoutError{}, true},
+		{"wrapped timeout", fmt.Errorf("get balance: %w", inURLError(timeoutError{})), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"canceled", context.Canceled, false},
+		{"connection refused", inURLError(refused), true},
+		{"connection reset", fmt.Errorf("mint: %w", inURLError(reset)), true},
+		{"bare errno", syscall.ECONNRESET, true},
+		{"joined", errors.Join(errors.New("first"), refused), true},
+		{"dns temporary", inURLError(&net.OpError{Op: "dial", Err: &net.DNSError{Name: "node.example.com", IsTemporary: true}}), true},
+		{"dns timeout", &net.DNSError{IsTimeout: true}, true},
+		{"dns not found", inURLError(&net.OpError{Op: "dial", Err: &net.DNSError{Name: "nope.invalid", IsNotFound: true}}), false},
+		{"eof", io.ErrUnexpectedEOF, false},
+		{"502", fmt.Errorf("mint: %w", &alchemy.UnexpectedResponseError{StatusCode: http.StatusBadGateway}), true},
+		{"503", &alchemy.UnexpectedResponseError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"504", &alchemy.UnexpectedResponseError{StatusCode: http.StatusGatewayTimeout}, true},
+		{"500", &alchemy.UnexpectedResponseError{StatusCode: http.StatusInternalServerError}, false},
+		{"404", &alchemy.UnexpectedResponseError{StatusCode: http.StatusNotFound}, false},
+		{"rate limited", fmt.Errorf("x: %w", &alchemy.RateLimitError{StatusCode: http.StatusTooManyRequests}), true},
+		{"rpc error", fmt.Errorf("mint: %w", &alchemy.RPCError{Code: -32000, Message: "nonce too low"}), false},
+		{"rpc error with timeout", errors.Join(&alchemy.RPCError{Message: "timeout"}, timeoutError{}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alchemy.IsTransient(tt.err); got != tt.want {
+				t.Fatalf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// failingTransport fails requests for method with err and counts them; other requests go
+// through
+func failingTransport(method string, err error, attempts *atomic.Int32) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, readErr := io.ReadAll(r.Body)
+		if readErr != nil {
+			return nil, readErr
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"method":"`+method+`"`)) {
+			attempts.Add(1)
+			return nil, err
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+}
+
+func TestRetryClassification(t *testing.T) {
+	tests := []struct {
+		name     string
+		write    bool
+		err      error
+		attempts int32
+	}{
+		{"read timeout", false, timeoutError{}, 3},
+		{"read reset", false, reset, 3},
+		{"read refused", false, refused, 3},
+		{"read plain error", false, errors.New("boom"), 1},
+		{"write refused", true, refused, 3},
+		{"write dns", true, &net.OpError{Op: "dial", Err: &net.DNSError{IsTemporary: true}}, 3},
+		// The request may have been processed
+		{"write reset", true, reset, 1},
+		{"write timeout", true, timeoutError{}, 1},
+	}
+	policy := alchemy.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := "getSupplyCap"
+			if tt.write {
+				method = "mint"
+			}
+			var attempts atomic.Int32
+			_, client, _ := newTestServer(t, alchemy.WithRetryPolicy(policy), alchemy.WithHTTPClient(failingTransport(method, tt.err, &attempts)))
+
+			var err error
+			if tt.write {
+				err = client.Mint(testToken, testRecipient, "1", 0).Err()
+			} else {
+				err = client.GetSupplyCap(testToken).Err()
+			}
+			if err == nil {
+				t.Fatal("call succeeded")
+			}
+			if n := attempts.Load(); n != tt.attempts {
+				t.Fatalf("%d attempts, want %d", n, tt.attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Transport carries JSON-RPC requests to the node and the token service. The default
+// transport POSTs JSON over HTTP with the client's headers, credentials, TLS, proxy and
+// compression settings. Custom transports (test fakes, Unix sockets, gRPC bridges) must be
+// safe for concurrent use and return JSON-RPC error responses as *RPCError; the client's
+// retry policy and logging wrap every transport.
+type Transport interface {
+	// Call sends method with params to endpoint (the node or token service URL) and
+	// returns the raw JSON-RPC result
+	Call(ctx context.Context, endpoint, method string, params interface{}) (json.RawMessage, error)
+}
+
+// RPCError is a JSON-RPC error response from the node or the token service
+type RPCError struct {
+	Code    int
+	Message string
+	Data    json.RawMessage // the error's "data" member, nil when absent
+}
+
+func (e *RPCError) Error() string {
+	return "RPC error: " + e.Message
+}
+
+// WithTransport sends requests through transport instead of HTTP. The HTTP client
+// settings (WithTimeout, WithTLS, WithProxy) then only apply to WebSocket subscriptions.
+func WithTransport(transport Transport) Option {
+	return func(o *clientOptions) { o.transport = transport }
+}
+
+// ConfigTransport sends requests of the default client through transport; nil restores
+// the HTTP transport
+func ConfigTransport(transport Transport) {
+	if transport == nil {
+		transport = &httpTransport{client: defaultClient}
+	}
+	defaultClient.transport = transport
+}
+
+// httpTransport is the default Transport
+type httpTransport struct {
+	client *Client
+}
+
+// Call implements Transport
+func (t *httpTransport) Call(ctx context.Context, endpoint, method string, params interface{}) (json.RawMessage, error) {
+	c := t.client
+	rpcReq := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  method,
+		"params":  params,
+		"id":      1,
+	}
+
+	reqBody, err := json.Marshal(rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.postJSON(ctx, endpoint, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+
+	// Checked before the body is read: a large 429 page must not turn into ResponseTooLargeError
+	if err := rateLimitError(resp, method, time.Now()); err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(resp.Body, method, responseLimit(ctx, c.maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	return decodeRPCResponse(resp, respBody, method)
+}
+
+// responseLimitKey carries a per-call response size limit to the HTTP transport
+type responseLimitKey struct{}
+
+// Internal method: response size limit from ctx, def when none is set
+func responseLimit(ctx context.Context, def int64) int64 {
+	if limit, ok := ctx.Value(responseLimitKey{}).(int64); ok && limit > 0 {
+		return limit
+	}
+	return def
+}
+
+// Internal method: send a JSON-RPC request through the transport, retrying per the
+// client's policy. limit caps the response size, 0 means the configured maximum.
+func (c *Client) call(ctx context.Context, endpoint, method string, params interface{}, limit int64) (json.RawMessage, error) {
+	ctx, done, err := c.beginRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+	endpoint = c.routeEndpoint(ctx, endpoint, method)
+	if limit > 0 {
+		ctx = context.WithValue(ctx, responseLimitKey{}, limit)
+	}
+	send := func() (json.RawMessage, error) {
+		return c.withRetry(ctx, method, func() (json.RawMessage, error) {
+			return c.callEndpoints(ctx, endpoint, method, params)
+		})
+	}
+	var result json.RawMessage
+	if key, ok := c.coalesceKey(ctx, endpoint, method, params, limit); ok {
+		result, err = c.flights.do(ctx, key, send)
+	} else {
+		result, err = send()
+	}
+	return result, withSigner(ctx, withMethod(method, err))
+}
@@ -0,0 +1,155 @@
+package alchemy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+func TestLargeRateLimitPageIsRateLimitError(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResponse("getSupplyCap", alchemytest.Response{
+		Status: http.StatusTooManyRequests,
+		Body:   strings.Repeat("slow down ", 1000),
+		Header: http.Header{"Retry-After": {"7"}},
+	})
+
+	err := client.GetSupplyCap(testToken, alchemy.WithMaxResponseSize(64)).Err()
+	var rateErr *alchemy.RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("err = %v, want a *RateLimitError", err)
+	}
+	if rateErr.RetryAfter != 7*time.Second {
+		t.Fatalf("RetryAfter = %v, want 7s", rateErr.RetryAfter)
+	}
+	var tooLarge *alchemy.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		t.Fatal("rate limit reported as a too-large response")
+	}
+}
+
+func TestLargeResponseStillCapped(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("getSupplyCap", strings.Repeat("9", 1000))
+
+	var tooLarge *alchemy.ResponseTooLargeError
+	if err := client.GetSupplyCap(testToken, alchemy.WithMaxResponseSize(64)).Err(); !errors.As(err, &tooLarge) {
+		t.Fatalf("err = %v, want a *ResponseTooLargeError", err)
+	}
+}
+
+// transportCall is one call seen by fakeTransport
+type transportCall struct {
+	endpoint, method string
+	params           string // JSON-encoded
+}
+
+// fakeTransport records calls and answers them from results, keyed by method
+type fakeTransport struct {
+	mu      sync.Mutex
+	calls   []transportCall
+	results map[string][]interface{} // json-encodable result or error, consumed in order
+}
+
+func (f *fakeTransport) Call(ctx context.Context, endpoint, method string, params interface{}) (json.RawMessage, error) {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, transportCall{endpoint, method, string(encoded)})
+
+	queue := f.results[method]
+	if len(queue) == 0 {
+		return nil, &alchemy.RPCError{Code: -32601, Message: "unexpected " + method}
+	}
+	next := queue[0]
+	if len(queue) > 1 {
+		f.results[method] = queue[1:]
+	}
+	if err, ok := next.(error); ok {
+		return nil, err
+	}
+	return json.Marshal(next)
+}
+
+func TestMockTransport(t *testing.T) {
+	fake := &fakeTransport{results: map[string][]interface{}{
+		"eth_blockNumber": {"0x2a"},
+		"eth_getBalance":  {"0x10"},
+		"mint":            {map[string]string{"hash": "0xfeed"}},
+	}}
+	client, err := alchemy.NewClient("http://node.invalid", alchemy.WithTransport(fake),
+		alchemy.WithPrivateKey("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	balance, err := client.GetBalance(testRecipient).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := client.Mint(testToken, testRecipient, "1000", 3).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Wei != "16" || tx.Hash != "0xfeed" {
+		t.Fatalf("balance %+v, tx %+v", balance, tx)
+	}
+
+	calls := fake.calls
+	if len(calls) != 3 {
+		t.Fatalf("calls %+v", calls)
+	}
+	if calls[0] != (transportCall{"http://node.invalid", "eth_getBalance", `["` + testRecipient + `","latest"]`}) {
+		t.Fatalf("balance call %+v", calls[0])
+	}
+	if calls[1] != (transportCall{"http://node.invalid", "eth_blockNumber", `[]`}) {
+		t.Fatalf("checkpoint call %+v", calls[1])
+	}
+	mint := calls[2]
+	if mint.endpoint != "http://node.invalid/rpc" || mint.method != "mint" {
+		t.Fatalf("mint call %+v", mint)
+	}
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(mint.params), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params["token"] != testToken || params["nonce"] != 3.0 || params["recentCheckpoint"] != 42.0 || params["signature"] == nil {
+		t.Fatalf("mint params %v", params)
+	}
+}
+
+func TestMockTransportUnderRetryPolicy(t *testing.T) {
+	unavailable := &alchemy.UnexpectedResponseError{Method: "eth_getBalance", StatusCode: http.StatusServiceUnavailable}
+	fake := &fakeTransport{results: map[string][]interface{}{
+		"eth_getBalance": {unavailable, "0x1"},
+	}}
+	client, err := alchemy.NewClient("http://node.invalid", alchemy.WithTransport(fake),
+		alchemy.WithRetryPolicy(alchemy.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.GetBalance(testRecipient).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.calls) != 2 {
+		t.Fatalf("%d calls, want the retry to go through the transport", len(fake.calls))
+	}
+
+	fake.results["eth_getBalance"] = []interface{}{&alchemy.RPCError{Code: -32000, Message: "header not found"}}
+	var rpcErr *alchemy.RPCError
+	if err := client.GetBalance(testRecipient).Err(); !errors.As(err, &rpcErr) || rpcErr.Message != "header not found" {
+		t.Fatalf("err = %v, want the transport's *RPCError", err)
+	}
+}
@@ -0,0 +1,240 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrQueueClosed is returned for operations enqueued after Shutdown or cancelled by it
+var ErrQueueClosed = errors.New("transaction queue closed")
+
+// TxQueue serializes the writes of one client: operations are submitted one at a time in
+// enqueue order, each with the next nonce of its signer (see WithSignerKey) for its token.
+// Nonces are fetched with GetNonce on first use per signer and token and counted locally
+// afterwards; after a failed submission the counter is resynced from the server. Writes
+// made outside the queue with the same key can still collide with it.
+type TxQueue struct {
+	client *Client
+
+	mu       sync.Mutex
+	pending  []*queuedTx
+	inFlight int
+	closed   bool
+	wake     chan struct{}
+	stopped  chan struct{}
+
+	nonces map[string]int64 // signer name and lower-cased token address -> next nonce, worker only
+}
+
+type queuedTx struct {
+	op     Operation
+	opts   []CallOption
+	future *Future[*TransactionResult]
+}
+
+// NewTxQueue starts a queue submitting through c. Call Shutdown to stop it.
+func (c *Client) NewTxQueue() *TxQueue {
+	q := &TxQueue{
+		client:  c,
+		wake:    make(chan struct{}, 1),
+		stopped: make(chan struct{}),
+		nonces:  map[string]int64{},
+	}
+	if !c.registerQueue(q) {
+		q.closed = true
+	}
+	go q.run()
+	return q
+}
+
+// Enqueue adds op to the queue and returns a handle to await its result. Call options
+// (e.g. WithIdempotencyKey) apply to the submission; the nonce is assigned by the queue.
+// Operations of methods with an SDK wrapper (Mint, Seize, ...) are submitted through it and
+// validated like direct calls.
+func (q *TxQueue) Enqueue(op Operation, opts ...CallOption) *Future[*TransactionResult] {
+	item := &queuedTx{op: op, opts: opts, future: newFuture[*TransactionResult]()}
+
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		err := ErrQueueClosed
+		if q.client.isClosed() {
+			err = ErrClientClosed
+		}
+		item.future.complete(&ResponseHandler[*TransactionResult]{err: err})
+		return item.future
+	}
+	q.pending = append(q.pending, item)
+	q.mu.Unlock()
+
+	q.signal()
+	return item.future
+}
+
+// Depth returns the number of operations enqueued but not yet completed, including the
+// one being submitted
+func (q *TxQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending) + q.inFlight
+}
+
+// Shutdown stops accepting operations and waits until the pending ones have been
+// submitted. If ctx ends first, operations not yet submitted fail with ErrQueueClosed and
+// ctx.Err() is returned; a submission already in flight still completes.
+func (q *TxQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.signal()
+
+	select {
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+	}
+
+	q.mu.Lock()
+	cancelled := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+	for _, item := range cancelled {
+		item.future.complete(&ResponseHandler[*TransactionResult]{err: ErrQueueClosed})
+	}
+	return ctx.Err()
+}
+
+// Internal method: wake the worker
+func (q *TxQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Internal method: worker loop, submits one operation at a time
+func (q *TxQueue) run() {
+	defer close(q.stopped)
+	defer q.client.unregisterQueue(q)
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			closed := q.closed
+			q.mu.Unlock()
+			if closed {
+				return
+			}
+			<-q.wake
+			continue
+		}
+		item := q.pending[0]
+		q.pending = q.pending[1:]
+		q.inFlight = 1
+		q.mu.Unlock()
+
+		item.future.complete(q.submit(item))
+
+		q.mu.Lock()
+		q.inFlight = 0
+		q.mu.Unlock()
+	}
+}
+
+// Internal method: submit one operation with the managed nonce
+func (q *TxQueue) submit(item *queuedTx) *ResponseHandler[*TransactionResult] {
+	// Queued writes are still submitted while Close drains the queue
+	drain := context.WithValue(context.Background(), queueDrainKey{}, true)
+	// Each signer (see WithSignerKey) has its own nonce sequence per token
+	signer := q.client.newCallConfig(item.opts).signerName
+	drain = withSignerName(drain, signer)
+	key := signer + "\x00" + strings.ToLower(item.op.Token)
+	nonce, ok := q.nonces[key]
+	if !ok {
+		fetched, err := q.client.getAccountNonce(drain, item.op.Token)
+		if err != nil {
+			return &ResponseHandler[*TransactionResult]{err: err}
+		}
+		nonce = fetched
+	}
+
+	opts := append(item.opts[:len(item.opts):len(item.opts)], func(cfg *callConfig) {
+		if cfg.ctx == nil {
+			cfg.ctx = context.Background()
+		}
+		cfg.ctx = context.WithValue(cfg.ctx, queueDrainKey{}, true)
+	})
+	result := q.client.writeOperation(item.op, nonce, opts...)
+	if result.err != nil {
+		// The nonce may or may not have been consumed; ask the server next time
+		delete(q.nonces, key)
+		return result
+	}
+
+	if result.data != nil {
+		nonce = result.data.Nonce // nonce retries may have moved it
+	}
+	q.nonces[key] = nonce + 1
+	return result
+}
+
+// Internal method: send a write operation through its typed method when there is one, so
+// that addresses and amounts are validated as in direct calls; other methods go through
+// CallWrite
+func (c *Client) writeOperation(op Operation, nonce int64, opts ...CallOption) *ResponseHandler[*TransactionResult] {
+	arity, typed := typedWriteArity[op.Method]
+	if !typed {
+		return c.CallWrite(op.Token, op.Method, op.Args, nonce, opts...)
+	}
+
+	args := make([]string, len(op.Args))
+	for i, arg := range op.Args {
+		text, ok := arg.(string)
+		if !ok {
+			return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("%w: %s args[%d] must be a string", ErrUnsupportedValue, op.Method, i)}
+		}
+		args[i] = text
+	}
+	if len(args) != arity {
+		return &ResponseHandler[*TransactionResult]{err: fmt.Errorf("%s takes %d args, got %d", op.Method, arity, len(args))}
+	}
+
+	switch op.Method {
+	case "mint":
+		return c.Mint(op.Token, args[0], args[1], nonce, opts...)
+	case "burn":
+		return c.Burn(op.Token, args[0], nonce, opts...)
+	case "adminBurn":
+		return c.AdminBurn(op.Token, args[0], args[1], nonce, opts...)
+	case "seize":
+		return c.Seize(op.Token, args[0], args[1], args[2], nonce, opts...)
+	case "grantAuthority":
+		return c.GrantAuthority(op.Token, Role(args[0]), args[1], nonce, opts...)
+	case "revokeAuthority":
+		return c.RevokeAuthority(op.Token, Role(args[0]), args[1], nonce, opts...)
+	case "transferMasterAuthority":
+		return c.TransferMasterAuthorityIrreversibly(op.Token, args[0], nonce, opts...)
+	case "addToBlacklist":
+		return c.AddToBlacklist(op.Token, args[0], nonce, opts...)
+	case "removeFromBlacklist":
+		return c.RemoveFromBlacklist(op.Token, args[0], nonce, opts...)
+	default: // setSupplyCap
+		return c.SetSupplyCap(op.Token, args[0], nonce, opts...)
+	}
+}
+
+// typedWriteArity lists the write methods with argument validation and their arg counts
+var typedWriteArity = map[string]int{
+	"mint":                    2,
+	"burn":                    1,
+	"adminBurn":               2,
+	"seize":                   3,
+	"grantAuthority":          2,
+	"revokeAuthority":         2,
+	"transferMasterAuthority": 1,
+	"addToBlacklist":          1,
+	"removeFromBlacklist":     1,
+	"setSupplyCap":            1,
+}
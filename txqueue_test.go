@@ -0,0 +1,180 @@
+package alchemy_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestTxQueueValidatesOperations(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	queue := client.NewTxQueue()
+	defer queue.Shutdown(context.Background())
+
+	tests := []struct {
+		name string
+		op   alchemy.Operation
+		want error
+	}{
+		{"bad recipient", alchemy.MintOperation(testToken, "0x1234", "100"), alchemy.ErrInvalidAddress},
+		{"negative amount", alchemy.MintOperation(testToken, testRecipient, "-5"), alchemy.ErrInvalidAmount},
+		{"decimal amount", alchemy.BurnOperation(testToken, "1.5"), alchemy.ErrInvalidAmount},
+		{"seize to zero", alchemy.SeizeOperation(testToken, testRecipient, "0x0000000000000000000000000000000000000000", "1"), alchemy.ErrInvalidAddress},
+		{"non-string arg", alchemy.Operation{Token: testToken, Method: "mint", Args: []interface{}{testRecipient, 100}}, alchemy.ErrUnsupportedValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := queue.Enqueue(tt.op).Result()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	for _, req := range srv.Requests() {
+		if req.Signature != nil {
+			t.Fatalf("invalid operation sent as %s", req.Method)
+		}
+	}
+
+	if _, err := queue.Enqueue(alchemy.Operation{Token: testToken, Method: "mint", Args: []interface{}{testRecipient}}).Result(); err == nil {
+		t.Fatal("mint with one arg accepted")
+	}
+}
+
+func TestTxQueueConcurrentEnqueueAssignsSequentialNonces(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	queue := client.NewTxQueue()
+
+	const n = 100
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			amount := fmt.Sprint(i + 1)
+			if _, err := queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, amount)).Result(); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err := queue.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mints := srv.RequestsFor("mint")
+	if len(mints) != n {
+		t.Fatalf("got %d mints, want %d", len(mints), n)
+	}
+	// Strictly increasing in arrival order, with no gaps or repeats
+	for i, req := range mints {
+		if got := fmt.Sprint(req.ParamMap["nonce"]); got != fmt.Sprint(i) {
+			t.Fatalf("mint %d sent with nonce %s", i, got)
+		}
+	}
+	if queue.Depth() != 0 {
+		t.Fatalf("Depth = %d after Shutdown", queue.Depth())
+	}
+	if _, err := queue.Enqueue(alchemy.PauseOperation(testToken)).Result(); !errors.Is(err, alchemy.ErrQueueClosed) {
+		t.Fatalf("err = %v, want ErrQueueClosed", err)
+	}
+}
+
+// gatedMethod returns an HTTP client whose requests for method block until release is
+// closed, and a channel receiving a value as each of them arrives
+func gatedMethod(method string, release <-chan struct{}) (*http.Client, <-chan struct{}) {
+	arrived := make(chan struct{}, 100)
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if bytes.Contains(body, []byte(`"method":"`+method+`"`)) {
+			select {
+			case arrived <- struct{}{}:
+			default:
+			}
+			<-release
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	return client, arrived
+}
+
+func TestTxQueueShutdownDrains(t *testing.T) {
+	release := make(chan struct{})
+	gated, _ := gatedMethod("mint", release)
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(gated))
+	queue := client.NewTxQueue()
+
+	futures := make([]*alchemy.Future[*alchemy.TransactionResult], 5)
+	for i := range futures {
+		futures[i] = queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "1"))
+	}
+	if depth := queue.Depth(); depth != 5 {
+		t.Fatalf("Depth = %d, want 5", depth)
+	}
+
+	done := make(chan error)
+	go func() { done <- queue.Shutdown(context.Background()) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Shutdown returned %v with mints pending", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	for i, future := range futures {
+		if _, err := future.Result(); err != nil {
+			t.Fatalf("mint %d: %v", i, err)
+		}
+	}
+	if n := len(srv.RequestsFor("mint")); n != 5 {
+		t.Fatalf("%d mints sent, want 5", n)
+	}
+}
+
+func TestTxQueueShutdownCancelsPending(t *testing.T) {
+	release := make(chan struct{})
+	gated, arrived := gatedMethod("mint", release)
+	srv, client, _ := newTestServer(t, alchemy.WithHTTPClient(gated))
+	queue := client.NewTxQueue()
+
+	futures := make([]*alchemy.Future[*alchemy.TransactionResult], 4)
+	for i := range futures {
+		futures[i] = queue.Enqueue(alchemy.MintOperation(testToken, testRecipient, "1"))
+	}
+	<-arrived // the first mint is in flight
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := queue.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Shutdown = %v, want DeadlineExceeded", err)
+	}
+	for i, future := range futures[1:] {
+		if _, err := future.Result(); !errors.Is(err, alchemy.ErrQueueClosed) {
+			t.Fatalf("pending mint %d err = %v, want ErrQueueClosed", i+1, err)
+		}
+	}
+
+	// The submission in flight still completes
+	close(release)
+	if _, err := futures[0].Result(); err != nil {
+		t.Fatalf("in-flight mint: %v", err)
+	}
+	if n := len(srv.RequestsFor("mint")); n != 1 {
+		t.Fatalf("%d mints sent, want 1", n)
+	}
+}
@@ -0,0 +1,256 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrSignerMismatch is reported by Verify when the signing key doesn't derive to the
+// expected signer address, or a Signer's signatures don't recover to its Address
+var ErrSignerMismatch = errors.New("signing key doesn't match the expected signer")
+
+// Verify check names, in report order
+const (
+	CheckNode    = "node"    // the node answers eth_blockNumber
+	CheckService = "service" // the /rpc service answers get_server_info
+	CheckKey     = "key"     // the signing key loads, signs and derives to the expected signer
+	CheckChain   = "chain"   // the node (and the service, if it says) is on the expected chain
+)
+
+// CheckStatus is the outcome of one Verify check
+type CheckStatus int
+
+const (
+	CheckPassed CheckStatus = iota
+	CheckFailed
+	CheckSkipped // nothing to check, e.g. no key or no expected chain ID configured
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckPassed:
+		return "PASS"
+	case CheckFailed:
+		return "FAIL"
+	default:
+		return "SKIP"
+	}
+}
+
+// VerifyCheck is the outcome of one check
+type VerifyCheck struct {
+	Name     string
+	Status   CheckStatus
+	Detail   string // what was found, e.g. "block 1234" or "signer 0x…"
+	Err      error  // set when Status is CheckFailed
+	Duration time.Duration
+}
+
+// VerifyReport lists the outcome of every Verify check
+type VerifyReport struct {
+	Checks []VerifyCheck
+}
+
+// OK reports whether no check failed
+func (r *VerifyReport) OK() bool {
+	return r.Err() == nil
+}
+
+// Err joins the failed checks as "name: error", nil when none failed
+func (r *VerifyReport) Err() error {
+	var errs []error
+	for _, check := range r.Checks {
+		if check.Status == CheckFailed {
+			errs = append(errs, fmt.Errorf("%s: %w", check.Name, check.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check returns the check named name, nil if the report has none
+func (r *VerifyReport) Check(name string) *VerifyCheck {
+	for i := range r.Checks {
+		if r.Checks[i].Name == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// String renders one line per check, e.g. for CI logs
+func (r *VerifyReport) String() string {
+	var b strings.Builder
+	for _, check := range r.Checks {
+		detail := check.Detail
+		if check.Err != nil {
+			detail = check.Err.Error()
+		}
+		fmt.Fprintf(&b, "%s %-7s %s (%v)\n", check.Status, check.Name, detail, check.Duration.Round(time.Millisecond))
+	}
+	return b.String()
+}
+
+// PreflightError is returned by NewClient when WithPreflight is set and a check failed
+type PreflightError struct {
+	Report *VerifyReport
+}
+
+func (e *PreflightError) Error() string {
+	return "alchemy: preflight failed: " + e.Report.Err().Error()
+}
+
+// Unwrap returns the joined check errors
+func (e *PreflightError) Unwrap() error {
+	return e.Report.Err()
+}
+
+// ConfigExpectedSigner sets the address the default client's key must derive to (see Verify)
+func ConfigExpectedSigner(address string) {
+	defaultClient.expectedSigner = address
+}
+
+// WithExpectedSigner makes Verify check that the signing key derives to address, catching
+// a key from the wrong environment. Signing itself isn't affected.
+func WithExpectedSigner(address string) Option {
+	return func(o *clientOptions) { o.expectedSigner = address }
+}
+
+// WithPreflight makes NewClient run Verify and fail with a *PreflightError when a check
+// fails. Leave it out to construct clients offline.
+func WithPreflight() Option {
+	return func(o *clientOptions) { o.preflight = true }
+}
+
+// Verify checks the configuration before real work starts: the node answers eth_blockNumber,
+// the /rpc service answers get_server_info (older servers rejecting it still count as
+// reachable), the signing key loads, signs and derives to WithExpectedSigner's address, and
+// the chain ID matches WithExpectedChainID or WithChainID. Checks without anything to check
+// are skipped. Each request is bounded by DefaultPingTimeout and not retried; no state is
+// cached.
+func (c *Client) Verify(ctx context.Context) *VerifyReport {
+	report := &VerifyReport{}
+	run := func(name string, check func() (string, CheckStatus, error)) {
+		start := time.Now()
+		detail, status, err := check()
+		if err != nil {
+			status = CheckFailed
+		}
+		report.Checks = append(report.Checks, VerifyCheck{Name: name, Status: status, Detail: detail, Err: err, Duration: time.Since(start)})
+	}
+
+	run(CheckNode, func() (string, CheckStatus, error) {
+		result, err := c.pingEndpoint(ctx, c.nodeEndpoint(), "eth_blockNumber", []interface{}{}, true)
+		if err != nil {
+			return "", CheckFailed, fmt.Errorf("%w: %w", ErrNodeUnavailable, err)
+		}
+		number, err := decodeHexQuantity(result)
+		if err != nil {
+			return "", CheckFailed, fmt.Errorf("%w: eth_blockNumber: %w", ErrNodeUnavailable, err)
+		}
+		return fmt.Sprintf("block %d", number), CheckPassed, nil
+	})
+
+	var serviceChain uint64
+	run(CheckService, func() (string, CheckStatus, error) {
+		result, err := c.pingEndpoint(ctx, c.serviceEndpoint(), "get_server_info", map[string]interface{}{}, false)
+		if err != nil {
+			return "", CheckFailed, fmt.Errorf("%w: %w", ErrServiceUnavailable, err)
+		}
+		if result == nil {
+			return "reachable, get_server_info not supported", CheckPassed, nil
+		}
+		var info ServerInfo
+		if err := json.Unmarshal(result, &info); err != nil {
+			return "", CheckFailed, fmt.Errorf("%w: decode server info: %w", ErrServiceUnavailable, err)
+		}
+		serviceChain = info.ChainID
+		return fmt.Sprintf("version %q, %d methods", info.Version, len(info.Methods)), CheckPassed, nil
+	})
+
+	run(CheckKey, c.verifyKey)
+
+	run(CheckChain, func() (string, CheckStatus, error) {
+		c.chainMu.Lock()
+		expected, configured := c.expectedChain, c.chainID
+		c.chainMu.Unlock()
+		if expected == 0 {
+			expected = configured
+		}
+		if expected == 0 && serviceChain == 0 {
+			return "no expected chain ID configured", CheckSkipped, nil
+		}
+
+		result, err := c.pingEndpoint(ctx, c.nodeEndpoint(), "eth_chainId", []interface{}{}, true)
+		if err != nil {
+			return "", CheckFailed, fmt.Errorf("eth_chainId: %w", err)
+		}
+		id, err := decodeHexQuantity(result)
+		if err != nil {
+			return "", CheckFailed, fmt.Errorf("eth_chainId: %w", err)
+		}
+		if expected != 0 && id != expected {
+			return "", CheckFailed, fmt.Errorf("%w: expected chain id %d, node reports %d", ErrWrongChain, expected, id)
+		}
+		if serviceChain != 0 && serviceChain != id {
+			return "", CheckFailed, fmt.Errorf("%w: node reports chain id %d, service %d", ErrWrongChain, id, serviceChain)
+		}
+		return fmt.Sprintf("chain %d", id), CheckPassed, nil
+	})
+
+	return report
+}
+
+// Internal method: the key check of Verify
+func (c *Client) verifyKey() (string, CheckStatus, error) {
+	if c.signer == nil && c.key == nil && c.privateKey == "" {
+		if c.expectedSigner != "" {
+			return "", CheckFailed, fmt.Errorf("%w: no signing key configured", ErrSignerMismatch)
+		}
+		return "no signing key configured", CheckSkipped, nil
+	}
+	signer, err := c.getSigner(context.Background())
+	if err != nil {
+		return "", CheckFailed, err
+	}
+	address := signer.Address()
+
+	// Sign a probe, which also reaches remote signers, and recover it
+	hash := crypto.Keccak256([]byte("alchemy verify"))
+	signature, err := signer.Sign(hash)
+	if err != nil {
+		return "", CheckFailed, fmt.Errorf("sign probe: %w", err)
+	}
+	if len(signature) != 65 || signature[64] > 1 {
+		return "", CheckFailed, errors.New("sign probe: signer returned a malformed signature")
+	}
+	if signatureScheme(signer) == SignatureSchemePersonal {
+		hash = PersonalDigest(hash)
+	}
+	pub, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return "", CheckFailed, fmt.Errorf("sign probe: %w", err)
+	}
+	if recovered := crypto.PubkeyToAddress(*pub).Hex(); !strings.EqualFold(recovered, address) {
+		return "", CheckFailed, fmt.Errorf("%w: signer reports %s but signs as %s", ErrSignerMismatch, address, recovered)
+	}
+
+	if c.expectedSigner != "" && !strings.EqualFold(c.expectedSigner, address) {
+		return "", CheckFailed, fmt.Errorf("%w: key derives to %s, expected %s", ErrSignerMismatch, address, c.expectedSigner)
+	}
+	return "signer " + address, CheckPassed, nil
+}
+
+// Internal method: decode a JSON hex quantity result
+func decodeHexQuantity(result json.RawMessage) (uint64, error) {
+	var hex string
+	if err := json.Unmarshal(result, &hex); err != nil {
+		return 0, err
+	}
+	return parseHexQuantity(hex)
+}
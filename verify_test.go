@@ -0,0 +1,173 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// checkStatuses returns name=status for every check of report
+func checkStatuses(report *alchemy.VerifyReport) string {
+	var parts []string
+	for _, check := range report.Checks {
+		parts = append(parts, check.Name+"="+check.Status.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestVerifyAllPass(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(alchemy.WithKey(key), alchemy.WithExpectedChainID(5), alchemy.WithExpectedSigner(strings.ToLower(signer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.SetChainID(5)
+	srv.SetBlockNumber(1234)
+	srv.SetResult("get_server_info", map[string]interface{}{"version": "2.1.0", "chainId": 5, "methods": []string{"mint"}})
+
+	report := client.Verify(context.Background())
+	if !report.OK() || report.Err() != nil {
+		t.Fatalf("report:\n%s", report)
+	}
+	if got := checkStatuses(report); got != "node=PASS service=PASS key=PASS chain=PASS" {
+		t.Fatalf("checks %s", got)
+	}
+	for name, want := range map[string]string{
+		alchemy.CheckNode:    "block 1234",
+		alchemy.CheckService: `version "2.1.0", 1 methods`,
+		alchemy.CheckKey:     "signer " + signer,
+		alchemy.CheckChain:   "chain 5",
+	} {
+		if got := report.Check(name).Detail; got != want {
+			t.Fatalf("%s detail %q, want %q", name, got, want)
+		}
+	}
+	if !strings.Contains(report.String(), "PASS key     signer "+signer) {
+		t.Fatalf("report:\n%s", report)
+	}
+}
+
+func TestVerifyReportsEachFailure(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetChainID(1)
+	srv.SetResponse("eth_blockNumber", alchemytest.Response{Error: &alchemytest.RPCError{Code: -32000, Message: "syncing"}})
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithKey(key), alchemy.WithServiceURL(deadURL(t)),
+		alchemy.WithExpectedChainID(5), alchemy.WithExpectedSigner(testRecipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.Verify(context.Background())
+	if report.OK() {
+		t.Fatalf("report OK:\n%s", report)
+	}
+	if got := checkStatuses(report); got != "node=FAIL service=FAIL key=FAIL chain=FAIL" {
+		t.Fatalf("checks %s", got)
+	}
+	err = report.Err()
+	for _, want := range []error{alchemy.ErrNodeUnavailable, alchemy.ErrServiceUnavailable, alchemy.ErrSignerMismatch, alchemy.ErrWrongChain} {
+		if !errors.Is(err, want) {
+			t.Fatalf("err = %v, want %v", err, want)
+		}
+	}
+	for _, line := range []string{"FAIL node", "FAIL service", "FAIL key", "FAIL chain"} {
+		if !strings.Contains(report.String(), line) {
+			t.Fatalf("report has no %q line:\n%s", line, report)
+		}
+	}
+	if !strings.Contains(report.Check(alchemy.CheckKey).Err.Error(), "expected "+testRecipient) {
+		t.Fatalf("key error %v", report.Check(alchemy.CheckKey).Err)
+	}
+}
+
+func TestVerifySkipsWhatIsNotConfigured(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetError("get_server_info", alchemytest.CodeMethodNotFound, "method not found")
+	client, err := srv.NewClient() // no key, no expected chain
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := client.Verify(context.Background())
+	if !report.OK() {
+		t.Fatalf("report:\n%s", report)
+	}
+	if got := checkStatuses(report); got != "node=PASS service=PASS key=SKIP chain=SKIP" {
+		t.Fatalf("checks %s", got)
+	}
+	if len(srv.RequestsFor("eth_chainId")) != 0 {
+		t.Fatal("chain ID fetched without an expectation")
+	}
+
+	// An expected signer without a key fails
+	client, err = srv.NewClient(alchemy.WithExpectedSigner(testRecipient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if check := client.Verify(context.Background()).Check(alchemy.CheckKey); check.Status != alchemy.CheckFailed || !errors.Is(check.Err, alchemy.ErrSignerMismatch) {
+		t.Fatalf("key check %+v", check)
+	}
+}
+
+// lyingSigner reports an address other than the one it signs with
+type lyingSigner struct{ alchemy.Signer }
+
+func (lyingSigner) Address() string { return testRecipient }
+
+func TestVerifyCatchesSignerAddressMismatch(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	client, err := srv.NewClient(alchemy.WithSigner(lyingSigner{alchemy.NewKeySigner(key)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check := client.Verify(context.Background()).Check(alchemy.CheckKey)
+	if check.Status != alchemy.CheckFailed || !errors.Is(check.Err, alchemy.ErrSignerMismatch) {
+		t.Fatalf("key check %+v", check)
+	}
+}
+
+func TestNewClientPreflight(t *testing.T) {
+	srv := alchemytest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.SetChainID(1)
+
+	if _, err := srv.NewClient(alchemy.WithPreflight(), alchemy.WithExpectedChainID(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := srv.NewClient(alchemy.WithPreflight(), alchemy.WithExpectedChainID(5))
+	var preflightErr *alchemy.PreflightError
+	if !errors.As(err, &preflightErr) || !errors.Is(err, alchemy.ErrWrongChain) {
+		t.Fatalf("err = %v, want a *PreflightError for the wrong chain", err)
+	}
+	if got := checkStatuses(preflightErr.Report); got != "node=PASS service=PASS key=SKIP chain=FAIL" {
+		t.Fatalf("checks %s", got)
+	}
+
+	// Without WithPreflight construction never touches the network
+	if _, err := alchemy.NewClient(deadURL(t), alchemy.WithExpectedChainID(5)); err != nil {
+		t.Fatalf("offline construction: %v", err)
+	}
+}
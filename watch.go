@@ -0,0 +1,337 @@
+package alchemy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventCursor is the position of the last processed event: every event at or
+// before (Block, LogIndex) has been handled. LogIndex EndOfBlock means Block
+// was scanned completely.
+type EventCursor struct {
+	Block    int64 `json:"block"`
+	LogIndex int64 `json:"logIndex"`
+}
+
+// EndOfBlock marks a cursor whose block has been fully processed
+const EndOfBlock int64 = math.MaxInt64
+
+// after reports whether the event comes after the cursor position
+func (c EventCursor) after(event TokenEvent) bool {
+	return event.BlockNumber > c.Block || (event.BlockNumber == c.Block && event.LogIndex > c.LogIndex)
+}
+
+// CursorStore persists watcher cursors, keyed per watched token
+type CursorStore interface {
+	// Load returns the saved cursor, ok is false when nothing was saved yet
+	Load(key string) (cursor EventCursor, ok bool, err error)
+	Save(key string, cursor EventCursor) error
+}
+
+// MemoryCursorStore is an in-memory CursorStore, the default for WatchTokenEvents
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]EventCursor
+}
+
+// NewMemoryCursorStore creates an empty in-memory cursor store
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: make(map[string]EventCursor)}
+}
+
+// Load implements CursorStore
+func (s *MemoryCursorStore) Load(key string) (EventCursor, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cursor, ok := s.cursors[key]
+	return cursor, ok, nil
+}
+
+// Save implements CursorStore
+func (s *MemoryCursorStore) Save(key string, cursor EventCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[key] = cursor
+	return nil
+}
+
+// WatchError is returned by WatchTokenEvents when the handler fails; resume from Block
+type WatchError struct {
+	Block    int64
+	LogIndex int64
+	Err      error
+}
+
+func (e *WatchError) Error() string {
+	return fmt.Sprintf("handler failed at block %d (log %d): %v", e.Block, e.LogIndex, e.Err)
+}
+
+func (e *WatchError) Unwrap() error {
+	return e.Err
+}
+
+// ErrCursorTooOld is reported by WatchTokenEvents when the stored cursor points before the
+// oldest block whose events the server still retains: the events in between can no longer be
+// delivered and must be backfilled from another source
+var ErrCursorTooOld = errors.New("event cursor is older than the server retention window")
+
+// CursorTooOldError is returned by WatchTokenEvents for a stored cursor older than the
+// server retention window; it matches ErrCursorTooOld. Events after Cursor and before
+// OldestBlock are missing.
+type CursorTooOldError struct {
+	Cursor      EventCursor
+	OldestBlock int64 // oldest block the server returns events for
+}
+
+func (e *CursorTooOldError) Error() string {
+	return fmt.Sprintf("%v: cursor at block %d, oldest retained block %d", ErrCursorTooOld, e.Cursor.Block, e.OldestBlock)
+}
+
+// Is makes errors.Is(err, ErrCursorTooOld) match
+func (e *CursorTooOldError) Is(target error) bool {
+	return target == ErrCursorTooOld
+}
+
+// WatchOption configures WatchTokenEvents
+type WatchOption func(*watchConfig)
+
+type watchConfig struct {
+	store        CursorStore
+	pollInterval time.Duration
+	chunkSize    int64
+	maxBackoff   time.Duration
+	filter       EventFilter
+}
+
+// WithCursorStore persists the watcher position in store (default: in-memory)
+func WithCursorStore(store CursorStore) WatchOption {
+	return func(c *watchConfig) {
+		if store != nil {
+			c.store = store
+		}
+	}
+}
+
+// WithWatchInterval sets how often the watcher polls once it has caught up (default 2s)
+func WithWatchInterval(interval time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		if interval > 0 {
+			c.pollInterval = interval
+		}
+	}
+}
+
+// WithWatchChunkSize sets the number of blocks queried per request (default DefaultEventChunkSize)
+func WithWatchChunkSize(blocks int64) WatchOption {
+	return func(c *watchConfig) {
+		if blocks > 0 {
+			c.chunkSize = blocks
+		}
+	}
+}
+
+// WithWatchMaxBackoff caps the retry delay after transient failures (default 30s)
+func WithWatchMaxBackoff(d time.Duration) WatchOption {
+	return func(c *watchConfig) {
+		if d > 0 {
+			c.maxBackoff = d
+		}
+	}
+}
+
+// WithWatchFilter restricts watched events by type and account (block range fields are ignored)
+func WithWatchFilter(filter EventFilter) WatchOption {
+	return func(c *watchConfig) {
+		c.filter = filter
+	}
+}
+
+// WatchTokenEvents polls token events starting at fromBlock (or at the stored cursor if one
+// exists) and calls handler for each event in chain order, saving the cursor after every
+// handled event. Transient query failures (IsTransient) are retried with backoff without
+// advancing; other query errors are returned. If handler returns an error, watching stops
+// with a *WatchError naming the failed block; calling again with the same CursorStore
+// resumes at that event. Returns ctx.Err() when ctx is cancelled.
+//
+// The cursor is saved only after handler returned successfully, so delivery is at least
+// once: a process killed between handling an event and saving the cursor handles that event
+// again after restarting. When the server advertises a retention window
+// (ServerInfo.EventRetentionBlocks) and the stored cursor is older, a *CursorTooOldError
+// (ErrCursorTooOld) is returned before any event is handled.
+func (c *Client) WatchTokenEvents(ctx context.Context, tokenAddress string, fromBlock int64, handler func(TokenEvent) error, opts ...WatchOption) (err error) {
+	defer withOperationErr(&err, "WatchTokenEvents", tokenAddress)
+	cfg := watchConfig{
+		pollInterval: DefaultBlockPollInterval,
+		chunkSize:    DefaultEventChunkSize,
+		maxBackoff:   30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.store == nil {
+		cfg.store = NewMemoryCursorStore()
+	}
+
+	key := strings.ToLower(tokenAddress)
+	cursor, ok, err := cfg.store.Load(key)
+	if err != nil {
+		return fmt.Errorf("load cursor: %w", err)
+	}
+	if !ok {
+		cursor = EventCursor{Block: fromBlock - 1, LogIndex: EndOfBlock}
+	}
+
+	backoff := newWatchBackoff(cfg.maxBackoff)
+	if ok {
+		if err := c.checkCursorRetention(ctx, cursor, backoff); err != nil {
+			return err
+		}
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		start := cursor.Block
+		if cursor.LogIndex == EndOfBlock {
+			start++
+		}
+
+		latest, err := c.getBlockNumber()
+		if err != nil {
+			if !IsTransient(err) {
+				return err
+			}
+			if err := backoff.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		if start > latest {
+			if err := sleepContext(ctx, cfg.pollInterval); err != nil {
+				return err
+			}
+			continue
+		}
+
+		end := start + cfg.chunkSize - 1
+		if end > latest {
+			end = latest
+		}
+
+		events, err := c.queryTokenEvents(context.Background(), tokenAddress, cfg.filter, start, end)
+		if err != nil {
+			if !IsTransient(err) {
+				return err
+			}
+			if err := backoff.wait(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		backoff.reset()
+
+		sort.SliceStable(events, func(i, j int) bool {
+			if events[i].BlockNumber != events[j].BlockNumber {
+				return events[i].BlockNumber < events[j].BlockNumber
+			}
+			return events[i].LogIndex < events[j].LogIndex
+		})
+
+		for _, event := range events {
+			if !cursor.after(event) {
+				continue // already handled before a restart
+			}
+			if err := handler(event); err != nil {
+				return &WatchError{Block: event.BlockNumber, LogIndex: event.LogIndex, Err: err}
+			}
+			cursor = EventCursor{Block: event.BlockNumber, LogIndex: event.LogIndex}
+			if err := cfg.store.Save(key, cursor); err != nil {
+				return fmt.Errorf("save cursor: %w", err)
+			}
+		}
+
+		cursor = EventCursor{Block: end, LogIndex: EndOfBlock}
+		if err := cfg.store.Save(key, cursor); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
+		}
+	}
+}
+
+// Internal method: fail with a *CursorTooOldError when events after cursor are no longer
+// retained by the server. Servers that don't advertise a retention window (or whose server
+// info can't be fetched) are assumed to keep all events.
+func (c *Client) checkCursorRetention(ctx context.Context, cursor EventCursor, backoff *watchBackoff) error {
+	info, err := c.getServerInfo()
+	if err != nil || info.EventRetentionBlocks <= 0 {
+		return nil
+	}
+
+	var latest int64
+	for {
+		if latest, err = c.getBlockNumber(); err == nil {
+			break
+		}
+		if !IsTransient(err) {
+			return err
+		}
+		if err := backoff.wait(ctx); err != nil {
+			return err
+		}
+	}
+	backoff.reset()
+
+	oldest := latest - info.EventRetentionBlocks + 1
+	next := cursor.Block
+	if cursor.LogIndex == EndOfBlock {
+		next++
+	}
+	if next < oldest {
+		return &CursorTooOldError{Cursor: cursor, OldestBlock: oldest}
+	}
+	return nil
+}
+
+// watchBackoff is an exponential retry delay
+type watchBackoff struct {
+	delay time.Duration
+	max   time.Duration
+}
+
+func newWatchBackoff(max time.Duration) *watchBackoff {
+	return &watchBackoff{max: max}
+}
+
+func (b *watchBackoff) wait(ctx context.Context) error {
+	if b.delay == 0 {
+		b.delay = 500 * time.Millisecond
+	} else {
+		b.delay *= 2
+	}
+	if b.delay > b.max {
+		b.delay = b.max
+	}
+	return sleepContext(ctx, b.delay)
+}
+
+func (b *watchBackoff) reset() {
+	b.delay = 0
+}
+
+// Internal method: sleep that ends early when ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
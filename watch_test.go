@@ -0,0 +1,173 @@
+package alchemy_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/Alchemy-Pay/alchemy-chain-go-sdk/alchemytest"
+)
+
+var errStore = errors.New("store unavailable")
+
+// failingStore fails every Load
+type failingStore struct{}
+
+func (failingStore) Load(string) (alchemy.EventCursor, bool, error) {
+	return alchemy.EventCursor{}, false, errStore
+}
+
+func (failingStore) Save(string, alchemy.EventCursor) error { return errStore }
+
+func TestDefaultWatchTokenEventsPassesOptions(t *testing.T) {
+	_, client, _ := newTestServer(t)
+	useDefaultClient(t, client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := alchemy.WatchTokenEvents(ctx, testToken, 0, func(alchemy.TokenEvent) error { return nil },
+		alchemy.WithCursorStore(failingStore{}))
+	if !errors.Is(err, errStore) {
+		t.Fatalf("err = %v, want the cursor store error", err)
+	}
+}
+
+func TestWatchTokenEventsReturnsRPCErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetError("get_token_events", alchemytest.CodeInvalidParams, "unknown token")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := client.WatchTokenEvents(ctx, testToken, 1, func(alchemy.TokenEvent) error { return nil })
+	var rpcErr *alchemy.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("err = %v, want the *RPCError", err)
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 1 {
+		t.Fatalf("got %d queries, want 1", n)
+	}
+}
+
+func TestWatchTokenEventsRetriesTransientErrors(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.FailNext("get_token_events", http.StatusServiceUnavailable, 1)
+	srv.SetResult("get_token_events", []map[string]interface{}{
+		{"type": "Mint", "blockNumber": 1, "txHash": "0x01", "logIndex": 0},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errDone := errors.New("done")
+	err := client.WatchTokenEvents(ctx, testToken, 1, func(event alchemy.TokenEvent) error {
+		return errDone
+	})
+	var watchErr *alchemy.WatchError
+	if !errors.As(err, &watchErr) || !errors.Is(err, errDone) || watchErr.Block != 1 {
+		t.Fatalf("err = %v, want a *WatchError for block 1", err)
+	}
+	if n := len(srv.RequestsFor("get_token_events")); n != 2 {
+		t.Fatalf("got %d queries, want 2", n)
+	}
+}
+
+// newEventLog serves eth_blockNumber and get_token_events for a fixed chain of events,
+// answering each query with only the events in its block range; other methods are not found
+func newEventLog(t *testing.T, head int64, events []alchemy.TokenEvent) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var result interface{}
+		switch req.Method {
+		case "eth_blockNumber":
+			result = fmt.Sprintf("0x%x", head)
+		case "get_token_events":
+			var params struct{ FromBlock, ToBlock int64 }
+			json.Unmarshal(req.Params, &params)
+			matched := []alchemy.TokenEvent{}
+			for _, event := range events {
+				if event.BlockNumber >= params.FromBlock && event.BlockNumber <= params.ToBlock {
+					matched = append(matched, event)
+				}
+			}
+			result = matched
+		default:
+			json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID,
+				"error": map[string]interface{}{"code": -32601, "message": "method not found: " + req.Method}})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestWatchTokenEventsRestartMidStream(t *testing.T) {
+	var chain []alchemy.TokenEvent
+	for block := int64(1); block <= 10; block++ {
+		for i := int64(0); i < block%3; i++ { // 0, 1 or 2 events per block
+			chain = append(chain, alchemy.TokenEvent{Type: "Mint", BlockNumber: block, LogIndex: i})
+		}
+	}
+	srv := newEventLog(t, 10, chain)
+	client, err := alchemy.NewClient(srv.URL, alchemy.WithServiceURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := alchemy.NewMemoryCursorStore()
+	var handled []string
+	errFlaky := errors.New("flaky handler")
+	failed := false
+	// Each run is killed after a few events; the third one also fails an event once
+	for run := 0; len(handled) < len(chain); run++ {
+		if run > 10 {
+			t.Fatalf("no progress, handled %v", handled)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		seen := 0
+		err := client.WatchTokenEvents(ctx, testToken, 1, func(event alchemy.TokenEvent) error {
+			if run == 2 && !failed {
+				failed = true
+				return errFlaky
+			}
+			handled = append(handled, fmt.Sprintf("%d/%d", event.BlockNumber, event.LogIndex))
+			if seen++; seen == 3 || len(handled) == len(chain) {
+				cancel()
+			}
+			return nil
+		}, alchemy.WithCursorStore(store), alchemy.WithWatchChunkSize(4), alchemy.WithWatchInterval(time.Millisecond))
+		cancel()
+
+		var watchErr *alchemy.WatchError
+		switch {
+		case errors.As(err, &watchErr):
+			if !errors.Is(err, errFlaky) {
+				t.Fatalf("run %d: %v", run, err)
+			}
+		case !errors.Is(err, context.Canceled):
+			t.Fatalf("run %d: err = %v", run, err)
+		}
+	}
+
+	var want []string
+	for _, event := range chain {
+		want = append(want, fmt.Sprintf("%d/%d", event.BlockNumber, event.LogIndex))
+	}
+	if fmt.Sprint(handled) != fmt.Sprint(want) {
+		t.Fatalf("handled %v\nwant    %v", handled, want)
+	}
+}
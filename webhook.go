@@ -0,0 +1,190 @@
+package alchemy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WebhookSignatureHeader is the header carrying the HMAC-SHA256 of a webhook delivery body,
+// as "sha256=<hex>"
+const WebhookSignatureHeader = "X-Alchemy-Signature"
+
+// ErrInvalidWebhookSignature is returned by VerifyWebhookPayload for deliveries whose
+// signature is missing, malformed or doesn't match the body
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// Webhook is a registered webhook
+type Webhook struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`    // event types delivered, e.g. EventMint
+	CreatedAt int64    `json:"createdAt"` // unix seconds, 0 if the server doesn't say
+}
+
+// AllowInsecureWebhookURL lets RegisterWebhook accept a plain http callback URL, e.g. for a
+// local receiver during development. The shared secret then travels unencrypted.
+func AllowInsecureWebhookURL() CallOption {
+	return func(c *callConfig) {
+		c.insecureWebhookURL = true
+	}
+}
+
+// RegisterWebhook registers callbackURL to receive the token events of the given types.
+// Deliveries are signed with secret (see VerifyWebhookPayload). The callback must be an
+// https URL unless AllowInsecureWebhookURL is passed. The request is signed like
+// create_token.
+func (c *Client) RegisterWebhook(callbackURL string, events []string, secret string, opts ...CallOption) (r *ResponseHandler[*Webhook]) {
+	defer withOperation(&r, "RegisterWebhook", "")
+	cfg := c.newCallConfig(opts)
+	if err := checkWebhookURL(callbackURL, cfg.insecureWebhookURL); err != nil {
+		return &ResponseHandler[*Webhook]{err: err}
+	}
+	if len(events) == 0 {
+		return &ResponseHandler[*Webhook]{err: errors.New("webhook: at least one event type is required")}
+	}
+	eventArgs := make([]interface{}, len(events))
+	for i, event := range events {
+		if strings.TrimSpace(event) == "" {
+			return &ResponseHandler[*Webhook]{err: errors.New("webhook: empty event type")}
+		}
+		eventArgs[i] = event
+	}
+	if secret == "" {
+		return &ResponseHandler[*Webhook]{err: errors.New("webhook: secret is required")}
+	}
+
+	result, err := c.signedServiceCall("register_webhook", map[string]interface{}{
+		"events": eventArgs,
+		"secret": secret,
+		"url":    callbackURL,
+	}, cfg, true)
+	if err != nil {
+		return &ResponseHandler[*Webhook]{err: err}
+	}
+
+	var webhook Webhook
+	if err := c.decodeResult(result, &webhook); err != nil {
+		return &ResponseHandler[*Webhook]{err: err}
+	}
+	if webhook.ID == "" {
+		return &ResponseHandler[*Webhook]{err: errors.New("decode webhook: missing id")}
+	}
+	return &ResponseHandler[*Webhook]{data: &webhook}
+}
+
+// ListWebhooks lists the webhooks registered by the configured key
+func (c *Client) ListWebhooks() (r *ResponseHandler[[]Webhook]) {
+	defer withOperation(&r, "ListWebhooks", "")
+	result, err := c.signedServiceCall("list_webhooks", map[string]interface{}{}, c.newCallConfig(nil), false)
+	if err != nil {
+		return &ResponseHandler[[]Webhook]{err: err}
+	}
+
+	webhooks := []Webhook{}
+	if err := c.decodeResult(result, &webhooks); err != nil {
+		return &ResponseHandler[[]Webhook]{err: err}
+	}
+	if webhooks == nil { // null result
+		webhooks = []Webhook{}
+	}
+	return &ResponseHandler[[]Webhook]{data: webhooks}
+}
+
+// DeleteWebhook removes the webhook with the given id
+func (c *Client) DeleteWebhook(id string) (r *ResponseHandler[struct{}]) {
+	defer withOperation(&r, "DeleteWebhook", "")
+	if id == "" {
+		return &ResponseHandler[struct{}]{err: errors.New("webhook: id is required")}
+	}
+	if _, err := c.signedServiceCall("delete_webhook", map[string]interface{}{"id": id}, c.newCallConfig(nil), true); err != nil {
+		return &ResponseHandler[struct{}]{err: err}
+	}
+	return &ResponseHandler[struct{}]{}
+}
+
+// VerifyWebhookPayload checks that body was signed with secret: signatureHeader is the
+// WebhookSignatureHeader value, "sha256=" followed by the hex HMAC-SHA256 of the body (the
+// bare hex digest is accepted too). Returns an error wrapping ErrInvalidWebhookSignature if
+// the signature doesn't match. Verify the raw body before decoding it.
+func VerifyWebhookPayload(body []byte, signatureHeader, secret string) error {
+	if secret == "" {
+		return errors.New("webhook: secret is required")
+	}
+	digest := strings.TrimSpace(signatureHeader)
+	if scheme, value, ok := strings.Cut(digest, "="); ok {
+		if !strings.EqualFold(scheme, "sha256") {
+			return fmt.Errorf("%w: unsupported scheme %q", ErrInvalidWebhookSignature, scheme)
+		}
+		digest = value
+	}
+	if digest == "" {
+		return fmt.Errorf("%w: missing signature", ErrInvalidWebhookSignature)
+	}
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("%w: signature is not hex", ErrInvalidWebhookSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidWebhookSignature)
+	}
+	return nil
+}
+
+// Internal method: require an absolute https URL (or http when allowed)
+func checkWebhookURL(callbackURL string, allowInsecure bool) error {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid url: %w", err)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("webhook: url %q has no host", callbackURL)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		return nil
+	case "http":
+		if allowInsecure {
+			return nil
+		}
+		return fmt.Errorf("webhook: url %q is not https (use AllowInsecureWebhookURL for local receivers)", callbackURL)
+	default:
+		return fmt.Errorf("webhook: unsupported url scheme %q", u.Scheme)
+	}
+}
+
+// Internal method: sign params with nonce 0 and the recentCheckpoint like create_token and
+// call a token service method that isn't scoped to a token
+func (c *Client) signedServiceCall(method string, params map[string]interface{}, cfg *callConfig, write bool) (json.RawMessage, error) {
+	ctx := cfg.context()
+	if err := c.checkChainContext(ctx); err != nil {
+		return nil, err
+	}
+	blockNum, err := c.getBlockNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	params["nonce"] = int64(0)
+	params["recentCheckpoint"] = blockNum
+	if cfg.idempotencyKey != "" {
+		params["idempotencyKey"] = cfg.idempotencyKey
+	}
+
+	reqParams, err := c.signRequestContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if write {
+		return c.rpcWriteContext(ctx, method, reqParams, cfg.maxResponseSize)
+	}
+	return c.rpcCallContext(ctx, method, reqParams, cfg.maxResponseSize)
+}
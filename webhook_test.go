@@ -0,0 +1,157 @@
+package alchemy_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+func TestRegisterWebhook(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	srv.SetResult("register_webhook", map[string]interface{}{
+		"id": "wh_1", "url": "https://hooks.example.com/alchemy", "events": []string{"Mint", "Pause"}, "createdAt": 1700000000,
+	})
+
+	webhook, err := client.RegisterWebhook("https://hooks.example.com/alchemy", []string{alchemy.EventMint, alchemy.EventPause}, "s3cret").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &alchemy.Webhook{ID: "wh_1", URL: "https://hooks.example.com/alchemy", Events: []string{"Mint", "Pause"}, CreatedAt: 1700000000}
+	if !reflect.DeepEqual(webhook, want) {
+		t.Fatalf("got %+v, want %+v", webhook, want)
+	}
+
+	req := srv.RequestsFor("register_webhook")[0]
+	if req.SignatureErr != nil || req.Signer == "" {
+		t.Fatalf("signature error %v", req.SignatureErr)
+	}
+	for field, want := range map[string]string{
+		"url": "https://hooks.example.com/alchemy", "events": "[Mint Pause]", "secret": "s3cret", "nonce": "0", "recentCheckpoint": "1",
+	} {
+		if got := fmt.Sprint(req.ParamMap[field]); got != want {
+			t.Fatalf("%s = %s, want %s", field, got, want)
+		}
+	}
+}
+
+func TestRegisterWebhookValidation(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	tests := []struct {
+		name   string
+		url    string
+		events []string
+		secret string
+		opts   []alchemy.CallOption
+		ok     bool
+	}{
+		{"http rejected", "http://hooks.example.com/a", []string{"Mint"}, "s", nil, false},
+		{"http allowed", "http://localhost:8080/a", []string{"Mint"}, "s", []alchemy.CallOption{alchemy.AllowInsecureWebhookURL()}, true},
+		{"other scheme", "ftp://hooks.example.com/a", []string{"Mint"}, "s", []alchemy.CallOption{alchemy.AllowInsecureWebhookURL()}, false},
+		{"relative", "/hooks", []string{"Mint"}, "s", nil, false},
+		{"no events", "https://hooks.example.com/a", nil, "s", nil, false},
+		{"empty event", "https://hooks.example.com/a", []string{"Mint", " "}, "s", nil, false},
+		{"no secret", "https://hooks.example.com/a", []string{"Mint"}, "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.Reset()
+			srv.SetResult("register_webhook", map[string]interface{}{"id": "wh_2"})
+			err := client.RegisterWebhook(tt.url, tt.events, tt.secret, tt.opts...).Err()
+			if (err == nil) != tt.ok {
+				t.Fatalf("err = %v", err)
+			}
+			if sent := len(srv.RequestsFor("register_webhook")); !tt.ok && sent != 0 {
+				t.Fatalf("invalid registration sent")
+			}
+		})
+	}
+}
+
+func TestListAndDeleteWebhooks(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+	useDefaultClient(t, client)
+	srv.SetResult("list_webhooks", []map[string]interface{}{
+		{"id": "wh_1", "url": "https://a.example.com", "events": []string{"Mint"}},
+		{"id": "wh_2", "url": "https://b.example.com", "events": []string{"Pause", "Unpause"}},
+	})
+
+	webhooks, err := alchemy.ListWebhooks().Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhooks) != 2 || webhooks[1].ID != "wh_2" || len(webhooks[1].Events) != 2 {
+		t.Fatalf("got %+v", webhooks)
+	}
+	if req := srv.RequestsFor("list_webhooks")[0]; req.SignatureErr != nil || req.Signer == "" {
+		t.Fatalf("list not signed: %v", req.SignatureErr)
+	}
+
+	srv.SetResult("list_webhooks", nil)
+	if webhooks, err := client.ListWebhooks().Result(); err != nil || webhooks == nil || len(webhooks) != 0 {
+		t.Fatalf("null result: %v, %v", webhooks, err)
+	}
+
+	srv.SetResult("delete_webhook", true)
+	if err := alchemy.DeleteWebhook("wh_1").Err(); err != nil {
+		t.Fatal(err)
+	}
+	req := srv.RequestsFor("delete_webhook")[0]
+	if req.SignatureErr != nil || fmt.Sprint(req.ParamMap["id"]) != "wh_1" {
+		t.Fatalf("params %v, signature error %v", req.ParamMap, req.SignatureErr)
+	}
+
+	srv.SetError("delete_webhook", -32000, "webhook not found")
+	if err := client.DeleteWebhook("wh_9").Err(); !errors.Is(err, alchemy.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if err := client.DeleteWebhook("").Err(); err == nil {
+		t.Fatal("empty id accepted")
+	}
+}
+
+func TestVerifyWebhookPayload(t *testing.T) {
+	body := []byte(`{"type":"Mint","blockNumber":12,"fields":{"amount":"100"}}`)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	digest := hex.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name   string
+		body   []byte
+		header string
+		secret string
+		ok     bool
+	}{
+		{"valid", body, "sha256=" + digest, "s3cret", true},
+		{"bare digest", body, digest, "s3cret", true},
+		{"upper-case hex and scheme", body, "SHA256=" + strings.ToUpper(digest), "s3cret", true},
+		{"wrong secret", body, "sha256=" + digest, "other", false},
+		{"tampered body", []byte(strings.Replace(string(body), "100", "900", 1)), "sha256=" + digest, "s3cret", false},
+		{"truncated digest", body, "sha256=" + digest[:40], "s3cret", false},
+		{"not hex", body, "sha256=zz", "s3cret", false},
+		{"other scheme", body, "sha1=" + digest, "s3cret", false},
+		{"missing header", body, "", "s3cret", false},
+		{"empty secret", body, "sha256=" + digest, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := alchemy.VerifyWebhookPayload(tt.body, tt.header, tt.secret)
+			if tt.ok && err != nil {
+				t.Fatalf("err = %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("verified")
+			}
+			if !tt.ok && tt.secret != "" && !errors.Is(err, alchemy.ErrInvalidWebhookSignature) {
+				t.Fatalf("err = %v, want ErrInvalidWebhookSignature", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package alchemy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrSubscriptionClosed is reported when a subscription gives up reconnecting
+var ErrSubscriptionClosed = errors.New("subscription closed")
+
+// ConfigWebSocketURL sets the WebSocket URL of the token service.
+// By default it is derived from the service URL (http -> ws, https -> wss).
+func ConfigWebSocketURL(url string) {
+	defaultClient.wsURL = url
+}
+
+// SubscribeOption configures a subscription
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	onError        func(error)
+	reconnectDelay time.Duration
+	maxReconnects  int
+	buffer         int
+	pollInterval   time.Duration
+}
+
+func defaultSubscribeConfig() subscribeConfig {
+	return subscribeConfig{
+		onError:        func(error) {},
+		reconnectDelay: time.Second,
+		maxReconnects:  10,
+		buffer:         64,
+		pollInterval:   DefaultBlockPollInterval,
+	}
+}
+
+// OnSubscriptionError sets a callback for subscription errors. It receives transient
+// errors (disconnects, undecodable notifications) as well as the final error, wrapping
+// ErrSubscriptionClosed, right before the channel is closed because reconnecting failed.
+func OnSubscriptionError(handler func(error)) SubscribeOption {
+	return func(c *subscribeConfig) {
+		if handler != nil {
+			c.onError = handler
+		}
+	}
+}
+
+// WithReconnect sets the initial reconnect delay (doubled per attempt, capped at 30s)
+// and the number of consecutive failed reconnects before giving up
+func WithReconnect(delay time.Duration, maxAttempts int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		if delay > 0 {
+			c.reconnectDelay = delay
+		}
+		if maxAttempts >= 0 {
+			c.maxReconnects = maxAttempts
+		}
+	}
+}
+
+// Internal method: token service WebSocket URL
+func (c *Client) serviceWebSocketURL() (string, error) {
+	if c.wsURL != "" {
+		return c.wsURL, nil
+	}
+	return toWebSocketURL(c.serviceEndpoint())
+}
+
+// Internal method: convert an http(s) URL to ws(s)
+func toWebSocketURL(httpURL string) (string, error) {
+	u, err := url.Parse(httpURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	case "ws", "wss":
+	default:
+		return "", fmt.Errorf("cannot derive websocket url from %q", httpURL)
+	}
+	return u.String(), nil
+}
+
+// wsSubscription is a JSON-RPC pubsub subscription (<namespace>_subscribe) that
+// reconnects and resubscribes when the connection drops
+type wsSubscription struct {
+	client    *Client
+	url       string
+	namespace string
+	params    []interface{}
+	cfg       subscribeConfig
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+
+	done chan struct{} // closed when run returns
+}
+
+// Internal method: open a subscription, delivering notification results on the returned channel
+// until ctx is cancelled, the client is closed or reconnecting fails. The first connection
+// is made synchronously.
+func (c *Client) subscribe(ctx context.Context, endpoint, namespace string, params []interface{}, cfg subscribeConfig) (<-chan json.RawMessage, error) {
+	ctx, cancel, err := c.backgroundContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub := &wsSubscription{client: c, url: endpoint, namespace: namespace, params: params, cfg: cfg, done: make(chan struct{})}
+
+	subID, err := sub.connect(ctx)
+	if err != nil {
+		cancel()
+		c.life.background.Done()
+		return nil, err
+	}
+
+	out := make(chan json.RawMessage, cfg.buffer)
+	go sub.run(ctx, subID, out)
+	go func() {
+		defer c.life.background.Done()
+		defer cancel()
+		select {
+		case <-ctx.Done():
+		case <-sub.done: // gave up reconnecting
+		}
+		sub.close()
+		<-sub.done
+	}()
+
+	return out, nil
+}
+
+// Internal method: dial and issue the subscribe call, returning the subscription id
+func (s *wsSubscription) connect(ctx context.Context) (string, error) {
+	header := http.Header{}
+	if err := s.client.applyHeaders(header); err != nil {
+		return "", err
+	}
+	conn, _, err := s.client.wsDialer().DialContext(ctx, s.url, header)
+	if err != nil {
+		return "", fmt.Errorf("websocket dial: %w", s.client.redactProxyError(err))
+	}
+	conn.SetReadLimit(s.client.maxResponseSize)
+
+	req := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  s.namespace + "_subscribe",
+		"params":  s.params,
+		"id":      1,
+	}
+	if err := conn.WriteJSON(req); err != nil {
+		conn.Close()
+		return "", fmt.Errorf("websocket subscribe: %w", err)
+	}
+
+	var resp struct {
+		ID     int             `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := conn.ReadJSON(&resp); err != nil {
+		conn.Close()
+		return "", fmt.Errorf("websocket subscribe: %w", err)
+	}
+	if resp.Error != nil {
+		conn.Close()
+		return "", fmt.Errorf("RPC error: %s", resp.Error.Message)
+	}
+
+	var subID string
+	if err := json.Unmarshal(resp.Result, &subID); err != nil {
+		conn.Close()
+		return "", fmt.Errorf("invalid subscription id: %w", err)
+	}
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+
+	// Cancelled while connecting
+	if ctx.Err() != nil {
+		s.close()
+		return "", ctx.Err()
+	}
+
+	return subID, nil
+}
+
+// Internal method: read notifications, reconnecting on failure, until ctx is done
+func (s *wsSubscription) run(ctx context.Context, subID string, out chan<- json.RawMessage) {
+	defer close(s.done)
+	defer close(out)
+
+	for {
+		err := s.read(ctx, subID, out)
+		if ctx.Err() != nil {
+			return
+		}
+		s.cfg.onError(fmt.Errorf("websocket disconnected: %w", err))
+
+		subID, err = s.reconnect(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.cfg.onError(fmt.Errorf("%w: %w", ErrSubscriptionClosed, err))
+			}
+			return
+		}
+	}
+}
+
+// Internal method: deliver notifications for subID from the current connection until it fails
+func (s *wsSubscription) read(ctx context.Context, subID string, out chan<- json.RawMessage) error {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+	if conn == nil {
+		return ErrSubscriptionClosed
+	}
+
+	for {
+		var msg struct {
+			Method string `json:"method"`
+			Params struct {
+				Subscription string          `json:"subscription"`
+				Result       json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			return err
+		}
+		if msg.Method != s.namespace+"_subscription" || msg.Params.Subscription != subID {
+			continue
+		}
+
+		select {
+		case out <- msg.Params.Result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Internal method: reconnect and resubscribe with exponential backoff
+func (s *wsSubscription) reconnect(ctx context.Context) (string, error) {
+	s.close()
+
+	delay := s.cfg.reconnectDelay
+	var lastErr error
+	for attempt := 0; attempt < s.cfg.maxReconnects; attempt++ {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+
+		subID, err := s.connect(ctx)
+		if err == nil {
+			return subID, nil
+		}
+		lastErr = err
+
+		delay *= 2
+		if delay > 30*time.Second {
+			delay = 30 * time.Second
+		}
+	}
+	if lastErr == nil {
+		lastErr = errors.New("reconnects disabled")
+	}
+	return "", fmt.Errorf("reconnect failed after %d attempts: %w", s.cfg.maxReconnects, lastErr)
+}
+
+// Internal method: close the current connection with a close frame
+func (s *wsSubscription) close() {
+	s.mu.Lock()
+	conn := s.conn
+	s.conn = nil
+	s.mu.Unlock()
+
+	if conn != nil {
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		conn.Close()
+	}
+}
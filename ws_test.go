@@ -0,0 +1,160 @@
+package alchemy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+	"github.com/gorilla/websocket"
+)
+
+// newWebSocketServer accepts token_subscribe, sends one event and hangs up. The nth
+// connection uses subscription id n and sends an event at block n.
+func newWebSocketServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	var connections atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req map[string]interface{}
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		n := connections.Add(1)
+		subID := fmt.Sprintf("0x%x", n)
+		conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req["id"], "result": subID})
+		// Notifications for other subscriptions are ignored
+		conn.WriteJSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "token_subscription",
+			"params":  map[string]interface{}{"subscription": "0xdead", "result": map[string]interface{}{"type": "Burn"}},
+		})
+		conn.WriteJSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  "token_subscription",
+			"params": map[string]interface{}{
+				"subscription": subID,
+				"result":       map[string]interface{}{"type": "Mint", "blockNumber": n, "txHash": "0x01", "logIndex": 0},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// goroutinesIn counts the goroutines whose stack contains fn
+func goroutinesIn(fn string) int {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	n := 0
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		if strings.Contains(stack, fn) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSubscriptionReleasesGoroutinesWhenGivingUp(t *testing.T) {
+	ws := newWebSocketServer(t)
+	client, err := alchemy.NewClient(ws.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan error, 1)
+	// ctx is never cancelled: the subscription must clean up after itself
+	events, err := client.SubscribeTokenEvents(context.Background(), "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		alchemy.WithReconnect(time.Millisecond, 0),
+		alchemy.OnSubscriptionError(func(err error) {
+			if errors.Is(err, alchemy.ErrSubscriptionClosed) {
+				closed <- err
+			}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []alchemy.TokenEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	if len(got) != 1 || got[0].Type != "Mint" {
+		t.Fatalf("got events %+v", got)
+	}
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ErrSubscriptionClosed not reported")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for goroutinesIn(".(*Client).subscribe.func") > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription goroutine still running after the subscription closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSubscriptionReconnects(t *testing.T) {
+	ws := newWebSocketServer(t)
+	client, err := alchemy.NewClient(ws.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var disconnects atomic.Int64
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := client.SubscribeTokenEvents(ctx, "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		alchemy.WithReconnect(time.Millisecond, 3),
+		alchemy.OnSubscriptionError(func(err error) {
+			if !errors.Is(err, alchemy.ErrSubscriptionClosed) {
+				disconnects.Add(1)
+			}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every connection is dropped after one event; each reconnect resubscribes
+	for want := int64(1); want <= 3; want++ {
+		select {
+		case event := <-events:
+			if event.BlockNumber != want {
+				t.Fatalf("event at block %d, want %d", event.BlockNumber, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no event %d", want)
+		}
+	}
+	if disconnects.Load() < 2 {
+		t.Fatalf("%d disconnects reported, want at least 2", disconnects.Load())
+	}
+
+	cancel()
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed after cancel")
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package alchemy_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	alchemy "github.com/Alchemy-Pay/alchemy-chain-go-sdk"
+)
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+func TestZeroAddressGuards(t *testing.T) {
+	writes := []struct {
+		name   string
+		method string
+		call   func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error
+	}{
+		{"CreateToken masterAuthority", "create_token", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.CreateToken("My Token", "MTK", 18, target, opts...).Err()
+		}},
+		{"Mint recipient", "mint", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.Mint(testToken, target, "1", 0, opts...).Err()
+		}},
+		{"MintBig recipient", "mint", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.MintBig(testToken, target, big.NewInt(1), 0, opts...).Err()
+		}},
+		{"MintWithMemo recipient", "mint", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.MintWithMemo(testToken, target, "1", "payout-1", 0, opts...).Err()
+		}},
+		{"MintBatch recipient", "mint", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return batchErr(c.MintBatch(testToken, []alchemy.MintRecipient{{To: target, Amount: "1"}}, 0,
+				alchemy.WithBatchCallOptions(opts...)))
+		}},
+		{"MintBatch server batch recipient", "mintBatch", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return batchErr(c.MintBatch(testToken, []alchemy.MintRecipient{{To: target, Amount: "1"}}, 0,
+				alchemy.WithServerBatch(), alchemy.WithBatchCallOptions(opts...)))
+		}},
+		{"Seize recipient", "seize", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.Seize(testToken, blacklistedAccount, target, "1", 0, opts...).Err()
+		}},
+		{"GrantAuthority account", "grantAuthority", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.GrantAuthority(testToken, alchemy.RoleMint, target, 0, opts...).Err()
+		}},
+		{"GrantCustomAuthority account", "grantAuthority", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.GrantCustomAuthority(testToken, "AUDITOR_ROLE", target, 0, opts...).Err()
+		}},
+		{"TransferMasterAuthorityIrreversibly", "transferMasterAuthority", func(c *alchemy.Client, target string, opts ...alchemy.CallOption) error {
+			return c.TransferMasterAuthorityIrreversibly(testToken, target, 0, opts...).Err()
+		}},
+	}
+
+	for _, w := range writes {
+		t.Run(w.name, func(t *testing.T) {
+			srv, client, _ := newTestServer(t)
+
+			err := w.call(client, zeroAddress)
+			if !errors.Is(err, alchemy.ErrInvalidAddress) || !strings.Contains(err.Error(), "zero address") {
+				t.Fatalf("err = %v, want a zero address ErrInvalidAddress", err)
+			}
+			if n := len(srv.Requests()); n != 0 {
+				t.Fatalf("%d requests sent", n)
+			}
+
+			if err := w.call(client, zeroAddress, alchemy.AllowZeroAddress()); err != nil {
+				t.Fatalf("with AllowZeroAddress: %v", err)
+			}
+			if n := len(srv.RequestsFor(w.method)); n != 1 {
+				t.Fatalf("%d %s requests with AllowZeroAddress", n, w.method)
+			}
+		})
+	}
+}
+
+// batchErr is the error of a MintBatch call or of its failed entries
+func batchErr(r *alchemy.ResponseHandler[*alchemy.BatchResult]) error {
+	result, err := r.Result()
+	if err != nil {
+		return err
+	}
+	return result.Err()
+}
+
+func TestMintToTokenContractRefused(t *testing.T) {
+	srv, client, _ := newTestServer(t)
+
+	for _, to := range []string{testToken, strings.ToLower(testToken)} {
+		for _, opts := range [][]alchemy.CallOption{nil, {alchemy.AllowZeroAddress()}} {
+			err := client.Mint(testToken, to, "1", 0, opts...).Err()
+			if !errors.Is(err, alchemy.ErrInvalidAddress) || !strings.Contains(err.Error(), "token contract") {
+				t.Fatalf("mint to %s: err = %v", to, err)
+			}
+			for _, batch := range [][]alchemy.BatchOption{nil, {alchemy.WithServerBatch()}} {
+				recipients := []alchemy.MintRecipient{{To: testRecipient, Amount: "1"}, {To: to, Amount: "1"}}
+				err := client.MintBatch(testToken, recipients, 0, append(batch, alchemy.WithBatchCallOptions(opts...))...).Err()
+				if !errors.Is(err, alchemy.ErrInvalidAddress) || !strings.Contains(err.Error(), "recipients[1].To is the token contract") {
+					t.Fatalf("batch mint to %s: err = %v", to, err)
+				}
+			}
+		}
+	}
+	if n := len(srv.Requests()); n != 0 {
+		t.Fatalf("%d requests sent", n)
+	}
+}